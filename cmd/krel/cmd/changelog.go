@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"k8s.io/release/pkg/changelog"
+	"k8s.io/release/pkg/release"
+)
+
+// changelogCmd represents the subcommand for `krel changelog`.
+var changelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "Work with the CHANGELOG directory of kubernetes/kubernetes",
+	Long: `krel changelog
+Subcommand to work with the CHANGELOG files written during the release process.
+See each subcommand for more information.
+`,
+	SilenceUsage:  false,
+	SilenceErrors: false,
+}
+
+var changelogAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Check the CHANGELOG directory for consistency",
+	Long: `krel changelog audit
+
+Walks the CHANGELOG directory of a kubernetes/kubernetes checkout and verifies
+that:
+
+- every released tag has a section in the changelog of its minor
+- the generated table of contents matches the headings
+- the download tables reference existing artifacts of their release
+- no PR appears in two patch releases of the same minor unless it got
+  cherry picked again
+- the CHANGELOG/README.md links all changelog files
+
+The command prints all findings and fails if there are any.
+`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runChangelogAudit(changelogAuditOpts)
+	},
+}
+
+var changelogAuditOpts = &changelog.AuditOptions{}
+
+func init() {
+	changelogAuditCmd.PersistentFlags().StringVar(
+		&changelogAuditOpts.RepoPath,
+		"repo",
+		".",
+		"the local path to the kubernetes/kubernetes repository",
+	)
+
+	changelogAuditCmd.PersistentFlags().StringVar(
+		&changelogAuditOpts.Since,
+		"since",
+		"",
+		"the first release to audit, for example v1.28.0 (audits all releases if empty)",
+	)
+
+	changelogAuditCmd.PersistentFlags().BoolVar(
+		&changelogAuditOpts.CheckDownloads,
+		"check-downloads",
+		true,
+		"request every artifact of the download tables to verify that it exists",
+	)
+
+	changelogAuditCmd.PersistentFlags().StringVar(
+		&changelogAuditOpts.Bucket,
+		"bucket",
+		release.ProductionBucket,
+		"the release bucket the download tables link to",
+	)
+
+	changelogCmd.AddCommand(changelogAuditCmd)
+	rootCmd.AddCommand(changelogCmd)
+}
+
+func runChangelogAudit(opts *changelog.AuditOptions) error {
+	findings, err := changelog.NewAuditor(opts).Run()
+	if err != nil {
+		return fmt.Errorf("audit changelog: %w", err)
+	}
+
+	if len(findings) == 0 {
+		logrus.Info("CHANGELOG directory is consistent")
+		return nil
+	}
+
+	for _, finding := range findings {
+		fmt.Println(finding)
+	}
+	return fmt.Errorf("found %d changelog inconsistencies", len(findings))
+}
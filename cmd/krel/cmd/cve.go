@@ -34,14 +34,15 @@ import (
 // releaseNotesCmd represents the subcommand for `krel release-notes`.
 var cveCmd = &cobra.Command{
 	Use:   "cve",
-	Short: "Add and edit CVE information",
+	Short: "Add, edit and export CVE information",
 	Long: `krel cve
 Subcommand to work with CVE data maps used to publish vulnerability information.
 This subcommand enables a Release Manager to write and import new data maps with
 CVE vulnerability information.
 
 The command enables creatin, editing and deleting existing CVE entries in the 
-release bucket. See each subcommand for more information.
+release bucket as well as exporting them as OSV and CSAF documents. See each
+subcommand for more information.
 `,
 	SilenceUsage:  false,
 	SilenceErrors: false,
@@ -69,6 +70,11 @@ the new CVE entry and upload it to the release bucket.
 
 To abort the editing process, do no change anything in the file or simply
 delete all content from the file.
+
+If a local kubernetes repository is provided via --repo, the releases fixing
+the CVE and the affected version ranges are computed from the tags containing
+the linked PRs. They are added to the map as fixedIn and affected fields and
+shown in the editor for confirmation before publishing.
 `,
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -78,11 +84,34 @@ delete all content from the file.
 	Args: argFunc,
 }
 
+var cveExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export all CVE maps as OSV and CSAF documents",
+	Long: `The export command converts all CVE maps of the release bucket into an
+OSV feed and one CSAF 2.0 security advisory per CVE. The OSV entries are
+written to the osv and the advisories to the csaf subdirectory of the output
+directory.
+
+The affected version ranges are taken from the maps, as computed by
+"krel cve edit --repo". If a local kubernetes repository is provided, they
+are derived again from the releases which contain the linked pull requests.
+`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return exportCVEs(cveExportOpts)
+	},
+	Args: cobra.NoArgs,
+}
+
 type cveOptions struct {
 	CVE      string   // CVE identifier to work on
 	mapFiles []string // List of mapfiles
+	repoPath string   // Local kubernetes repository to compute the affected versions
 }
 
+var cveExportOpts = &cve.ExportOptions{}
+
 var argFunc = func(cmd *cobra.Command, args []string) error {
 	if len(args) != 1 {
 		return errors.New("command takes only one argument: a CVE identifier")
@@ -105,7 +134,36 @@ func init() {
 		"update vulnerability data from a local map file",
 	)
 
-	cveCmd.AddCommand(cveEditCmd, cveDeleteCmd)
+	cveEditCmd.PersistentFlags().StringVar(
+		&cveOpts.repoPath,
+		"repo",
+		"",
+		"local kubernetes repository to compute the fixed and affected versions from the linked PRs",
+	)
+
+	cveExportCmd.PersistentFlags().StringVarP(
+		&cveExportOpts.OutputDir,
+		"output",
+		"o",
+		".",
+		"directory to write the OSV entries and CSAF advisories to",
+	)
+
+	cveExportCmd.PersistentFlags().StringVar(
+		&cveExportOpts.MapsDir,
+		"maps-dir",
+		"",
+		"local directory of CVE maps to export instead of the maps in the release bucket",
+	)
+
+	cveExportCmd.PersistentFlags().StringVar(
+		&cveExportOpts.RepoPath,
+		"repo",
+		"",
+		"local kubernetes repository to derive the affected versions from",
+	)
+
+	cveCmd.AddCommand(cveEditCmd, cveDeleteCmd, cveExportCmd)
 	rootCmd.AddCommand(cveCmd)
 }
 
@@ -123,17 +181,9 @@ func writeNewCVE(opts *cveOptions) (err error) {
 		return fmt.Errorf("reading local copy of CVE entry: %w", err)
 	}
 
-	kubeEditor := editor.NewDefaultEditor([]string{"KUBE_EDITOR", "EDITOR"})
-	changes, tempFilePath, err := kubeEditor.LaunchTempFile(
-		"cve-datamap-", ".yaml", bytes.NewReader(oldFile),
-	)
-	if err != nil {
-		return fmt.Errorf("launching editor: %w", err)
-	}
-
-	if bytes.Equal(changes, oldFile) || len(changes) == 0 {
-		logrus.Info("CVE information not modified")
-		return nil
+	tempFilePath, err := launchCVEEditor(client, opts, oldFile, oldFile)
+	if err != nil || tempFilePath == "" {
+		return err
 	}
 
 	logrus.Infof("Creating %s entry", opts.CVE)
@@ -195,17 +245,20 @@ func editExistingCVE(opts *cveOptions) (err error) {
 		return fmt.Errorf("reading local copy of CVE entry: %w", err)
 	}
 
-	kubeEditor := editor.NewDefaultEditor([]string{"KUBE_EDITOR", "EDITOR"})
-	changes, tempFilePath, err := kubeEditor.LaunchTempFile(
-		"cve-datamap-", ".yaml", bytes.NewReader(oldFile),
-	)
-	if err != nil {
-		return fmt.Errorf("launching editor: %w", err)
+	// Show the computed versions of the existing map right away
+	content := oldFile
+	if opts.repoPath != "" {
+		if _, err := client.AnnotateMap(opts.repoPath, file.Name()); err != nil {
+			return fmt.Errorf("computing affected versions: %w", err)
+		}
+		if content, err = os.ReadFile(file.Name()); err != nil {
+			return fmt.Errorf("reading annotated CVE entry: %w", err)
+		}
 	}
 
-	if bytes.Equal(changes, oldFile) || len(changes) == 0 {
-		logrus.Info("CVE information not modified")
-		return nil
+	tempFilePath, err := launchCVEEditor(client, opts, oldFile, content)
+	if err != nil || tempFilePath == "" {
+		return err
 	}
 
 	logrus.Infof("Updating %s entry", opts.CVE)
@@ -213,3 +266,46 @@ func editExistingCVE(opts *cveOptions) (err error) {
 	// If the file was changed, re-write it:
 	return client.Write(opts.CVE, tempFilePath)
 }
+
+// exportCVEs writes the OSV and CSAF documents of all CVE maps.
+func exportCVEs(opts *cve.ExportOptions) error {
+	return cve.NewClient().Export(opts)
+}
+
+// launchCVEEditor opens the content in the user's editor and returns the
+// path to the edited map, or an empty string if the original map was not
+// modified. If the computed versions of the edited map differ from the ones
+// stored in it, the editor is opened again to confirm them.
+func launchCVEEditor(client *cve.Client, opts *cveOptions, original, content []byte) (string, error) {
+	kubeEditor := editor.NewDefaultEditor([]string{"KUBE_EDITOR", "EDITOR"})
+	for {
+		changes, tempFilePath, err := kubeEditor.LaunchTempFile(
+			"cve-datamap-", ".yaml", bytes.NewReader(content),
+		)
+		if err != nil {
+			return "", fmt.Errorf("launching editor: %w", err)
+		}
+
+		if bytes.Equal(changes, original) || len(changes) == 0 {
+			logrus.Info("CVE information not modified")
+			return "", nil
+		}
+
+		if opts.repoPath == "" {
+			return tempFilePath, nil
+		}
+
+		changed, err := client.AnnotateMap(opts.repoPath, tempFilePath)
+		if err != nil {
+			return "", fmt.Errorf("computing affected versions: %w", err)
+		}
+		if !changed {
+			return tempFilePath, nil
+		}
+
+		logrus.Info("Added the computed affected versions to the CVE map, please confirm them")
+		if content, err = os.ReadFile(tempFilePath); err != nil {
+			return "", fmt.Errorf("reading annotated CVE entry: %w", err)
+		}
+	}
+}
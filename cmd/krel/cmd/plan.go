@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/release-sdk/git"
+
+	"k8s.io/release/pkg/anago"
+	"k8s.io/release/pkg/release"
+)
+
+const (
+	planOutputText = "text"
+	planOutputJSON = "json"
+)
+
+type planOptions struct {
+	release.PlanOptions
+	output string
+}
+
+var planOpts = &planOptions{
+	PlanOptions: release.PlanOptions{
+		ReleaseType:   release.ReleaseTypeAlpha,
+		ReleaseBranch: git.DefaultBranch,
+		RepoPath:      ".",
+	},
+	output: planOutputText,
+}
+
+// planCmd is a krel subcommand which prints what a stage and release would
+// create without modifying anything.
+var planCmd = &cobra.Command{
+	Use:   "plan --branch release-1.30 --type rc [--repo ./kubernetes]",
+	Short: "Print the versions, tags, branches and version markers of a release cut",
+	Long: `krel plan
+
+Uses the branches and tags of a local Kubernetes repository to print the full
+set of versions, tags and branches a 'krel stage' and 'krel release' would
+create for the provided branch and release type. It also prints the version
+markers which would be updated if the released versions are newer than their
+current content.
+
+The build version is discovered from the repository the same way the
+Kubernetes build does, but can be overridden by using --build-version.
+`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPlan(planOpts)
+	},
+}
+
+func init() {
+	planCmd.PersistentFlags().StringVar(
+		&planOpts.ReleaseType,
+		"type",
+		planOpts.ReleaseType,
+		releaseTypeUsage(),
+	)
+
+	planCmd.PersistentFlags().StringVar(
+		&planOpts.ReleaseBranch,
+		"branch",
+		planOpts.ReleaseBranch,
+		"The release branch for which the release should be planned",
+	)
+
+	planCmd.PersistentFlags().StringVar(
+		&planOpts.BuildVersion,
+		buildVersionFlag,
+		"",
+		"The build version to be released, discovered from the repository if not set",
+	)
+
+	planCmd.PersistentFlags().StringVar(
+		&planOpts.RepoPath,
+		"repo",
+		planOpts.RepoPath,
+		"The path to the local Kubernetes repository",
+	)
+
+	planCmd.PersistentFlags().StringVar(
+		&versionPolicyFile,
+		versionPolicyFlag,
+		"",
+		"Path to a YAML version policy defining custom pre-release labels "+
+			"and release branch names",
+	)
+
+	planCmd.PersistentFlags().StringVarP(
+		&planOpts.output,
+		"output",
+		"o",
+		planOpts.output,
+		fmt.Sprintf("The output format, must be one of: '%s', '%s'",
+			planOutputText, planOutputJSON,
+		),
+	)
+
+	rootCmd.AddCommand(planCmd)
+}
+
+func runPlan(opts *planOptions) error {
+	if opts.output != planOutputText && opts.output != planOutputJSON {
+		return fmt.Errorf("unsupported output format: %s", opts.output)
+	}
+
+	anagoOptions := &anago.Options{
+		NoMock:        rootOpts.nomock,
+		ReleaseType:   opts.ReleaseType,
+		ReleaseBranch: opts.ReleaseBranch,
+	}
+	if err := loadVersionPolicy(anagoOptions); err != nil {
+		return err
+	}
+	if err := anagoOptions.Validate(); err != nil {
+		return fmt.Errorf("validate options: %w", err)
+	}
+	opts.Bucket = anagoOptions.Bucket()
+	opts.VersionPolicy = anagoOptions.Policy()
+
+	plan, err := release.NewPlanner().Plan(&opts.PlanOptions)
+	if err != nil {
+		return fmt.Errorf("create release plan: %w", err)
+	}
+
+	if opts.output == planOutputJSON {
+		content, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal release plan: %w", err)
+		}
+		fmt.Println(string(content))
+		return nil
+	}
+
+	fmt.Print(plan.String())
+	return nil
+}
@@ -18,7 +18,6 @@ package cmd
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -26,7 +25,6 @@ import (
 	"sigs.k8s.io/release-sdk/github"
 
 	"k8s.io/release/pkg/anago"
-	"k8s.io/release/pkg/release"
 )
 
 // releaseCmd represents the subcommand for `krel release`.
@@ -80,14 +78,7 @@ func init() {
 			&releaseOptions.ReleaseType,
 			"type",
 			releaseOptions.ReleaseType,
-			fmt.Sprintf("The release type, must be one of: '%s'",
-				strings.Join([]string{
-					release.ReleaseTypeAlpha,
-					release.ReleaseTypeBeta,
-					release.ReleaseTypeRC,
-					release.ReleaseTypeOfficial,
-				}, "', '"),
-			))
+			releaseTypeUsage())
 
 	releaseCmd.PersistentFlags().
 		StringVar(
@@ -121,8 +112,68 @@ func init() {
 			"Run the Google Cloud Build job synchronously",
 		)
 
-	if err := releaseCmd.PersistentFlags().MarkHidden(submitJobFlag); err != nil {
-		logrus.Fatal(err)
+	releaseCmd.PersistentFlags().
+		BoolVar(
+			&releaseOptions.Resume,
+			resumeFlag,
+			false,
+			"Resume a previously failed local run by skipping all already completed steps",
+		)
+
+	releaseCmd.PersistentFlags().
+		StringSliceVar(
+			&releaseOptions.OnlySteps,
+			onlyStepsFlag,
+			nil,
+			"Run only the steps with the provided names when resuming a local run",
+		)
+
+	releaseCmd.PersistentFlags().
+		StringSliceVar(
+			&releaseOptions.SkipSteps,
+			skipStepsFlag,
+			nil,
+			"Skip the steps with the provided names when resuming a local run",
+		)
+
+	releaseCmd.PersistentFlags().
+		StringVar(
+			&releaseOptions.ReportFile,
+			reportFileFlag,
+			"",
+			"Path to the JSON run report written after the run finished or failed "+
+				"(defaults to a file in the workspace directory)",
+		)
+
+	releaseCmd.PersistentFlags().
+		StringVar(
+			&versionPolicyFile,
+			versionPolicyFlag,
+			"",
+			"Path to a YAML version policy defining custom pre-release labels "+
+				"and release branch names",
+		)
+
+	releaseCmd.PersistentFlags().
+		StringVar(
+			&versionPolicyData,
+			versionPolicyDataFlag,
+			"",
+			"Base64 encoded JSON version policy, used to pass the policy to Google Cloud Build jobs",
+		)
+
+	releaseCmd.PersistentFlags().
+		BoolVar(
+			&listSteps,
+			listStepsFlag,
+			false,
+			"List all available steps and exit",
+		)
+
+	for _, flag := range []string{submitJobFlag, versionPolicyDataFlag} {
+		if err := releaseCmd.PersistentFlags().MarkHidden(flag); err != nil {
+			logrus.Fatal(err)
+		}
 	}
 
 	if err := releaseCmd.MarkPersistentFlagRequired(buildVersionFlag); err != nil {
@@ -134,9 +185,24 @@ func init() {
 
 func runRelease(options *anago.ReleaseOptions) error {
 	options.NoMock = rootOpts.nomock
+	if err := loadVersionPolicy(options.Options); err != nil {
+		return err
+	}
 	rel := anago.NewRelease(options)
 
+	if listSteps {
+		p, err := rel.Pipeline()
+		if err != nil {
+			return fmt.Errorf("get release steps: %w", err)
+		}
+		printSteps(p)
+		return nil
+	}
+
 	if submitJob {
+		if err := validateLocalRunOptions(options.Options); err != nil {
+			return err
+		}
 		// Perform a local check of the specified options
 		// before launching a Cloud Build job:
 		if err := options.Validate(&anago.State{}); err != nil {
@@ -136,12 +136,19 @@ type releaseNotesOptions struct {
 	websiteRepo        string
 	githubOrg          string
 	draftRepo          string
+	stateDir           string
+	inferLabels        bool
+	ownershipMap       string
 	mapProviders       []string
+	formats            []string
 }
 
 type releaseNotesResult struct {
 	markdown string
 	json     string
+
+	// formats are the additionally rendered formats of the notes
+	formats map[string]string
 }
 
 // A datatype to record a notes repair session.
@@ -193,7 +200,7 @@ func init() {
 		"maps-from",
 		"m",
 		[]string{},
-		"specify a location to recursively look for release notes *.y[a]ml file mappings",
+		"specify a location to recursively look for release notes *.y[a]ml file mappings, which can be a local directory, a gs:// path or a git+<url>[#<ref>[:<path>]] repository",
 	)
 
 	releaseNotesCmd.PersistentFlags().BoolVar(
@@ -217,6 +224,37 @@ func init() {
 		"enable experimental implementation to list commits (ListReleaseNotesV2)",
 	)
 
+	releaseNotesCmd.PersistentFlags().StringVar(
+		&releaseNotesOpts.stateDir,
+		"state-dir",
+		"",
+		"directory to persist the gathered notes, which allows subsequent runs to only process new commits",
+	)
+
+	releaseNotesCmd.PersistentFlags().BoolVar(
+		&releaseNotesOpts.inferLabels,
+		"infer-labels",
+		false,
+		"propose the kind, SIGs and areas of notes whose PRs miss the corresponding labels, to be confirmed with --fix",
+	)
+
+	releaseNotesCmd.PersistentFlags().StringVar(
+		&releaseNotesOpts.ownershipMap,
+		"ownership-map",
+		"",
+		"OWNERS-style file with path filters to sig/ and area/ labels, used to infer SIGs and areas from the touched files",
+	)
+
+	releaseNotesCmd.PersistentFlags().StringSliceVar(
+		&releaseNotesOpts.formats,
+		"format",
+		[]string{},
+		fmt.Sprintf(
+			"additional formats to write the release notes draft in (options: %s)",
+			strings.Join(document.Formats(), ", "),
+		),
+	)
+
 	releaseNotesCmd.PersistentFlags().BoolVar(
 		&releaseNotesOpts.interactiveMode,
 		"interactiveMode",
@@ -451,6 +489,19 @@ func createDraftPR(repoPath, tag string) (err error) {
 	}
 	logrus.Infof("Release Notes JSON version written to %s", filepath.Join(releaseDir, releaseNotesWorkDir, draftJSONFile))
 
+	// Write the additional formats
+	for _, format := range releaseNotesOpts.formats {
+		fileName, err := draftFormatFile(format)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(releaseDir, releaseNotesWorkDir, fileName)
+		if err := os.WriteFile(path, []byte(result.formats[format]), os.FileMode(0o644)); err != nil {
+			return fmt.Errorf("writing release notes %s file: %w", format, err)
+		}
+		logrus.Infof("Release Notes %s version written to %s", format, path)
+	}
+
 	// If we are in interactive mode, ask before continuing
 	if !autoCreatePullRequest {
 		_, autoCreatePullRequest, err = util.Ask("Create pull request with your changes? (y/n)", "y:Y:yes|n:N:no|y", 10)
@@ -543,6 +594,17 @@ func createDraftCommit(repo *git.Repo, releasePath, commitMessage string) error
 		return fmt.Errorf("adding release notes json to staging area: %w", err)
 	}
 
+	// add the additional formats
+	for _, format := range releaseNotesOpts.formats {
+		fileName, err := draftFormatFile(format)
+		if err != nil {
+			return err
+		}
+		if err := repo.Add(filepath.Join(releasePath, releaseNotesWorkDir, fileName)); err != nil {
+			return fmt.Errorf("adding release notes %s to staging area: %w", format, err)
+		}
+	}
+
 	// List of directories we'll consider for the PR
 	releaseDirectories := []struct{ Path, Name, Ext string }{
 		{
@@ -871,6 +933,9 @@ func releaseNotesJSON(repoPath, tag string) (jsonString string, err error) {
 	notesOptions.EndRev = tag
 	notesOptions.Debug = logrus.StandardLogger().Level >= logrus.DebugLevel
 	notesOptions.MapProviderStrings = releaseNotesOpts.mapProviders
+	notesOptions.StateDir = releaseNotesOpts.stateDir
+	notesOptions.InferLabels = releaseNotesOpts.inferLabels
+	notesOptions.OwnershipMapPath = releaseNotesOpts.ownershipMap
 	notesOptions.AddMarkdownLinks = true
 
 	// If the release for the tag we are using has a mapping directory,
@@ -924,6 +989,9 @@ func gatherNotesFrom(repoPath, startTag string) (*notes.ReleaseNotes, error) {
 	notesOptions.EndRev = releaseNotesOpts.tag
 	notesOptions.Debug = logrus.StandardLogger().Level >= logrus.DebugLevel
 	notesOptions.MapProviderStrings = releaseNotesOpts.mapProviders
+	notesOptions.StateDir = releaseNotesOpts.stateDir
+	notesOptions.InferLabels = releaseNotesOpts.inferLabels
+	notesOptions.OwnershipMapPath = releaseNotesOpts.ownershipMap
 	notesOptions.ListReleaseNotesV2 = releaseNotesOpts.listReleaseNotesV2
 	notesOptions.AddMarkdownLinks = true
 
@@ -969,7 +1037,27 @@ func buildNotesResult(startTag string, releaseNotes *notes.ReleaseNotes) (*relea
 		return nil, fmt.Errorf("generating release notes JSON: %w", err)
 	}
 
-	return &releaseNotesResult{markdown: markdown, json: string(j)}, nil
+	// Render the additional formats
+	formats := map[string]string{}
+	for _, format := range releaseNotesOpts.formats {
+		rendered, err := doc.Render(format, "", "", "")
+		if err != nil {
+			return nil, fmt.Errorf("rendering release notes to %s: %w", format, err)
+		}
+		formats[format] = rendered
+	}
+
+	return &releaseNotesResult{markdown: markdown, json: string(j), formats: formats}, nil
+}
+
+// draftFormatFile returns the release notes draft file name for the provided
+// additional format.
+func draftFormatFile(format string) (string, error) {
+	renderer, err := document.RendererFor(format)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("release-notes-draft-%s%s", format, renderer.FileExtension()), nil
 }
 
 // Validate checks if passed cmdline options are sane.
@@ -986,6 +1074,12 @@ func (o *releaseNotesOptions) Validate() error {
 		return fmt.Errorf("reading tag: %s: %w", releaseNotesOpts.tag, err)
 	}
 
+	for _, format := range o.formats {
+		if _, err := document.RendererFor(format); err != nil {
+			return fmt.Errorf("checking release notes format: %w", err)
+		}
+	}
+
 	// Options for PR creation
 	if o.createDraftPR || o.createWebsitePR {
 		if o.userFork == "" {
@@ -1168,9 +1262,9 @@ func fixReleaseNotes(workDir string, releaseNotes *notes.ReleaseNotes) error {
 		}
 
 		fmt.Println(pointIfChanged("Author", note.Author, originalNote.Author), "@"+note.Author)
-		fmt.Println(pointIfChanged("SIGs", note.SIGs, originalNote.SIGs), note.SIGs)
-		fmt.Println(pointIfChanged("Kinds", note.Kinds, originalNote.Kinds), note.Kinds)
-		fmt.Println(pointIfChanged("Areas", note.Areas, originalNote.Areas), note.Areas)
+		fmt.Println(pointIfChanged("SIGs", note.SIGs, originalNote.SIGs), note.SIGs, inferredMarker(note, notes.InferredSIGs))
+		fmt.Println(pointIfChanged("Kinds", note.Kinds, originalNote.Kinds), note.Kinds, inferredMarker(note, notes.InferredKinds))
+		fmt.Println(pointIfChanged("Areas", note.Areas, originalNote.Areas), note.Areas, inferredMarker(note, notes.InferredAreas))
 		fmt.Println(pointIfChanged("Feature", note.Feature, originalNote.Feature), note.Feature)
 		fmt.Println(pointIfChanged("ActionRequired", note.ActionRequired, originalNote.ActionRequired), note.ActionRequired)
 		fmt.Println(pointIfChanged("DoNotPublish", note.DoNotPublish, originalNote.DoNotPublish), note.DoNotPublish)
@@ -1181,6 +1275,26 @@ func fixReleaseNotes(workDir string, releaseNotes *notes.ReleaseNotes) error {
 		text := util.WrapText(note.Text, 80)
 		fmt.Println(spacer + strings.ReplaceAll(text, nl, nl+spacer))
 
+		if len(note.Inferred) > 0 {
+			_, confirm, err := util.Ask(
+				fmt.Sprintf("\n- Confirm the inferred %s for PR #%d? (Y/n)", strings.Join(note.Inferred, ", "), note.PrNumber),
+				"y:Y:yes|n:N:no|y", 10,
+			)
+			if err != nil {
+				// If the user cancelled with ctr+c exit and continue the PR flow
+				if err.(util.UserInputError).IsCtrlC() {
+					logrus.Info("Input cancelled, exiting edit flow")
+					return nil
+				}
+				return fmt.Errorf("while asking to confirm inferred labels: %w", err)
+			}
+			if confirm {
+				if err := confirmInferredLabels(pr, workDir, note); err != nil {
+					return fmt.Errorf("while confirming inferred labels: %w", err)
+				}
+			}
+		}
+
 		_, choice, err := util.Ask(fmt.Sprintf("\n- Fix note for PR #%d? (y/N)", note.PrNumber), "y:Y:yes|n:N:no|n", 10)
 		if err != nil {
 			// If the user cancelled with ctr+c exit and continue the PR flow
@@ -1236,6 +1350,49 @@ func fixReleaseNotes(workDir string, releaseNotes *notes.ReleaseNotes) error {
 	return nil
 }
 
+// inferredMarker returns a marker for fields of the note which have been
+// inferred instead of being set from the PR labels.
+func inferredMarker(note *notes.ReleaseNote, field string) string {
+	if note.IsInferred(field) {
+		return "(inferred)"
+	}
+	return ""
+}
+
+// confirmInferredLabels writes a map which sets the inferred fields of the
+// note explicitly, which marks them as confirmed for subsequent runs. The
+// map is applied before the map of editReleaseNote, which can still override
+// the confirmed values.
+func confirmInferredLabels(pr int, workDir string, note *notes.ReleaseNote) error {
+	noteMap := &notes.ReleaseNotesMap{PR: pr}
+	for _, field := range note.Inferred {
+		switch field {
+		case notes.InferredSIGs:
+			noteMap.ReleaseNote.SIGs = &note.SIGs
+		case notes.InferredKinds:
+			noteMap.ReleaseNote.Kinds = &note.Kinds
+		case notes.InferredAreas:
+			noteMap.ReleaseNote.Areas = &note.Areas
+		}
+	}
+
+	mapYAML, err := yaml.Marshal(noteMap)
+	if err != nil {
+		return fmt.Errorf("marshalling inferred labels map: %w", err)
+	}
+
+	mapPath := filepath.Join(workDir, mapsMainDirectory, fmt.Sprintf("pr-%d-inferred-map.yaml", pr))
+	if err := os.WriteFile(mapPath, mapYAML, os.FileMode(0o644)); err != nil {
+		return fmt.Errorf("writing inferred labels map: %w", err)
+	}
+
+	if err := note.ApplyMap(noteMap, false); err != nil {
+		return fmt.Errorf("applying inferred labels map: %w", err)
+	}
+	logrus.Infof("Confirmed inferred labels of PR #%d in %s", pr, mapPath)
+	return nil
+}
+
 // Check two values and print a prefix if they are different.
 func pointIfChanged(label string, var1, var2 interface{}) string {
 	changed := false
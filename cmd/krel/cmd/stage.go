@@ -17,6 +17,7 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/base64"
 	"fmt"
 	"strings"
 
@@ -26,6 +27,7 @@ import (
 	"sigs.k8s.io/release-sdk/github"
 
 	"k8s.io/release/pkg/anago"
+	"k8s.io/release/pkg/pipeline"
 	"k8s.io/release/pkg/release"
 )
 
@@ -67,15 +69,31 @@ Build (GCB) job which does:
 }
 
 var (
-	stageOptions = anago.DefaultStageOptions()
-	submitJob    = true
-	stream       = false
+	stageOptions      = anago.DefaultStageOptions()
+	submitJob         = true
+	stream            = false
+	listSteps         = false
+	versionPolicyFile = ""
+	versionPolicyData = ""
 )
 
 const (
-	buildVersionFlag = "build-version"
-	submitJobFlag    = "submit"
-	streamFlag       = "stream"
+	buildVersionFlag      = "build-version"
+	submitJobFlag         = "submit"
+	streamFlag            = "stream"
+	resumeFlag            = "resume"
+	onlyStepsFlag         = "only"
+	skipStepsFlag         = "skip"
+	listStepsFlag         = "list-steps"
+	reportFileFlag        = "report-file"
+	localDirFlag          = "local-dir"
+	versionPolicyFlag     = "version-policy"
+	versionPolicyDataFlag = "version-policy-data"
+	notesStateDirFlag     = "notes-state-dir"
+	changelogTemplateFlag = "changelog-template-file"
+	moduleCacheDirFlag    = "dependencies-module-cache-dir"
+	osvDatabaseFlag       = "dependencies-osv-database"
+	dependenciesJSONFlag  = "dependencies-json-file"
 )
 
 func init() {
@@ -84,14 +102,7 @@ func init() {
 			&stageOptions.ReleaseType,
 			"type",
 			stageOptions.ReleaseType,
-			fmt.Sprintf("The release type, must be one of: '%s'",
-				strings.Join([]string{
-					release.ReleaseTypeAlpha,
-					release.ReleaseTypeBeta,
-					release.ReleaseTypeRC,
-					release.ReleaseTypeOfficial,
-				}, "', '"),
-			))
+			releaseTypeUsage())
 
 	stageCmd.PersistentFlags().
 		StringVar(
@@ -125,7 +136,119 @@ func init() {
 			"Run the Google Cloud Build job synchronously",
 		)
 
-	for _, flag := range []string{buildVersionFlag, submitJobFlag} {
+	stageCmd.PersistentFlags().
+		BoolVar(
+			&stageOptions.Resume,
+			resumeFlag,
+			false,
+			"Resume a previously failed local run by skipping all already completed steps",
+		)
+
+	stageCmd.PersistentFlags().
+		StringSliceVar(
+			&stageOptions.OnlySteps,
+			onlyStepsFlag,
+			nil,
+			"Run only the steps with the provided names when resuming a local run",
+		)
+
+	stageCmd.PersistentFlags().
+		StringSliceVar(
+			&stageOptions.SkipSteps,
+			skipStepsFlag,
+			nil,
+			"Skip the steps with the provided names when resuming a local run",
+		)
+
+	stageCmd.PersistentFlags().
+		StringVar(
+			&stageOptions.ReportFile,
+			reportFileFlag,
+			"",
+			"Path to the JSON run report written after the run finished or failed "+
+				"(defaults to a file in the workspace directory)",
+		)
+
+	stageCmd.PersistentFlags().
+		StringVar(
+			&stageOptions.LocalDir,
+			localDirFlag,
+			"",
+			"Run a mocked local stage fully offline by storing all artifacts in the "+
+				"provided directory instead of GCS and writing the container images "+
+				"to an OCI image layout inside of it",
+		)
+
+	stageCmd.PersistentFlags().
+		StringVar(
+			&stageOptions.NotesStateDir,
+			notesStateDirFlag,
+			"",
+			"Directory to persist the release notes gathered for the changelog, "+
+				"consecutive local runs then only process the new commits",
+		)
+
+	stageCmd.PersistentFlags().
+		StringVar(
+			&stageOptions.ChangelogTemplateFile,
+			changelogTemplateFlag,
+			"",
+			"File with Go templates which redefine the default changelog templates, "+
+				"for example {{define \"downloads\"}}...{{end}}",
+		)
+
+	stageCmd.PersistentFlags().
+		StringVar(
+			&stageOptions.DependenciesModuleCacheDir,
+			moduleCacheDirFlag,
+			"",
+			"Directory to detect the licenses of the changed dependencies from, "+
+				"defaults to the Go module cache",
+		)
+
+	stageCmd.PersistentFlags().
+		StringVar(
+			&stageOptions.DependenciesOSVDatabase,
+			osvDatabaseFlag,
+			"",
+			"Offline OSV database snapshot, either a directory or zip archive, "+
+				"to annotate the changed dependencies with their vulnerabilities",
+		)
+
+	stageCmd.PersistentFlags().
+		StringVar(
+			&stageOptions.DependenciesJSONFile,
+			dependenciesJSONFlag,
+			"",
+			"File to additionally write the dependency report of the changelog to as JSON",
+		)
+
+	stageCmd.PersistentFlags().
+		StringVar(
+			&versionPolicyFile,
+			versionPolicyFlag,
+			"",
+			"Path to a YAML version policy defining custom pre-release labels "+
+				"and release branch names",
+		)
+
+	stageCmd.PersistentFlags().
+		StringVar(
+			&versionPolicyData,
+			versionPolicyDataFlag,
+			"",
+			"Base64 encoded JSON version policy, used to pass the policy to Google Cloud Build jobs",
+		)
+
+	stageCmd.PersistentFlags().
+		BoolVar(
+			&listSteps,
+			listStepsFlag,
+			false,
+			"List all available steps and exit",
+		)
+
+	for _, flag := range []string{buildVersionFlag, submitJobFlag, versionPolicyDataFlag} {
 		if err := stageCmd.PersistentFlags().MarkHidden(flag); err != nil {
 			logrus.Fatal(err)
 		}
@@ -136,8 +259,23 @@ func init() {
 
 func runStage(options *anago.StageOptions) error {
 	options.NoMock = rootOpts.nomock
+	if err := loadVersionPolicy(options.Options); err != nil {
+		return err
+	}
 	stage := anago.NewStage(options)
+	if listSteps {
+		p, err := stage.Pipeline()
+		if err != nil {
+			return fmt.Errorf("get stage steps: %w", err)
+		}
+		printSteps(p)
+		return nil
+	}
+
 	if submitJob {
+		if err := validateLocalRunOptions(options.Options); err != nil {
+			return err
+		}
 		// Perform a local check of the specified options before launching a
 		// Cloud Build job:
 		if err := options.Validate(&anago.State{}); err != nil {
@@ -147,3 +285,77 @@ func runStage(options *anago.StageOptions) error {
 	}
 	return stage.Run()
 }
+
+// validateLocalRunOptions ensures that options which only apply to local runs
+// are not used when submitting a Google Cloud Build job.
+func validateLocalRunOptions(options *anago.Options) error {
+	if options.Resume || len(options.OnlySteps) > 0 || len(options.SkipSteps) > 0 {
+		return fmt.Errorf(
+			"--%s, --%s and --%s are only supported for local runs",
+			resumeFlag, onlyStepsFlag, skipStepsFlag,
+		)
+	}
+	if options.LocalDir != "" {
+		return fmt.Errorf("--%s is only supported for local runs", localDirFlag)
+	}
+	if options.NotesStateDir != "" {
+		return fmt.Errorf("--%s is only supported for local runs", notesStateDirFlag)
+	}
+	if options.ChangelogTemplateFile != "" {
+		return fmt.Errorf("--%s is only supported for local runs", changelogTemplateFlag)
+	}
+	if options.DependenciesModuleCacheDir != "" {
+		return fmt.Errorf("--%s is only supported for local runs", moduleCacheDirFlag)
+	}
+	if options.DependenciesOSVDatabase != "" {
+		return fmt.Errorf("--%s is only supported for local runs", osvDatabaseFlag)
+	}
+	if options.DependenciesJSONFile != "" {
+		return fmt.Errorf("--%s is only supported for local runs", dependenciesJSONFlag)
+	}
+	return nil
+}
+
+// loadVersionPolicy sets the version policy of the options if a policy file
+// or encoded policy data has been provided.
+func loadVersionPolicy(options *anago.Options) error {
+	var (
+		policy *release.VersionPolicy
+		err    error
+	)
+	switch {
+	case versionPolicyFile != "" && versionPolicyData != "":
+		return fmt.Errorf("--%s and --%s are mutually exclusive", versionPolicyFlag, versionPolicyDataFlag)
+	case versionPolicyFile != "":
+		policy, err = release.LoadVersionPolicy(versionPolicyFile)
+	case versionPolicyData != "":
+		content, decodeErr := base64.StdEncoding.DecodeString(versionPolicyData)
+		if decodeErr != nil {
+			return fmt.Errorf("decode version policy: %w", decodeErr)
+		}
+		policy, err = release.ParseVersionPolicy(content)
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("load version policy: %w", err)
+	}
+	options.VersionPolicy = policy
+	return nil
+}
+
+// releaseTypeUsage returns the usage of the release type flags.
+func releaseTypeUsage() string {
+	return fmt.Sprintf(
+		"The release type, must be one of: '%s' or a pre-release label of the --%s",
+		strings.Join(release.DefaultVersionPolicy().ReleaseTypes(), "', '"),
+		versionPolicyFlag,
+	)
+}
+
+// printSteps prints the name and description of every pipeline step.
+func printSteps(p *pipeline.Pipeline) {
+	for _, step := range p.Steps() {
+		fmt.Printf("%-25s %s\n", step.Name, step.Description)
+	}
+}
@@ -31,8 +31,18 @@ import (
 	"k8s.io/release/pkg/notes"
 )
 
+const (
+	validateOutputText   = "text"
+	validateOutputGitHub = "github"
+)
+
 type releaseNotesValidateOptions struct {
 	pathToReleaseNotes string
+	outputFormat       string
+	releaseNotesJSON   string
+	knownSIGs          []string
+	knownAreas         []string
+	printSchema        bool
 }
 
 var releaseNotesValidateOpts = &releaseNotesValidateOptions{}
@@ -45,6 +55,45 @@ func init() {
 		"The path to the release notes to validate. Can be a top level directory or a specific file.",
 	)
 
+	validateCmd.PersistentFlags().StringVar(
+		&releaseNotesValidateOpts.outputFormat,
+		"output-format",
+		validateOutputText,
+		fmt.Sprintf(
+			"The format of the reported issues, %q prints GitHub annotations for presubmits (options: %s, %s)",
+			validateOutputGitHub, validateOutputText, validateOutputGitHub,
+		),
+	)
+
+	validateCmd.PersistentFlags().StringVar(
+		&releaseNotesValidateOpts.releaseNotesJSON,
+		"release-notes-json",
+		"",
+		"The release notes JSON of the release range as written by 'release-notes generate --format=json'. "+
+			"If set, maps for PRs which are not part of it are reported.",
+	)
+
+	validateCmd.PersistentFlags().StringSliceVar(
+		&releaseNotesValidateOpts.knownSIGs,
+		"known-sigs",
+		notes.DefaultMapLabels().SIGs,
+		"The known sig label values of the maps",
+	)
+
+	validateCmd.PersistentFlags().StringSliceVar(
+		&releaseNotesValidateOpts.knownAreas,
+		"known-areas",
+		[]string{},
+		"The known area label values of the maps, areas are not validated if empty",
+	)
+
+	validateCmd.PersistentFlags().BoolVar(
+		&releaseNotesValidateOpts.printSchema,
+		"print-schema",
+		false,
+		"Print the JSON schema of the release notes maps and exit",
+	)
+
 	// Add the validation subcommand to the release-notes command
 	releaseNotesCmd.AddCommand(validateCmd)
 }
@@ -59,10 +108,21 @@ The 'validate' subcommand of krel has been developed to:
 
 1. Check release notes maps for valid yaml.
 
-2. Check release notes maps for valid punctuation.`,
+2. Check release notes maps for valid punctuation.
+
+3. Check release notes maps against their JSON schema and the known kind,
+   sig and area label values.
+
+4. Check for duplicate maps of the same PR and, if a release notes JSON is
+   provided, for maps of PRs which are not part of the release.`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if releaseNotesValidateOpts.printSchema {
+			fmt.Print(string(notes.ReleaseNotesMapSchema))
+			return nil
+		}
+
 		// Ensure exactly one argument is provided
 		if releaseNotesValidateOpts.pathToReleaseNotes == "" {
 			return errors.New("path to release notes must be provided via --path-to-release-notes")
@@ -84,7 +144,26 @@ func runValidateReleaseNotes(releaseNotesPath string) (err error) {
 		return fmt.Errorf("release notes path %s does not exist", releaseNotesPath)
 	}
 
+	opts := releaseNotesValidateOpts
+	if opts.outputFormat != validateOutputText && opts.outputFormat != validateOutputGitHub {
+		return fmt.Errorf("invalid output format: %s", opts.outputFormat)
+	}
+
+	labels := notes.DefaultMapLabels()
+	labels.SIGs = opts.knownSIGs
+	labels.Areas = opts.knownAreas
+	validator := notes.NewMapValidator(labels)
+
+	if opts.releaseNotesJSON != "" {
+		releaseNotes, err := notes.ReadReleaseNotesJSON(opts.releaseNotesJSON)
+		if err != nil {
+			return fmt.Errorf("reading release notes of the release: %w", err)
+		}
+		validator.SetReleasePRs(releaseNotes.History())
+	}
+
 	// Validate the YAML files in the directory
+	validationErrors := []string{}
 	err = filepath.Walk(releaseNotesPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -95,7 +174,24 @@ func runValidateReleaseNotes(releaseNotesPath string) (err error) {
 
 			// Validate YAML
 			if err := ValidateYamlMap(path); err != nil {
-				return fmt.Errorf("validating YAML file %s: %w", path, err)
+				validationErrors = append(validationErrors, fmt.Sprintf("validating YAML file %s: %v", path, err))
+				if opts.outputFormat == validateOutputGitHub {
+					issue := &notes.MapValidationIssue{
+						File: path, Line: 1, Severity: notes.MapValidationError, Message: err.Error(),
+					}
+					fmt.Println(issue.GitHubAnnotation())
+				}
+				return nil
+			}
+
+			// Validate against the schema and the other maps
+			issuesBefore := len(validator.Issues())
+			if err := validator.ValidateFile(path); err != nil {
+				return fmt.Errorf("validating map file %s: %w", path, err)
+			}
+			if len(validator.Issues()) > issuesBefore {
+				fmt.Printf("YAML file %s has validation issues.\n", path)
+				return nil
 			}
 
 			fmt.Printf("YAML file %s is valid.\n", path)
@@ -106,6 +202,24 @@ func runValidateReleaseNotes(releaseNotesPath string) (err error) {
 		return fmt.Errorf("validating release notes: %w", err)
 	}
 
+	for _, issue := range validator.Issues() {
+		if opts.outputFormat == validateOutputGitHub {
+			fmt.Println(issue.GitHubAnnotation())
+		} else {
+			fmt.Println(issue.String())
+		}
+		if issue.Severity == notes.MapValidationError {
+			validationErrors = append(validationErrors, issue.String())
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return fmt.Errorf(
+			"validating release notes: found %d errors:\n%s",
+			len(validationErrors), strings.Join(validationErrors, "\n"),
+		)
+	}
+
 	fmt.Println("All release notes are valid.")
 	return nil
 }
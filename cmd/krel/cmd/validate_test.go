@@ -17,6 +17,8 @@ limitations under the License.
 package cmd
 
 import (
+	"io"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -55,3 +57,49 @@ func TestRunValidateReleaseNotes(t *testing.T) {
 	assert.Error(t, err, "Expected error for invalid yaml")
 	assert.Contains(t, err.Error(), "YAML unmarshaling testdata/validation-data/invalid-indent.yaml", "Error should be about invalid yaml")
 }
+
+func TestRunValidateReleaseNotesOptions(t *testing.T) {
+	testDataPath := "testdata/validation-data"
+	defaultOpts := *releaseNotesValidateOpts
+	defer func() { *releaseNotesValidateOpts = defaultOpts }()
+
+	// Invalid output format
+	releaseNotesValidateOpts.outputFormat = "invalid"
+	err := runValidateReleaseNotes(filepath.Join(testDataPath, "valid.yaml"))
+	assert.Error(t, err, "Expected error for invalid output format")
+
+	// GitHub annotations
+	releaseNotesValidateOpts.outputFormat = validateOutputGitHub
+	err = runValidateReleaseNotes(filepath.Join(testDataPath, "valid.yaml"))
+	assert.NoError(t, err, "Expected no error for valid YAML file")
+
+	// Map of a PR which is not part of the release
+	releaseNotesJSON := filepath.Join(t.TempDir(), "release-notes.json")
+	assert.NoError(t, os.WriteFile(
+		releaseNotesJSON, []byte(`{"1": {"pr_number": 1, "text": "Text."}}`), 0o600,
+	))
+	releaseNotesValidateOpts.releaseNotesJSON = releaseNotesJSON
+	output := captureStdout(t, func() {
+		err = runValidateReleaseNotes(filepath.Join(testDataPath, "valid.yaml"))
+	})
+	assert.Error(t, err, "Expected error for PR outside of the release")
+	assert.Contains(t, err.Error(), "PR #125157 is not part of the release")
+	assert.Contains(t, output, "valid.yaml has validation issues.")
+	assert.NotContains(t, output, "is valid.")
+}
+
+func captureStdout(t *testing.T, fn func()) string {
+	reader, writer, err := os.Pipe()
+	assert.NoError(t, err)
+
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() { os.Stdout = stdout }()
+
+	fn()
+	assert.NoError(t, writer.Close())
+
+	output, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	return string(output)
+}
@@ -45,6 +45,7 @@ const (
 type checkPROptions struct {
 	options.Options
 	PullRequests []int
+	MinScore     int
 }
 
 func (o *checkPROptions) ValidateAndFinish() error {
@@ -68,8 +69,13 @@ func (o *checkPROptions) ValidateAndFinish() error {
 		orgErr = errors.New("no GitHub repository specified")
 	}
 
+	var scoreErr error
+	if o.MinScore < 0 || o.MinScore > notes.MaxQualityScore {
+		scoreErr = fmt.Errorf("minimum quality score has to be between 0 and %d", notes.MaxQualityScore)
+	}
+
 	return errors.Join(
-		lenErr, prNrErr, orgErr, repoErr,
+		lenErr, prNrErr, orgErr, repoErr, scoreErr,
 	)
 }
 
@@ -114,6 +120,13 @@ func addCheckPRFlags(subcommand *cobra.Command) {
 		[]int{},
 		"pull request number(s) to check",
 	)
+
+	subcommand.PersistentFlags().IntVar(
+		&checkPROpts.MinScore,
+		"min-score",
+		0,
+		"minimum quality score of the release notes, suggestions are printed for notes scoring below it",
+	)
 }
 
 func addCheckPR(parent *cobra.Command) {
@@ -166,16 +179,29 @@ To generate release notes from these blocks, use release-notes generate.
 			}
 
 			errs := []error{}
+			missingNotes := false
 
 			for _, prNr := range checkPROpts.PullRequests {
-				_, err := g.ReleaseNoteForPullRequest(prNr)
+				note, err := g.ReleaseNoteForPullRequest(prNr)
 				if err != nil {
 					errs = append(errs, fmt.Errorf("checking notes for PR #%d: %w", prNr, err))
+					missingNotes = true
+					continue
+				}
+
+				if quality := notes.ScoreReleaseNote(note); quality.Score < checkPROpts.MinScore {
+					fmt.Fprint(os.Stderr, notes.QualityReport{quality}.Text())
+					errs = append(errs, fmt.Errorf(
+						"release note of PR #%d scores %d, which is below %d",
+						prNr, quality.Score, checkPROpts.MinScore,
+					))
 				}
 			}
 
-			if len(errs) > 0 {
+			if missingNotes {
 				fmt.Fprintf(os.Stderr, "\nError Checking Release Notes:\n\n"+pullRequestGuidance)
+			}
+			if len(errs) > 0 {
 				return errors.Join(errs...)
 			}
 
@@ -85,6 +85,18 @@ func TestPROptsValidateAndFinish(t *testing.T) {
 			},
 			mustErr: true,
 		},
+		{
+			name: "invalid min score",
+			sut: checkPROptions{
+				Options: options.Options{
+					GithubOrg:  testOrg,
+					GithubRepo: testRepo,
+				},
+				PullRequests: []int{1},
+				MinScore:     101,
+			},
+			mustErr: true,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			if tc.mustErr {
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/release-utils/env"
+
+	"k8s.io/release/pkg/notes"
+)
+
+const (
+	diffFormatText     = "text"
+	diffFormatMarkdown = "markdown"
+	diffFormatJSON     = "json"
+)
+
+type diffOptions struct {
+	format     string
+	outputFile string
+}
+
+var diffOpts = &diffOptions{}
+
+func (o *diffOptions) ValidateAndFinish() error {
+	switch o.format {
+	case diffFormatText, diffFormatMarkdown, diffFormatJSON:
+		return nil
+	}
+	return fmt.Errorf("invalid diff format: %s", o.format)
+}
+
+func addDiffFlags(subcommand *cobra.Command) {
+	subcommand.PersistentFlags().StringVar(
+		&diffOpts.format,
+		"format",
+		env.Default("FORMAT", diffFormatText),
+		fmt.Sprintf("The format of the diff output (options: %s)",
+			strings.Join([]string{
+				diffFormatText, diffFormatMarkdown, diffFormatJSON,
+			}, ", "),
+		),
+	)
+
+	subcommand.PersistentFlags().StringVar(
+		&diffOpts.outputFile,
+		"output",
+		env.Default("OUTPUT", ""),
+		"The path to the file where the diff will be written, defaults to stdout",
+	)
+}
+
+// addDiff adds the diff subcommand to the main release notes cobra cmd.
+func addDiff(parent *cobra.Command) {
+	diffCmd := &cobra.Command{
+		Short: "Compare two release notes JSON documents",
+		Long: `release-notes diff compares two release notes JSON documents as
+written by "release-notes generate --format=json" and reports the added,
+removed and modified notes per PR. Modifications include the changes of the
+note text, kinds, SIGs, areas and the action required and do not publish flags.`,
+		Use:           "diff OLD.json NEW.json",
+		Args:          cobra.ExactArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PreRunE: func(*cobra.Command, []string) error {
+			return diffOpts.ValidateAndFinish()
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runDiff(args[0], args[1])
+		},
+	}
+
+	addDiffFlags(diffCmd)
+	parent.AddCommand(diffCmd)
+}
+
+func runDiff(oldPath, newPath string) error {
+	oldNotes, err := notes.ReadReleaseNotesJSON(oldPath)
+	if err != nil {
+		return fmt.Errorf("reading old release notes: %w", err)
+	}
+
+	newNotes, err := notes.ReadReleaseNotesJSON(newPath)
+	if err != nil {
+		return fmt.Errorf("reading new release notes: %w", err)
+	}
+
+	diff := notes.DiffReleaseNotes(oldNotes, newNotes)
+
+	var output string
+	switch diffOpts.format {
+	case diffFormatMarkdown:
+		output = diff.Markdown()
+	case diffFormatJSON:
+		output, err = diff.JSON()
+		if err != nil {
+			return err
+		}
+		output += "\n"
+	default:
+		output = diff.Text()
+	}
+
+	if diffOpts.outputFile == "" {
+		fmt.Print(output)
+		return nil
+	}
+
+	if err := os.WriteFile(
+		diffOpts.outputFile, []byte(output), os.FileMode(0o644),
+	); err != nil {
+		return fmt.Errorf("writing diff output: %w", err)
+	}
+	return nil
+}
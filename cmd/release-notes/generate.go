@@ -26,6 +26,8 @@ import (
 	"sigs.k8s.io/release-utils/env"
 
 	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/document"
+	"k8s.io/release/pkg/notes/forge"
 	"k8s.io/release/pkg/notes/options"
 	"k8s.io/release/pkg/release"
 )
@@ -63,6 +65,25 @@ func addGenerateFlags(subcommand *cobra.Command) {
 		"Name of github repository",
 	)
 
+	// forge is the type of the code forge hosting the repository.
+	subcommand.PersistentFlags().StringVar(
+		&opts.Forge,
+		"forge",
+		env.Default("FORGE", forge.GitHub),
+		fmt.Sprintf(
+			"Code forge hosting the repository, one of: %s, %s or %s. The org and repo refer to the namespace and project of other forges, which read their token from %s or %s",
+			forge.GitHub, forge.GitLab, forge.Gitea, forge.GitLabTokenEnvKey, forge.GiteaTokenEnvKey,
+		),
+	)
+
+	// forgeURL is the base URL of a GitLab or Gitea instance.
+	subcommand.PersistentFlags().StringVar(
+		&opts.ForgeURL,
+		"forge-url",
+		env.Default("FORGE_URL", ""),
+		fmt.Sprintf("Base URL of the GitLab or Gitea instance, defaults to %s for GitLab", forge.DefaultGitLabURL),
+	)
+
 	// output contains the path on the filesystem to where the resultant
 	// release notes should be printed.
 	subcommand.PersistentFlags().StringVar(
@@ -141,7 +162,13 @@ func addGenerateFlags(subcommand *cobra.Command) {
 		"format",
 		env.Default("FORMAT", options.FormatMarkdown),
 		fmt.Sprintf("The format for notes output (options: %s)",
-			options.FormatJSON+", "+options.FormatMarkdown,
+			strings.Join([]string{
+				options.FormatJSON,
+				options.FormatMarkdown,
+				options.FormatAsciiDoc,
+				options.FormatRST,
+				options.FormatKeepAChangelog,
+			}, ", "),
 		),
 	)
 
@@ -229,6 +256,76 @@ func addGenerateFlags(subcommand *cobra.Command) {
 		"Replay a previously recorded API from a directory",
 	)
 
+	subcommand.PersistentFlags().StringVar(
+		&opts.PRCacheDir,
+		"pr-cache",
+		env.Default("PR_CACHE", ""),
+		"Directory of the persistent PR metadata cache, refreshed from the API unless running --offline",
+	)
+
+	subcommand.PersistentFlags().BoolVar(
+		&opts.Offline,
+		"offline",
+		env.IsSet("OFFLINE"),
+		"Generate the notes purely from the local repository and the PR cache (requires --pr-cache)",
+	)
+
+	subcommand.PersistentFlags().StringVar(
+		&opts.StateDir,
+		"state-dir",
+		env.Default("STATE_DIR", ""),
+		"Directory to persist the gathered notes, which allows subsequent runs to only process new commits",
+	)
+
+	subcommand.PersistentFlags().BoolVar(
+		&opts.InferLabels,
+		"infer-labels",
+		env.IsSet("INFER_LABELS"),
+		"Propose the kind, SIGs and areas of notes whose PRs miss the corresponding labels",
+	)
+
+	subcommand.PersistentFlags().StringVar(
+		&opts.OwnershipMapPath,
+		"ownership-map",
+		env.Default("OWNERSHIP_MAP", ""),
+		"OWNERS-style file with path filters to sig/ and area/ labels, used to infer SIGs and areas from the touched files",
+	)
+
+	subcommand.PersistentFlags().StringSliceVar(
+		&releaseNotesOpts.filter.SIGs,
+		"filter-sigs",
+		[]string{},
+		"Only include notes of the provided SIGs, for example node or sig/node",
+	)
+
+	subcommand.PersistentFlags().StringSliceVar(
+		&releaseNotesOpts.filter.Areas,
+		"filter-areas",
+		[]string{},
+		"Only include notes of the provided areas, for example kubeadm or area/kubeadm",
+	)
+
+	subcommand.PersistentFlags().StringSliceVar(
+		&releaseNotesOpts.filter.Kinds,
+		"filter-kinds",
+		[]string{},
+		"Only include notes of the provided kinds, for example bug or kind/bug",
+	)
+
+	subcommand.PersistentFlags().StringSliceVar(
+		&releaseNotesOpts.filter.PathPrefixes,
+		"filter-paths",
+		[]string{},
+		"Only include notes of PRs touching files below the provided path prefixes, which requires retrieving the files of every PR",
+	)
+
+	subcommand.PersistentFlags().StringVar(
+		&releaseNotesOpts.splitBy,
+		"split-by",
+		"",
+		fmt.Sprintf("Write one document per group instead of a single one, supported is only %q. The group gets appended to the output file name", document.SplitBySIG),
+	)
+
 	subcommand.PersistentFlags().BoolVar(
 		&releaseNotesOpts.dependencies,
 		"dependencies",
@@ -236,12 +333,33 @@ func addGenerateFlags(subcommand *cobra.Command) {
 		"Add dependency report",
 	)
 
+	subcommand.PersistentFlags().StringVar(
+		&releaseNotesOpts.dependencyOptions.ModuleCacheDir,
+		"dependencies-module-cache-dir",
+		"",
+		"Directory to detect the licenses of the changed dependencies from, defaults to the Go module cache",
+	)
+
+	subcommand.PersistentFlags().StringVar(
+		&releaseNotesOpts.dependencyOptions.OSVDatabase,
+		"dependencies-osv-database",
+		"",
+		"Offline OSV database snapshot, either a directory or zip archive, to annotate the changed dependencies with their vulnerabilities",
+	)
+
+	subcommand.PersistentFlags().StringVar(
+		&releaseNotesOpts.dependenciesJSONFile,
+		"dependencies-json-file",
+		"",
+		"File to additionally write the dependency report to as JSON",
+	)
+
 	subcommand.PersistentFlags().StringSliceVarP(
 		&opts.MapProviderStrings,
 		"maps-from",
 		"m",
 		[]string{},
-		"specify a location to recursively look for release notes *.y[a]ml file mappings",
+		"specify a location to recursively look for release notes *.y[a]ml file mappings, which can be a local directory, a gs:// path or a git+<url>[#<ref>[:<path>]] repository",
 	)
 	subcommand.PersistentFlags().BoolVar(
 		&opts.ListReleaseNotesV2,
@@ -268,6 +386,9 @@ func addGenerate(parent *cobra.Command) {
 			return WriteReleaseNotes(releaseNotes)
 		},
 		PreRunE: func(*cobra.Command, []string) error {
+			if err := releaseNotesOpts.ValidateAndFinish(); err != nil {
+				return err
+			}
 			return opts.ValidateAndFinish()
 		},
 	}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -29,6 +30,7 @@ import (
 	"sigs.k8s.io/mdtoc/pkg/mdtoc"
 	"sigs.k8s.io/release-sdk/git"
 	"sigs.k8s.io/release-utils/log"
+	"sigs.k8s.io/release-utils/util"
 	"sigs.k8s.io/release-utils/version"
 
 	"k8s.io/release/pkg/notes"
@@ -40,6 +42,24 @@ type releaseNotesOptions struct {
 	outputFile      string
 	tableOfContents bool
 	dependencies    bool
+	filter          document.Filter
+	splitBy         string
+
+	dependencyOptions    notes.DependencyOptions
+	dependenciesJSONFile string
+}
+
+// ValidateAndFinish checks the document related options and enables
+// recording the files of PRs if they get filtered by path.
+func (o *releaseNotesOptions) ValidateAndFinish() error {
+	if o.splitBy != "" && o.splitBy != document.SplitBySIG {
+		return fmt.Errorf("unsupported split mode %q, supported is only %q", o.splitBy, document.SplitBySIG)
+	}
+
+	if len(o.filter.PathPrefixes) > 0 {
+		opts.RecordFiles = true
+	}
+	return nil
 }
 
 var (
@@ -47,7 +67,44 @@ var (
 	opts             = options.New()
 )
 
-func WriteReleaseNotes(releaseNotes *notes.ReleaseNotes) (err error) {
+func WriteReleaseNotes(releaseNotes *notes.ReleaseNotes) error {
+	releaseNotes = releaseNotesOpts.filter.Apply(releaseNotes)
+
+	if releaseNotesOpts.splitBy == "" {
+		return writeReleaseNotesFile(releaseNotes, releaseNotesOpts.outputFile, "release-notes-")
+	}
+
+	split, err := document.Split(releaseNotes, releaseNotesOpts.splitBy)
+	if err != nil {
+		return fmt.Errorf("splitting release notes: %w", err)
+	}
+
+	for _, key := range document.SplitKeys(split) {
+		outputFile := ""
+		if releaseNotesOpts.outputFile != "" {
+			outputFile = splitOutputFile(releaseNotesOpts.outputFile, releaseNotesOpts.splitBy, key)
+		}
+
+		logrus.Infof("Writing release notes for %s %s", releaseNotesOpts.splitBy, key)
+		if err := writeReleaseNotesFile(
+			split[key], outputFile, fmt.Sprintf("release-notes-%s-%s-", releaseNotesOpts.splitBy, key),
+		); err != nil {
+			return fmt.Errorf("writing release notes for %s %s: %w", releaseNotesOpts.splitBy, key, err)
+		}
+	}
+	return nil
+}
+
+// splitOutputFile inserts the split mode and key into the provided output
+// file name, for example "notes.md" becomes "notes-sig-node.md".
+func splitOutputFile(outputFile, mode, key string) string {
+	ext := filepath.Ext(outputFile)
+	return fmt.Sprintf("%s-%s-%s%s", strings.TrimSuffix(outputFile, ext), mode, key, ext)
+}
+
+// writeReleaseNotesFile renders the release notes into the output file or a
+// temporary file matching the pattern if no output file is provided.
+func writeReleaseNotesFile(releaseNotes *notes.ReleaseNotes, outputFile, tempPattern string) (err error) {
 	logrus.Infof(
 		"Got %d release notes, performing rendering",
 		len(releaseNotes.History()),
@@ -59,17 +116,18 @@ func WriteReleaseNotes(releaseNotes *notes.ReleaseNotes) (err error) {
 		existingNotes notes.ReleaseNotesByPR
 	)
 
-	if releaseNotesOpts.outputFile != "" {
-		output, err = os.OpenFile(releaseNotesOpts.outputFile, os.O_RDWR|os.O_CREATE, os.FileMode(0o644))
+	if outputFile != "" {
+		output, err = os.OpenFile(outputFile, os.O_RDWR|os.O_CREATE, os.FileMode(0o644))
 		if err != nil {
 			return fmt.Errorf("opening the supplied output file: %w", err)
 		}
 	} else {
-		output, err = os.CreateTemp("", "release-notes-")
+		output, err = os.CreateTemp("", tempPattern)
 		if err != nil {
 			return fmt.Errorf("creating a temporary file to write the release notes to: %w", err)
 		}
 	}
+	defer output.Close()
 
 	// Contextualized release notes can be printed in a variety of formats
 	if opts.Format == options.FormatJSON {
@@ -105,6 +163,20 @@ func WriteReleaseNotes(releaseNotes *notes.ReleaseNotes) (err error) {
 		if err := enc.Encode(releaseNotes.ByPR()); err != nil {
 			return fmt.Errorf("encoding JSON output: %w", err)
 		}
+	} else if opts.Format != options.FormatMarkdown {
+		doc, err := document.New(releaseNotes, opts.StartRev, opts.EndRev)
+		if err != nil {
+			return fmt.Errorf("creating release note document: %w", err)
+		}
+
+		rendered, err := doc.Render(opts.Format, opts.ReleaseBucket, opts.ReleaseTars, "")
+		if err != nil {
+			return fmt.Errorf("rendering release note document: %w", err)
+		}
+
+		if _, err := output.WriteString(rendered); err != nil {
+			return fmt.Errorf("writing output file: %w", err)
+		}
 	} else {
 		doc, err := document.New(releaseNotes, opts.StartRev, opts.EndRev)
 		if err != nil {
@@ -121,10 +193,7 @@ func WriteReleaseNotes(releaseNotes *notes.ReleaseNotes) (err error) {
 			if opts.StartSHA == opts.EndSHA {
 				logrus.Info("Skipping dependency report because start and end SHA are the same")
 			} else {
-				url := git.GetRepoURL(opts.GithubOrg, opts.GithubRepo, false)
-				deps, err := notes.NewDependencies().ChangesForURL(
-					url, opts.StartSHA, opts.EndSHA,
-				)
+				deps, err := dependencyReport()
 				if err != nil {
 					return fmt.Errorf("generating dependency report: %w", err)
 				}
@@ -153,6 +222,38 @@ func WriteReleaseNotes(releaseNotes *notes.ReleaseNotes) (err error) {
 	return nil
 }
 
+// dependencyReport creates the dependency report between the start and end
+// SHA from the local repository, which gets cloned if it does not exist. It
+// returns the markdown of the report and writes it as JSON if requested.
+func dependencyReport() (string, error) {
+	if !util.Exists(opts.RepoPath) {
+		if _, err := git.CloneOrOpenGitHubRepo(
+			opts.RepoPath, opts.GithubOrg, opts.GithubRepo, false,
+		); err != nil {
+			return "", fmt.Errorf("cloning repository: %w", err)
+		}
+	}
+
+	dependencyOpts := releaseNotesOpts.dependencyOptions
+	dependencyOpts.RepoPath = opts.RepoPath
+	report, err := notes.NewDependencies().Report(&dependencyOpts, opts.StartSHA, opts.EndSHA)
+	if err != nil {
+		return "", err
+	}
+
+	if releaseNotesOpts.dependenciesJSONFile != "" {
+		content, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("marshal dependency report: %w", err)
+		}
+		if err := os.WriteFile(releaseNotesOpts.dependenciesJSONFile, content, os.FileMode(0o644)); err != nil {
+			return "", fmt.Errorf("write dependency report: %w", err)
+		}
+		logrus.Infof("Dependency report written to file: %s", releaseNotesOpts.dependenciesJSONFile)
+	}
+	return report.Markdown(), nil
+}
+
 // hackDefaultSubcommand is a utility function that hacks the "generate"
 // subcommand as default to avoid breaking compatibility with previoud
 // versions of release-notes.
@@ -164,7 +265,7 @@ func hackDefaultSubcommand(cmd *cobra.Command) {
 
 		// Check if the first arg corresponds to a registered subcommand
 		for _, command := range cmd.Commands() {
-			if command.Use == os.Args[1] {
+			if command.Name() == os.Args[1] {
 				return
 			}
 		}
@@ -187,6 +288,8 @@ func main() {
 
 	addGenerate(cmd)
 	addCheckPR(cmd)
+	addDiff(cmd)
+	addQuality(cmd)
 
 	cmd.AddCommand(version.WithFont("slant"))
 
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/release-utils/command"
+
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/document"
+)
+
+func TestReleaseNotesOptsValidateAndFinish(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		sut         releaseNotesOptions
+		mustErr     bool
+		recordFiles bool
+	}{
+		{
+			name: "no filter",
+		},
+		{
+			name:    "split by SIG",
+			sut:     releaseNotesOptions{splitBy: document.SplitBySIG},
+			mustErr: false,
+		},
+		{
+			name:    "unsupported split mode",
+			sut:     releaseNotesOptions{splitBy: "area"},
+			mustErr: true,
+		},
+		{
+			name:        "path filter records files",
+			sut:         releaseNotesOptions{filter: document.Filter{PathPrefixes: []string{"cmd/kubeadm"}}},
+			recordFiles: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			opts.RecordFiles = false
+			err := tc.sut.ValidateAndFinish()
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.recordFiles, opts.RecordFiles)
+		})
+	}
+}
+
+func TestSplitOutputFile(t *testing.T) {
+	require.Equal(t, "notes-sig-node.md", splitOutputFile("notes.md", document.SplitBySIG, "node"))
+	require.Equal(t, "/tmp/notes-sig-cli", splitOutputFile("/tmp/notes", document.SplitBySIG, "cli"))
+}
+
+func TestDependencyReport(t *testing.T) {
+	repo := t.TempDir()
+	git := func(args ...string) string {
+		res, err := command.NewWithWorkDir(repo, "git", append([]string{
+			"-c", "user.name=test", "-c", "user.email=test@example.com",
+		}, args...)...).RunSilentSuccessOutput()
+		require.NoError(t, err)
+		return res.OutputTrimNL()
+	}
+	commitGoMod := func(dependency string) string {
+		require.NoError(t, os.WriteFile(filepath.Join(repo, "go.mod"), []byte(
+			"module k8s.io/kubernetes\n\ngo 1.22\n\nrequire "+dependency+"\n",
+		), 0o600))
+		git("add", "go.mod")
+		git("commit", "-m", dependency)
+		return git("rev-parse", "HEAD")
+	}
+	git("init")
+	start := commitGoMod("github.com/google/uuid v1.5.0")
+	end := commitGoMod("github.com/google/uuid v1.6.0")
+
+	defaultOpts, defaultReleaseNotesOpts := *opts, *releaseNotesOpts
+	defer func() { *opts, *releaseNotesOpts = defaultOpts, defaultReleaseNotesOpts }()
+	opts.RepoPath = repo
+	opts.StartSHA = start
+	opts.EndSHA = end
+	releaseNotesOpts.dependencyOptions.ModuleCacheDir = t.TempDir()
+	releaseNotesOpts.dependenciesJSONFile = filepath.Join(t.TempDir(), "dependencies.json")
+
+	markdown, err := dependencyReport()
+	require.NoError(t, err)
+	require.Contains(t, markdown, "github.com/google/uuid")
+	require.Contains(t, markdown, "v1.6.0")
+
+	content, err := os.ReadFile(releaseNotesOpts.dependenciesJSONFile)
+	require.NoError(t, err)
+	report := &notes.DependencyReport{}
+	require.NoError(t, json.Unmarshal(content, report))
+	require.Len(t, report.Changed, 1)
+	require.Equal(t, "v1.5.0", report.Changed[0].From)
+}
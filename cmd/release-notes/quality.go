@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/release-utils/env"
+
+	"k8s.io/release/pkg/notes"
+)
+
+const (
+	qualityFormatText = "text"
+	qualityFormatJSON = "json"
+)
+
+type qualityOptions struct {
+	format     string
+	outputFile string
+	minScore   int
+}
+
+var qualityOpts = &qualityOptions{}
+
+func (o *qualityOptions) ValidateAndFinish() error {
+	if o.format != qualityFormatText && o.format != qualityFormatJSON {
+		return fmt.Errorf("invalid quality report format: %s", o.format)
+	}
+	if o.minScore < 0 || o.minScore > notes.MaxQualityScore {
+		return fmt.Errorf("minimum score has to be between 0 and %d", notes.MaxQualityScore)
+	}
+	return nil
+}
+
+func addQualityFlags(subcommand *cobra.Command) {
+	subcommand.PersistentFlags().StringVar(
+		&qualityOpts.format,
+		"format",
+		env.Default("FORMAT", qualityFormatText),
+		fmt.Sprintf("The format of the quality report (options: %s, %s)", qualityFormatText, qualityFormatJSON),
+	)
+
+	subcommand.PersistentFlags().StringVar(
+		&qualityOpts.outputFile,
+		"output",
+		env.Default("OUTPUT", ""),
+		"The path to the file where the report will be written, defaults to stdout",
+	)
+
+	subcommand.PersistentFlags().IntVar(
+		&qualityOpts.minScore,
+		"min-score",
+		notes.MaxQualityScore,
+		"Only report notes which score below this value",
+	)
+}
+
+// addQuality adds the quality subcommand to the main release notes cobra cmd.
+func addQuality(parent *cobra.Command) {
+	qualityCmd := &cobra.Command{
+		Short: "Score the quality of release notes and suggest improvements",
+		Long: `release-notes quality scores all notes of a release notes JSON document as
+written by "release-notes generate --format=json". The score of every note starts
+at 100 and gets reduced for each found issue: notes which are too short or too long,
+do not use the past tense, do not start with a verb, miss the final punctuation,
+do not mention the affected component, contain project internal jargon or raw
+pull request links. The notes are reported with the lowest score first, together
+with suggestions on how to improve them.`,
+		Use:           "quality NOTES.json",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PreRunE: func(*cobra.Command, []string) error {
+			return qualityOpts.ValidateAndFinish()
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runQuality(args[0])
+		},
+	}
+
+	addQualityFlags(qualityCmd)
+	parent.AddCommand(qualityCmd)
+}
+
+func runQuality(path string) error {
+	releaseNotes, err := notes.ReadReleaseNotesJSON(path)
+	if err != nil {
+		return fmt.Errorf("reading release notes: %w", err)
+	}
+
+	report := notes.ScoreReleaseNotes(releaseNotes).Filter(qualityOpts.minScore)
+
+	var output string
+	if qualityOpts.format == qualityFormatJSON {
+		output, err = report.JSON()
+		if err != nil {
+			return err
+		}
+		output += "\n"
+	} else {
+		output = report.Text()
+	}
+
+	if qualityOpts.outputFile == "" {
+		fmt.Print(output)
+		return nil
+	}
+
+	if err := os.WriteFile(
+		qualityOpts.outputFile, []byte(output), os.FileMode(0o644),
+	); err != nil {
+		return fmt.Errorf("writing quality report: %w", err)
+	}
+	return nil
+}
@@ -25,11 +25,11 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/release-sdk/git"
-	"sigs.k8s.io/release-utils/log"
 	"sigs.k8s.io/release-utils/util"
 	"sigs.k8s.io/release-utils/version"
 
 	"k8s.io/release/pkg/announce"
+	"k8s.io/release/pkg/pipeline"
 	"k8s.io/release/pkg/release"
 )
 
@@ -55,6 +55,19 @@ const (
 	// announcementHTMLFile is the file containing the release announcement in HTML format.
 	announcementHTMLFile = workspaceDir + "/src/" + announce.AnnouncementFile
 
+	// stageCheckpointFile is the default file for persisting the stage state.
+	stageCheckpointFile = workspaceDir + "/stage-checkpoint.json"
+
+	// releaseCheckpointFile is the default file for persisting the release
+	// state.
+	releaseCheckpointFile = workspaceDir + "/release-checkpoint.json"
+
+	// stageReportFile is the default file for the stage run report.
+	stageReportFile = workspaceDir + "/stage-report.json"
+
+	// releaseReportFile is the default file for the release run report.
+	releaseReportFile = workspaceDir + "/release-report.json"
+
 	// The default license for all artifacts.
 	LicenseIdentifier = "Apache-2.0"
 )
@@ -78,6 +91,66 @@ type Options struct {
 	// The build version to be released. Has to be specified in the format:
 	// `vX.Y.Z-[alpha|beta|rc].N.C+SHA`
 	BuildVersion string
+
+	// Resume a previously failed run by restoring the state from the
+	// checkpoint file and skipping all steps which have been already
+	// completed.
+	Resume bool
+
+	// CheckpointFile is the path where the state gets persisted after each
+	// completed step. Defaults to a file inside the workspace directory.
+	CheckpointFile string
+
+	// ReportFile is the path where the JSON run report gets written to after
+	// the run finished or failed. Defaults to a file inside the workspace
+	// directory.
+	ReportFile string
+
+	// CustomSteps are additional steps which get inserted into the stage or
+	// release pipeline.
+	CustomSteps []*pipeline.CustomStep
+
+	// OnlySteps restricts the run to the steps with the provided names.
+	// Idempotent steps like the options validation always run. Requires
+	// Resume, because later steps consume the state of the earlier ones.
+	OnlySteps []string
+
+	// SkipSteps are the names of the steps which should not run. Requires
+	// Resume, like OnlySteps.
+	SkipSteps []string
+
+	// LocalDir can be set to run the stage fully offline: all artifacts get
+	// stored in this directory instead of Google Cloud Storage and container
+	// images get written to an OCI image layout inside of it. Only
+	// supported for mocked stages.
+	LocalDir string
+
+	// NotesStateDir persists the release notes gathered for the changelog,
+	// which allows consecutive local stages to only process the new
+	// commits. Only used for staging.
+	NotesStateDir string
+
+	// ChangelogTemplateFile is an optional file with Go templates which
+	// redefine the default changelog templates. Only used for staging.
+	ChangelogTemplateFile string
+
+	// DependenciesModuleCacheDir is the directory to detect the licenses of
+	// the changed dependencies from, defaults to the Go module cache. Only
+	// used for staging.
+	DependenciesModuleCacheDir string
+
+	// DependenciesOSVDatabase is an optional offline OSV database snapshot
+	// to annotate the changed dependencies with their vulnerabilities. Only
+	// used for staging.
+	DependenciesOSVDatabase string
+
+	// DependenciesJSONFile is an optional file to write the dependency
+	// report of the changelog to as JSON. Only used for staging.
+	DependenciesJSONFile string
+
+	// VersionPolicy defines the supported release types and release branch
+	// names. Defaults to the Kubernetes version policy if nil.
+	VersionPolicy *release.VersionPolicy
 }
 
 // DefaultOptions returns a new Options instance.
@@ -91,8 +164,8 @@ func DefaultOptions() *Options {
 // String returns a string representation for the `ReleaseOptions` type.
 func (o *Options) String() string {
 	return fmt.Sprintf(
-		"NoMock: %v, ReleaseType: %q, BuildVersion: %q, ReleaseBranch: %q",
-		o.NoMock, o.ReleaseType, o.BuildVersion, o.ReleaseBranch,
+		"NoMock: %v, ReleaseType: %q, BuildVersion: %q, ReleaseBranch: %q, Resume: %v",
+		o.NoMock, o.ReleaseType, o.BuildVersion, o.ReleaseBranch, o.Resume,
 	)
 }
 
@@ -100,20 +173,40 @@ func (o *Options) String() string {
 func (o *Options) Validate() error {
 	logrus.Infof("Validating generic options: %s", o.String())
 
-	if o.ReleaseType != release.ReleaseTypeAlpha &&
-		o.ReleaseType != release.ReleaseTypeBeta &&
-		o.ReleaseType != release.ReleaseTypeRC &&
-		o.ReleaseType != release.ReleaseTypeOfficial {
+	policy := o.Policy()
+	if !policy.ValidReleaseType(o.ReleaseType) {
 		return fmt.Errorf("invalid release type: %s", o.ReleaseType)
 	}
 
-	if !git.IsReleaseBranch(o.ReleaseBranch) {
+	if !policy.ValidBranch(o.ReleaseBranch) {
 		return fmt.Errorf("invalid release branch: %s", o.ReleaseBranch)
 	}
 
+	if o.NoMock && o.LocalDir != "" {
+		return errors.New("local directory is only supported for mocked runs")
+	}
+
 	return nil
 }
 
+// filterSteps restricts the pipeline to the selected steps. Filtering is only
+// supported when resuming, because the skipped steps would otherwise leave the
+// state (like the release versions) of the selected ones unset.
+func (o *Options) filterSteps(p *pipeline.Pipeline) error {
+	if (len(o.OnlySteps) > 0 || len(o.SkipSteps) > 0) && !o.Resume {
+		return errors.New("selecting or skipping steps requires resuming from a checkpoint")
+	}
+	return p.SetFilter(o.OnlySteps, o.SkipSteps)
+}
+
+// Policy returns the version policy for these `Options`.
+func (o *Options) Policy() *release.VersionPolicy {
+	if o.VersionPolicy == nil {
+		return release.DefaultVersionPolicy()
+	}
+	return o.VersionPolicy
+}
+
 func (o *Options) ValidateBuildVersion(state *State) error {
 	// Verify the build version is correct:
 	correct, err := release.IsValidReleaseBuild(o.BuildVersion)
@@ -166,6 +259,19 @@ type State struct {
 
 	// startTime is the time when stage/release starts
 	startTime time.Time
+
+	// checkpointFile is the file target where the state gets persisted.
+	checkpointFile string
+
+	// completedSteps are the names of the already completed steps.
+	completedSteps []string
+
+	// stepResults are the results of the completed steps, including the
+	// ones of previous runs.
+	stepResults []*pipeline.StepResult
+
+	// results are the collected outputs of the run used for the report.
+	results runResults
 }
 
 // DefaultState returns a new empty State.
@@ -233,12 +339,13 @@ func (s *StageOptions) Validate(state *State) error {
 
 // Stage is the structure to be used for staging releases.
 type Stage struct {
-	client stageClient
+	client  stageClient
+	options *StageOptions
 }
 
 // NewStage creates a new `Stage` instance.
 func NewStage(options *StageOptions) *Stage {
-	return &Stage{NewDefaultStage(options)}
+	return &Stage{NewDefaultStage(options), options}
 }
 
 // SetClient can be used to set the internal stage client.
@@ -264,67 +371,113 @@ func (s *Stage) Run() error {
 		return fmt.Errorf("init log file: %w", err)
 	}
 
-	logger := log.NewStepLogger(12)
-	v := version.GetVersionInfo()
-	logger.Infof("Using krel version: %s", v.GitVersion)
-
-	logger.WithStep().Info("Validating options")
-	if err := s.client.ValidateOptions(); err != nil {
-		return fmt.Errorf("validate options: %w", err)
+	if err := s.client.InitCheckpoint(); err != nil {
+		return fmt.Errorf("init checkpoint: %w", err)
 	}
 
-	logger.WithStep().Info("Checking prerequisites")
-	if err := s.client.CheckPrerequisites(); err != nil {
-		return fmt.Errorf("check prerequisites: %w", err)
-	}
-
-	logger.WithStep().Info("Checking release branch state")
-	if err := s.client.CheckReleaseBranchState(); err != nil {
-		return fmt.Errorf("check release branch state: %w", err)
+	p, err := s.Pipeline()
+	if err != nil {
+		return fmt.Errorf("create stage pipeline: %w", err)
 	}
 
-	logger.WithStep().Info("Generating release version")
-	if err := s.client.GenerateReleaseVersion(); err != nil {
-		return fmt.Errorf("generate release version: %w", err)
-	}
+	v := version.GetVersionInfo()
+	logrus.Infof("Using krel version: %s", v.GitVersion)
 
-	logger.WithStep().Info("Preparing workspace")
-	if err := s.client.PrepareWorkspace(); err != nil {
-		return fmt.Errorf("prepare workspace: %w", err)
+	runErr := p.Run()
+	if err := s.client.WriteReport(p.Results(), runErr); err != nil {
+		if runErr == nil {
+			return fmt.Errorf("write report: %w", err)
+		}
+		logrus.Errorf("Unable to write run report: %v", err)
 	}
-
-	logger.WithStep().Info("Tagging repository")
-	if err := s.client.TagRepository(); err != nil {
-		return fmt.Errorf("tag repository: %w", err)
+	if runErr != nil {
+		return fmt.Errorf("run stage pipeline: %w", runErr)
 	}
 
-	logger.WithStep().Info("Building release")
-	if err := s.client.Build(); err != nil {
-		return fmt.Errorf("build release: %w", err)
-	}
-
-	logger.WithStep().Info("Generating changelog")
-	if err := s.client.GenerateChangelog(); err != nil {
-		return fmt.Errorf("generate changelog: %w", err)
-	}
+	logrus.Info("Stage done")
+	return nil
+}
 
-	logger.WithStep().Info("Verifying artifacts")
-	if err := s.client.VerifyArtifacts(); err != nil {
-		return fmt.Errorf("verifying artifacts: %w", err)
-	}
+// Pipeline returns all steps of a stage run including the custom ones.
+// Validation steps and entering the workspace are idempotent and therefore
+// always run, while all other steps are recorded in the state checkpoint.
+func (s *Stage) Pipeline() (*pipeline.Pipeline, error) {
+	p := pipeline.New(
+		&pipeline.Step{
+			Name:        "ValidateOptions",
+			Description: "Validating options",
+			Run:         s.client.ValidateOptions,
+			Idempotent:  true,
+		},
+		&pipeline.Step{
+			Name:        "CheckPrerequisites",
+			Description: "Checking prerequisites",
+			Run:         s.client.CheckPrerequisites,
+			Idempotent:  true,
+		},
+		&pipeline.Step{
+			Name:        "CheckReleaseBranchState",
+			Description: "Checking release branch state",
+			Run:         s.client.CheckReleaseBranchState,
+		},
+		&pipeline.Step{
+			Name:        "GenerateReleaseVersion",
+			Description: "Generating release version",
+			Run:         s.client.GenerateReleaseVersion,
+		},
+		&pipeline.Step{
+			Name:        "PrepareWorkspace",
+			Description: "Preparing workspace",
+			Run:         s.client.PrepareWorkspace,
+		},
+		&pipeline.Step{
+			Name:        "EnterWorkspace",
+			Description: "Entering workspace",
+			Run:         s.client.EnterWorkspace,
+			Idempotent:  true,
+		},
+		&pipeline.Step{
+			Name:        "TagRepository",
+			Description: "Tagging repository",
+			Run:         s.client.TagRepository,
+		},
+		&pipeline.Step{
+			Name:        "Build",
+			Description: "Building release",
+			Run:         s.client.Build,
+		},
+		&pipeline.Step{
+			Name:        "GenerateChangelog",
+			Description: "Generating changelog",
+			Run:         s.client.GenerateChangelog,
+		},
+		&pipeline.Step{
+			Name:        "VerifyArtifacts",
+			Description: "Verifying artifacts",
+			Run:         s.client.VerifyArtifacts,
+		},
+		&pipeline.Step{
+			Name:        "GenerateBillOfMaterials",
+			Description: "Generating bill of materials",
+			Run:         s.client.GenerateBillOfMaterials,
+		},
+		&pipeline.Step{
+			Name:        "StageArtifacts",
+			Description: "Staging artifacts",
+			Run:         s.client.StageArtifacts,
+		},
+	)
 
-	logger.WithStep().Info("Generating bill of materials")
-	if err := s.client.GenerateBillOfMaterials(); err != nil {
-		return fmt.Errorf("generating sbom: %w", err)
+	if err := p.Insert(s.options.CustomSteps...); err != nil {
+		return nil, fmt.Errorf("insert custom steps: %w", err)
 	}
 
-	logger.WithStep().Info("Staging artifacts")
-	if err := s.client.StageArtifacts(); err != nil {
-		return fmt.Errorf("stage release artifacts: %w", err)
+	if err := s.options.filterSteps(p); err != nil {
+		return nil, fmt.Errorf("filter steps: %w", err)
 	}
 
-	logger.Info("Stage done")
-	return nil
+	p.SetCheckpointer(s.client)
+	return p, nil
 }
 
 // ReleaseState holds the release process state.
@@ -369,12 +522,13 @@ func (r *ReleaseOptions) Validate(state *State) error {
 
 // Release is the structure to be used for releasing staged releases.
 type Release struct {
-	client releaseClient
+	client  releaseClient
+	options *ReleaseOptions
 }
 
 // NewRelease creates a new `Release` instance.
 func NewRelease(options *ReleaseOptions) *Release {
-	return &Release{NewDefaultRelease(options)}
+	return &Release{NewDefaultRelease(options), options}
 }
 
 // SetClient can be used to set the internal stage client.
@@ -399,62 +553,109 @@ func (r *Release) Run() error {
 		return fmt.Errorf("init log file: %w", err)
 	}
 
-	logger := log.NewStepLogger(11)
-	v := version.GetVersionInfo()
-	logger.Infof("Using krel version: %s", v.GitVersion)
-
-	logger.WithStep().Info("Validating options")
-	if err := r.client.ValidateOptions(); err != nil {
-		return fmt.Errorf("validate options: %w", err)
+	if err := r.client.InitCheckpoint(); err != nil {
+		return fmt.Errorf("init checkpoint: %w", err)
 	}
 
-	logger.WithStep().Info("Checking prerequisites")
-	if err := r.client.CheckPrerequisites(); err != nil {
-		return fmt.Errorf("check prerequisites: %w", err)
+	p, err := r.Pipeline()
+	if err != nil {
+		return fmt.Errorf("create release pipeline: %w", err)
 	}
 
-	logger.WithStep().Info("Checking release branch state")
-	if err := r.client.CheckReleaseBranchState(); err != nil {
-		return fmt.Errorf("check release branch state: %w", err)
-	}
+	v := version.GetVersionInfo()
+	logrus.Infof("Using krel version: %s", v.GitVersion)
 
-	logger.WithStep().Info("Generating release version")
-	if err := r.client.GenerateReleaseVersion(); err != nil {
-		return fmt.Errorf("generate release version: %w", err)
+	runErr := p.Run()
+	if err := r.client.WriteReport(p.Results(), runErr); err != nil {
+		if runErr == nil {
+			return fmt.Errorf("write report: %w", err)
+		}
+		logrus.Errorf("Unable to write run report: %v", err)
 	}
-
-	logger.WithStep().Info("Preparing workspace")
-	if err := r.client.PrepareWorkspace(); err != nil {
-		return fmt.Errorf("prepare workspace: %w", err)
+	if runErr != nil {
+		return fmt.Errorf("run release pipeline: %w", runErr)
 	}
 
-	logger.WithStep().Info("Checking artifacts provenance")
-	if err := r.client.CheckProvenance(); err != nil {
-		// For now, we only notify provenance errors as not to treat
-		// them as fatal while we finish testing SLSA compliance.
-		logrus.Warnf("Unable to check provenance attestation: %v", err)
-	}
-
-	logger.WithStep().Info("Creating announcement")
-	if err := r.client.CreateAnnouncement(); err != nil {
-		return fmt.Errorf("create announcement: %w", err)
-	}
+	logrus.Info("Release done")
+	return nil
+}
 
-	logger.WithStep().Info("Pushing artifacts")
-	if err := r.client.PushArtifacts(); err != nil {
-		return fmt.Errorf("push artifacts: %w", err)
-	}
+// Pipeline returns all steps of a release run including the custom ones.
+// Validation steps and entering the workspace are idempotent and therefore
+// always run, while all other steps are recorded in the state checkpoint.
+func (r *Release) Pipeline() (*pipeline.Pipeline, error) {
+	p := pipeline.New(
+		&pipeline.Step{
+			Name:        "ValidateOptions",
+			Description: "Validating options",
+			Run:         r.client.ValidateOptions,
+			Idempotent:  true,
+		},
+		&pipeline.Step{
+			Name:        "CheckPrerequisites",
+			Description: "Checking prerequisites",
+			Run:         r.client.CheckPrerequisites,
+			Idempotent:  true,
+		},
+		&pipeline.Step{
+			Name:        "CheckReleaseBranchState",
+			Description: "Checking release branch state",
+			Run:         r.client.CheckReleaseBranchState,
+		},
+		&pipeline.Step{
+			Name:        "GenerateReleaseVersion",
+			Description: "Generating release version",
+			Run:         r.client.GenerateReleaseVersion,
+		},
+		&pipeline.Step{
+			Name:        "PrepareWorkspace",
+			Description: "Preparing workspace",
+			Run:         r.client.PrepareWorkspace,
+		},
+		&pipeline.Step{
+			Name:        "EnterWorkspace",
+			Description: "Entering workspace",
+			Run:         r.client.EnterWorkspace,
+			Idempotent:  true,
+		},
+		&pipeline.Step{
+			Name:        "CheckProvenance",
+			Description: "Checking artifacts provenance",
+			Run:         r.client.CheckProvenance,
+			// For now, we only notify provenance errors as not to treat
+			// them as fatal while we finish testing SLSA compliance.
+			ContinueOnError: true,
+		},
+		&pipeline.Step{
+			Name:        "CreateAnnouncement",
+			Description: "Creating announcement",
+			Run:         r.client.CreateAnnouncement,
+		},
+		&pipeline.Step{
+			Name:        "PushArtifacts",
+			Description: "Pushing artifacts",
+			Run:         r.client.PushArtifacts,
+		},
+		&pipeline.Step{
+			Name:        "PushGitObjects",
+			Description: "Pushing git objects",
+			Run:         r.client.PushGitObjects,
+		},
+		&pipeline.Step{
+			Name:        "UpdateGitHubPage",
+			Description: "Updating GitHub release page",
+			Run:         r.client.UpdateGitHubPage,
+		},
+	)
 
-	logger.WithStep().Info("Pushing git objects")
-	if err := r.client.PushGitObjects(); err != nil {
-		return fmt.Errorf("push git objects: %w", err)
+	if err := p.Insert(r.options.CustomSteps...); err != nil {
+		return nil, fmt.Errorf("insert custom steps: %w", err)
 	}
 
-	logger.WithStep().Info("Updating GitHub release page")
-	if err := r.client.UpdateGitHubPage(); err != nil {
-		return fmt.Errorf("updating github page: %w", err)
+	if err := r.options.filterSteps(p); err != nil {
+		return nil, fmt.Errorf("filter steps: %w", err)
 	}
 
-	logger.Info("Release done")
-	return nil
+	p.SetCheckpointer(r.client)
+	return p, nil
 }
@@ -26,6 +26,7 @@ import (
 
 	"k8s.io/release/pkg/anago"
 	"k8s.io/release/pkg/anago/anagofakes"
+	"k8s.io/release/pkg/pipeline"
 	"k8s.io/release/pkg/release"
 )
 
@@ -243,6 +244,42 @@ func TestValidateOptions(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // success local dir
+			provided: &anago.Options{
+				ReleaseType:   release.ReleaseTypeAlpha,
+				ReleaseBranch: git.DefaultBranch,
+				LocalDir:      "/tmp",
+			},
+			shouldError: false,
+		},
+		{ // local dir on nomock
+			provided: &anago.Options{
+				NoMock:        true,
+				ReleaseType:   release.ReleaseTypeAlpha,
+				ReleaseBranch: git.DefaultBranch,
+				LocalDir:      "/tmp",
+			},
+			shouldError: true,
+		},
+		{ // success custom version policy
+			provided: &anago.Options{
+				ReleaseType:   "hotfix",
+				ReleaseBranch: "stable-2.1",
+				VersionPolicy: &release.VersionPolicy{
+					PreReleaseLabels:     []string{"dev", release.ReleaseTypeAlpha},
+					ReleaseBranchLabels:  []string{"hotfix"},
+					ReleaseBranchPattern: `^stable-(?P<major>\d+)\.(?P<minor>\d+)$`,
+				},
+			},
+			shouldError: false,
+		},
+		{ // release type not part of the default version policy
+			provided: &anago.Options{
+				ReleaseType:   "hotfix",
+				ReleaseBranch: git.DefaultBranch,
+			},
+			shouldError: true,
+		},
 	} {
 		err := tc.provided.Validate()
 		if tc.shouldError {
@@ -395,3 +432,97 @@ func TestSubmitRelease(t *testing.T) {
 		}
 	}
 }
+
+func TestRunStageResumeEntersWorkspace(t *testing.T) {
+	opts := anago.DefaultStageOptions()
+	opts.Resume = true
+	sut := anago.NewStage(opts)
+
+	// Everything up to the build has been completed by a previous run
+	mock := &anagofakes.FakeStageClient{}
+	completed := map[string]bool{
+		"CheckReleaseBranchState": true,
+		"GenerateReleaseVersion":  true,
+		"PrepareWorkspace":        true,
+		"TagRepository":           true,
+	}
+	mock.StepCompletedCalls(func(step string) bool { return completed[step] })
+
+	calls := []string{}
+	mock.EnterWorkspaceCalls(func() error {
+		calls = append(calls, "EnterWorkspace")
+		return nil
+	})
+	mock.BuildCalls(func() error {
+		calls = append(calls, "Build")
+		return nil
+	})
+	sut.SetClient(mock)
+
+	require.Nil(t, sut.Run())
+	require.Equal(t, 0, mock.PrepareWorkspaceCallCount())
+	require.Equal(t, []string{"EnterWorkspace", "Build"}, calls)
+}
+
+func TestStagePipeline(t *testing.T) {
+	opts := anago.DefaultStageOptions()
+	customStepCalled := false
+	opts.CustomSteps = []*pipeline.CustomStep{{
+		After: "Build",
+		Step: &pipeline.Step{
+			Name: "Scan",
+			Run: func() error {
+				customStepCalled = true
+				return nil
+			},
+		},
+	}}
+	opts.OnlySteps = []string{"Scan", "StageArtifacts"}
+	opts.Resume = true
+
+	sut := anago.NewStage(opts)
+	mock := &anagofakes.FakeStageClient{}
+	sut.SetClient(mock)
+
+	p, err := sut.Pipeline()
+	require.Nil(t, err)
+	require.Contains(t, p.Names(), "Scan")
+
+	require.Nil(t, sut.Run())
+	require.True(t, customStepCalled)
+	require.Equal(t, 1, mock.ValidateOptionsCallCount())
+	require.Equal(t, 0, mock.BuildCallCount())
+	require.Equal(t, 1, mock.StageArtifactsCallCount())
+
+	opts.SkipSteps = []string{"Wrong"}
+	require.NotNil(t, sut.Run())
+}
+
+func TestStageOnlyStepsWithoutResume(t *testing.T) {
+	opts := anago.DefaultStageOptions()
+	opts.OnlySteps = []string{"Build"}
+
+	sut := anago.NewStage(opts)
+	mock := &anagofakes.FakeStageClient{}
+	sut.SetClient(mock)
+
+	require.NotNil(t, sut.Run())
+	require.Equal(t, 0, mock.GenerateReleaseVersionCallCount())
+	require.Equal(t, 0, mock.BuildCallCount())
+
+	opts.Resume = true
+	require.Nil(t, sut.Run())
+	require.Equal(t, 1, mock.BuildCallCount())
+}
+
+func TestReleaseSkipStepsWithoutResume(t *testing.T) {
+	opts := anago.DefaultReleaseOptions()
+	opts.SkipSteps = []string{"GenerateReleaseVersion"}
+
+	sut := anago.NewRelease(opts)
+	mock := &anagofakes.FakeReleaseClient{}
+	sut.SetClient(mock)
+
+	require.NotNil(t, sut.Run())
+	require.Equal(t, 0, mock.PushArtifactsCallCount())
+}
@@ -19,6 +19,8 @@ package anagofakes
 
 import (
 	"sync"
+
+	"k8s.io/release/pkg/pipeline"
 )
 
 type FakeReleaseClient struct {
@@ -52,6 +54,17 @@ type FakeReleaseClient struct {
 	checkReleaseBranchStateReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CompleteStepStub        func(*pipeline.StepResult) error
+	completeStepMutex       sync.RWMutex
+	completeStepArgsForCall []struct {
+		arg1 *pipeline.StepResult
+	}
+	completeStepReturns struct {
+		result1 error
+	}
+	completeStepReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CreateAnnouncementStub        func() error
 	createAnnouncementMutex       sync.RWMutex
 	createAnnouncementArgsForCall []struct {
@@ -62,6 +75,16 @@ type FakeReleaseClient struct {
 	createAnnouncementReturnsOnCall map[int]struct {
 		result1 error
 	}
+	EnterWorkspaceStub        func() error
+	enterWorkspaceMutex       sync.RWMutex
+	enterWorkspaceArgsForCall []struct {
+	}
+	enterWorkspaceReturns struct {
+		result1 error
+	}
+	enterWorkspaceReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GenerateReleaseVersionStub        func() error
 	generateReleaseVersionMutex       sync.RWMutex
 	generateReleaseVersionArgsForCall []struct {
@@ -72,6 +95,16 @@ type FakeReleaseClient struct {
 	generateReleaseVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
+	InitCheckpointStub        func() error
+	initCheckpointMutex       sync.RWMutex
+	initCheckpointArgsForCall []struct {
+	}
+	initCheckpointReturns struct {
+		result1 error
+	}
+	initCheckpointReturnsOnCall map[int]struct {
+		result1 error
+	}
 	InitLogFileStub        func() error
 	initLogFileMutex       sync.RWMutex
 	initLogFileArgsForCall []struct {
@@ -116,6 +149,17 @@ type FakeReleaseClient struct {
 	pushGitObjectsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StepCompletedStub        func(string) bool
+	stepCompletedMutex       sync.RWMutex
+	stepCompletedArgsForCall []struct {
+		arg1 string
+	}
+	stepCompletedReturns struct {
+		result1 bool
+	}
+	stepCompletedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	SubmitStub        func(bool) error
 	submitMutex       sync.RWMutex
 	submitArgsForCall []struct {
@@ -147,6 +191,18 @@ type FakeReleaseClient struct {
 	validateOptionsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WriteReportStub        func([]*pipeline.StepResult, error) error
+	writeReportMutex       sync.RWMutex
+	writeReportArgsForCall []struct {
+		arg1 []*pipeline.StepResult
+		arg2 error
+	}
+	writeReportReturns struct {
+		result1 error
+	}
+	writeReportReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -310,6 +366,67 @@ func (fake *FakeReleaseClient) CheckReleaseBranchStateReturnsOnCall(i int, resul
 	}{result1}
 }
 
+func (fake *FakeReleaseClient) CompleteStep(arg1 *pipeline.StepResult) error {
+	fake.completeStepMutex.Lock()
+	ret, specificReturn := fake.completeStepReturnsOnCall[len(fake.completeStepArgsForCall)]
+	fake.completeStepArgsForCall = append(fake.completeStepArgsForCall, struct {
+		arg1 *pipeline.StepResult
+	}{arg1})
+	stub := fake.CompleteStepStub
+	fakeReturns := fake.completeStepReturns
+	fake.recordInvocation("CompleteStep", []interface{}{arg1})
+	fake.completeStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseClient) CompleteStepCallCount() int {
+	fake.completeStepMutex.RLock()
+	defer fake.completeStepMutex.RUnlock()
+	return len(fake.completeStepArgsForCall)
+}
+
+func (fake *FakeReleaseClient) CompleteStepCalls(stub func(*pipeline.StepResult) error) {
+	fake.completeStepMutex.Lock()
+	defer fake.completeStepMutex.Unlock()
+	fake.CompleteStepStub = stub
+}
+
+func (fake *FakeReleaseClient) CompleteStepArgsForCall(i int) *pipeline.StepResult {
+	fake.completeStepMutex.RLock()
+	defer fake.completeStepMutex.RUnlock()
+	argsForCall := fake.completeStepArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeReleaseClient) CompleteStepReturns(result1 error) {
+	fake.completeStepMutex.Lock()
+	defer fake.completeStepMutex.Unlock()
+	fake.CompleteStepStub = nil
+	fake.completeStepReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseClient) CompleteStepReturnsOnCall(i int, result1 error) {
+	fake.completeStepMutex.Lock()
+	defer fake.completeStepMutex.Unlock()
+	fake.CompleteStepStub = nil
+	if fake.completeStepReturnsOnCall == nil {
+		fake.completeStepReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.completeStepReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReleaseClient) CreateAnnouncement() error {
 	fake.createAnnouncementMutex.Lock()
 	ret, specificReturn := fake.createAnnouncementReturnsOnCall[len(fake.createAnnouncementArgsForCall)]
@@ -363,6 +480,59 @@ func (fake *FakeReleaseClient) CreateAnnouncementReturnsOnCall(i int, result1 er
 	}{result1}
 }
 
+func (fake *FakeReleaseClient) EnterWorkspace() error {
+	fake.enterWorkspaceMutex.Lock()
+	ret, specificReturn := fake.enterWorkspaceReturnsOnCall[len(fake.enterWorkspaceArgsForCall)]
+	fake.enterWorkspaceArgsForCall = append(fake.enterWorkspaceArgsForCall, struct {
+	}{})
+	stub := fake.EnterWorkspaceStub
+	fakeReturns := fake.enterWorkspaceReturns
+	fake.recordInvocation("EnterWorkspace", []interface{}{})
+	fake.enterWorkspaceMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseClient) EnterWorkspaceCallCount() int {
+	fake.enterWorkspaceMutex.RLock()
+	defer fake.enterWorkspaceMutex.RUnlock()
+	return len(fake.enterWorkspaceArgsForCall)
+}
+
+func (fake *FakeReleaseClient) EnterWorkspaceCalls(stub func() error) {
+	fake.enterWorkspaceMutex.Lock()
+	defer fake.enterWorkspaceMutex.Unlock()
+	fake.EnterWorkspaceStub = stub
+}
+
+func (fake *FakeReleaseClient) EnterWorkspaceReturns(result1 error) {
+	fake.enterWorkspaceMutex.Lock()
+	defer fake.enterWorkspaceMutex.Unlock()
+	fake.EnterWorkspaceStub = nil
+	fake.enterWorkspaceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseClient) EnterWorkspaceReturnsOnCall(i int, result1 error) {
+	fake.enterWorkspaceMutex.Lock()
+	defer fake.enterWorkspaceMutex.Unlock()
+	fake.EnterWorkspaceStub = nil
+	if fake.enterWorkspaceReturnsOnCall == nil {
+		fake.enterWorkspaceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.enterWorkspaceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReleaseClient) GenerateReleaseVersion() error {
 	fake.generateReleaseVersionMutex.Lock()
 	ret, specificReturn := fake.generateReleaseVersionReturnsOnCall[len(fake.generateReleaseVersionArgsForCall)]
@@ -416,6 +586,59 @@ func (fake *FakeReleaseClient) GenerateReleaseVersionReturnsOnCall(i int, result
 	}{result1}
 }
 
+func (fake *FakeReleaseClient) InitCheckpoint() error {
+	fake.initCheckpointMutex.Lock()
+	ret, specificReturn := fake.initCheckpointReturnsOnCall[len(fake.initCheckpointArgsForCall)]
+	fake.initCheckpointArgsForCall = append(fake.initCheckpointArgsForCall, struct {
+	}{})
+	stub := fake.InitCheckpointStub
+	fakeReturns := fake.initCheckpointReturns
+	fake.recordInvocation("InitCheckpoint", []interface{}{})
+	fake.initCheckpointMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseClient) InitCheckpointCallCount() int {
+	fake.initCheckpointMutex.RLock()
+	defer fake.initCheckpointMutex.RUnlock()
+	return len(fake.initCheckpointArgsForCall)
+}
+
+func (fake *FakeReleaseClient) InitCheckpointCalls(stub func() error) {
+	fake.initCheckpointMutex.Lock()
+	defer fake.initCheckpointMutex.Unlock()
+	fake.InitCheckpointStub = stub
+}
+
+func (fake *FakeReleaseClient) InitCheckpointReturns(result1 error) {
+	fake.initCheckpointMutex.Lock()
+	defer fake.initCheckpointMutex.Unlock()
+	fake.InitCheckpointStub = nil
+	fake.initCheckpointReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseClient) InitCheckpointReturnsOnCall(i int, result1 error) {
+	fake.initCheckpointMutex.Lock()
+	defer fake.initCheckpointMutex.Unlock()
+	fake.InitCheckpointStub = nil
+	if fake.initCheckpointReturnsOnCall == nil {
+		fake.initCheckpointReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.initCheckpointReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReleaseClient) InitLogFile() error {
 	fake.initLogFileMutex.Lock()
 	ret, specificReturn := fake.initLogFileReturnsOnCall[len(fake.initLogFileArgsForCall)]
@@ -652,6 +875,67 @@ func (fake *FakeReleaseClient) PushGitObjectsReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *FakeReleaseClient) StepCompleted(arg1 string) bool {
+	fake.stepCompletedMutex.Lock()
+	ret, specificReturn := fake.stepCompletedReturnsOnCall[len(fake.stepCompletedArgsForCall)]
+	fake.stepCompletedArgsForCall = append(fake.stepCompletedArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.StepCompletedStub
+	fakeReturns := fake.stepCompletedReturns
+	fake.recordInvocation("StepCompleted", []interface{}{arg1})
+	fake.stepCompletedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseClient) StepCompletedCallCount() int {
+	fake.stepCompletedMutex.RLock()
+	defer fake.stepCompletedMutex.RUnlock()
+	return len(fake.stepCompletedArgsForCall)
+}
+
+func (fake *FakeReleaseClient) StepCompletedCalls(stub func(string) bool) {
+	fake.stepCompletedMutex.Lock()
+	defer fake.stepCompletedMutex.Unlock()
+	fake.StepCompletedStub = stub
+}
+
+func (fake *FakeReleaseClient) StepCompletedArgsForCall(i int) string {
+	fake.stepCompletedMutex.RLock()
+	defer fake.stepCompletedMutex.RUnlock()
+	argsForCall := fake.stepCompletedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeReleaseClient) StepCompletedReturns(result1 bool) {
+	fake.stepCompletedMutex.Lock()
+	defer fake.stepCompletedMutex.Unlock()
+	fake.StepCompletedStub = nil
+	fake.stepCompletedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeReleaseClient) StepCompletedReturnsOnCall(i int, result1 bool) {
+	fake.stepCompletedMutex.Lock()
+	defer fake.stepCompletedMutex.Unlock()
+	fake.StepCompletedStub = nil
+	if fake.stepCompletedReturnsOnCall == nil {
+		fake.stepCompletedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.stepCompletedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeReleaseClient) Submit(arg1 bool) error {
 	fake.submitMutex.Lock()
 	ret, specificReturn := fake.submitReturnsOnCall[len(fake.submitArgsForCall)]
@@ -819,6 +1103,73 @@ func (fake *FakeReleaseClient) ValidateOptionsReturnsOnCall(i int, result1 error
 	}{result1}
 }
 
+func (fake *FakeReleaseClient) WriteReport(arg1 []*pipeline.StepResult, arg2 error) error {
+	var arg1Copy []*pipeline.StepResult
+	if arg1 != nil {
+		arg1Copy = make([]*pipeline.StepResult, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.writeReportMutex.Lock()
+	ret, specificReturn := fake.writeReportReturnsOnCall[len(fake.writeReportArgsForCall)]
+	fake.writeReportArgsForCall = append(fake.writeReportArgsForCall, struct {
+		arg1 []*pipeline.StepResult
+		arg2 error
+	}{arg1Copy, arg2})
+	stub := fake.WriteReportStub
+	fakeReturns := fake.writeReportReturns
+	fake.recordInvocation("WriteReport", []interface{}{arg1Copy, arg2})
+	fake.writeReportMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseClient) WriteReportCallCount() int {
+	fake.writeReportMutex.RLock()
+	defer fake.writeReportMutex.RUnlock()
+	return len(fake.writeReportArgsForCall)
+}
+
+func (fake *FakeReleaseClient) WriteReportCalls(stub func([]*pipeline.StepResult, error) error) {
+	fake.writeReportMutex.Lock()
+	defer fake.writeReportMutex.Unlock()
+	fake.WriteReportStub = stub
+}
+
+func (fake *FakeReleaseClient) WriteReportArgsForCall(i int) ([]*pipeline.StepResult, error) {
+	fake.writeReportMutex.RLock()
+	defer fake.writeReportMutex.RUnlock()
+	argsForCall := fake.writeReportArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeReleaseClient) WriteReportReturns(result1 error) {
+	fake.writeReportMutex.Lock()
+	defer fake.writeReportMutex.Unlock()
+	fake.WriteReportStub = nil
+	fake.writeReportReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseClient) WriteReportReturnsOnCall(i int, result1 error) {
+	fake.writeReportMutex.Lock()
+	defer fake.writeReportMutex.Unlock()
+	fake.WriteReportStub = nil
+	if fake.writeReportReturnsOnCall == nil {
+		fake.writeReportReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeReportReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReleaseClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -828,10 +1179,16 @@ func (fake *FakeReleaseClient) Invocations() map[string][][]interface{} {
 	defer fake.checkProvenanceMutex.RUnlock()
 	fake.checkReleaseBranchStateMutex.RLock()
 	defer fake.checkReleaseBranchStateMutex.RUnlock()
+	fake.completeStepMutex.RLock()
+	defer fake.completeStepMutex.RUnlock()
 	fake.createAnnouncementMutex.RLock()
 	defer fake.createAnnouncementMutex.RUnlock()
+	fake.enterWorkspaceMutex.RLock()
+	defer fake.enterWorkspaceMutex.RUnlock()
 	fake.generateReleaseVersionMutex.RLock()
 	defer fake.generateReleaseVersionMutex.RUnlock()
+	fake.initCheckpointMutex.RLock()
+	defer fake.initCheckpointMutex.RUnlock()
 	fake.initLogFileMutex.RLock()
 	defer fake.initLogFileMutex.RUnlock()
 	fake.initStateMutex.RLock()
@@ -842,12 +1199,16 @@ func (fake *FakeReleaseClient) Invocations() map[string][][]interface{} {
 	defer fake.pushArtifactsMutex.RUnlock()
 	fake.pushGitObjectsMutex.RLock()
 	defer fake.pushGitObjectsMutex.RUnlock()
+	fake.stepCompletedMutex.RLock()
+	defer fake.stepCompletedMutex.RUnlock()
 	fake.submitMutex.RLock()
 	defer fake.submitMutex.RUnlock()
 	fake.updateGitHubPageMutex.RLock()
 	defer fake.updateGitHubPageMutex.RUnlock()
 	fake.validateOptionsMutex.RLock()
 	defer fake.validateOptionsMutex.RUnlock()
+	fake.writeReportMutex.RLock()
+	defer fake.writeReportMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
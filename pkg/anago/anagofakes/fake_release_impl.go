@@ -30,12 +30,13 @@ import (
 )
 
 type FakeReleaseImpl struct {
-	BranchNeedsCreationStub        func(string, string, semver.Version) (bool, error)
+	BranchNeedsCreationStub        func(*release.VersionPolicy, string, string, semver.Version) (bool, error)
 	branchNeedsCreationMutex       sync.RWMutex
 	branchNeedsCreationArgsForCall []struct {
-		arg1 string
+		arg1 *release.VersionPolicy
 		arg2 string
-		arg3 semver.Version
+		arg3 string
+		arg4 semver.Version
 	}
 	branchNeedsCreationReturns struct {
 		result1 bool
@@ -45,6 +46,17 @@ type FakeReleaseImpl struct {
 		result1 bool
 		result2 error
 	}
+	ChdirStub        func(string) error
+	chdirMutex       sync.RWMutex
+	chdirArgsForCall []struct {
+		arg1 string
+	}
+	chdirReturns struct {
+		result1 error
+	}
+	chdirReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CheckPrerequisitesStub        func() error
 	checkPrerequisitesMutex       sync.RWMutex
 	checkPrerequisitesArgsForCall []struct {
@@ -116,10 +128,11 @@ type FakeReleaseImpl struct {
 	createAnnouncementReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CreatePubBotBranchIssueStub        func(string) error
+	CreatePubBotBranchIssueStub        func(string, *release.VersionPolicy) error
 	createPubBotBranchIssueMutex       sync.RWMutex
 	createPubBotBranchIssueArgsForCall []struct {
 		arg1 string
+		arg2 *release.VersionPolicy
 	}
 	createPubBotBranchIssueReturns struct {
 		result1 error
@@ -127,13 +140,14 @@ type FakeReleaseImpl struct {
 	createPubBotBranchIssueReturnsOnCall map[int]struct {
 		result1 error
 	}
-	GenerateReleaseVersionStub        func(string, string, string, bool) (*release.Versions, error)
+	GenerateReleaseVersionStub        func(*release.VersionPolicy, string, string, string, bool) (*release.Versions, error)
 	generateReleaseVersionMutex       sync.RWMutex
 	generateReleaseVersionArgsForCall []struct {
-		arg1 string
+		arg1 *release.VersionPolicy
 		arg2 string
 		arg3 string
-		arg4 bool
+		arg4 string
+		arg5 bool
 	}
 	generateReleaseVersionReturns struct {
 		result1 *release.Versions
@@ -143,6 +157,21 @@ type FakeReleaseImpl struct {
 		result1 *release.Versions
 		result2 error
 	}
+	ImageDigestsStub        func(string, string, string) (map[string]string, error)
+	imageDigestsMutex       sync.RWMutex
+	imageDigestsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	imageDigestsReturns struct {
+		result1 map[string]string
+		result2 error
+	}
+	imageDigestsReturnsOnCall map[int]struct {
+		result1 map[string]string
+		result2 error
+	}
 	NewGitPusherStub        func(*release.GitObjectPusherOptions) (*release.GitObjectPusher, error)
 	newGitPusherMutex       sync.RWMutex
 	newGitPusherArgsForCall []struct {
@@ -298,20 +327,21 @@ type FakeReleaseImpl struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeReleaseImpl) BranchNeedsCreation(arg1 string, arg2 string, arg3 semver.Version) (bool, error) {
+func (fake *FakeReleaseImpl) BranchNeedsCreation(arg1 *release.VersionPolicy, arg2 string, arg3 string, arg4 semver.Version) (bool, error) {
 	fake.branchNeedsCreationMutex.Lock()
 	ret, specificReturn := fake.branchNeedsCreationReturnsOnCall[len(fake.branchNeedsCreationArgsForCall)]
 	fake.branchNeedsCreationArgsForCall = append(fake.branchNeedsCreationArgsForCall, struct {
-		arg1 string
+		arg1 *release.VersionPolicy
 		arg2 string
-		arg3 semver.Version
-	}{arg1, arg2, arg3})
+		arg3 string
+		arg4 semver.Version
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.BranchNeedsCreationStub
 	fakeReturns := fake.branchNeedsCreationReturns
-	fake.recordInvocation("BranchNeedsCreation", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("BranchNeedsCreation", []interface{}{arg1, arg2, arg3, arg4})
 	fake.branchNeedsCreationMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -325,17 +355,17 @@ func (fake *FakeReleaseImpl) BranchNeedsCreationCallCount() int {
 	return len(fake.branchNeedsCreationArgsForCall)
 }
 
-func (fake *FakeReleaseImpl) BranchNeedsCreationCalls(stub func(string, string, semver.Version) (bool, error)) {
+func (fake *FakeReleaseImpl) BranchNeedsCreationCalls(stub func(*release.VersionPolicy, string, string, semver.Version) (bool, error)) {
 	fake.branchNeedsCreationMutex.Lock()
 	defer fake.branchNeedsCreationMutex.Unlock()
 	fake.BranchNeedsCreationStub = stub
 }
 
-func (fake *FakeReleaseImpl) BranchNeedsCreationArgsForCall(i int) (string, string, semver.Version) {
+func (fake *FakeReleaseImpl) BranchNeedsCreationArgsForCall(i int) (*release.VersionPolicy, string, string, semver.Version) {
 	fake.branchNeedsCreationMutex.RLock()
 	defer fake.branchNeedsCreationMutex.RUnlock()
 	argsForCall := fake.branchNeedsCreationArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeReleaseImpl) BranchNeedsCreationReturns(result1 bool, result2 error) {
@@ -364,6 +394,67 @@ func (fake *FakeReleaseImpl) BranchNeedsCreationReturnsOnCall(i int, result1 boo
 	}{result1, result2}
 }
 
+func (fake *FakeReleaseImpl) Chdir(arg1 string) error {
+	fake.chdirMutex.Lock()
+	ret, specificReturn := fake.chdirReturnsOnCall[len(fake.chdirArgsForCall)]
+	fake.chdirArgsForCall = append(fake.chdirArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ChdirStub
+	fakeReturns := fake.chdirReturns
+	fake.recordInvocation("Chdir", []interface{}{arg1})
+	fake.chdirMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeReleaseImpl) ChdirCallCount() int {
+	fake.chdirMutex.RLock()
+	defer fake.chdirMutex.RUnlock()
+	return len(fake.chdirArgsForCall)
+}
+
+func (fake *FakeReleaseImpl) ChdirCalls(stub func(string) error) {
+	fake.chdirMutex.Lock()
+	defer fake.chdirMutex.Unlock()
+	fake.ChdirStub = stub
+}
+
+func (fake *FakeReleaseImpl) ChdirArgsForCall(i int) string {
+	fake.chdirMutex.RLock()
+	defer fake.chdirMutex.RUnlock()
+	argsForCall := fake.chdirArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeReleaseImpl) ChdirReturns(result1 error) {
+	fake.chdirMutex.Lock()
+	defer fake.chdirMutex.Unlock()
+	fake.ChdirStub = nil
+	fake.chdirReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReleaseImpl) ChdirReturnsOnCall(i int, result1 error) {
+	fake.chdirMutex.Lock()
+	defer fake.chdirMutex.Unlock()
+	fake.ChdirStub = nil
+	if fake.chdirReturnsOnCall == nil {
+		fake.chdirReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.chdirReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReleaseImpl) CheckPrerequisites() error {
 	fake.checkPrerequisitesMutex.Lock()
 	ret, specificReturn := fake.checkPrerequisitesReturnsOnCall[len(fake.checkPrerequisitesArgsForCall)]
@@ -728,18 +819,19 @@ func (fake *FakeReleaseImpl) CreateAnnouncementReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
-func (fake *FakeReleaseImpl) CreatePubBotBranchIssue(arg1 string) error {
+func (fake *FakeReleaseImpl) CreatePubBotBranchIssue(arg1 string, arg2 *release.VersionPolicy) error {
 	fake.createPubBotBranchIssueMutex.Lock()
 	ret, specificReturn := fake.createPubBotBranchIssueReturnsOnCall[len(fake.createPubBotBranchIssueArgsForCall)]
 	fake.createPubBotBranchIssueArgsForCall = append(fake.createPubBotBranchIssueArgsForCall, struct {
 		arg1 string
-	}{arg1})
+		arg2 *release.VersionPolicy
+	}{arg1, arg2})
 	stub := fake.CreatePubBotBranchIssueStub
 	fakeReturns := fake.createPubBotBranchIssueReturns
-	fake.recordInvocation("CreatePubBotBranchIssue", []interface{}{arg1})
+	fake.recordInvocation("CreatePubBotBranchIssue", []interface{}{arg1, arg2})
 	fake.createPubBotBranchIssueMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -753,17 +845,17 @@ func (fake *FakeReleaseImpl) CreatePubBotBranchIssueCallCount() int {
 	return len(fake.createPubBotBranchIssueArgsForCall)
 }
 
-func (fake *FakeReleaseImpl) CreatePubBotBranchIssueCalls(stub func(string) error) {
+func (fake *FakeReleaseImpl) CreatePubBotBranchIssueCalls(stub func(string, *release.VersionPolicy) error) {
 	fake.createPubBotBranchIssueMutex.Lock()
 	defer fake.createPubBotBranchIssueMutex.Unlock()
 	fake.CreatePubBotBranchIssueStub = stub
 }
 
-func (fake *FakeReleaseImpl) CreatePubBotBranchIssueArgsForCall(i int) string {
+func (fake *FakeReleaseImpl) CreatePubBotBranchIssueArgsForCall(i int) (string, *release.VersionPolicy) {
 	fake.createPubBotBranchIssueMutex.RLock()
 	defer fake.createPubBotBranchIssueMutex.RUnlock()
 	argsForCall := fake.createPubBotBranchIssueArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeReleaseImpl) CreatePubBotBranchIssueReturns(result1 error) {
@@ -789,21 +881,22 @@ func (fake *FakeReleaseImpl) CreatePubBotBranchIssueReturnsOnCall(i int, result1
 	}{result1}
 }
 
-func (fake *FakeReleaseImpl) GenerateReleaseVersion(arg1 string, arg2 string, arg3 string, arg4 bool) (*release.Versions, error) {
+func (fake *FakeReleaseImpl) GenerateReleaseVersion(arg1 *release.VersionPolicy, arg2 string, arg3 string, arg4 string, arg5 bool) (*release.Versions, error) {
 	fake.generateReleaseVersionMutex.Lock()
 	ret, specificReturn := fake.generateReleaseVersionReturnsOnCall[len(fake.generateReleaseVersionArgsForCall)]
 	fake.generateReleaseVersionArgsForCall = append(fake.generateReleaseVersionArgsForCall, struct {
-		arg1 string
+		arg1 *release.VersionPolicy
 		arg2 string
 		arg3 string
-		arg4 bool
-	}{arg1, arg2, arg3, arg4})
+		arg4 string
+		arg5 bool
+	}{arg1, arg2, arg3, arg4, arg5})
 	stub := fake.GenerateReleaseVersionStub
 	fakeReturns := fake.generateReleaseVersionReturns
-	fake.recordInvocation("GenerateReleaseVersion", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GenerateReleaseVersion", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.generateReleaseVersionMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -817,17 +910,17 @@ func (fake *FakeReleaseImpl) GenerateReleaseVersionCallCount() int {
 	return len(fake.generateReleaseVersionArgsForCall)
 }
 
-func (fake *FakeReleaseImpl) GenerateReleaseVersionCalls(stub func(string, string, string, bool) (*release.Versions, error)) {
+func (fake *FakeReleaseImpl) GenerateReleaseVersionCalls(stub func(*release.VersionPolicy, string, string, string, bool) (*release.Versions, error)) {
 	fake.generateReleaseVersionMutex.Lock()
 	defer fake.generateReleaseVersionMutex.Unlock()
 	fake.GenerateReleaseVersionStub = stub
 }
 
-func (fake *FakeReleaseImpl) GenerateReleaseVersionArgsForCall(i int) (string, string, string, bool) {
+func (fake *FakeReleaseImpl) GenerateReleaseVersionArgsForCall(i int) (*release.VersionPolicy, string, string, string, bool) {
 	fake.generateReleaseVersionMutex.RLock()
 	defer fake.generateReleaseVersionMutex.RUnlock()
 	argsForCall := fake.generateReleaseVersionArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeReleaseImpl) GenerateReleaseVersionReturns(result1 *release.Versions, result2 error) {
@@ -856,6 +949,72 @@ func (fake *FakeReleaseImpl) GenerateReleaseVersionReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *FakeReleaseImpl) ImageDigests(arg1 string, arg2 string, arg3 string) (map[string]string, error) {
+	fake.imageDigestsMutex.Lock()
+	ret, specificReturn := fake.imageDigestsReturnsOnCall[len(fake.imageDigestsArgsForCall)]
+	fake.imageDigestsArgsForCall = append(fake.imageDigestsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ImageDigestsStub
+	fakeReturns := fake.imageDigestsReturns
+	fake.recordInvocation("ImageDigests", []interface{}{arg1, arg2, arg3})
+	fake.imageDigestsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeReleaseImpl) ImageDigestsCallCount() int {
+	fake.imageDigestsMutex.RLock()
+	defer fake.imageDigestsMutex.RUnlock()
+	return len(fake.imageDigestsArgsForCall)
+}
+
+func (fake *FakeReleaseImpl) ImageDigestsCalls(stub func(string, string, string) (map[string]string, error)) {
+	fake.imageDigestsMutex.Lock()
+	defer fake.imageDigestsMutex.Unlock()
+	fake.ImageDigestsStub = stub
+}
+
+func (fake *FakeReleaseImpl) ImageDigestsArgsForCall(i int) (string, string, string) {
+	fake.imageDigestsMutex.RLock()
+	defer fake.imageDigestsMutex.RUnlock()
+	argsForCall := fake.imageDigestsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeReleaseImpl) ImageDigestsReturns(result1 map[string]string, result2 error) {
+	fake.imageDigestsMutex.Lock()
+	defer fake.imageDigestsMutex.Unlock()
+	fake.ImageDigestsStub = nil
+	fake.imageDigestsReturns = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReleaseImpl) ImageDigestsReturnsOnCall(i int, result1 map[string]string, result2 error) {
+	fake.imageDigestsMutex.Lock()
+	defer fake.imageDigestsMutex.Unlock()
+	fake.ImageDigestsStub = nil
+	if fake.imageDigestsReturnsOnCall == nil {
+		fake.imageDigestsReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+			result2 error
+		})
+	}
+	fake.imageDigestsReturnsOnCall[i] = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeReleaseImpl) NewGitPusher(arg1 *release.GitObjectPusherOptions) (*release.GitObjectPusher, error) {
 	fake.newGitPusherMutex.Lock()
 	ret, specificReturn := fake.newGitPusherReturnsOnCall[len(fake.newGitPusherArgsForCall)]
@@ -1629,6 +1788,8 @@ func (fake *FakeReleaseImpl) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.branchNeedsCreationMutex.RLock()
 	defer fake.branchNeedsCreationMutex.RUnlock()
+	fake.chdirMutex.RLock()
+	defer fake.chdirMutex.RUnlock()
 	fake.checkPrerequisitesMutex.RLock()
 	defer fake.checkPrerequisitesMutex.RUnlock()
 	fake.checkReleaseBucketMutex.RLock()
@@ -1645,6 +1806,8 @@ func (fake *FakeReleaseImpl) Invocations() map[string][][]interface{} {
 	defer fake.createPubBotBranchIssueMutex.RUnlock()
 	fake.generateReleaseVersionMutex.RLock()
 	defer fake.generateReleaseVersionMutex.RUnlock()
+	fake.imageDigestsMutex.RLock()
+	defer fake.imageDigestsMutex.RUnlock()
 	fake.newGitPusherMutex.RLock()
 	defer fake.newGitPusherMutex.RUnlock()
 	fake.normalizePathMutex.RLock()
@@ -19,6 +19,8 @@ package anagofakes
 
 import (
 	"sync"
+
+	"k8s.io/release/pkg/pipeline"
 )
 
 type FakeStageClient struct {
@@ -52,6 +54,27 @@ type FakeStageClient struct {
 	checkReleaseBranchStateReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CompleteStepStub        func(*pipeline.StepResult) error
+	completeStepMutex       sync.RWMutex
+	completeStepArgsForCall []struct {
+		arg1 *pipeline.StepResult
+	}
+	completeStepReturns struct {
+		result1 error
+	}
+	completeStepReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnterWorkspaceStub        func() error
+	enterWorkspaceMutex       sync.RWMutex
+	enterWorkspaceArgsForCall []struct {
+	}
+	enterWorkspaceReturns struct {
+		result1 error
+	}
+	enterWorkspaceReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GenerateBillOfMaterialsStub        func() error
 	generateBillOfMaterialsMutex       sync.RWMutex
 	generateBillOfMaterialsArgsForCall []struct {
@@ -82,6 +105,16 @@ type FakeStageClient struct {
 	generateReleaseVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
+	InitCheckpointStub        func() error
+	initCheckpointMutex       sync.RWMutex
+	initCheckpointArgsForCall []struct {
+	}
+	initCheckpointReturns struct {
+		result1 error
+	}
+	initCheckpointReturnsOnCall map[int]struct {
+		result1 error
+	}
 	InitLogFileStub        func() error
 	initLogFileMutex       sync.RWMutex
 	initLogFileArgsForCall []struct {
@@ -116,6 +149,17 @@ type FakeStageClient struct {
 	stageArtifactsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StepCompletedStub        func(string) bool
+	stepCompletedMutex       sync.RWMutex
+	stepCompletedArgsForCall []struct {
+		arg1 string
+	}
+	stepCompletedReturns struct {
+		result1 bool
+	}
+	stepCompletedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	SubmitStub        func(bool) error
 	submitMutex       sync.RWMutex
 	submitArgsForCall []struct {
@@ -157,6 +201,18 @@ type FakeStageClient struct {
 	verifyArtifactsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WriteReportStub        func([]*pipeline.StepResult, error) error
+	writeReportMutex       sync.RWMutex
+	writeReportArgsForCall []struct {
+		arg1 []*pipeline.StepResult
+		arg2 error
+	}
+	writeReportReturns struct {
+		result1 error
+	}
+	writeReportReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -320,6 +376,120 @@ func (fake *FakeStageClient) CheckReleaseBranchStateReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeStageClient) CompleteStep(arg1 *pipeline.StepResult) error {
+	fake.completeStepMutex.Lock()
+	ret, specificReturn := fake.completeStepReturnsOnCall[len(fake.completeStepArgsForCall)]
+	fake.completeStepArgsForCall = append(fake.completeStepArgsForCall, struct {
+		arg1 *pipeline.StepResult
+	}{arg1})
+	stub := fake.CompleteStepStub
+	fakeReturns := fake.completeStepReturns
+	fake.recordInvocation("CompleteStep", []interface{}{arg1})
+	fake.completeStepMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageClient) CompleteStepCallCount() int {
+	fake.completeStepMutex.RLock()
+	defer fake.completeStepMutex.RUnlock()
+	return len(fake.completeStepArgsForCall)
+}
+
+func (fake *FakeStageClient) CompleteStepCalls(stub func(*pipeline.StepResult) error) {
+	fake.completeStepMutex.Lock()
+	defer fake.completeStepMutex.Unlock()
+	fake.CompleteStepStub = stub
+}
+
+func (fake *FakeStageClient) CompleteStepArgsForCall(i int) *pipeline.StepResult {
+	fake.completeStepMutex.RLock()
+	defer fake.completeStepMutex.RUnlock()
+	argsForCall := fake.completeStepArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStageClient) CompleteStepReturns(result1 error) {
+	fake.completeStepMutex.Lock()
+	defer fake.completeStepMutex.Unlock()
+	fake.CompleteStepStub = nil
+	fake.completeStepReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageClient) CompleteStepReturnsOnCall(i int, result1 error) {
+	fake.completeStepMutex.Lock()
+	defer fake.completeStepMutex.Unlock()
+	fake.CompleteStepStub = nil
+	if fake.completeStepReturnsOnCall == nil {
+		fake.completeStepReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.completeStepReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageClient) EnterWorkspace() error {
+	fake.enterWorkspaceMutex.Lock()
+	ret, specificReturn := fake.enterWorkspaceReturnsOnCall[len(fake.enterWorkspaceArgsForCall)]
+	fake.enterWorkspaceArgsForCall = append(fake.enterWorkspaceArgsForCall, struct {
+	}{})
+	stub := fake.EnterWorkspaceStub
+	fakeReturns := fake.enterWorkspaceReturns
+	fake.recordInvocation("EnterWorkspace", []interface{}{})
+	fake.enterWorkspaceMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageClient) EnterWorkspaceCallCount() int {
+	fake.enterWorkspaceMutex.RLock()
+	defer fake.enterWorkspaceMutex.RUnlock()
+	return len(fake.enterWorkspaceArgsForCall)
+}
+
+func (fake *FakeStageClient) EnterWorkspaceCalls(stub func() error) {
+	fake.enterWorkspaceMutex.Lock()
+	defer fake.enterWorkspaceMutex.Unlock()
+	fake.EnterWorkspaceStub = stub
+}
+
+func (fake *FakeStageClient) EnterWorkspaceReturns(result1 error) {
+	fake.enterWorkspaceMutex.Lock()
+	defer fake.enterWorkspaceMutex.Unlock()
+	fake.EnterWorkspaceStub = nil
+	fake.enterWorkspaceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageClient) EnterWorkspaceReturnsOnCall(i int, result1 error) {
+	fake.enterWorkspaceMutex.Lock()
+	defer fake.enterWorkspaceMutex.Unlock()
+	fake.EnterWorkspaceStub = nil
+	if fake.enterWorkspaceReturnsOnCall == nil {
+		fake.enterWorkspaceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.enterWorkspaceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStageClient) GenerateBillOfMaterials() error {
 	fake.generateBillOfMaterialsMutex.Lock()
 	ret, specificReturn := fake.generateBillOfMaterialsReturnsOnCall[len(fake.generateBillOfMaterialsArgsForCall)]
@@ -479,6 +649,59 @@ func (fake *FakeStageClient) GenerateReleaseVersionReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeStageClient) InitCheckpoint() error {
+	fake.initCheckpointMutex.Lock()
+	ret, specificReturn := fake.initCheckpointReturnsOnCall[len(fake.initCheckpointArgsForCall)]
+	fake.initCheckpointArgsForCall = append(fake.initCheckpointArgsForCall, struct {
+	}{})
+	stub := fake.InitCheckpointStub
+	fakeReturns := fake.initCheckpointReturns
+	fake.recordInvocation("InitCheckpoint", []interface{}{})
+	fake.initCheckpointMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageClient) InitCheckpointCallCount() int {
+	fake.initCheckpointMutex.RLock()
+	defer fake.initCheckpointMutex.RUnlock()
+	return len(fake.initCheckpointArgsForCall)
+}
+
+func (fake *FakeStageClient) InitCheckpointCalls(stub func() error) {
+	fake.initCheckpointMutex.Lock()
+	defer fake.initCheckpointMutex.Unlock()
+	fake.InitCheckpointStub = stub
+}
+
+func (fake *FakeStageClient) InitCheckpointReturns(result1 error) {
+	fake.initCheckpointMutex.Lock()
+	defer fake.initCheckpointMutex.Unlock()
+	fake.InitCheckpointStub = nil
+	fake.initCheckpointReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageClient) InitCheckpointReturnsOnCall(i int, result1 error) {
+	fake.initCheckpointMutex.Lock()
+	defer fake.initCheckpointMutex.Unlock()
+	fake.InitCheckpointStub = nil
+	if fake.initCheckpointReturnsOnCall == nil {
+		fake.initCheckpointReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.initCheckpointReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStageClient) InitLogFile() error {
 	fake.initLogFileMutex.Lock()
 	ret, specificReturn := fake.initLogFileReturnsOnCall[len(fake.initLogFileArgsForCall)]
@@ -662,6 +885,67 @@ func (fake *FakeStageClient) StageArtifactsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStageClient) StepCompleted(arg1 string) bool {
+	fake.stepCompletedMutex.Lock()
+	ret, specificReturn := fake.stepCompletedReturnsOnCall[len(fake.stepCompletedArgsForCall)]
+	fake.stepCompletedArgsForCall = append(fake.stepCompletedArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.StepCompletedStub
+	fakeReturns := fake.stepCompletedReturns
+	fake.recordInvocation("StepCompleted", []interface{}{arg1})
+	fake.stepCompletedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageClient) StepCompletedCallCount() int {
+	fake.stepCompletedMutex.RLock()
+	defer fake.stepCompletedMutex.RUnlock()
+	return len(fake.stepCompletedArgsForCall)
+}
+
+func (fake *FakeStageClient) StepCompletedCalls(stub func(string) bool) {
+	fake.stepCompletedMutex.Lock()
+	defer fake.stepCompletedMutex.Unlock()
+	fake.StepCompletedStub = stub
+}
+
+func (fake *FakeStageClient) StepCompletedArgsForCall(i int) string {
+	fake.stepCompletedMutex.RLock()
+	defer fake.stepCompletedMutex.RUnlock()
+	argsForCall := fake.stepCompletedArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStageClient) StepCompletedReturns(result1 bool) {
+	fake.stepCompletedMutex.Lock()
+	defer fake.stepCompletedMutex.Unlock()
+	fake.StepCompletedStub = nil
+	fake.stepCompletedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeStageClient) StepCompletedReturnsOnCall(i int, result1 bool) {
+	fake.stepCompletedMutex.Lock()
+	defer fake.stepCompletedMutex.Unlock()
+	fake.StepCompletedStub = nil
+	if fake.stepCompletedReturnsOnCall == nil {
+		fake.stepCompletedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.stepCompletedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeStageClient) Submit(arg1 bool) error {
 	fake.submitMutex.Lock()
 	ret, specificReturn := fake.submitReturnsOnCall[len(fake.submitArgsForCall)]
@@ -882,6 +1166,73 @@ func (fake *FakeStageClient) VerifyArtifactsReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *FakeStageClient) WriteReport(arg1 []*pipeline.StepResult, arg2 error) error {
+	var arg1Copy []*pipeline.StepResult
+	if arg1 != nil {
+		arg1Copy = make([]*pipeline.StepResult, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.writeReportMutex.Lock()
+	ret, specificReturn := fake.writeReportReturnsOnCall[len(fake.writeReportArgsForCall)]
+	fake.writeReportArgsForCall = append(fake.writeReportArgsForCall, struct {
+		arg1 []*pipeline.StepResult
+		arg2 error
+	}{arg1Copy, arg2})
+	stub := fake.WriteReportStub
+	fakeReturns := fake.writeReportReturns
+	fake.recordInvocation("WriteReport", []interface{}{arg1Copy, arg2})
+	fake.writeReportMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageClient) WriteReportCallCount() int {
+	fake.writeReportMutex.RLock()
+	defer fake.writeReportMutex.RUnlock()
+	return len(fake.writeReportArgsForCall)
+}
+
+func (fake *FakeStageClient) WriteReportCalls(stub func([]*pipeline.StepResult, error) error) {
+	fake.writeReportMutex.Lock()
+	defer fake.writeReportMutex.Unlock()
+	fake.WriteReportStub = stub
+}
+
+func (fake *FakeStageClient) WriteReportArgsForCall(i int) ([]*pipeline.StepResult, error) {
+	fake.writeReportMutex.RLock()
+	defer fake.writeReportMutex.RUnlock()
+	argsForCall := fake.writeReportArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStageClient) WriteReportReturns(result1 error) {
+	fake.writeReportMutex.Lock()
+	defer fake.writeReportMutex.Unlock()
+	fake.WriteReportStub = nil
+	fake.writeReportReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageClient) WriteReportReturnsOnCall(i int, result1 error) {
+	fake.writeReportMutex.Lock()
+	defer fake.writeReportMutex.Unlock()
+	fake.WriteReportStub = nil
+	if fake.writeReportReturnsOnCall == nil {
+		fake.writeReportReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeReportReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStageClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -891,12 +1242,18 @@ func (fake *FakeStageClient) Invocations() map[string][][]interface{} {
 	defer fake.checkPrerequisitesMutex.RUnlock()
 	fake.checkReleaseBranchStateMutex.RLock()
 	defer fake.checkReleaseBranchStateMutex.RUnlock()
+	fake.completeStepMutex.RLock()
+	defer fake.completeStepMutex.RUnlock()
+	fake.enterWorkspaceMutex.RLock()
+	defer fake.enterWorkspaceMutex.RUnlock()
 	fake.generateBillOfMaterialsMutex.RLock()
 	defer fake.generateBillOfMaterialsMutex.RUnlock()
 	fake.generateChangelogMutex.RLock()
 	defer fake.generateChangelogMutex.RUnlock()
 	fake.generateReleaseVersionMutex.RLock()
 	defer fake.generateReleaseVersionMutex.RUnlock()
+	fake.initCheckpointMutex.RLock()
+	defer fake.initCheckpointMutex.RUnlock()
 	fake.initLogFileMutex.RLock()
 	defer fake.initLogFileMutex.RUnlock()
 	fake.initStateMutex.RLock()
@@ -905,6 +1262,8 @@ func (fake *FakeStageClient) Invocations() map[string][][]interface{} {
 	defer fake.prepareWorkspaceMutex.RUnlock()
 	fake.stageArtifactsMutex.RLock()
 	defer fake.stageArtifactsMutex.RUnlock()
+	fake.stepCompletedMutex.RLock()
+	defer fake.stepCompletedMutex.RUnlock()
 	fake.submitMutex.RLock()
 	defer fake.submitMutex.RUnlock()
 	fake.tagRepositoryMutex.RLock()
@@ -913,6 +1272,8 @@ func (fake *FakeStageClient) Invocations() map[string][][]interface{} {
 	defer fake.validateOptionsMutex.RUnlock()
 	fake.verifyArtifactsMutex.RLock()
 	defer fake.verifyArtifactsMutex.RUnlock()
+	fake.writeReportMutex.RLock()
+	defer fake.writeReportMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -57,12 +57,13 @@ type FakeStageImpl struct {
 	addTarfilesToSBOMReturnsOnCall map[int]struct {
 		result1 error
 	}
-	BranchNeedsCreationStub        func(string, string, semver.Version) (bool, error)
+	BranchNeedsCreationStub        func(*release.VersionPolicy, string, string, semver.Version) (bool, error)
 	branchNeedsCreationMutex       sync.RWMutex
 	branchNeedsCreationArgsForCall []struct {
-		arg1 string
+		arg1 *release.VersionPolicy
 		arg2 string
-		arg3 semver.Version
+		arg3 string
+		arg4 semver.Version
 	}
 	branchNeedsCreationReturns struct {
 		result1 bool
@@ -85,6 +86,17 @@ type FakeStageImpl struct {
 		result1 *spdx.Document
 		result2 error
 	}
+	ChdirStub        func(string) error
+	chdirMutex       sync.RWMutex
+	chdirArgsForCall []struct {
+		arg1 string
+	}
+	chdirReturns struct {
+		result1 error
+	}
+	chdirReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CheckPrerequisitesStub        func() error
 	checkPrerequisitesMutex       sync.RWMutex
 	checkPrerequisitesArgsForCall []struct {
@@ -191,13 +203,14 @@ type FakeStageImpl struct {
 	generateChangelogReturnsOnCall map[int]struct {
 		result1 error
 	}
-	GenerateReleaseVersionStub        func(string, string, string, bool) (*release.Versions, error)
+	GenerateReleaseVersionStub        func(*release.VersionPolicy, string, string, string, bool) (*release.Versions, error)
 	generateReleaseVersionMutex       sync.RWMutex
 	generateReleaseVersionArgsForCall []struct {
-		arg1 string
+		arg1 *release.VersionPolicy
 		arg2 string
 		arg3 string
-		arg4 bool
+		arg4 string
+		arg5 bool
 	}
 	generateReleaseVersionReturns struct {
 		result1 *release.Versions
@@ -260,6 +273,19 @@ type FakeStageImpl struct {
 		result1 []in_toto.Subject
 		result2 error
 	}
+	ImageDigestsStub        func(*build.Options) (map[string]string, error)
+	imageDigestsMutex       sync.RWMutex
+	imageDigestsArgsForCall []struct {
+		arg1 *build.Options
+	}
+	imageDigestsReturns struct {
+		result1 map[string]string
+		result2 error
+	}
+	imageDigestsReturnsOnCall map[int]struct {
+		result1 map[string]string
+		result2 error
+	}
 	ListBinariesStub func(string) ([]struct {
 		Path     string
 		Platform string
@@ -492,6 +518,17 @@ type FakeStageImpl struct {
 	verifyArtifactsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WriteInventoryStub        func(*build.Options) error
+	writeInventoryMutex       sync.RWMutex
+	writeInventoryArgsForCall []struct {
+		arg1 *build.Options
+	}
+	writeInventoryReturns struct {
+		result1 error
+	}
+	writeInventoryReturnsOnCall map[int]struct {
+		result1 error
+	}
 	WriteSourceBOMStub        func(*spdx.Document, string) error
 	writeSourceBOMMutex       sync.RWMutex
 	writeSourceBOMArgsForCall []struct {
@@ -632,20 +669,21 @@ func (fake *FakeStageImpl) AddTarfilesToSBOMReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
-func (fake *FakeStageImpl) BranchNeedsCreation(arg1 string, arg2 string, arg3 semver.Version) (bool, error) {
+func (fake *FakeStageImpl) BranchNeedsCreation(arg1 *release.VersionPolicy, arg2 string, arg3 string, arg4 semver.Version) (bool, error) {
 	fake.branchNeedsCreationMutex.Lock()
 	ret, specificReturn := fake.branchNeedsCreationReturnsOnCall[len(fake.branchNeedsCreationArgsForCall)]
 	fake.branchNeedsCreationArgsForCall = append(fake.branchNeedsCreationArgsForCall, struct {
-		arg1 string
+		arg1 *release.VersionPolicy
 		arg2 string
-		arg3 semver.Version
-	}{arg1, arg2, arg3})
+		arg3 string
+		arg4 semver.Version
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.BranchNeedsCreationStub
 	fakeReturns := fake.branchNeedsCreationReturns
-	fake.recordInvocation("BranchNeedsCreation", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("BranchNeedsCreation", []interface{}{arg1, arg2, arg3, arg4})
 	fake.branchNeedsCreationMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -659,17 +697,17 @@ func (fake *FakeStageImpl) BranchNeedsCreationCallCount() int {
 	return len(fake.branchNeedsCreationArgsForCall)
 }
 
-func (fake *FakeStageImpl) BranchNeedsCreationCalls(stub func(string, string, semver.Version) (bool, error)) {
+func (fake *FakeStageImpl) BranchNeedsCreationCalls(stub func(*release.VersionPolicy, string, string, semver.Version) (bool, error)) {
 	fake.branchNeedsCreationMutex.Lock()
 	defer fake.branchNeedsCreationMutex.Unlock()
 	fake.BranchNeedsCreationStub = stub
 }
 
-func (fake *FakeStageImpl) BranchNeedsCreationArgsForCall(i int) (string, string, semver.Version) {
+func (fake *FakeStageImpl) BranchNeedsCreationArgsForCall(i int) (*release.VersionPolicy, string, string, semver.Version) {
 	fake.branchNeedsCreationMutex.RLock()
 	defer fake.branchNeedsCreationMutex.RUnlock()
 	argsForCall := fake.branchNeedsCreationArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeStageImpl) BranchNeedsCreationReturns(result1 bool, result2 error) {
@@ -762,6 +800,67 @@ func (fake *FakeStageImpl) BuildBaseArtifactsSBOMReturnsOnCall(i int, result1 *s
 	}{result1, result2}
 }
 
+func (fake *FakeStageImpl) Chdir(arg1 string) error {
+	fake.chdirMutex.Lock()
+	ret, specificReturn := fake.chdirReturnsOnCall[len(fake.chdirArgsForCall)]
+	fake.chdirArgsForCall = append(fake.chdirArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ChdirStub
+	fakeReturns := fake.chdirReturns
+	fake.recordInvocation("Chdir", []interface{}{arg1})
+	fake.chdirMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageImpl) ChdirCallCount() int {
+	fake.chdirMutex.RLock()
+	defer fake.chdirMutex.RUnlock()
+	return len(fake.chdirArgsForCall)
+}
+
+func (fake *FakeStageImpl) ChdirCalls(stub func(string) error) {
+	fake.chdirMutex.Lock()
+	defer fake.chdirMutex.Unlock()
+	fake.ChdirStub = stub
+}
+
+func (fake *FakeStageImpl) ChdirArgsForCall(i int) string {
+	fake.chdirMutex.RLock()
+	defer fake.chdirMutex.RUnlock()
+	argsForCall := fake.chdirArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStageImpl) ChdirReturns(result1 error) {
+	fake.chdirMutex.Lock()
+	defer fake.chdirMutex.Unlock()
+	fake.ChdirStub = nil
+	fake.chdirReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageImpl) ChdirReturnsOnCall(i int, result1 error) {
+	fake.chdirMutex.Lock()
+	defer fake.chdirMutex.Unlock()
+	fake.ChdirStub = nil
+	if fake.chdirReturnsOnCall == nil {
+		fake.chdirReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.chdirReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStageImpl) CheckPrerequisites() error {
 	fake.checkPrerequisitesMutex.Lock()
 	ret, specificReturn := fake.checkPrerequisitesReturnsOnCall[len(fake.checkPrerequisitesArgsForCall)]
@@ -1306,21 +1405,22 @@ func (fake *FakeStageImpl) GenerateChangelogReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
-func (fake *FakeStageImpl) GenerateReleaseVersion(arg1 string, arg2 string, arg3 string, arg4 bool) (*release.Versions, error) {
+func (fake *FakeStageImpl) GenerateReleaseVersion(arg1 *release.VersionPolicy, arg2 string, arg3 string, arg4 string, arg5 bool) (*release.Versions, error) {
 	fake.generateReleaseVersionMutex.Lock()
 	ret, specificReturn := fake.generateReleaseVersionReturnsOnCall[len(fake.generateReleaseVersionArgsForCall)]
 	fake.generateReleaseVersionArgsForCall = append(fake.generateReleaseVersionArgsForCall, struct {
-		arg1 string
+		arg1 *release.VersionPolicy
 		arg2 string
 		arg3 string
-		arg4 bool
-	}{arg1, arg2, arg3, arg4})
+		arg4 string
+		arg5 bool
+	}{arg1, arg2, arg3, arg4, arg5})
 	stub := fake.GenerateReleaseVersionStub
 	fakeReturns := fake.generateReleaseVersionReturns
-	fake.recordInvocation("GenerateReleaseVersion", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GenerateReleaseVersion", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.generateReleaseVersionMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1334,17 +1434,17 @@ func (fake *FakeStageImpl) GenerateReleaseVersionCallCount() int {
 	return len(fake.generateReleaseVersionArgsForCall)
 }
 
-func (fake *FakeStageImpl) GenerateReleaseVersionCalls(stub func(string, string, string, bool) (*release.Versions, error)) {
+func (fake *FakeStageImpl) GenerateReleaseVersionCalls(stub func(*release.VersionPolicy, string, string, string, bool) (*release.Versions, error)) {
 	fake.generateReleaseVersionMutex.Lock()
 	defer fake.generateReleaseVersionMutex.Unlock()
 	fake.GenerateReleaseVersionStub = stub
 }
 
-func (fake *FakeStageImpl) GenerateReleaseVersionArgsForCall(i int) (string, string, string, bool) {
+func (fake *FakeStageImpl) GenerateReleaseVersionArgsForCall(i int) (*release.VersionPolicy, string, string, string, bool) {
 	fake.generateReleaseVersionMutex.RLock()
 	defer fake.generateReleaseVersionMutex.RUnlock()
 	argsForCall := fake.generateReleaseVersionArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeStageImpl) GenerateReleaseVersionReturns(result1 *release.Versions, result2 error) {
@@ -1629,6 +1729,70 @@ func (fake *FakeStageImpl) GetProvenanceSubjectsReturnsOnCall(i int, result1 []i
 	}{result1, result2}
 }
 
+func (fake *FakeStageImpl) ImageDigests(arg1 *build.Options) (map[string]string, error) {
+	fake.imageDigestsMutex.Lock()
+	ret, specificReturn := fake.imageDigestsReturnsOnCall[len(fake.imageDigestsArgsForCall)]
+	fake.imageDigestsArgsForCall = append(fake.imageDigestsArgsForCall, struct {
+		arg1 *build.Options
+	}{arg1})
+	stub := fake.ImageDigestsStub
+	fakeReturns := fake.imageDigestsReturns
+	fake.recordInvocation("ImageDigests", []interface{}{arg1})
+	fake.imageDigestsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStageImpl) ImageDigestsCallCount() int {
+	fake.imageDigestsMutex.RLock()
+	defer fake.imageDigestsMutex.RUnlock()
+	return len(fake.imageDigestsArgsForCall)
+}
+
+func (fake *FakeStageImpl) ImageDigestsCalls(stub func(*build.Options) (map[string]string, error)) {
+	fake.imageDigestsMutex.Lock()
+	defer fake.imageDigestsMutex.Unlock()
+	fake.ImageDigestsStub = stub
+}
+
+func (fake *FakeStageImpl) ImageDigestsArgsForCall(i int) *build.Options {
+	fake.imageDigestsMutex.RLock()
+	defer fake.imageDigestsMutex.RUnlock()
+	argsForCall := fake.imageDigestsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStageImpl) ImageDigestsReturns(result1 map[string]string, result2 error) {
+	fake.imageDigestsMutex.Lock()
+	defer fake.imageDigestsMutex.Unlock()
+	fake.ImageDigestsStub = nil
+	fake.imageDigestsReturns = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStageImpl) ImageDigestsReturnsOnCall(i int, result1 map[string]string, result2 error) {
+	fake.imageDigestsMutex.Lock()
+	defer fake.imageDigestsMutex.Unlock()
+	fake.ImageDigestsStub = nil
+	if fake.imageDigestsReturnsOnCall == nil {
+		fake.imageDigestsReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+			result2 error
+		})
+	}
+	fake.imageDigestsReturnsOnCall[i] = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStageImpl) ListBinaries(arg1 string) ([]struct {
 	Path     string
 	Platform string
@@ -2788,6 +2952,67 @@ func (fake *FakeStageImpl) VerifyArtifactsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStageImpl) WriteInventory(arg1 *build.Options) error {
+	fake.writeInventoryMutex.Lock()
+	ret, specificReturn := fake.writeInventoryReturnsOnCall[len(fake.writeInventoryArgsForCall)]
+	fake.writeInventoryArgsForCall = append(fake.writeInventoryArgsForCall, struct {
+		arg1 *build.Options
+	}{arg1})
+	stub := fake.WriteInventoryStub
+	fakeReturns := fake.writeInventoryReturns
+	fake.recordInvocation("WriteInventory", []interface{}{arg1})
+	fake.writeInventoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStageImpl) WriteInventoryCallCount() int {
+	fake.writeInventoryMutex.RLock()
+	defer fake.writeInventoryMutex.RUnlock()
+	return len(fake.writeInventoryArgsForCall)
+}
+
+func (fake *FakeStageImpl) WriteInventoryCalls(stub func(*build.Options) error) {
+	fake.writeInventoryMutex.Lock()
+	defer fake.writeInventoryMutex.Unlock()
+	fake.WriteInventoryStub = stub
+}
+
+func (fake *FakeStageImpl) WriteInventoryArgsForCall(i int) *build.Options {
+	fake.writeInventoryMutex.RLock()
+	defer fake.writeInventoryMutex.RUnlock()
+	argsForCall := fake.writeInventoryArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStageImpl) WriteInventoryReturns(result1 error) {
+	fake.writeInventoryMutex.Lock()
+	defer fake.writeInventoryMutex.Unlock()
+	fake.WriteInventoryStub = nil
+	fake.writeInventoryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStageImpl) WriteInventoryReturnsOnCall(i int, result1 error) {
+	fake.writeInventoryMutex.Lock()
+	defer fake.writeInventoryMutex.Unlock()
+	fake.WriteInventoryStub = nil
+	if fake.writeInventoryReturnsOnCall == nil {
+		fake.writeInventoryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeInventoryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStageImpl) WriteSourceBOM(arg1 *spdx.Document, arg2 string) error {
 	fake.writeSourceBOMMutex.Lock()
 	ret, specificReturn := fake.writeSourceBOMReturnsOnCall[len(fake.writeSourceBOMArgsForCall)]
@@ -2861,6 +3086,8 @@ func (fake *FakeStageImpl) Invocations() map[string][][]interface{} {
 	defer fake.branchNeedsCreationMutex.RUnlock()
 	fake.buildBaseArtifactsSBOMMutex.RLock()
 	defer fake.buildBaseArtifactsSBOMMutex.RUnlock()
+	fake.chdirMutex.RLock()
+	defer fake.chdirMutex.RUnlock()
 	fake.checkPrerequisitesMutex.RLock()
 	defer fake.checkPrerequisitesMutex.RUnlock()
 	fake.checkReleaseBucketMutex.RLock()
@@ -2889,6 +3116,8 @@ func (fake *FakeStageImpl) Invocations() map[string][][]interface{} {
 	defer fake.getOutputDirSubjectsMutex.RUnlock()
 	fake.getProvenanceSubjectsMutex.RLock()
 	defer fake.getProvenanceSubjectsMutex.RUnlock()
+	fake.imageDigestsMutex.RLock()
+	defer fake.imageDigestsMutex.RUnlock()
 	fake.listBinariesMutex.RLock()
 	defer fake.listBinariesMutex.RUnlock()
 	fake.listImageArchivesMutex.RLock()
@@ -2925,6 +3154,8 @@ func (fake *FakeStageImpl) Invocations() map[string][][]interface{} {
 	defer fake.toFileMutex.RUnlock()
 	fake.verifyArtifactsMutex.RLock()
 	defer fake.verifyArtifactsMutex.RUnlock()
+	fake.writeInventoryMutex.RLock()
+	defer fake.writeInventoryMutex.RUnlock()
 	fake.writeSourceBOMMutex.RLock()
 	defer fake.writeSourceBOMMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
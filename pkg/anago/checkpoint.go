@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package anago
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/blang/semver/v4"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/pipeline"
+	"k8s.io/release/pkg/release"
+)
+
+// checkpoint is the serializable representation of the `State`, which gets
+// persisted to disk after each completed step.
+type checkpoint struct {
+	// The options the checkpoint has been written for. They have to match
+	// when resuming a run.
+	NoMock        bool   `json:"noMock"`
+	ReleaseType   string `json:"releaseType"`
+	ReleaseBranch string `json:"releaseBranch"`
+	BuildVersion  string `json:"buildVersion"`
+
+	SemverBuildVersion  semver.Version `json:"semverBuildVersion"`
+	CreateReleaseBranch bool           `json:"createReleaseBranch"`
+	Versions            *stateVersions `json:"versions,omitempty"`
+	CompletedSteps      []string       `json:"completedSteps"`
+	Results             runResults     `json:"results"`
+
+	// Steps are the results of the completed steps, which get reported
+	// for resumed runs.
+	Steps []*pipeline.StepResult `json:"steps,omitempty"`
+}
+
+// stateVersions is the serializable representation of `release.Versions`.
+type stateVersions struct {
+	Prime    string `json:"prime"`
+	Official string `json:"official,omitempty"`
+	RC       string `json:"rc,omitempty"`
+	Beta     string `json:"beta,omitempty"`
+	Alpha    string `json:"alpha,omitempty"`
+
+	// Custom are the versions of additional pre-release labels defined by
+	// the version policy.
+	Custom map[string]string `json:"custom,omitempty"`
+}
+
+// newStateVersions converts the provided versions into their serializable
+// representation.
+func newStateVersions(versions *release.Versions) *stateVersions {
+	if versions == nil {
+		return nil
+	}
+	return &stateVersions{
+		Prime:    versions.Prime(),
+		Official: versions.Official(),
+		RC:       versions.RC(),
+		Beta:     versions.Beta(),
+		Alpha:    versions.Alpha(),
+		Custom:   versions.Custom(),
+	}
+}
+
+// releaseVersions converts the serializable representation back into
+// `release.Versions`.
+func (v *stateVersions) releaseVersions() *release.Versions {
+	versions := release.NewReleaseVersions(v.Prime, v.Official, v.RC, v.Beta, v.Alpha)
+	for label, version := range v.Custom {
+		versions.Set(label, version)
+	}
+	return versions
+}
+
+// initCheckpoint sets the checkpoint file target and restores the state from
+// it if the options indicate that a previous run should be resumed.
+func (s *State) initCheckpoint(options *Options, defaultFile string) error {
+	s.checkpointFile = options.CheckpointFile
+	if s.checkpointFile == "" {
+		s.checkpointFile = defaultFile
+	}
+
+	if !options.Resume {
+		logrus.Infof("Persisting state checkpoints to %s", s.checkpointFile)
+		return nil
+	}
+
+	content, err := os.ReadFile(s.checkpointFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logrus.Warnf(
+				"No checkpoint found in %s, starting from scratch",
+				s.checkpointFile,
+			)
+			return nil
+		}
+		return fmt.Errorf("read checkpoint file: %w", err)
+	}
+
+	c := &checkpoint{}
+	if err := json.Unmarshal(content, c); err != nil {
+		return fmt.Errorf("unmarshal checkpoint: %w", err)
+	}
+
+	if c.NoMock != options.NoMock ||
+		c.ReleaseType != options.ReleaseType ||
+		c.ReleaseBranch != options.ReleaseBranch ||
+		c.BuildVersion != options.BuildVersion {
+		return fmt.Errorf(
+			"checkpoint %s does not match the provided options "+
+				"(NoMock: %v, ReleaseType: %q, BuildVersion: %q, ReleaseBranch: %q)",
+			s.checkpointFile, c.NoMock, c.ReleaseType, c.BuildVersion, c.ReleaseBranch,
+		)
+	}
+
+	s.semverBuildVersion = c.SemverBuildVersion
+	s.createReleaseBranch = c.CreateReleaseBranch
+	s.completedSteps = c.CompletedSteps
+	s.results = c.Results
+	s.stepResults = c.Steps
+	if c.Versions != nil {
+		s.versions = c.Versions.releaseVersions()
+	}
+
+	logrus.Infof(
+		"Resuming from checkpoint %s with completed steps: %v",
+		s.checkpointFile, s.completedSteps,
+	)
+	return nil
+}
+
+// StepCompleted returns true if the step with the provided name has been
+// already completed.
+func (s *State) StepCompleted(step string) bool {
+	return slices.Contains(s.completedSteps, step)
+}
+
+// CompletedSteps returns the names of all completed steps.
+func (s *State) CompletedSteps() []string {
+	return s.completedSteps
+}
+
+// completeStep marks the step of the result as completed and persists the
+// state to the checkpoint file.
+func (s *State) completeStep(result *pipeline.StepResult, options *Options) error {
+	if !s.StepCompleted(result.Name) {
+		s.completedSteps = append(s.completedSteps, result.Name)
+	}
+	s.stepResults = slices.DeleteFunc(s.stepResults, func(r *pipeline.StepResult) bool {
+		return r.Name == result.Name
+	})
+	s.stepResults = append(s.stepResults, result)
+
+	if s.checkpointFile == "" {
+		return nil
+	}
+
+	c := &checkpoint{
+		NoMock:              options.NoMock,
+		ReleaseType:         options.ReleaseType,
+		ReleaseBranch:       options.ReleaseBranch,
+		BuildVersion:        options.BuildVersion,
+		SemverBuildVersion:  s.semverBuildVersion,
+		CreateReleaseBranch: s.createReleaseBranch,
+		Versions:            newStateVersions(s.versions),
+		CompletedSteps:      s.completedSteps,
+		Results:             s.results,
+		Steps:               s.stepResults,
+	}
+
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal checkpoint: %w", err)
+	}
+	if err := os.WriteFile(s.checkpointFile, content, 0o644); err != nil {
+		return fmt.Errorf("write checkpoint file: %w", err)
+	}
+	return nil
+}
+
+// completedStepResult returns the result of the step if it has been completed
+// by a previous run, otherwise nil.
+func (s *State) completedStepResult(step string) *pipeline.StepResult {
+	i := slices.IndexFunc(s.stepResults, func(r *pipeline.StepResult) bool {
+		return r.Name == step
+	})
+	if i < 0 {
+		return nil
+	}
+	return s.stepResults[i]
+}
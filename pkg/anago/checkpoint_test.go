@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package anago_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/anago"
+	"k8s.io/release/pkg/anago/anagofakes"
+	"k8s.io/release/pkg/pipeline"
+	"k8s.io/release/pkg/release"
+)
+
+func TestCheckpointStage(t *testing.T) {
+	checkpointFile := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	opts := anago.DefaultStageOptions()
+	opts.CheckpointFile = checkpointFile
+	opts.BuildVersion = "v1.20.0-rc.1.1+f9d6f3d9fe5fa1"
+
+	// First run persists the completed steps
+	sut := anago.NewDefaultStage(opts)
+	sut.InitState()
+	require.Nil(t, sut.InitCheckpoint())
+	require.False(t, sut.StepCompleted("TagRepository"))
+	sut.State().SetVersions(release.NewReleaseVersions("v1.20.0", "v1.20.0", "", "", ""))
+	require.Nil(t, sut.CompleteStep(&pipeline.StepResult{
+		Name: "TagRepository", Outcome: pipeline.OutcomeSucceeded,
+	}))
+	require.True(t, sut.StepCompleted("TagRepository"))
+	require.FileExists(t, checkpointFile)
+
+	// Non resumed runs start from scratch
+	sut = anago.NewDefaultStage(opts)
+	sut.InitState()
+	require.Nil(t, sut.InitCheckpoint())
+	require.False(t, sut.StepCompleted("TagRepository"))
+
+	// Resumed runs restore the state
+	opts.Resume = true
+	sut = anago.NewDefaultStage(opts)
+	sut.InitState()
+	require.Nil(t, sut.InitCheckpoint())
+	require.True(t, sut.StepCompleted("TagRepository"))
+	require.False(t, sut.StepCompleted("Build"))
+	require.Equal(t, []string{"TagRepository"}, sut.State().CompletedSteps())
+
+	// Resuming with different options fails
+	opts.ReleaseType = release.ReleaseTypeRC
+	sut = anago.NewDefaultStage(opts)
+	sut.InitState()
+	require.NotNil(t, sut.InitCheckpoint())
+
+	// Resuming without a checkpoint starts from scratch
+	require.Nil(t, os.Remove(checkpointFile))
+	sut = anago.NewDefaultStage(opts)
+	sut.InitState()
+	require.Nil(t, sut.InitCheckpoint())
+	require.Empty(t, sut.State().CompletedSteps())
+}
+
+func TestRunStageSkipsCompletedSteps(t *testing.T) {
+	mock := &anagofakes.FakeStageClient{}
+	mock.StepCompletedCalls(func(step string) bool {
+		return step == "TagRepository" || step == "Build"
+	})
+
+	sut := anago.NewStage(anago.DefaultStageOptions())
+	sut.SetClient(mock)
+	require.Nil(t, sut.Run())
+
+	require.Equal(t, 0, mock.TagRepositoryCallCount())
+	require.Equal(t, 0, mock.BuildCallCount())
+	require.Equal(t, 1, mock.ValidateOptionsCallCount())
+	require.Equal(t, 1, mock.StageArtifactsCallCount())
+	require.Equal(t, 7, mock.CompleteStepCallCount())
+}
+
+func TestRunReleaseCompleteStepFails(t *testing.T) {
+	mock := &anagofakes.FakeReleaseClient{}
+	mock.CompleteStepReturns(err)
+
+	sut := anago.NewRelease(anago.DefaultReleaseOptions())
+	sut.SetClient(mock)
+	require.NotNil(t, sut.Run())
+	require.Equal(t, 0, mock.PushGitObjectsCallCount())
+}
@@ -33,6 +33,7 @@ import (
 	"k8s.io/release/pkg/announce/github"
 	"k8s.io/release/pkg/build"
 	"k8s.io/release/pkg/gcp/gcb"
+	"k8s.io/release/pkg/pipeline"
 	"k8s.io/release/pkg/release"
 )
 
@@ -49,6 +50,22 @@ type releaseClient interface {
 	// InitLogFile sets up the log file target.
 	InitLogFile() error
 
+	// InitCheckpoint sets up the state checkpoint file and restores the
+	// state from it if a previous run should be resumed.
+	InitCheckpoint() error
+
+	// StepCompleted returns true if the step with the provided name has
+	// been already completed.
+	StepCompleted(step string) bool
+
+	// CompleteStep marks the step with the provided name as completed and
+	// persists the current state to the checkpoint file.
+	CompleteStep(result *pipeline.StepResult) error
+
+	// WriteReport writes the machine readable run report for the provided
+	// step results and the error which caused the run to fail, if any.
+	WriteReport(steps []*pipeline.StepResult, runErr error) error
+
 	// Validate if the provided `ReleaseOptions` are correctly set.
 	ValidateOptions() error
 
@@ -71,6 +88,11 @@ type releaseClient interface {
 	// bucket which should contain a copy of the repository.
 	PrepareWorkspace() error
 
+	// EnterWorkspace changes the working directory into the repository
+	// prepared by PrepareWorkspace. Subsequent steps like building rely on
+	// it, which is why it has to run again when resuming.
+	EnterWorkspace() error
+
 	// CheckProvenance downloads the artifacts from the staging bucket
 	// and verifies them against the provenance metadata.
 	CheckProvenance() error
@@ -126,10 +148,13 @@ type releaseImpl interface {
 	ToFile(fileName string) error
 	CheckPrerequisites() error
 	BranchNeedsCreation(
+		policy *release.VersionPolicy,
 		branch, releaseType string, buildVersion semver.Version,
 	) (bool, error)
 	PrepareWorkspaceRelease(buildVersion, bucket string) error
+	Chdir(dir string) error
 	GenerateReleaseVersion(
+		policy *release.VersionPolicy,
 		releaseType, version, branch string, branchFromMaster bool,
 	) (*release.Versions, error)
 	CheckReleaseBucket(options *build.Options) error
@@ -137,6 +162,7 @@ type releaseImpl interface {
 		options *build.Options, stagedBucket, buildVersion string,
 	) error
 	ValidateImages(registry, version, buildPath string) error
+	ImageDigests(registry, version, buildPath string) (map[string]string, error)
 	PublishVersion(
 		buildType, version, buildDir, bucket, gcsRoot string,
 		versionMarkers []string,
@@ -155,7 +181,7 @@ type releaseImpl interface {
 	PublishReleaseNotesIndex(
 		gcsIndexRootPath, gcsReleaseNotesPath, version string,
 	) error
-	CreatePubBotBranchIssue(string) error
+	CreatePubBotBranchIssue(string, *release.VersionPolicy) error
 	CheckStageProvenance(string, string, *release.Versions) error
 }
 
@@ -172,9 +198,12 @@ func (d *defaultReleaseImpl) CheckPrerequisites() error {
 }
 
 func (d *defaultReleaseImpl) BranchNeedsCreation(
+	policy *release.VersionPolicy,
 	branch, releaseType string, buildVersion semver.Version,
 ) (bool, error) {
-	return release.NewBranchChecker().NeedsCreation(
+	checker := release.NewBranchChecker()
+	checker.SetVersionPolicy(policy)
+	return checker.NeedsCreation(
 		branch, releaseType, buildVersion,
 	)
 }
@@ -182,18 +211,18 @@ func (d *defaultReleaseImpl) BranchNeedsCreation(
 func (d *defaultReleaseImpl) PrepareWorkspaceRelease(
 	buildVersion, bucket string,
 ) error {
-	if err := release.PrepareWorkspaceRelease(
-		gitRoot, buildVersion, bucket,
-	); err != nil {
-		return err
-	}
-	return os.Chdir(gitRoot)
+	return release.PrepareWorkspaceRelease(gitRoot, buildVersion, bucket)
+}
+
+func (d *defaultReleaseImpl) Chdir(dir string) error {
+	return os.Chdir(dir)
 }
 
 func (d *defaultReleaseImpl) GenerateReleaseVersion(
+	policy *release.VersionPolicy,
 	releaseType, version, branch string, branchFromMaster bool,
 ) (*release.Versions, error) {
-	return release.GenerateReleaseVersion(
+	return policy.GenerateReleaseVersion(
 		releaseType, version, branch, branchFromMaster,
 	)
 }
@@ -217,6 +246,12 @@ func (d *defaultReleaseImpl) ValidateImages(
 	return release.NewImages().Validate(registry, version, buildPath)
 }
 
+func (d *defaultReleaseImpl) ImageDigests(
+	registry, version, buildPath string,
+) (map[string]string, error) {
+	return release.NewImages().Digests(registry, version, buildPath)
+}
+
 func (d *defaultReleaseImpl) PublishVersion(
 	buildType, version, buildDir, bucket, gcsRoot string, //nolint: gocritic
 	versionMarkers []string, //nolint: gocritic
@@ -235,6 +270,7 @@ func (d *DefaultRelease) Submit(stream bool) error {
 	options.Branch = d.options.ReleaseBranch
 	options.ReleaseType = d.options.ReleaseType
 	options.BuildVersion = d.options.BuildVersion
+	options.VersionPolicy = d.options.VersionPolicy
 	return d.impl.Submit(options)
 }
 
@@ -242,6 +278,24 @@ func (d *DefaultRelease) InitState() {
 	d.state = &ReleaseState{DefaultState()}
 }
 
+func (d *DefaultRelease) InitCheckpoint() error {
+	return d.state.initCheckpoint(d.options.Options, releaseCheckpointFile)
+}
+
+func (d *DefaultRelease) StepCompleted(step string) bool {
+	return d.state.StepCompleted(step)
+}
+
+func (d *DefaultRelease) CompleteStep(result *pipeline.StepResult) error {
+	return d.state.completeStep(result, d.options.Options)
+}
+
+func (d *DefaultRelease) WriteReport(steps []*pipeline.StepResult, runErr error) error {
+	return d.state.writeReport(
+		"release", d.options.Options, releaseReportFile, steps, runErr,
+	)
+}
+
 func (d *DefaultRelease) InitLogFile() error {
 	logrus.SetFormatter(
 		&logrus.TextFormatter{FullTimestamp: true, ForceColors: true},
@@ -303,8 +357,10 @@ func (d *defaultReleaseImpl) PublishReleaseNotesIndex(
 	)
 }
 
-func (d *defaultReleaseImpl) CreatePubBotBranchIssue(branchName string) error {
-	return release.CreatePubBotBranchIssue(branchName)
+func (d *defaultReleaseImpl) CreatePubBotBranchIssue(
+	branchName string, policy *release.VersionPolicy,
+) error {
+	return release.CreatePubBotBranchIssue(branchName, policy)
 }
 
 // NewGitPusher returns a new instance of the git pusher to reuse.
@@ -331,6 +387,7 @@ func (d *DefaultRelease) CheckPrerequisites() error {
 
 func (d *DefaultRelease) CheckReleaseBranchState() error {
 	createReleaseBranch, err := d.impl.BranchNeedsCreation(
+		d.options.Policy(),
 		d.options.ReleaseBranch,
 		d.options.ReleaseType,
 		d.state.semverBuildVersion,
@@ -344,6 +401,7 @@ func (d *DefaultRelease) CheckReleaseBranchState() error {
 
 func (d *DefaultRelease) GenerateReleaseVersion() error {
 	versions, err := d.impl.GenerateReleaseVersion(
+		d.options.Policy(),
 		d.options.ReleaseType,
 		d.options.BuildVersion,
 		d.options.ReleaseBranch,
@@ -366,6 +424,13 @@ func (d *DefaultRelease) PrepareWorkspace() error {
 	return nil
 }
 
+func (d *DefaultRelease) EnterWorkspace() error {
+	if err := d.impl.Chdir(gitRoot); err != nil {
+		return fmt.Errorf("enter workspace: %w", err)
+	}
+	return nil
+}
+
 func (d *DefaultRelease) PushArtifacts() error {
 	const gcsRoot = "release"
 
@@ -410,11 +475,22 @@ func (d *DefaultRelease) PushArtifacts() error {
 			return fmt.Errorf("validate container images: %w", err)
 		}
 
+		// Record the image digests for the run report, which is not
+		// considered to be critical.
+		digests, err := d.impl.ImageDigests(targetRegistry, version, buildDir)
+		if err != nil {
+			logrus.Warnf("Unable to retrieve image digests for %s: %v", version, err)
+		}
+		d.state.results.addImageDigests(digests)
+
 		if err := d.impl.PublishVersion(
 			"release", version, buildDir, bucket, gcsRoot, nil, false, false,
 		); err != nil {
 			return fmt.Errorf("publish release: %w", err)
 		}
+		d.state.results.GCSPaths = append(
+			d.state.results.GCSPaths, filepath.Join(bucket, gcsRoot, version),
+		)
 	}
 
 	logrus.Info("Publishing release notes JSON and announcement")
@@ -442,6 +518,7 @@ func (d *DefaultRelease) PushArtifacts() error {
 		); err != nil {
 			return fmt.Errorf("copy file notes to bucket: %w", err)
 		}
+		d.state.results.GCSPaths = append(d.state.results.GCSPaths, to)
 	}
 
 	for _, version := range d.state.versions.Ordered() {
@@ -475,7 +552,8 @@ func (d *DefaultRelease) PushGitObjects() error {
 		&release.GitObjectPusherOptions{
 			DryRun: !d.options.NoMock,
 			// MaxRetries: options.maxRetries,
-			RepoPath: gitRoot,
+			RepoPath:      gitRoot,
+			VersionPolicy: d.options.Policy(),
 		})
 	if err != nil {
 		return fmt.Errorf("getting git pusher from the release implementation: %w", err)
@@ -487,6 +565,7 @@ func (d *DefaultRelease) PushGitObjects() error {
 	if err := d.impl.PushTags(pusher, d.state.versions.Ordered()); err != nil {
 		return fmt.Errorf("pushing release tags: %w", err)
 	}
+	d.state.results.Tags = d.state.versions.Ordered()
 
 	// Determine which branches have to be pushed, except main
 	// which gets pushed at the end by itself
@@ -499,12 +578,14 @@ func (d *DefaultRelease) PushGitObjects() error {
 	if err := d.impl.PushBranches(pusher, branchList); err != nil {
 		return fmt.Errorf("pushing branches to the remote repository: %w", err)
 	}
+	d.state.results.Branches = branchList
 
 	// For files created on master with new branches and
 	// for $CHANGELOG_FILEPATH, update the main branch
 	if err := d.impl.PushMainBranch(pusher); err != nil {
 		return fmt.Errorf("pushing changes in main branch: %w", err)
 	}
+	d.state.results.Branches = append(d.state.results.Branches, git.DefaultBranch)
 
 	logrus.Infof(
 		"Git objects push complete (%d branches, %d tags & main branch)",
@@ -549,7 +630,9 @@ func (d *DefaultRelease) CreateAnnouncement() error {
 		d.options.ReleaseBranch != git.DefaultBranch {
 		if d.options.NoMock {
 			// Create the publishing bot issue
-			if err := d.impl.CreatePubBotBranchIssue(d.options.ReleaseBranch); err != nil {
+			if err := d.impl.CreatePubBotBranchIssue(
+				d.options.ReleaseBranch, d.options.Policy(),
+			); err != nil {
 				// If it fails, log the error, but do not treat it
 				// as fatal to avoid breaking the release process:
 				logrus.Warn("Failed to create Publishing Bot Issue")
@@ -296,6 +296,19 @@ func TestPrepareWorkspaceRelease(t *testing.T) {
 	}
 }
 
+func TestEnterWorkspaceRelease(t *testing.T) {
+	sut := anago.NewDefaultRelease(anago.DefaultReleaseOptions())
+	mock := &anagofakes.FakeReleaseImpl{}
+	sut.SetImpl(mock)
+
+	require.Nil(t, sut.EnterWorkspace())
+	require.Equal(t, 1, mock.ChdirCallCount())
+	require.Equal(t, "/workspace/src/k8s.io/kubernetes", mock.ChdirArgsForCall(0))
+
+	mock.ChdirReturns(err)
+	require.NotNil(t, sut.EnterWorkspace())
+}
+
 func TestSubmitReleaseImpl(t *testing.T) {
 	for _, tc := range []struct {
 		prepare     func(*anagofakes.FakeReleaseImpl)
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package anago
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/version"
+
+	"k8s.io/release/pkg/pipeline"
+)
+
+// runResults are the outputs of a stage or release run which get collected
+// while the steps are executed.
+type runResults struct {
+	// Tags are the created (stage) or pushed (release) git tags.
+	Tags []string `json:"tags,omitempty"`
+
+	// Branches are the created (stage) or pushed (release) git branches.
+	Branches []string `json:"branches,omitempty"`
+
+	// GCSPaths are the Google Cloud Storage locations artifacts have been
+	// uploaded to.
+	GCSPaths []string `json:"gcsPaths,omitempty"`
+
+	// ImageDigests maps the pushed container image references to their
+	// digests.
+	ImageDigests map[string]string `json:"imageDigests,omitempty"`
+
+	// ProvenanceSubjects is the number of subjects in the provenance
+	// attestation.
+	ProvenanceSubjects int `json:"provenanceSubjects,omitempty"`
+}
+
+// addImageDigests merges the provided digests into the results.
+func (r *runResults) addImageDigests(digests map[string]string) {
+	if r.ImageDigests == nil {
+		r.ImageDigests = map[string]string{}
+	}
+	for reference, digest := range digests {
+		r.ImageDigests[reference] = digest
+	}
+}
+
+// report is the machine readable summary of a stage or release run.
+type report struct {
+	// Kind is either "stage" or "release".
+	Kind        string `json:"kind"`
+	KrelVersion string `json:"krelVersion"`
+
+	NoMock        bool   `json:"noMock"`
+	ReleaseType   string `json:"releaseType"`
+	ReleaseBranch string `json:"releaseBranch"`
+	BuildVersion  string `json:"buildVersion"`
+
+	StartTime       time.Time `json:"startTime"`
+	EndTime         time.Time `json:"endTime"`
+	DurationSeconds float64   `json:"durationSeconds"`
+
+	// Success is true if the run finished without an error.
+	Success bool `json:"success"`
+
+	// ErrorChain contains the messages of the error which caused the run to
+	// fail and of all its wrapped errors.
+	ErrorChain []string `json:"errorChain,omitempty"`
+
+	Steps    []*pipeline.StepResult `json:"steps"`
+	Versions *stateVersions         `json:"versions,omitempty"`
+
+	runResults
+}
+
+// writeReport writes the run report for the provided step results and run
+// error as JSON into the configured report file. Steps completed by a
+// previous run are reported with their original results.
+func (s *State) writeReport(
+	kind string, options *Options, defaultFile string,
+	steps []*pipeline.StepResult, runErr error,
+) error {
+	reportFile := options.ReportFile
+	if reportFile == "" {
+		reportFile = defaultFile
+	}
+
+	reportSteps := make([]*pipeline.StepResult, 0, len(steps))
+	for _, step := range steps {
+		if step.Outcome == pipeline.OutcomeAlreadyCompleted {
+			if previous := s.completedStepResult(step.Name); previous != nil {
+				step = previous
+			}
+		}
+		reportSteps = append(reportSteps, step)
+	}
+
+	endTime := time.Now()
+	r := &report{
+		Kind:            kind,
+		KrelVersion:     version.GetVersionInfo().GitVersion,
+		NoMock:          options.NoMock,
+		ReleaseType:     options.ReleaseType,
+		ReleaseBranch:   options.ReleaseBranch,
+		BuildVersion:    options.BuildVersion,
+		StartTime:       s.startTime,
+		EndTime:         endTime,
+		DurationSeconds: endTime.Sub(s.startTime).Seconds(),
+		Success:         runErr == nil,
+		ErrorChain:      pipeline.ErrorChain(runErr),
+		Steps:           reportSteps,
+		Versions:        newStateVersions(s.versions),
+		runResults:      s.results,
+	}
+
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal report: %w", err)
+	}
+	if err := os.WriteFile(reportFile, content, 0o644); err != nil {
+		return fmt.Errorf("write report file: %w", err)
+	}
+
+	logrus.Infof("Run report written to %s", reportFile)
+	return nil
+}
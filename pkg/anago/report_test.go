@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package anago_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/anago"
+	"k8s.io/release/pkg/anago/anagofakes"
+	"k8s.io/release/pkg/pipeline"
+	"k8s.io/release/pkg/release"
+)
+
+func TestWriteReportRelease(t *testing.T) {
+	reportFile := filepath.Join(t.TempDir(), "report.json")
+
+	opts := anago.DefaultReleaseOptions()
+	opts.ReportFile = reportFile
+	opts.BuildVersion = "v1.20.0-rc.1.1+f9d6f3d9fe5fa1"
+
+	sut := anago.NewDefaultRelease(opts)
+	sut.SetState(
+		generateTestingReleaseState(&testStateParameters{versionsTag: &testVersionTag}),
+	)
+	mock := &anagofakes.FakeReleaseImpl{}
+	mock.ImageDigestsReturns(map[string]string{"image:v1.20.0": "sha256:123"}, nil)
+	sut.SetImpl(mock)
+	require.Nil(t, sut.PushArtifacts())
+	require.Nil(t, sut.PushGitObjects())
+
+	steps := []*pipeline.StepResult{
+		{Name: "PushArtifacts", Outcome: pipeline.OutcomeSucceeded},
+		{
+			Name:       "UpdateGitHubPage",
+			Outcome:    pipeline.OutcomeFailed,
+			ErrorChain: []string{"wrapped: error", "error"},
+		},
+	}
+	require.Nil(t, sut.WriteReport(steps, fmt.Errorf("wrapped: %w", err)))
+
+	content, err := os.ReadFile(reportFile)
+	require.Nil(t, err)
+
+	report := map[string]any{}
+	require.Nil(t, json.Unmarshal(content, &report))
+	require.Equal(t, "release", report["kind"])
+	require.Equal(t, false, report["success"])
+	require.Equal(t, []any{"wrapped: error", "error"}, report["errorChain"])
+	require.Equal(t, opts.BuildVersion, report["buildVersion"])
+	require.Len(t, report["steps"], 2)
+	require.Equal(t, testVersionTag, report["versions"].(map[string]any)["official"])
+	require.Equal(t, []any{testVersionTag}, report["tags"])
+	require.Contains(t, report["branches"], "master")
+	require.Contains(t, report["gcsPaths"], filepath.Join(release.TestBucket, "release", testVersionTag))
+	require.Equal(t, "sha256:123", report["imageDigests"].(map[string]any)["image:v1.20.0"])
+}
+
+func TestWriteReportResumed(t *testing.T) {
+	tempDir := t.TempDir()
+	reportFile := filepath.Join(tempDir, "report.json")
+
+	opts := anago.DefaultReleaseOptions()
+	opts.ReportFile = reportFile
+	opts.CheckpointFile = filepath.Join(tempDir, "checkpoint.json")
+	opts.BuildVersion = "v1.20.0-rc.1.1+f9d6f3d9fe5fa1"
+
+	// The first run completes pushing the git objects
+	sut := anago.NewDefaultRelease(opts)
+	sut.SetState(
+		generateTestingReleaseState(&testStateParameters{versionsTag: &testVersionTag}),
+	)
+	require.Nil(t, sut.InitCheckpoint())
+	sut.SetImpl(&anagofakes.FakeReleaseImpl{})
+	require.Nil(t, sut.PushGitObjects())
+	require.Nil(t, sut.CompleteStep(&pipeline.StepResult{
+		Name: "PushGitObjects", Outcome: pipeline.OutcomeSucceeded, DurationSeconds: 12,
+	}))
+
+	// The resumed run reports the results of the first one
+	opts.Resume = true
+	sut = anago.NewDefaultRelease(opts)
+	sut.InitState()
+	require.Nil(t, sut.InitCheckpoint())
+	require.Nil(t, sut.WriteReport([]*pipeline.StepResult{
+		{Name: "PushGitObjects", Outcome: pipeline.OutcomeAlreadyCompleted},
+		{Name: "PushArtifacts", Outcome: pipeline.OutcomeSucceeded},
+	}, nil))
+
+	content, err := os.ReadFile(reportFile)
+	require.Nil(t, err)
+
+	report := map[string]any{}
+	require.Nil(t, json.Unmarshal(content, &report))
+	steps := report["steps"].([]any)
+	require.Len(t, steps, 2)
+	require.Equal(t, string(pipeline.OutcomeSucceeded), steps[0].(map[string]any)["outcome"])
+	require.EqualValues(t, 12, steps[0].(map[string]any)["durationSeconds"])
+	require.Equal(t, []any{testVersionTag}, report["tags"])
+	require.Contains(t, report["branches"], "master")
+}
+
+func TestRunStageWritesReportOnFailure(t *testing.T) {
+	mock := &anagofakes.FakeStageClient{}
+	mock.BuildReturns(err)
+
+	sut := anago.NewStage(anago.DefaultStageOptions())
+	sut.SetClient(mock)
+	require.NotNil(t, sut.Run())
+	require.Equal(t, 1, mock.WriteReportCallCount())
+
+	results, runErr := mock.WriteReportArgsForCall(0)
+	require.NotNil(t, runErr)
+	require.Equal(t, "Build", results[len(results)-1].Name)
+	require.Equal(t, pipeline.OutcomeFailed, results[len(results)-1].Outcome)
+}
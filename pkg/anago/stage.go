@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/blang/semver/v4"
@@ -37,6 +36,7 @@ import (
 	"k8s.io/release/pkg/build"
 	"k8s.io/release/pkg/changelog"
 	"k8s.io/release/pkg/gcp/gcb"
+	"k8s.io/release/pkg/pipeline"
 	"k8s.io/release/pkg/release"
 )
 
@@ -53,6 +53,22 @@ type stageClient interface {
 	// InitLogFile sets up the log file target.
 	InitLogFile() error
 
+	// InitCheckpoint sets up the state checkpoint file and restores the
+	// state from it if a previous run should be resumed.
+	InitCheckpoint() error
+
+	// StepCompleted returns true if the step with the provided name has
+	// been already completed.
+	StepCompleted(step string) bool
+
+	// CompleteStep marks the step with the provided name as completed and
+	// persists the current state to the checkpoint file.
+	CompleteStep(result *pipeline.StepResult) error
+
+	// WriteReport writes the machine readable run report for the provided
+	// step results and the error which caused the run to fail, if any.
+	WriteReport(steps []*pipeline.StepResult, runErr error) error
+
 	// Validate if the provided `StageOptions` are correctly set.
 	ValidateOptions() error
 
@@ -75,6 +91,11 @@ type stageClient interface {
 	// out repository is in a clean state.
 	PrepareWorkspace() error
 
+	// EnterWorkspace changes the working directory into the repository
+	// prepared by PrepareWorkspace. Subsequent steps like building rely on
+	// it, which is why it has to run again when resuming.
+	EnterWorkspace() error
+
 	// TagRepository creates all necessary git objects by tagging the
 	// repository for the provided `versions` the main version `versionPrime`
 	// and the `parentBranch`.
@@ -138,10 +159,13 @@ type stageImpl interface {
 	ToFile(fileName string) error
 	CheckPrerequisites() error
 	BranchNeedsCreation(
+		policy *release.VersionPolicy,
 		branch, releaseType string, buildVersion semver.Version,
 	) (bool, error)
 	PrepareWorkspaceStage(noMock bool) error
+	Chdir(dir string) error
 	GenerateReleaseVersion(
+		policy *release.VersionPolicy,
 		releaseType, version, branch string, branchFromMaster bool,
 	) (*release.Versions, error)
 	OpenRepo(repoPath string) (*git.Repo, error)
@@ -165,6 +189,8 @@ type stageImpl interface {
 		options *build.Options, srcPath, gcsPath string,
 	) error
 	PushContainerImages(options *build.Options) error
+	ImageDigests(options *build.Options) (map[string]string, error)
+	WriteInventory(options *build.Options) error
 	GenerateVersionArtifactsBOM(string) error
 	GenerateSourceTreeBOM(options *spdx.DocGenerateOptions) (*spdx.Document, error)
 	WriteSourceBOM(spdxDoc *spdx.Document, version string) error
@@ -194,24 +220,29 @@ func (d *defaultStageImpl) CheckPrerequisites() error {
 }
 
 func (d *defaultStageImpl) BranchNeedsCreation(
+	policy *release.VersionPolicy,
 	branch, releaseType string, buildVersion semver.Version,
 ) (bool, error) {
-	return release.NewBranchChecker().NeedsCreation(
+	checker := release.NewBranchChecker()
+	checker.SetVersionPolicy(policy)
+	return checker.NeedsCreation(
 		branch, releaseType, buildVersion,
 	)
 }
 
 func (d *defaultStageImpl) PrepareWorkspaceStage(noMock bool) error {
-	if err := release.PrepareWorkspaceStage(gitRoot, noMock); err != nil {
-		return err
-	}
-	return os.Chdir(gitRoot)
+	return release.PrepareWorkspaceStage(gitRoot, noMock)
+}
+
+func (d *defaultStageImpl) Chdir(dir string) error {
+	return os.Chdir(dir)
 }
 
 func (d *defaultStageImpl) GenerateReleaseVersion(
+	policy *release.VersionPolicy,
 	releaseType, version, branch string, branchFromMaster bool,
 ) (*release.Versions, error) {
-	return release.GenerateReleaseVersion(
+	return policy.GenerateReleaseVersion(
 		releaseType, version, branch, branchFromMaster,
 	)
 }
@@ -294,6 +325,16 @@ func (d *defaultStageImpl) PushContainerImages(
 	return build.NewInstance(options).PushContainerImages()
 }
 
+func (d *defaultStageImpl) ImageDigests(
+	options *build.Options,
+) (map[string]string, error) {
+	return build.NewInstance(options).ImageDigests()
+}
+
+func (d *defaultStageImpl) WriteInventory(options *build.Options) error {
+	return build.NewInstance(options).WriteInventory()
+}
+
 func (d *DefaultStage) Submit(stream bool) error {
 	options := gcb.NewDefaultOptions()
 	options.Stream = stream
@@ -301,6 +342,7 @@ func (d *DefaultStage) Submit(stream bool) error {
 	options.NoMock = d.options.NoMock
 	options.Branch = d.options.ReleaseBranch
 	options.ReleaseType = d.options.ReleaseType
+	options.VersionPolicy = d.options.VersionPolicy
 	return d.impl.Submit(options)
 }
 
@@ -357,6 +399,24 @@ func (d *DefaultStage) InitState() {
 	d.state = &StageState{DefaultState()}
 }
 
+func (d *DefaultStage) InitCheckpoint() error {
+	return d.state.initCheckpoint(d.options.Options, stageCheckpointFile)
+}
+
+func (d *DefaultStage) StepCompleted(step string) bool {
+	return d.state.StepCompleted(step)
+}
+
+func (d *DefaultStage) CompleteStep(result *pipeline.StepResult) error {
+	return d.state.completeStep(result, d.options.Options)
+}
+
+func (d *DefaultStage) WriteReport(steps []*pipeline.StepResult, runErr error) error {
+	return d.state.writeReport(
+		"stage", d.options.Options, stageReportFile, steps, runErr,
+	)
+}
+
 func (d *DefaultStage) ValidateOptions() error {
 	if err := d.options.Validate(d.state.State); err != nil {
 		return fmt.Errorf("validating options: %w", err)
@@ -370,6 +430,7 @@ func (d *DefaultStage) CheckPrerequisites() error {
 
 func (d *DefaultStage) CheckReleaseBranchState() error {
 	createReleaseBranch, err := d.impl.BranchNeedsCreation(
+		d.options.Policy(),
 		d.options.ReleaseBranch,
 		d.options.ReleaseType,
 		d.state.semverBuildVersion,
@@ -383,6 +444,7 @@ func (d *DefaultStage) CheckReleaseBranchState() error {
 
 func (d *DefaultStage) GenerateReleaseVersion() error {
 	versions, err := d.impl.GenerateReleaseVersion(
+		d.options.Policy(),
 		d.options.ReleaseType,
 		d.options.BuildVersion,
 		d.options.ReleaseBranch,
@@ -403,6 +465,13 @@ func (d *DefaultStage) PrepareWorkspace() error {
 	return nil
 }
 
+func (d *DefaultStage) EnterWorkspace() error {
+	if err := d.impl.Chdir(gitRoot); err != nil {
+		return fmt.Errorf("enter workspace: %w", err)
+	}
+	return nil
+}
+
 func (d *DefaultStage) TagRepository() error {
 	repo, err := d.impl.OpenRepo(gitRoot)
 	if err != nil {
@@ -439,6 +508,9 @@ func (d *DefaultStage) TagRepository() error {
 				); err != nil {
 					return fmt.Errorf("create new release branch: %w", err)
 				}
+				d.state.results.Branches = append(
+					d.state.results.Branches, d.options.ReleaseBranch,
+				)
 			} else {
 				logrus.Infof(
 					"Version %s is not the prime, checking out %s branch",
@@ -492,7 +564,8 @@ func (d *DefaultStage) TagRepository() error {
 		//   - https://github.com/kubernetes/kubernetes/pull/88074
 
 		// When tagging a release branch, always create an empty commit:
-		if strings.HasPrefix(branch, "release-") {
+		isReleaseBranch := d.options.Policy().IsReleaseBranch(branch)
+		if isReleaseBranch {
 			logrus.Infof("Creating empty release commit for tag %s", version)
 			if err := d.impl.CommitEmpty(
 				repo,
@@ -507,7 +580,7 @@ func (d *DefaultStage) TagRepository() error {
 		// commits merged between the BuildVersion commit and the tag:
 		detachHead := release.IsDefaultK8sUpstream() &&
 			branch != "" &&
-			!strings.HasPrefix(branch, "release-")
+			!isReleaseBranch
 		if detachHead {
 			logrus.Infof("Detaching HEAD at commit %s to create tag %s", commit, version)
 			if err := d.impl.Checkout(repo, commit); err != nil {
@@ -536,6 +609,7 @@ func (d *DefaultStage) TagRepository() error {
 		); err != nil {
 			return fmt.Errorf("tag version: %w", err)
 		}
+		d.state.results.Tags = append(d.state.results.Tags, version)
 
 		// if we are working on master/main at this point, we are in
 		// detached HEAD state. So we checkout the branch again.
@@ -617,6 +691,13 @@ func (d *DefaultStage) GenerateChangelog() error {
 		CloneCVEMaps: true,
 		Tars:         filepath.Join(buildDir, release.ReleaseTarsPath),
 		Images:       buildDir,
+
+		NotesStateDir: d.options.NotesStateDir,
+		TemplateFile:  d.options.ChangelogTemplateFile,
+
+		ModuleCacheDir:       d.options.DependenciesModuleCacheDir,
+		OSVDatabase:          d.options.DependenciesOSVDatabase,
+		DependenciesJSONFile: d.options.DependenciesJSONFile,
 	})
 }
 
@@ -815,6 +896,7 @@ func (d *DefaultStage) StageArtifacts() error {
 		Registry:                   d.options.ContainerRegistry(),
 		AllowDup:                   true,
 		ValidateRemoteImageDigests: true,
+		LocalDir:                   d.options.LocalDir,
 	}
 	if err := d.impl.CheckReleaseBucket(pushBuildOptions); err != nil {
 		return fmt.Errorf("check release bucket access: %w", err)
@@ -856,27 +938,55 @@ func (d *DefaultStage) StageArtifacts() error {
 		)
 
 		// Push gcs-stage to GCS
+		gcsStagePath := filepath.Join(gcsPath, release.GCSStagePath, version)
 		if err := d.impl.PushReleaseArtifacts(
 			pushBuildOptions,
 			filepath.Join(buildDir, release.GCSStagePath, version),
-			filepath.Join(gcsPath, release.GCSStagePath, version),
+			gcsStagePath,
 		); err != nil {
 			return fmt.Errorf("pushing release artifacts: %w", err)
 		}
 
+		// Push container images into registry, which writes the image
+		// lockfile into the release-images
+		if err := d.impl.PushContainerImages(pushBuildOptions); err != nil {
+			return fmt.Errorf("pushing container images: %w", err)
+		}
+
 		// Push container release-images to GCS
+		gcsImagesPath := filepath.Join(gcsPath, release.ImagesPath)
 		if err := d.impl.PushReleaseArtifacts(
 			pushBuildOptions,
 			filepath.Join(buildDir, release.ImagesPath),
-			filepath.Join(gcsPath, release.ImagesPath),
+			gcsImagesPath,
 		); err != nil {
 			return fmt.Errorf("pushing release artifacts: %w", err)
 		}
 
-		// Push container images into registry
-		if err := d.impl.PushContainerImages(pushBuildOptions); err != nil {
-			return fmt.Errorf("pushing container images: %w", err)
+		// Write and push the inventory of all staged artifacts, which gets
+		// verified by the release before publishing them
+		if err := d.impl.WriteInventory(pushBuildOptions); err != nil {
+			return fmt.Errorf("writing artifact inventory: %w", err)
+		}
+		gcsInventoryPath := filepath.Join(gcsPath, release.InventoryFile)
+		if err := d.impl.PushReleaseArtifacts(
+			pushBuildOptions,
+			filepath.Join(buildDir, release.InventoryFile),
+			gcsInventoryPath,
+		); err != nil {
+			return fmt.Errorf("pushing artifact inventory: %w", err)
+		}
+		d.state.results.GCSPaths = append(
+			d.state.results.GCSPaths, gcsStagePath, gcsImagesPath, gcsInventoryPath,
+		)
+
+		// Record the image digests for the run report, which is not
+		// considered to be critical.
+		digests, err := d.impl.ImageDigests(pushBuildOptions)
+		if err != nil {
+			logrus.Warnf("Unable to retrieve image digests for %s: %v", version, err)
 		}
+		d.state.results.addImageDigests(digests)
 
 		// Add artifacts to the attestation, this should get both release-images
 		// and gcs-stage directories in one call.
@@ -893,6 +1003,11 @@ func (d *DefaultStage) StageArtifacts() error {
 	if err := d.impl.PushAttestation(statement, d.options); err != nil {
 		return fmt.Errorf("writing provenance metadata to disk: %w", err)
 	}
+	d.state.results.ProvenanceSubjects = len(statement.Subject)
+	d.state.results.GCSPaths = append(d.state.results.GCSPaths, filepath.Join(
+		d.options.Bucket(), release.StagePath, d.options.BuildVersion,
+		release.ProvenanceFilename,
+	))
 
 	// Delete the local source tarball
 	if err := d.impl.DeleteLocalSourceTarball(pushBuildOptions, workspaceDir); err != nil {
@@ -993,6 +1108,7 @@ func (d *defaultStageImpl) PushAttestation(attestation *provenance.Statement, op
 	pushBuildOptions := &build.Options{
 		Bucket:   options.Bucket(),
 		AllowDup: true,
+		LocalDir: options.LocalDir,
 	}
 
 	if err := d.CheckReleaseBucket(pushBuildOptions); err != nil {
@@ -228,6 +228,19 @@ func TestPrepareWorkspaceStage(t *testing.T) {
 	}
 }
 
+func TestEnterWorkspaceStage(t *testing.T) {
+	sut := anago.NewDefaultStage(anago.DefaultStageOptions())
+	mock := &anagofakes.FakeStageImpl{}
+	sut.SetImpl(mock)
+
+	require.Nil(t, sut.EnterWorkspace())
+	require.Equal(t, 1, mock.ChdirCallCount())
+	require.Equal(t, "/workspace/src/k8s.io/kubernetes", mock.ChdirArgsForCall(0))
+
+	mock.ChdirReturns(err)
+	require.NotNil(t, sut.EnterWorkspace())
+}
+
 func TestTagRepository(t *testing.T) {
 	newRCVersions := release.NewReleaseVersions(
 		"v1.20.0-rc.0", "", "v1.20.0-rc.0", "", "v1.21.0-alpha.0",
@@ -452,6 +465,11 @@ func TestGenerateChangelog(t *testing.T) {
 		},
 	} {
 		opts := anago.DefaultStageOptions()
+		opts.NotesStateDir = "/tmp/notes"
+		opts.ChangelogTemplateFile = "changelog.tmpl"
+		opts.DependenciesModuleCacheDir = "/tmp/mod"
+		opts.DependenciesOSVDatabase = "osv.zip"
+		opts.DependenciesJSONFile = "dependencies.json"
 		sut := anago.NewDefaultStage(opts)
 
 		etag := ""
@@ -468,6 +486,11 @@ func TestGenerateChangelog(t *testing.T) {
 			require.NotNil(t, err)
 		} else {
 			require.Nil(t, err)
+			require.Equal(t, "/tmp/notes", mock.GenerateChangelogArgsForCall(0).NotesStateDir)
+			require.Equal(t, "changelog.tmpl", mock.GenerateChangelogArgsForCall(0).TemplateFile)
+			require.Equal(t, "/tmp/mod", mock.GenerateChangelogArgsForCall(0).ModuleCacheDir)
+			require.Equal(t, "osv.zip", mock.GenerateChangelogArgsForCall(0).OSVDatabase)
+			require.Equal(t, "dependencies.json", mock.GenerateChangelogArgsForCall(0).DependenciesJSONFile)
 		}
 	}
 }
@@ -511,12 +534,24 @@ func TestStageArtifacts(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{ // PushReleaseArtifacts fails on inventory
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.PushReleaseArtifactsReturnsOnCall(2, err)
+			},
+			shouldError: true,
+		},
 		{ // PushContainerImages fails
 			prepare: func(mock *anagofakes.FakeStageImpl) {
 				mock.PushContainerImagesReturns(err)
 			},
 			shouldError: true,
 		},
+		{ // WriteInventory fails
+			prepare: func(mock *anagofakes.FakeStageImpl) {
+				mock.WriteInventoryReturns(err)
+			},
+			shouldError: true,
+		},
 		{ // DeleteLocalSourceTarball fails
 			prepare: func(mock *anagofakes.FakeStageImpl) {
 				mock.DeleteLocalSourceTarballReturns(err)
@@ -589,6 +624,7 @@ func TestSubmitStageImpl(t *testing.T) {
 		},
 	} {
 		opts := anago.DefaultStageOptions()
+		opts.VersionPolicy = release.DefaultVersionPolicy()
 		sut := anago.NewDefaultStage(opts)
 		mock := &anagofakes.FakeStageImpl{}
 		tc.prepare(mock)
@@ -598,6 +634,7 @@ func TestSubmitStageImpl(t *testing.T) {
 			require.NotNil(t, err)
 		} else {
 			require.Nil(t, err)
+			require.Equal(t, opts.VersionPolicy, mock.SubmitArgsForCall(0).VersionPolicy)
 		}
 	}
 }
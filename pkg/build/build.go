@@ -18,11 +18,13 @@ package build
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/release-sdk/object"
 
+	"k8s.io/release/pkg/localstore"
 	"k8s.io/release/pkg/release"
 )
 
@@ -31,7 +33,16 @@ var DefaultExtraVersionMarkers = []string{}
 // Instance is the main structure for creating and pushing builds.
 type Instance struct {
 	opts     *Options
-	objStore object.GCS
+	objStore objectStore
+	uploader *Uploader
+}
+
+// objectStore is the object store used by the `Instance`, which can be
+// either GCS or a local directory.
+type objectStore interface {
+	object.Store
+	WithNoClobber(noClobber bool) object.OptFn
+	WithAllowMissing(allowMissing bool) object.OptFn
 }
 
 // NewInstance can be used to create a new build `Instance`.
@@ -39,8 +50,16 @@ type Instance struct {
 func NewInstance(opts *Options) *Instance {
 	instance := &Instance{
 		opts:     opts,
-		objStore: *object.NewGCS(),
+		objStore: object.NewGCS(),
+		uploader: NewUploader(),
 	}
+	if opts.LocalDir != "" {
+		logrus.Infof("Using local object store in %s", opts.LocalDir)
+		store := localstore.New(opts.LocalDir)
+		instance.objStore = store
+		instance.uploader = NewLocalUploader(store)
+	}
+	instance.uploader.SetWorkers(opts.UploadWorkers)
 
 	instance.setBuildType()
 	instance.setBucket()
@@ -127,6 +146,24 @@ type Options struct {
 
 	// This sets the KUBE_BUILD_PLATFORMS value for make release/quick-release commands
 	KubeBuildPlatforms string
+
+	// LocalDir can be set to store all artifacts in a local directory
+	// instead of Google Cloud Storage. Container images will be written to
+	// an OCI image layout inside of it rather than pushed to `Registry`.
+	LocalDir string
+
+	// UploadWorkers is the maximum number of parallel artifact uploads.
+	// Defaults to `DefaultUploadWorkers` if not set.
+	UploadWorkers int
+}
+
+// LayoutPath returns the path of the OCI image layout used if `LocalDir` is
+// set.
+func (o *Options) LayoutPath() string {
+	if o.LocalDir == "" {
+		return ""
+	}
+	return filepath.Join(o.LocalDir, release.ImageLayoutDir)
 }
 
 // TODO: Refactor so that version is not required as a parameter.
@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/release-utils/tar"
 	"sigs.k8s.io/release-utils/util"
 
+	"k8s.io/release/pkg/localstore"
 	"k8s.io/release/pkg/release"
 )
 
@@ -151,7 +152,7 @@ func (bi *Instance) Push() error {
 
 	// Publish release to GCS
 	extraVersionMarkers := bi.opts.ExtraVersionMarkers
-	if err := release.NewPublisher().PublishVersion(
+	if err := bi.publisher().PublishVersion(
 		bi.opts.BuildType,
 		version,
 		bi.opts.BuildDir,
@@ -237,9 +238,21 @@ func setupBuildDir(bi *Instance) {
 	)
 }
 
+// publisher returns the release publisher for the configured object store.
+func (bi *Instance) publisher() *release.Publisher {
+	if bi.opts.LocalDir != "" {
+		return release.NewLocalPublisher(localstore.New(bi.opts.LocalDir))
+	}
+	return release.NewPublisher()
+}
+
 // CheckReleaseBucket verifies that a release bucket exists and the current
 // authenticated GCP user has write permissions to it.
 func (bi *Instance) CheckReleaseBucket() error {
+	if bi.opts.LocalDir != "" {
+		return bi.checkLocalReleaseBucket()
+	}
+
 	logrus.Infof("Checking bucket %s for write permissions", bi.opts.Bucket)
 
 	client, err := storage.NewClient(context.Background())
@@ -277,6 +290,28 @@ func (bi *Instance) CheckReleaseBucket() error {
 	return nil
 }
 
+// checkLocalReleaseBucket verifies that the release bucket directory inside
+// of the local object store can be written.
+func (bi *Instance) checkLocalReleaseBucket() error {
+	bucketDir := filepath.Join(bi.opts.LocalDir, bi.opts.Bucket)
+	logrus.Infof("Checking local bucket directory %s for write permissions", bucketDir)
+
+	if err := os.MkdirAll(bucketDir, os.FileMode(0o755)); err != nil {
+		return fmt.Errorf("create local bucket directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(bucketDir, ".write-check-")
+	if err != nil {
+		return fmt.Errorf("local bucket directory %s is not writable: %w", bucketDir, err)
+	}
+	f.Close()
+
+	if err := os.Remove(f.Name()); err != nil {
+		return fmt.Errorf("remove write check file: %w", err)
+	}
+	return nil
+}
+
 // StageLocalArtifacts locally stages the release artifacts.
 func (bi *Instance) StageLocalArtifacts() error {
 	logrus.Info("Staging local artifacts")
@@ -289,7 +324,9 @@ func (bi *Instance) StageLocalArtifacts() error {
 
 	// Copy release tarballs to local GCS staging directory for push
 	logrus.Info("Copying release tarballs")
-	if err := util.CopyDirContentsLocal(
+	uploader := NewDirUploader()
+	uploader.SetWorkers(bi.opts.UploadWorkers)
+	if _, err := uploader.Upload(
 		filepath.Join(bi.opts.BuildDir, release.ReleaseTarsPath), stageDir,
 	); err != nil {
 		return fmt.Errorf("copy source directory into destination: %w", err)
@@ -314,12 +351,17 @@ func (bi *Instance) StageLocalArtifacts() error {
 	plainBinariesPath := filepath.Join(bi.opts.BuildDir, release.ReleaseStagePath)
 	if util.Exists(plainBinariesPath) {
 		logrus.Info("Copying plain binaries")
-		if err := release.CopyBinaries(
-			filepath.Join(bi.opts.BuildDir, release.ReleaseStagePath),
-			stageDir,
-		); err != nil {
+		dirs, err := release.BinaryDirs(plainBinariesPath)
+		if err != nil {
 			return fmt.Errorf("stage binaries: %w", err)
 		}
+		for _, dir := range dirs {
+			if _, err := uploader.Upload(
+				dir.Src, filepath.Join(stageDir, dir.Dst),
+			); err != nil {
+				return fmt.Errorf("stage binaries from %s: %w", dir.Src, err)
+			}
+		}
 	} else {
 		logrus.Infof(
 			"Skipping not existing plain binaries dir %s", plainBinariesPath,
@@ -378,6 +420,8 @@ func (bi *Instance) copyStageFiles(stageDir string, files []stageFile) error {
 
 // PushReleaseArtifacts can be used to push local artifacts from the `srcPath`
 // to the remote `gcsPath`. The Bucket has to be set via the `Bucket` option.
+// Directories are uploaded in parallel by skipping all files which already
+// exist with the same content.
 func (bi *Instance) PushReleaseArtifacts(srcPath, gcsPath string) error {
 	dstPath, dstPathErr := bi.objStore.NormalizePath(gcsPath)
 	if dstPathErr != nil {
@@ -399,9 +443,14 @@ func (bi *Instance) PushReleaseArtifacts(srcPath, gcsPath string) error {
 		return nil
 	}
 
-	if err := bi.objStore.RsyncRecursive(srcPath, dstPath); err != nil {
-		return fmt.Errorf("rsync artifacts to GCS: %w", err)
+	res, err := bi.uploader.Upload(srcPath, dstPath)
+	if err != nil {
+		return fmt.Errorf("upload artifacts to GCS: %w", err)
 	}
+	logrus.Infof(
+		"Uploaded %d artifacts, skipped %d unchanged ones",
+		len(res.Uploaded), len(res.Skipped),
+	)
 	return nil
 }
 
@@ -417,11 +466,22 @@ func (bi *Instance) PushContainerImages() error {
 	images := release.NewImages()
 	logrus.Infof("Publishing container images for %s", bi.opts.Version)
 
-	if err := images.Publish(
+	if bi.opts.LocalDir != "" {
+		if err := images.PublishToLayout(
+			bi.opts.LayoutPath(), bi.opts.Registry, bi.opts.Version, bi.opts.BuildDir,
+		); err != nil {
+			return fmt.Errorf("write container images to layout: %w", err)
+		}
+		return nil
+	}
+
+	digests, err := images.Publish(
 		bi.opts.Registry, bi.opts.Version, bi.opts.BuildDir,
-	); err != nil {
+	)
+	if err != nil {
 		return fmt.Errorf("publish container images: %w", err)
 	}
+	logrus.Infof("Published %d container image manifests", len(digests))
 
 	if !bi.opts.ValidateRemoteImageDigests {
 		logrus.Info("Will not validate remote image digests")
@@ -437,6 +497,18 @@ func (bi *Instance) PushContainerImages() error {
 	return nil
 }
 
+// ImageDigests returns the digests of the published container images, either
+// from the `Registry` or the local OCI image layout.
+func (bi *Instance) ImageDigests() (map[string]string, error) {
+	images := release.NewImages()
+	if bi.opts.LocalDir != "" {
+		return images.LayoutDigests(
+			bi.opts.LayoutPath(), bi.opts.Registry, bi.opts.Version, bi.opts.BuildDir,
+		)
+	}
+	return images.Digests(bi.opts.Registry, bi.opts.Version, bi.opts.BuildDir)
+}
+
 // CopyStagedFromGCS copies artifacts from GCS and between buckets as needed.
 // TODO: Investigate if it's worthwhile to use any of the bi.objStore.Get*Path()
 //
@@ -457,27 +529,30 @@ func (bi *Instance) CopyStagedFromGCS(stagedBucket, buildVersion string) error {
 		return fmt.Errorf("normalize GCS source: %w", gcsSrcErr)
 	}
 
-	dst, dstErr := bi.objStore.NormalizePath(bi.opts.Bucket, "release", bi.opts.Version)
-	if dstErr != nil {
-		return fmt.Errorf("normalize GCS destination: %w", dstErr)
+	// Verify the staged objects against the inventory before anything gets
+	// copied into the public release bucket.
+	if err := bi.verifyStagedInventory(gcsStageRoot); err != nil {
+		return fmt.Errorf("verify staged artifacts: %w", err)
+	}
+
+	gcsDst, gcsDstErr := bi.objStore.NormalizePath(bi.opts.Bucket, "release", bi.opts.Version)
+	if gcsDstErr != nil {
+		return fmt.Errorf("normalize GCS destination: %w", gcsDstErr)
 	}
 
-	logrus.Infof("Bucket to bucket rsync from %s to %s", gcsSrc, dst)
-	if err := bi.objStore.RsyncRecursive(gcsSrc, dst); err != nil {
+	logrus.Infof("Bucket to bucket rsync from %s to %s", gcsSrc, gcsDst)
+	if err := bi.objStore.RsyncRecursive(gcsSrc, gcsDst); err != nil {
 		return fmt.Errorf("copy stage to release bucket: %w", err)
 	}
 
 	src = filepath.Join(src, release.KubernetesTar)
-	dst = filepath.Join(bi.opts.BuildDir, release.GCSStagePath, bi.opts.Version, release.KubernetesTar)
+	dst := filepath.Join(bi.opts.BuildDir, release.GCSStagePath, bi.opts.Version, release.KubernetesTar)
 	logrus.Infof("Copy kubernetes tarball %s to %s", src, dst)
 	if err := bi.objStore.CopyToLocal(src, dst); err != nil {
 		return fmt.Errorf("copy to local: %w", err)
 	}
 
 	src = filepath.Join(gcsStageRoot, release.ImagesPath)
-	if err := os.MkdirAll(bi.opts.BuildDir, os.FileMode(0o755)); err != nil {
-		return fmt.Errorf("create dst dir: %w", err)
-	}
 	logrus.Infof("Copy container images %s to %s", src, bi.opts.BuildDir)
 	if err := bi.objStore.CopyToLocal(src, bi.opts.BuildDir); err != nil {
 		return fmt.Errorf("copy to local: %w", err)
@@ -486,6 +561,72 @@ func (bi *Instance) CopyStagedFromGCS(stagedBucket, buildVersion string) error {
 	return nil
 }
 
+// verifyStagedInventory downloads the inventory of the stage and verifies the
+// staged objects against it by using the checksums of the object store. A
+// missing inventory is considered to be an error.
+func (bi *Instance) verifyStagedInventory(gcsStageRoot string) error {
+	src := filepath.Join(gcsStageRoot, release.InventoryFile)
+	gcsSrc, err := bi.objStore.NormalizePath(src)
+	if err != nil {
+		return fmt.Errorf("normalize GCS inventory path: %w", err)
+	}
+
+	exists, err := bi.objStore.PathExists(gcsSrc)
+	if err != nil {
+		return fmt.Errorf("check if inventory exists: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("no artifact inventory found in %s", gcsSrc)
+	}
+
+	if err := os.MkdirAll(bi.opts.BuildDir, os.FileMode(0o755)); err != nil {
+		return fmt.Errorf("create dst dir: %w", err)
+	}
+	dst := filepath.Join(bi.opts.BuildDir, release.InventoryFile)
+	logrus.Infof("Copy artifact inventory %s to %s", gcsSrc, dst)
+	if err := bi.objStore.CopyToLocal(gcsSrc, dst); err != nil {
+		return fmt.Errorf("copy inventory to local: %w", err)
+	}
+
+	inventory, err := release.ReadInventory(dst)
+	if err != nil {
+		return err
+	}
+
+	entries := map[string]release.InventoryEntry{}
+	for _, dir := range inventory.Dirs {
+		gcsDir, err := bi.objStore.NormalizePath(gcsStageRoot, dir)
+		if err != nil {
+			return fmt.Errorf("normalize GCS inventory directory: %w", err)
+		}
+		objects, err := bi.uploader.impl.ListObjects(gcsDir)
+		if err != nil {
+			return fmt.Errorf("list objects in %s: %w", gcsDir, err)
+		}
+		for file, obj := range objects {
+			path := dir + "/" + file
+			entries[path] = release.InventoryEntry{
+				Path: path, Size: obj.Size, SHA256: obj.SHA256, MD5: obj.MD5,
+			}
+		}
+	}
+	return inventory.VerifyEntries(entries)
+}
+
+// WriteInventory writes the inventory of all staged artifacts of the version
+// into the build directory.
+func (bi *Instance) WriteInventory() error {
+	inventory, err := release.NewInventory(
+		bi.opts.BuildDir, release.StagedInventoryDirs(bi.opts.Version)...,
+	)
+	if err != nil {
+		return fmt.Errorf("create inventory: %w", err)
+	}
+	path := filepath.Join(bi.opts.BuildDir, release.InventoryFile)
+	logrus.Infof("Writing inventory of %d artifacts to %s", len(inventory.Files), path)
+	return inventory.Write(path)
+}
+
 // StageLocalSourceTree creates a src.tar.gz from the Kubernetes sources and
 // uploads it to GCS.
 func (bi *Instance) StageLocalSourceTree(workDir, buildVersion string) error {
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/build"
+	"k8s.io/release/pkg/release"
+)
+
+func TestCopyStagedFromGCS(t *testing.T) {
+	const (
+		version      = "v1.30.0"
+		buildVersion = "v1.30.0-rc.0.1+abcdef"
+	)
+
+	for _, tc := range []struct {
+		name          string
+		modify        func(stageRoot string)
+		expectedError string
+	}{
+		{
+			name:   "success",
+			modify: func(string) {},
+		},
+		{
+			name: "modified artifact",
+			modify: func(stageRoot string) {
+				require.NoError(t, os.WriteFile(
+					filepath.Join(stageRoot, release.GCSStagePath, version, release.KubernetesTar),
+					[]byte("changed"), 0o644,
+				))
+			},
+			expectedError: "- modified: gcs-stage/v1.30.0/kubernetes.tar.gz",
+		},
+		{
+			name: "extra artifact",
+			modify: func(stageRoot string) {
+				require.NoError(t, os.WriteFile(
+					filepath.Join(stageRoot, release.ImagesPath, "extra"), []byte{}, 0o644,
+				))
+			},
+			expectedError: "- extra:    release-images/extra",
+		},
+		{
+			name: "missing inventory",
+			modify: func(stageRoot string) {
+				require.NoError(t, os.Remove(filepath.Join(stageRoot, release.InventoryFile)))
+			},
+			expectedError: "no artifact inventory found",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			root := t.TempDir()
+			stageRoot := filepath.Join(root, "bucket", release.StagePath, buildVersion, version)
+			for file, content := range map[string]string{
+				filepath.Join(release.GCSStagePath, version, release.KubernetesTar): "tarball",
+				filepath.Join(release.GCSStagePath, version, "bin", "kubectl"):      "kubectl",
+				filepath.Join(release.ImagesPath, "amd64", "kube-apiserver.tar"):    "image",
+			} {
+				path := filepath.Join(stageRoot, file)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+			}
+
+			inventory, err := release.NewInventory(stageRoot, release.StagedInventoryDirs(version)...)
+			require.NoError(t, err)
+			require.NoError(t, inventory.Write(filepath.Join(stageRoot, release.InventoryFile)))
+			tc.modify(stageRoot)
+
+			buildDir := t.TempDir()
+			sut := build.NewInstance(&build.Options{
+				LocalDir: root,
+				Bucket:   "bucket",
+				Version:  version,
+				BuildDir: buildDir,
+			})
+			err = sut.CopyStagedFromGCS("bucket", buildVersion)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				require.NoDirExists(t, filepath.Join(root, "bucket", "release"))
+				return
+			}
+
+			require.NoError(t, err)
+			require.FileExists(t, filepath.Join(root, "bucket", "release", version, "bin", "kubectl"))
+			require.FileExists(t, filepath.Join(buildDir, release.GCSStagePath, version, release.KubernetesTar))
+			require.FileExists(t, filepath.Join(buildDir, release.ImagesPath, "amd64", "kube-apiserver.tar"))
+		})
+	}
+}
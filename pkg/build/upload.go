@@ -0,0 +1,451 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"bufio"
+	"context"
+	"crypto/md5" //nolint:gosec // used to compare with GCS object checksums
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/storage"
+	"github.com/nozzle/throttler"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/iterator"
+
+	"sigs.k8s.io/release-sdk/object"
+	rhash "sigs.k8s.io/release-utils/hash"
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/localstore"
+)
+
+// DefaultUploadWorkers is the default number of parallel uploads.
+const DefaultUploadWorkers = 8
+
+// sha256MetadataKey is the object metadata key containing the SHA256 of
+// uploaded artifacts.
+const sha256MetadataKey = "sha256"
+
+// Uploader uploads local directory trees to an object store. It hashes all
+// files, skips objects whose remote checksum already matches and uploads the
+// remaining ones in parallel.
+type Uploader struct {
+	impl    uploaderImpl
+	workers int
+}
+
+// NewUploader creates a new `Uploader` for Google Cloud Storage.
+func NewUploader() *Uploader {
+	return &Uploader{&defaultUploaderImpl{}, DefaultUploadWorkers}
+}
+
+// NewLocalUploader creates a new `Uploader` for a local object store.
+func NewLocalUploader(store *localstore.Store) *Uploader {
+	return &Uploader{&localUploaderImpl{store}, DefaultUploadWorkers}
+}
+
+// NewDirUploader creates a new `Uploader` which copies into a local
+// directory, the destination paths are plain file system paths.
+func NewDirUploader() *Uploader {
+	return &Uploader{&dirUploaderImpl{}, DefaultUploadWorkers}
+}
+
+// SetImpl can be used to set the internal Uploader implementation.
+func (u *Uploader) SetImpl(impl uploaderImpl) {
+	u.impl = impl
+}
+
+// SetWorkers sets the maximum number of parallel hash and upload operations.
+func (u *Uploader) SetWorkers(workers int) {
+	if workers > 0 {
+		u.workers = workers
+	}
+}
+
+// RemoteObject contains the checksums of an object inside the store.
+type RemoteObject struct {
+	// SHA256 is the checksum recorded on upload, can be empty for objects
+	// which have not been uploaded by the `Uploader`.
+	SHA256 string
+
+	// MD5 is the hex encoded checksum computed by the object store, can be
+	// empty for composite objects.
+	MD5 string
+
+	// Size is the size of the object in bytes.
+	Size int64
+}
+
+// UploadResult contains the relative paths of all handled files.
+type UploadResult struct {
+	Uploaded []string
+	Skipped  []string
+}
+
+//counterfeiter:generate . uploaderImpl
+type uploaderImpl interface {
+	ListObjects(gcsPath string) (map[string]RemoteObject, error)
+	Upload(srcPath, gcsPath, sha256 string) error
+}
+
+// Upload uploads all files of `srcDir` to `gcsPath`. If `srcDir` contains a
+// SHA256SUMS file, then the uploaded objects get verified against it.
+func (u *Uploader) Upload(srcDir, gcsPath string) (*UploadResult, error) {
+	files, err := listFiles(srcDir)
+	if err != nil {
+		return nil, fmt.Errorf("list files in %s: %w", srcDir, err)
+	}
+
+	logrus.Infof("Hashing %d files in %s", len(files), srcDir)
+	hashes, err := u.hashFiles(srcDir, files)
+	if err != nil {
+		return nil, fmt.Errorf("hash files: %w", err)
+	}
+
+	remote, err := u.impl.ListObjects(gcsPath)
+	if err != nil {
+		return nil, fmt.Errorf("list remote objects: %w", err)
+	}
+
+	res := &UploadResult{Uploaded: []string{}, Skipped: []string{}}
+	toUpload := []string{}
+	for _, file := range files {
+		if hashes[file].matches(remote[file]) {
+			res.Skipped = append(res.Skipped, file)
+			continue
+		}
+		toUpload = append(toUpload, file)
+	}
+
+	logrus.Infof(
+		"Uploading %d files to %s (%d unchanged)",
+		len(toUpload), gcsPath, len(res.Skipped),
+	)
+	t := throttler.New(u.workers, len(toUpload))
+	for _, file := range toUpload {
+		go func(file string) {
+			t.Done(u.impl.Upload(
+				filepath.Join(srcDir, file),
+				gcsPath+"/"+file,
+				hashes[file].SHA256,
+			))
+		}(file)
+		if t.Throttle() > 0 {
+			break
+		}
+	}
+	if err := t.Err(); err != nil {
+		return nil, fmt.Errorf("upload files: %w", err)
+	}
+	res.Uploaded = toUpload
+
+	if err := u.verify(srcDir, gcsPath, hashes); err != nil {
+		return nil, fmt.Errorf("verify uploaded files: %w", err)
+	}
+	return res, nil
+}
+
+// verify checks the remote objects against the SHA256SUMS file of `srcDir`,
+// if it exists.
+func (u *Uploader) verify(
+	srcDir, gcsPath string, hashes map[string]RemoteObject,
+) error {
+	sums, err := readSHA256Sums(filepath.Join(srcDir, "SHA256SUMS"))
+	if errors.Is(err, os.ErrNotExist) {
+		logrus.Infof("No SHA256SUMS found in %s, skipping verification", srcDir)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read SHA256SUMS: %w", err)
+	}
+
+	remote, err := u.impl.ListObjects(gcsPath)
+	if err != nil {
+		return fmt.Errorf("list remote objects: %w", err)
+	}
+
+	logrus.Infof("Verifying %d uploaded files against SHA256SUMS", len(sums))
+	for file, sum := range sums {
+		local, ok := hashes[file]
+		if !ok || local.SHA256 != sum {
+			return fmt.Errorf("local file %s does not match SHA256SUMS", file)
+		}
+		obj, ok := remote[file]
+		if !ok {
+			return fmt.Errorf("remote object %s does not exist", file)
+		}
+		if !local.matches(obj) {
+			return fmt.Errorf("remote object %s does not match SHA256SUMS", file)
+		}
+	}
+	return nil
+}
+
+// matches returns true if the remote object has the same content like the
+// local file represented by `r`.
+func (r RemoteObject) matches(remote RemoteObject) bool {
+	if remote.SHA256 != "" {
+		return remote.SHA256 == r.SHA256
+	}
+	return remote.MD5 != "" && remote.MD5 == r.MD5
+}
+
+// hashFiles computes the checksums of all `files` in parallel.
+func (u *Uploader) hashFiles(
+	srcDir string, files []string,
+) (map[string]RemoteObject, error) {
+	var mu sync.Mutex
+	hashes := map[string]RemoteObject{}
+
+	t := throttler.New(u.workers, len(files))
+	for _, file := range files {
+		go func(file string) {
+			path := filepath.Join(srcDir, file)
+			sha, err := rhash.SHA256ForFile(path)
+			if err != nil {
+				t.Done(fmt.Errorf("get SHA256 of %s: %w", path, err))
+				return
+			}
+			md5sum, err := rhash.ForFile(path, md5.New()) //nolint:gosec // see import
+			if err != nil {
+				t.Done(fmt.Errorf("get MD5 of %s: %w", path, err))
+				return
+			}
+
+			mu.Lock()
+			hashes[file] = RemoteObject{SHA256: sha, MD5: md5sum}
+			mu.Unlock()
+			t.Done(nil)
+		}(file)
+		if t.Throttle() > 0 {
+			break
+		}
+	}
+	if err := t.Err(); err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// listFiles returns the sorted relative paths of all files in `dir`.
+func listFiles(dir string) ([]string, error) {
+	files := []string{}
+	if err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// readSHA256Sums parses a file written by `release.WriteChecksums`.
+func readSHA256Sums(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sums := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		sum, file, ok := strings.Cut(line, "  ")
+		if !ok {
+			return nil, fmt.Errorf("invalid checksum line: %q", line)
+		}
+		sums[file] = sum
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan %s: %w", path, err)
+	}
+	return sums, nil
+}
+
+// splitGCSPath splits `gs://bucket/path` into the bucket and object path.
+func splitGCSPath(gcsPath string) (bucket, path string, err error) {
+	if !strings.HasPrefix(gcsPath, object.GcsPrefix) {
+		return "", "", fmt.Errorf("path %s is not a GCS path", gcsPath)
+	}
+	bucket, path, _ = strings.Cut(strings.TrimPrefix(gcsPath, object.GcsPrefix), "/")
+	if bucket == "" {
+		return "", "", fmt.Errorf("path %s does not contain a bucket", gcsPath)
+	}
+	return bucket, path, nil
+}
+
+type defaultUploaderImpl struct {
+	once      sync.Once
+	client    *storage.Client
+	clientErr error
+}
+
+func (d *defaultUploaderImpl) storageClient() (*storage.Client, error) {
+	d.once.Do(func() {
+		d.client, d.clientErr = storage.NewClient(context.Background())
+	})
+	return d.client, d.clientErr
+}
+
+func (d *defaultUploaderImpl) ListObjects(gcsPath string) (map[string]RemoteObject, error) {
+	client, err := d.storageClient()
+	if err != nil {
+		return nil, fmt.Errorf("create storage client: %w", err)
+	}
+	bucket, prefix, err := splitGCSPath(gcsPath)
+	if err != nil {
+		return nil, err
+	}
+	prefix = strings.TrimSuffix(prefix, "/") + "/"
+
+	objects := map[string]RemoteObject{}
+	it := client.Bucket(bucket).Objects(
+		context.Background(), &storage.Query{Prefix: prefix},
+	)
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("list objects in %s: %w", gcsPath, err)
+		}
+		objects[strings.TrimPrefix(attrs.Name, prefix)] = RemoteObject{
+			SHA256: attrs.Metadata[sha256MetadataKey],
+			MD5:    hex.EncodeToString(attrs.MD5),
+			Size:   attrs.Size,
+		}
+	}
+	return objects, nil
+}
+
+func (d *defaultUploaderImpl) Upload(srcPath, gcsPath, sha256 string) error {
+	client, err := d.storageClient()
+	if err != nil {
+		return fmt.Errorf("create storage client: %w", err)
+	}
+	bucket, path, err := splitGCSPath(gcsPath)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(srcPath)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", srcPath, err)
+	}
+	defer f.Close()
+
+	logrus.Debugf("Uploading %s to %s", srcPath, gcsPath)
+	w := client.Bucket(bucket).Object(path).NewWriter(context.Background())
+	w.Metadata = map[string]string{sha256MetadataKey: sha256}
+	if _, err := io.Copy(w, f); err != nil {
+		w.Close()
+		return fmt.Errorf("upload %s: %w", srcPath, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("finish upload of %s: %w", srcPath, err)
+	}
+	return nil
+}
+
+type localUploaderImpl struct {
+	store *localstore.Store
+}
+
+func (l *localUploaderImpl) ListObjects(gcsPath string) (map[string]RemoteObject, error) {
+	dir, err := l.store.LocalPath(gcsPath)
+	if err != nil {
+		return nil, err
+	}
+	return listLocalObjects(dir)
+}
+
+func (l *localUploaderImpl) Upload(srcPath, gcsPath, _ string) error {
+	dst, err := l.store.LocalPath(gcsPath)
+	if err != nil {
+		return err
+	}
+	return copyLocalObject(srcPath, dst)
+}
+
+type dirUploaderImpl struct{}
+
+func (*dirUploaderImpl) ListObjects(dir string) (map[string]RemoteObject, error) {
+	return listLocalObjects(dir)
+}
+
+func (*dirUploaderImpl) Upload(srcPath, dst, _ string) error {
+	return copyLocalObject(srcPath, dst)
+}
+
+// listLocalObjects returns the checksums of all files in `dir`.
+func listLocalObjects(dir string) (map[string]RemoteObject, error) {
+	if !util.Exists(dir) {
+		return map[string]RemoteObject{}, nil
+	}
+
+	files, err := listFiles(dir)
+	if err != nil {
+		return nil, fmt.Errorf("list files in %s: %w", dir, err)
+	}
+	objects := map[string]RemoteObject{}
+	for _, file := range files {
+		path := filepath.Join(dir, file)
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("stat %s: %w", file, err)
+		}
+		sha, err := rhash.SHA256ForFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("get SHA256 of %s: %w", file, err)
+		}
+		objects[file] = RemoteObject{SHA256: sha, Size: info.Size()}
+	}
+	return objects, nil
+}
+
+// copyLocalObject copies `srcPath` to `dst` and creates its parent directory.
+func copyLocalObject(srcPath, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), os.FileMode(0o755)); err != nil {
+		return fmt.Errorf("create directory for %s: %w", dst, err)
+	}
+	return util.CopyFileLocal(srcPath, dst, true)
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/release-utils/hash"
+
+	"k8s.io/release/pkg/build"
+	"k8s.io/release/pkg/build/buildfakes"
+	"k8s.io/release/pkg/localstore"
+)
+
+// writeUploadTree creates a directory containing two files and a matching
+// SHA256SUMS file.
+func writeUploadTree(t *testing.T) (dir string, sums map[string]string) {
+	dir = t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "bin"), 0o755))
+	sums = map[string]string{}
+	for file, content := range map[string]string{
+		"kubernetes.tar.gz": "tarball",
+		"bin/kubectl":       "kubectl",
+	} {
+		path := filepath.Join(dir, file)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		sha, err := hash.SHA256ForFile(path)
+		require.NoError(t, err)
+		sums[file] = sha
+	}
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "SHA256SUMS"),
+		[]byte(sums["bin/kubectl"]+"  bin/kubectl\n"+
+			sums["kubernetes.tar.gz"]+"  kubernetes.tar.gz"),
+		0o644,
+	))
+	return dir, sums
+}
+
+func TestUpload(t *testing.T) {
+	for _, tc := range []struct {
+		prepare          func(*buildfakes.FakeUploaderImpl, map[string]string)
+		expectedUploaded []string
+		shouldError      bool
+	}{
+		{ // success, nothing remote
+			prepare: func(mock *buildfakes.FakeUploaderImpl, sums map[string]string) {
+				mock.ListObjectsReturnsOnCall(0, map[string]build.RemoteObject{}, nil)
+				mock.ListObjectsReturnsOnCall(1, map[string]build.RemoteObject{
+					"bin/kubectl":       {SHA256: sums["bin/kubectl"]},
+					"kubernetes.tar.gz": {SHA256: sums["kubernetes.tar.gz"]},
+				}, nil)
+			},
+			expectedUploaded: []string{"SHA256SUMS", "bin/kubectl", "kubernetes.tar.gz"},
+		},
+		{ // success, unchanged objects are skipped
+			prepare: func(mock *buildfakes.FakeUploaderImpl, sums map[string]string) {
+				mock.ListObjectsReturnsOnCall(0, map[string]build.RemoteObject{
+					"bin/kubectl":       {SHA256: sums["bin/kubectl"]},
+					"kubernetes.tar.gz": {SHA256: "changed"},
+				}, nil)
+				mock.ListObjectsReturnsOnCall(1, map[string]build.RemoteObject{
+					"bin/kubectl":       {SHA256: sums["bin/kubectl"]},
+					"kubernetes.tar.gz": {SHA256: sums["kubernetes.tar.gz"]},
+				}, nil)
+			},
+			expectedUploaded: []string{"SHA256SUMS", "kubernetes.tar.gz"},
+		},
+		{ // ListObjects fails
+			prepare: func(mock *buildfakes.FakeUploaderImpl, _ map[string]string) {
+				mock.ListObjectsReturns(nil, err)
+			},
+			shouldError: true,
+		},
+		{ // Upload fails
+			prepare: func(mock *buildfakes.FakeUploaderImpl, _ map[string]string) {
+				mock.UploadReturns(err)
+			},
+			shouldError: true,
+		},
+		{ // verification fails, remote object missing
+			prepare: func(mock *buildfakes.FakeUploaderImpl, _ map[string]string) {
+				mock.ListObjectsReturns(map[string]build.RemoteObject{}, nil)
+			},
+			expectedUploaded: []string{"SHA256SUMS", "bin/kubectl", "kubernetes.tar.gz"},
+			shouldError:      true,
+		},
+	} {
+		dir, sums := writeUploadTree(t)
+		mock := &buildfakes.FakeUploaderImpl{}
+		tc.prepare(mock, sums)
+
+		sut := build.NewUploader()
+		sut.SetImpl(mock)
+		sut.SetWorkers(2)
+
+		res, err := sut.Upload(dir, "gs://bucket/stage")
+		if tc.expectedUploaded != nil {
+			uploaded := []string{}
+			for i := range mock.UploadCallCount() {
+				_, gcsPath, _ := mock.UploadArgsForCall(i)
+				uploaded = append(uploaded, gcsPath)
+			}
+			expected := []string{}
+			for _, file := range tc.expectedUploaded {
+				expected = append(expected, "gs://bucket/stage/"+file)
+			}
+			require.ElementsMatch(t, expected, uploaded)
+		}
+		if tc.shouldError {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.expectedUploaded, res.Uploaded)
+	}
+}
+
+func TestUploadLocal(t *testing.T) {
+	dir, _ := writeUploadTree(t)
+	root := t.TempDir()
+	sut := build.NewLocalUploader(localstore.New(root))
+
+	res, err := sut.Upload(dir, "gs://bucket/stage")
+	require.NoError(t, err)
+	require.Len(t, res.Uploaded, 3)
+	require.Empty(t, res.Skipped)
+	require.FileExists(t, filepath.Join(root, "bucket", "stage", "bin", "kubectl"))
+
+	// Second upload skips all unchanged files
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "extra"), []byte("extra"), 0o644,
+	))
+	res, err = sut.Upload(dir, "gs://bucket/stage")
+	require.NoError(t, err)
+	require.Equal(t, []string{"extra"}, res.Uploaded)
+	require.Len(t, res.Skipped, 3)
+}
+
+func TestUploadDir(t *testing.T) {
+	dir, _ := writeUploadTree(t)
+	dst := filepath.Join(t.TempDir(), "stage")
+	sut := build.NewDirUploader()
+
+	res, err := sut.Upload(dir, dst)
+	require.NoError(t, err)
+	require.Len(t, res.Uploaded, 3)
+	require.FileExists(t, filepath.Join(dst, "bin", "kubectl"))
+
+	res, err = sut.Upload(dir, dst)
+	require.NoError(t, err)
+	require.Empty(t, res.Uploaded)
+	require.Len(t, res.Skipped, 3)
+}
@@ -0,0 +1,540 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package changelog
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/util"
+
+	"k8s.io/release/pkg/release"
+)
+
+const (
+	// AuditCheckSection verifies that every released tag has a section.
+	AuditCheckSection = "section"
+
+	// AuditCheckTOC verifies that the table of contents matches the headings.
+	AuditCheckTOC = "toc"
+
+	// AuditCheckDownloads verifies that the download tables reference
+	// existing artifacts of the corresponding release.
+	AuditCheckDownloads = "downloads"
+
+	// AuditCheckDuplicatePR verifies that no PR appears in two patch releases
+	// of the same minor unless it got cherry picked again.
+	AuditCheckDuplicatePR = "duplicate-pr"
+
+	// AuditCheckReadme verifies that the CHANGELOG/README.md links all
+	// changelog files.
+	AuditCheckReadme = "readme"
+)
+
+// AuditOptions are the settings for auditing the CHANGELOG directory.
+type AuditOptions struct {
+	// RepoPath is the path to the k/k checkout.
+	RepoPath string
+
+	// Since is the first release to audit, for example v1.28.0. Older
+	// changelogs may predate the current format and get skipped.
+	Since string
+
+	// CheckDownloads enables requesting every artifact of the download
+	// tables, which requires network access.
+	CheckDownloads bool
+
+	// Bucket is the release bucket the file tables link to. Defaults to the
+	// production bucket if empty.
+	Bucket string
+}
+
+// AuditFinding is a single inconsistency found by the auditor.
+type AuditFinding struct {
+	Check   string
+	File    string
+	Line    int
+	Message string
+}
+
+// String returns the finding in the common file:line format.
+func (f *AuditFinding) String() string {
+	location := f.File
+	if f.Line > 0 {
+		location = fmt.Sprintf("%s:%d", f.File, f.Line)
+	}
+	return fmt.Sprintf("%s: [%s] %s", location, f.Check, f.Message)
+}
+
+// Auditor verifies the consistency of the CHANGELOG directory.
+type Auditor struct {
+	options *AuditOptions
+	impl    auditImpl
+}
+
+// NewAuditor creates a new Auditor instance.
+func NewAuditor(opts *AuditOptions) *Auditor {
+	return &Auditor{
+		options: opts,
+		impl:    &defaultAuditImpl{},
+	}
+}
+
+// SetImpl can be used to set the internal implementation.
+func (a *Auditor) SetImpl(impl auditImpl) {
+	a.impl = impl
+}
+
+var (
+	changelogFileRegex  = regexp.MustCompile(`^CHANGELOG-(\d+)\.(\d+)\.md$`)
+	sectionHeadingRegex = regexp.MustCompile(`^# (v\d+\.\d+\.\d+\S*)\s*$`)
+	downloadRowRegex    = regexp.MustCompile(`^\[([^\]]+)\]\((https?://[^)]+)\) \| `)
+	prLinkRegex         = regexp.MustCompile(`\[#(\d+)\]\(https://github\.com/[^/]+/[^/]+/pull/\d+\)`)
+	prRefRegex          = regexp.MustCompile(`\(#(\d+), @`)
+	readmeLinkRegex     = regexp.MustCompile(`^- \[(CHANGELOG-[^\]]+\.md)\]\(\./([^)]+)\)`)
+)
+
+// changelogSection is the part of a changelog file belonging to a release.
+type changelogSection struct {
+	version semver.Version
+	tag     string
+	line    int
+
+	// downloads maps the artifact URLs to their line
+	downloads map[string]int
+
+	// prs maps the referenced PR numbers to their first line
+	prs map[int]int
+}
+
+// changelogFile is a parsed CHANGELOG-x.y.md file.
+type changelogFile struct {
+	path     string
+	content  string
+	sections []*changelogSection
+}
+
+// Run audits the CHANGELOG directory and returns the findings sorted by
+// file and line.
+func (a *Auditor) Run() ([]*AuditFinding, error) {
+	since := semver.Version{}
+	if a.options.Since != "" {
+		v, err := util.TagStringToSemver(a.options.Since)
+		if err != nil {
+			return nil, fmt.Errorf("parse since version %s: %w", a.options.Since, err)
+		}
+		since = v
+	}
+
+	changelogDir := filepath.Join(a.options.RepoPath, RepoChangelogDir)
+	files, err := a.readChangelogFiles(changelogDir, since)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("Auditing %d changelog files in %s", len(files), changelogDir)
+
+	findings := []*AuditFinding{}
+	for _, check := range []func(map[string]*changelogFile, semver.Version) ([]*AuditFinding, error){
+		a.checkSections,
+		a.checkTOC,
+		a.checkDownloads,
+		a.checkDuplicatePRs,
+	} {
+		res, err := check(files, since)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, res...)
+	}
+
+	readmeFindings, err := a.checkReadme(changelogDir)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, readmeFindings...)
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		return findings[i].Line < findings[j].Line
+	})
+	return findings, nil
+}
+
+// readChangelogFiles parses all changelog files of minors not older than
+// since, indexed by their file name.
+func (a *Auditor) readChangelogFiles(dir string, since semver.Version) (map[string]*changelogFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read changelog directory: %w", err)
+	}
+
+	res := map[string]*changelogFile{}
+	for _, entry := range entries {
+		match := changelogFileRegex.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		major, _ := strconv.ParseUint(match[1], 10, 64) //nolint:errcheck // guaranteed by the regex
+		minor, _ := strconv.ParseUint(match[2], 10, 64) //nolint:errcheck // guaranteed by the regex
+		if (semver.Version{Major: major, Minor: minor}).LT(semver.Version{Major: since.Major, Minor: since.Minor}) {
+			continue
+		}
+
+		path := filepath.Join(RepoChangelogDir, entry.Name())
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read changelog file: %w", err)
+		}
+		res[entry.Name()] = parseChangelogFile(path, string(content), a.downloadURLPrefix())
+	}
+	return res, nil
+}
+
+// downloadURLPrefix returns the URL prefix of all artifacts in the file
+// tables. Other tables, like the container images, link elsewhere.
+func (a *Auditor) downloadURLPrefix() string {
+	bucket := a.options.Bucket
+	if bucket == "" {
+		bucket = release.ProductionBucket
+	}
+	return release.URLPrefixForBucket(bucket) + "/"
+}
+
+func parseChangelogFile(path, content, downloadURLPrefix string) *changelogFile {
+	file := &changelogFile{path: path, content: content}
+
+	var section *changelogSection
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+
+		if match := sectionHeadingRegex.FindStringSubmatch(text); match != nil {
+			version, err := util.TagStringToSemver(match[1])
+			if err != nil {
+				logrus.Warnf("Skipping unparsable section %s in %s:%d", match[1], path, line)
+				section = nil
+				continue
+			}
+			section = &changelogSection{
+				version:   version,
+				tag:       match[1],
+				line:      line,
+				downloads: map[string]int{},
+				prs:       map[int]int{},
+			}
+			file.sections = append(file.sections, section)
+			continue
+		}
+
+		if section == nil {
+			continue
+		}
+
+		if match := downloadRowRegex.FindStringSubmatch(text); match != nil &&
+			strings.HasPrefix(match[2], downloadURLPrefix) {
+			section.downloads[match[2]] = line
+		}
+
+		for _, re := range []*regexp.Regexp{prLinkRegex, prRefRegex} {
+			for _, match := range re.FindAllStringSubmatch(text, -1) {
+				pr, err := strconv.Atoi(match[1])
+				if err != nil {
+					continue
+				}
+				if _, ok := section.prs[pr]; !ok {
+					section.prs[pr] = line
+				}
+			}
+		}
+	}
+	return file
+}
+
+// checkSections verifies that every released tag has a section in the
+// changelog of its minor. The alpha.0 and rc.0 tags are created when cutting
+// branches and never get released.
+func (a *Auditor) checkSections(files map[string]*changelogFile, since semver.Version) ([]*AuditFinding, error) {
+	tags, err := a.impl.Tags(a.options.RepoPath)
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+
+	findings := []*AuditFinding{}
+	for _, tag := range tags {
+		version, err := util.TagStringToSemver(tag)
+		if err != nil || version.LT(since) || isUnreleasedTag(version) {
+			continue
+		}
+
+		name := changelogFilename(version, "md")
+		file, ok := files[name]
+		if !ok {
+			findings = append(findings, &AuditFinding{
+				Check:   AuditCheckSection,
+				File:    filepath.Join(RepoChangelogDir, name),
+				Message: fmt.Sprintf("changelog file is missing for released tag %s", tag),
+			})
+			continue
+		}
+
+		if !slices.ContainsFunc(file.sections, func(s *changelogSection) bool {
+			return s.version.EQ(version)
+		}) {
+			findings = append(findings, &AuditFinding{
+				Check:   AuditCheckSection,
+				File:    file.path,
+				Message: fmt.Sprintf("no section found for released tag %s", tag),
+			})
+		}
+	}
+	return findings, nil
+}
+
+func isUnreleasedTag(version semver.Version) bool {
+	return len(version.Pre) == 2 && version.Pre[1].IsNum && version.Pre[1].VersionNum == 0
+}
+
+// checkTOC verifies that the table of contents between the markers written
+// by the changelog generation matches the headings of the file.
+func (a *Auditor) checkTOC(files map[string]*changelogFile, _ semver.Version) ([]*AuditFinding, error) {
+	findings := []*AuditFinding{}
+	for _, name := range sortedFileNames(files) {
+		file := files[name]
+
+		startIndex := strings.Index(file.content, tocStart)
+		endIndex := strings.Index(file.content, TocEnd)
+		if startIndex < 0 || endIndex < startIndex {
+			findings = append(findings, &AuditFinding{
+				Check:   AuditCheckTOC,
+				File:    file.path,
+				Message: "table of contents markers are missing or in the wrong order",
+			})
+			continue
+		}
+
+		expected, err := a.impl.GenerateTOC(file.content[endIndex+len(TocEnd):])
+		if err != nil {
+			return nil, fmt.Errorf("generate table of contents of %s: %w", file.path, err)
+		}
+
+		actual := file.content[startIndex+len(tocStart) : endIndex]
+		if strings.TrimSpace(actual) != strings.TrimSpace(expected) {
+			findings = append(findings, &AuditFinding{
+				Check:   AuditCheckTOC,
+				File:    file.path,
+				Line:    lineOf(file.content, startIndex),
+				Message: "table of contents does not match the headings",
+			})
+		}
+	}
+	return findings, nil
+}
+
+// checkDownloads verifies that the download tables of every section only
+// reference the artifacts of their release and, if enabled, that those
+// artifacts exist.
+func (a *Auditor) checkDownloads(files map[string]*changelogFile, since semver.Version) ([]*AuditFinding, error) {
+	findings := []*AuditFinding{}
+	for _, name := range sortedFileNames(files) {
+		file := files[name]
+		for _, section := range file.sections {
+			if section.version.LT(since) {
+				continue
+			}
+
+			urls := make([]string, 0, len(section.downloads))
+			for url := range section.downloads {
+				urls = append(urls, url)
+			}
+			sort.Strings(urls)
+
+			for _, url := range urls {
+				line := section.downloads[url]
+				if !strings.Contains(url, "/"+section.tag+"/") {
+					findings = append(findings, &AuditFinding{
+						Check:   AuditCheckDownloads,
+						File:    file.path,
+						Line:    line,
+						Message: fmt.Sprintf("artifact %s does not belong to %s", url, section.tag),
+					})
+					continue
+				}
+
+				if !a.options.CheckDownloads {
+					continue
+				}
+				exists, err := a.impl.URLExists(url)
+				if err != nil {
+					return nil, fmt.Errorf("check artifact %s: %w", url, err)
+				}
+				if !exists {
+					findings = append(findings, &AuditFinding{
+						Check:   AuditCheckDownloads,
+						File:    file.path,
+						Line:    line,
+						Message: fmt.Sprintf("artifact %s does not exist", url),
+					})
+				}
+			}
+		}
+	}
+	return findings, nil
+}
+
+// checkDuplicatePRs verifies that no PR appears in two patch releases of the
+// same minor, unless it got cherry picked between both of them.
+func (a *Auditor) checkDuplicatePRs(files map[string]*changelogFile, since semver.Version) ([]*AuditFinding, error) {
+	findings := []*AuditFinding{}
+	for _, name := range sortedFileNames(files) {
+		file := files[name]
+
+		patches := []*changelogSection{}
+		for _, section := range file.sections {
+			if section.version.Patch > 0 && len(section.version.Pre) == 0 && section.version.GTE(since) {
+				patches = append(patches, section)
+			}
+		}
+		sort.Slice(patches, func(i, j int) bool {
+			return patches[i].version.LT(patches[j].version)
+		})
+
+		// firstSeen maps the PRs to the first patch release containing them
+		firstSeen := map[int]*changelogSection{}
+		for _, section := range patches {
+			prs := make([]int, 0, len(section.prs))
+			for pr := range section.prs {
+				prs = append(prs, pr)
+			}
+			sort.Ints(prs)
+
+			for _, pr := range prs {
+				previous, ok := firstSeen[pr]
+				if !ok {
+					firstSeen[pr] = section
+					continue
+				}
+
+				cherryPicked, err := a.impl.CherryPickedPRs(a.options.RepoPath, previous.tag, section.tag)
+				if err != nil {
+					return nil, fmt.Errorf("list cherry picks: %w", err)
+				}
+				if slices.Contains(cherryPicked, pr) {
+					continue
+				}
+
+				findings = append(findings, &AuditFinding{
+					Check: AuditCheckDuplicatePR,
+					File:  file.path,
+					Line:  section.prs[pr],
+					Message: fmt.Sprintf(
+						"PR #%d of %s already appeared in %s without being cherry picked again",
+						pr, section.tag, previous.tag,
+					),
+				})
+			}
+		}
+	}
+	return findings, nil
+}
+
+// checkReadme verifies that the README links all changelog files and that
+// all links point to existing files.
+func (a *Auditor) checkReadme(changelogDir string) ([]*AuditFinding, error) {
+	readmePath := markdownChangelogReadme()
+	content, err := os.ReadFile(filepath.Join(changelogDir, "README.md"))
+	if os.IsNotExist(err) {
+		return []*AuditFinding{{
+			Check:   AuditCheckReadme,
+			File:    readmePath,
+			Message: "changelog README is missing",
+		}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read changelog README: %w", err)
+	}
+
+	findings := []*AuditFinding{}
+	linked := map[string]bool{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		match := readmeLinkRegex.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		linked[match[2]] = true
+
+		if match[1] != match[2] {
+			findings = append(findings, &AuditFinding{
+				Check:   AuditCheckReadme,
+				File:    readmePath,
+				Line:    line,
+				Message: fmt.Sprintf("link text %s does not match its target %s", match[1], match[2]),
+			})
+		}
+		if _, err := os.Stat(filepath.Join(changelogDir, match[2])); err != nil {
+			findings = append(findings, &AuditFinding{
+				Check:   AuditCheckReadme,
+				File:    readmePath,
+				Line:    line,
+				Message: fmt.Sprintf("linked changelog %s does not exist", match[2]),
+			})
+		}
+	}
+
+	entries, err := os.ReadDir(changelogDir)
+	if err != nil {
+		return nil, fmt.Errorf("read changelog directory: %w", err)
+	}
+	for _, entry := range entries {
+		if changelogFileRegex.MatchString(entry.Name()) && !linked[entry.Name()] {
+			findings = append(findings, &AuditFinding{
+				Check:   AuditCheckReadme,
+				File:    readmePath,
+				Message: fmt.Sprintf("changelog %s is not linked", entry.Name()),
+			})
+		}
+	}
+	return findings, nil
+}
+
+func sortedFileNames(files map[string]*changelogFile) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lineOf returns the line number of the byte offset within the content.
+func lineOf(content string, offset int) int {
+	return strings.Count(content[:offset], "\n") + 1
+}
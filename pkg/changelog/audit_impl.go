@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package changelog
+
+import (
+	"fmt"
+	nethttp "net/http"
+	"regexp"
+	"strconv"
+
+	"sigs.k8s.io/mdtoc/pkg/mdtoc"
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-utils/command"
+	"sigs.k8s.io/release-utils/http"
+)
+
+//counterfeiter:generate . auditImpl
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt changelogfakes/fake_audit_impl.go > changelogfakes/_fake_audit_impl.go && mv changelogfakes/_fake_audit_impl.go changelogfakes/fake_audit_impl.go"
+
+type auditImpl interface {
+	Tags(repoPath string) ([]string, error)
+	CherryPickedPRs(repoPath, from, to string) ([]int, error)
+	URLExists(url string) (bool, error)
+	GenerateTOC(markdown string) (string, error)
+}
+
+type defaultAuditImpl struct{}
+
+var cherryPickRegex = regexp.MustCompile(`automated-cherry-pick-of-#(\d+)`)
+
+func (*defaultAuditImpl) Tags(repoPath string) ([]string, error) {
+	repo, err := git.OpenRepo(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("open repository %s: %w", repoPath, err)
+	}
+	return repo.Tags()
+}
+
+// CherryPickedPRs returns the numbers of the original PRs which got cherry
+// picked by the merge commits between both revisions.
+func (*defaultAuditImpl) CherryPickedPRs(repoPath, from, to string) ([]int, error) {
+	res, err := command.NewWithWorkDir(
+		repoPath, "git", "log", "--format=%s", "--grep=automated-cherry-pick-of-#", from+".."+to,
+	).RunSilentSuccessOutput()
+	if err != nil {
+		return nil, fmt.Errorf("list cherry picks between %s and %s: %w", from, to, err)
+	}
+
+	prs := []int{}
+	for _, match := range cherryPickRegex.FindAllStringSubmatch(res.Output(), -1) {
+		pr, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("parse PR number %s: %w", match[1], err)
+		}
+		prs = append(prs, pr)
+	}
+	return prs, nil
+}
+
+func (*defaultAuditImpl) URLExists(url string) (bool, error) {
+	resp, err := http.NewAgent().WithFailOnHTTPError(false).HeadRequest(url)
+	if err != nil {
+		return false, fmt.Errorf("request %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode == nethttp.StatusOK, nil
+}
+
+func (*defaultAuditImpl) GenerateTOC(markdown string) (string, error) {
+	return mdtoc.GenerateTOC([]byte(markdown), mdtoc.Options{
+		Dryrun:     false,
+		SkipPrefix: false,
+		MaxDepth:   mdtoc.MaxHeaderDepth,
+	})
+}
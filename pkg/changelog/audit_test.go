@@ -0,0 +1,226 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package changelog_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/changelog"
+	"k8s.io/release/pkg/changelog/changelogfakes"
+)
+
+const (
+	testTOC = "- [v1.30.2](#v1302)\n- [v1.30.1](#v1301)"
+
+	testChangelog = `<!-- BEGIN MUNGE: GENERATED_TOC -->
+
+- [v1.30.2](#v1302)
+- [v1.30.1](#v1301)
+<!-- END MUNGE: GENERATED_TOC -->
+
+# v1.30.2
+
+## Downloads for v1.30.2
+
+[kubernetes.tar.gz](https://dl.k8s.io/v1.30.2/kubernetes.tar.gz) | abc
+
+## Changes by Kind
+
+- Fixed the kubelet ([#200](https://github.com/kubernetes/kubernetes/pull/200), [@jane](https://github.com/jane))
+
+# v1.30.1
+
+## Downloads for v1.30.1
+
+[kubernetes.tar.gz](https://dl.k8s.io/v1.30.1/kubernetes.tar.gz) | def
+
+## Changes by Kind
+
+- Fixed the kubelet (#100, @john)
+`
+	testReadme = `# CHANGELOGs
+
+- [CHANGELOG-1.30.md](./CHANGELOG-1.30.md)
+`
+)
+
+func TestAuditRun(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		changelog string
+		readme    string
+		prepare   func(*changelogfakes.FakeAuditImpl, *changelog.AuditOptions)
+		expected  []string
+		shouldErr bool
+	}{
+		{
+			name: "consistent changelog",
+		},
+		{
+			name: "missing sections",
+			prepare: func(mock *changelogfakes.FakeAuditImpl, _ *changelog.AuditOptions) {
+				mock.TagsReturns([]string{
+					"v1.29.5", "v1.30.0-rc.0", "v1.30.3", "v1.31.0-alpha.0", "v1.31.0-alpha.1",
+				}, nil)
+			},
+			expected: []string{
+				"CHANGELOG/CHANGELOG-1.30.md: [section] no section found for released tag v1.30.3",
+				"CHANGELOG/CHANGELOG-1.31.md: [section] changelog file is missing for released tag v1.31.0-alpha.1",
+			},
+		},
+		{
+			name:      "outdated table of contents",
+			changelog: strings.Replace(testChangelog, "- [v1.30.1](#v1301)\n<!-- END", "<!-- END", 1),
+			expected: []string{
+				"CHANGELOG/CHANGELOG-1.30.md:1: [toc] table of contents does not match the headings",
+			},
+		},
+		{
+			name:      "missing table of contents",
+			changelog: strings.Replace(testChangelog, "<!-- BEGIN MUNGE: GENERATED_TOC -->", "", 1),
+			expected: []string{
+				"CHANGELOG/CHANGELOG-1.30.md: [toc] table of contents markers are missing or in the wrong order",
+			},
+		},
+		{
+			name:      "wrong and missing downloads",
+			changelog: strings.Replace(testChangelog, "dl.k8s.io/v1.30.1/", "dl.k8s.io/v1.30.0/", 1),
+			prepare: func(mock *changelogfakes.FakeAuditImpl, opts *changelog.AuditOptions) {
+				opts.CheckDownloads = true
+				mock.URLExistsReturns(false, nil)
+			},
+			expected: []string{
+				"CHANGELOG/CHANGELOG-1.30.md:11: [downloads] artifact https://dl.k8s.io/v1.30.2/kubernetes.tar.gz does not exist",
+				"CHANGELOG/CHANGELOG-1.30.md:21: [downloads] artifact https://dl.k8s.io/v1.30.0/kubernetes.tar.gz does not belong to v1.30.1",
+			},
+		},
+		{
+			name: "image tables",
+			changelog: strings.Replace(testChangelog, "## Changes by Kind", `### Container Images
+
+name | architectures
+---- | -------------
+[registry.k8s.io/kube-apiserver:v1.30.2](https://console.cloud.google.com/artifacts/docker/k8s-artifacts-prod/southamerica-east1/images/kube-apiserver) | [amd64](https://console.cloud.google.com/artifacts/docker/k8s-artifacts-prod/southamerica-east1/images/kube-apiserver-amd64)
+
+## Changes by Kind`, 1),
+			prepare: func(_ *changelogfakes.FakeAuditImpl, opts *changelog.AuditOptions) {
+				opts.CheckDownloads = true
+			},
+		},
+		{
+			name:      "downloads of another bucket",
+			changelog: strings.Replace(testChangelog, "dl.k8s.io/v1.30.1/", "dl.k8s.io/v1.30.0/", 1),
+			prepare: func(_ *changelogfakes.FakeAuditImpl, opts *changelog.AuditOptions) {
+				opts.Bucket = "k8s-staging"
+			},
+		},
+		{
+			name:      "duplicate PR",
+			changelog: strings.ReplaceAll(testChangelog, "#200", "#100"),
+			expected: []string{
+				"CHANGELOG/CHANGELOG-1.30.md:15: [duplicate-pr] PR #100 of v1.30.2 already appeared in v1.30.1 without being cherry picked again",
+			},
+		},
+		{
+			name:      "duplicate PR cherry picked again",
+			changelog: strings.ReplaceAll(testChangelog, "#200", "#100"),
+			prepare: func(mock *changelogfakes.FakeAuditImpl, _ *changelog.AuditOptions) {
+				mock.CherryPickedPRsReturns([]int{100}, nil)
+			},
+		},
+		{
+			name:   "README out of sync",
+			readme: testReadme + "- [CHANGELOG-1.29.md](./CHANGELOG-1.29.md)\n",
+			expected: []string{
+				"CHANGELOG/README.md:4: [readme] linked changelog CHANGELOG-1.29.md does not exist",
+			},
+		},
+		{
+			name:   "README missing changelog",
+			readme: "# CHANGELOGs\n",
+			expected: []string{
+				"CHANGELOG/README.md: [readme] changelog CHANGELOG-1.30.md is not linked",
+			},
+		},
+		{
+			name: "error on tags",
+			prepare: func(mock *changelogfakes.FakeAuditImpl, _ *changelog.AuditOptions) {
+				mock.TagsReturns(nil, errors.New(""))
+			},
+			shouldErr: true,
+		},
+		{
+			name: "error on since",
+			prepare: func(_ *changelogfakes.FakeAuditImpl, opts *changelog.AuditOptions) {
+				opts.Since = "wrong"
+			},
+			shouldErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := t.TempDir()
+			dir := filepath.Join(repo, changelog.RepoChangelogDir)
+			require.NoError(t, os.MkdirAll(dir, 0o755))
+
+			content := testChangelog
+			if tc.changelog != "" {
+				content = tc.changelog
+			}
+			readme := testReadme
+			if tc.readme != "" {
+				readme = tc.readme
+			}
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "CHANGELOG-1.30.md"), []byte(content), 0o600))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "CHANGELOG-1.29.md.orig"), nil, 0o600))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte(readme), 0o600))
+
+			opts := &changelog.AuditOptions{RepoPath: repo, Since: "v1.30.0"}
+			mock := &changelogfakes.FakeAuditImpl{}
+			mock.TagsReturns([]string{"v1.30.1", "v1.30.2"}, nil)
+			mock.GenerateTOCReturns(testTOC, nil)
+			mock.URLExistsReturns(true, nil)
+			if tc.prepare != nil {
+				tc.prepare(mock, opts)
+			}
+
+			sut := changelog.NewAuditor(opts)
+			sut.SetImpl(mock)
+
+			findings, err := sut.Run()
+			if tc.shouldErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			res := []string{}
+			for _, finding := range findings {
+				res = append(res, finding.String())
+			}
+			if tc.expected == nil {
+				tc.expected = []string{}
+			}
+			require.Equal(t, tc.expected, res)
+		})
+	}
+}
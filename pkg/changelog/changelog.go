@@ -24,6 +24,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"text/template"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
@@ -32,6 +33,7 @@ import (
 	"sigs.k8s.io/release-sdk/github"
 	"sigs.k8s.io/release-utils/util"
 
+	"k8s.io/release/pkg/notes"
 	"k8s.io/release/pkg/notes/options"
 )
 
@@ -50,6 +52,26 @@ type Options struct {
 	CVEDataDir   string
 	CloneCVEMaps bool
 	Dependencies bool
+
+	// ModuleCacheDir is the directory to detect the licenses of the changed
+	// dependencies from, defaults to the Go module cache.
+	ModuleCacheDir string
+
+	// OSVDatabase is an optional offline OSV database snapshot to annotate
+	// the changed dependencies with their vulnerabilities.
+	OSVDatabase string
+
+	// DependenciesJSONFile is an optional file to write the dependency
+	// report to as JSON.
+	DependenciesJSONFile string
+
+	// TemplateFile is an optional file with Go templates which redefine the
+	// default changelog templates, see ParseTemplates.
+	TemplateFile string
+
+	// NotesStateDir is the directory used to persist the gathered release
+	// notes between consecutive runs.
+	NotesStateDir string
 }
 
 // Changelog can be used to generate the changelog for a release.
@@ -101,28 +123,18 @@ func (c *Changelog) Run() error {
 	}
 	logrus.Infof("Found latest %s commit %s", remoteBranch, head)
 
-	var markdown, jsonStr, startRev, endRev string
+	model := &Model{Release: Release{Tag: c.options.Tag}}
+	var startRev, endRev string
 	if tag.Patch == 0 {
 		if len(tag.Pre) == 0 { //nolint:gocritic // a switch case would not make it better
-			// Still create the downloads table
-			downloadsTable := &bytes.Buffer{}
-			startTag := util.SemverToTagString(semver.Version{
+			startRev = util.SemverToTagString(semver.Version{
 				Major: tag.Major, Minor: tag.Minor - 1, Patch: 0,
 			})
-
-			startRev = startTag
 			endRev = head
-
-			if err := c.impl.CreateDownloadsTable(
-				downloadsTable, c.options.Bucket, c.options.Tars,
-				c.options.Images, startRev, c.options.Tag,
-			); err != nil {
-				return fmt.Errorf("create downloads table: %w", err)
-			}
+			model.Release.NewMinor = true
 
 			// New final minor versions should have remote release notes
-			markdown, jsonStr, err = c.lookupRemoteReleaseNotes(branch)
-			markdown = downloadsTable.String() + markdown
+			model.RemoteNotes, model.ReleaseNotesJSON, err = c.lookupRemoteReleaseNotes(branch)
 		} else if tag.Pre[0].String() == "alpha" && tag.Pre[1].VersionNum == 1 {
 			// v1.x.0-alpha.1 releases use the previous minor as start commit.
 			// Those are usually the first releases being cut on master after
@@ -136,7 +148,7 @@ func (c *Changelog) Run() error {
 			// the current HEAD as end revision.
 			endRev = head
 
-			markdown, jsonStr, err = c.generateReleaseNotes(branch, startRev, endRev)
+			err = c.generateReleaseNotes(model, branch, startRev, endRev)
 		} else {
 			// New minor alpha, beta and rc releases get generated notes
 
@@ -159,7 +171,7 @@ func (c *Changelog) Run() error {
 				startRev = startTag
 				endRev = head
 
-				markdown, jsonStr, err = c.generateReleaseNotes(branch, startRev, endRev)
+				err = c.generateReleaseNotes(model, branch, startRev, endRev)
 			} else {
 				return fmt.Errorf(
 					"no latest tag available for branch %s", branch,
@@ -176,27 +188,54 @@ func (c *Changelog) Run() error {
 		}
 
 		// A patch version, let’s just use the previous patch
-		startTag := util.SemverToTagString(semver.Version{
+		startRev = util.SemverToTagString(semver.Version{
 			Major: tag.Major, Minor: tag.Minor, Patch: tag.Patch - 1,
 		})
-
-		startRev = startTag
 		endRev = head
 
-		markdown, jsonStr, err = c.generateReleaseNotes(branch, startTag, endRev)
+		err = c.generateReleaseNotes(model, branch, startRev, endRev)
 	}
 	if err != nil {
 		return fmt.Errorf("generate release notes: %w", err)
 	}
+	model.Release.PreviousRevision = startRev
+
+	model.FileDownloads, model.ImageDownloads, err = c.impl.FetchDownloads(
+		c.options.Bucket, c.options.Tars, c.options.Images, c.options.Tag,
+	)
+	if err != nil {
+		return fmt.Errorf("fetch downloads: %w", err)
+	}
 
 	if c.options.Dependencies {
 		logrus.Info("Generating dependency changes")
-		deps, err := c.impl.DependencyChanges(startRev, endRev)
+		model.DependencyReport, err = c.impl.DependencyReport(&notes.DependencyOptions{
+			RepoPath:       c.options.RepoPath,
+			ModuleCacheDir: c.options.ModuleCacheDir,
+			OSVDatabase:    c.options.OSVDatabase,
+		}, startRev, endRev)
 		if err != nil {
 			return fmt.Errorf("generate dependency changes: %w", err)
 		}
-		markdown += strings.Repeat(nl, 2) + deps
+		model.Dependencies = model.DependencyReport.Markdown()
+
+		if c.options.DependenciesJSONFile != "" {
+			if err := c.writeDependenciesJSON(model.DependencyReport); err != nil {
+				return fmt.Errorf("write dependencies JSON: %w", err)
+			}
+		}
+	}
+
+	tpl, err := c.templates()
+	if err != nil {
+		return fmt.Errorf("parse templates: %w", err)
+	}
+
+	markdown, err := c.render(tpl, TemplateMarkdown, model)
+	if err != nil {
+		return fmt.Errorf("render markdown: %w", err)
 	}
+	markdown = strings.TrimSpace(markdown)
 
 	logrus.Info("Generating TOC")
 	toc, err := c.impl.GenerateTOC(markdown)
@@ -228,12 +267,12 @@ func (c *Changelog) Run() error {
 	}
 
 	logrus.Info("Writing HTML")
-	if err := c.writeHTML(tag, markdown); err != nil {
+	if err := c.writeHTML(tpl, tag, model, markdown); err != nil {
 		return fmt.Errorf("write HTML: %w", err)
 	}
 
 	logrus.Info("Writing JSON")
-	if err := c.writeJSON(tag, jsonStr); err != nil {
+	if err := c.writeJSON(tpl, tag, model); err != nil {
 		return fmt.Errorf("write JSON: %w", err)
 	}
 
@@ -245,9 +284,11 @@ func (c *Changelog) Run() error {
 	return nil
 }
 
+// generateReleaseNotes gathers the release notes between both revisions and
+// adds them to the model.
 func (c *Changelog) generateReleaseNotes(
-	branch, startRev, endRev string,
-) (markdown, jsonStr string, err error) {
+	model *Model, branch, startRev, endRev string,
+) error {
 	logrus.Info("Generating release notes")
 
 	notesOptions := options.New()
@@ -260,6 +301,7 @@ func (c *Changelog) generateReleaseNotes(
 	notesOptions.Debug = logrus.StandardLogger().Level >= logrus.DebugLevel
 	notesOptions.RecordDir = c.options.RecordDir
 	notesOptions.ReplayDir = c.options.ReplayDir
+	notesOptions.StateDir = c.options.NotesStateDir
 	notesOptions.Pull = false
 	notesOptions.AddMarkdownLinks = true
 
@@ -270,33 +312,27 @@ func (c *Changelog) generateReleaseNotes(
 	}
 
 	if err := c.impl.ValidateAndFinish(notesOptions); err != nil {
-		return "", "", fmt.Errorf("validating notes options: %w", err)
+		return fmt.Errorf("validating notes options: %w", err)
 	}
 
 	releaseNotes, err := c.impl.GatherReleaseNotes(notesOptions)
 	if err != nil {
-		return "", "", fmt.Errorf("gather release notes: %w", err)
+		return fmt.Errorf("gather release notes: %w", err)
 	}
 
 	doc, err := c.impl.NewDocument(releaseNotes, startRev, c.options.Tag)
 	if err != nil {
-		return "", "", fmt.Errorf("create release note document: %w", err)
+		return fmt.Errorf("create release note document: %w", err)
 	}
 
 	releaseNotesJSON, err := json.MarshalIndent(releaseNotes.ByPR(), "", "  ")
 	if err != nil {
-		return "", "", fmt.Errorf("build release notes JSON: %w", err)
-	}
-
-	markdown, err = c.impl.RenderMarkdownTemplate(
-		doc, c.options.Bucket, c.options.Tars, c.options.Images,
-		options.GoTemplateInline+releaseNotesTemplate,
-	)
-	if err != nil {
-		return "", "", fmt.Errorf("render release notes to markdown: %w", err)
+		return fmt.Errorf("build release notes JSON: %w", err)
 	}
 
-	return markdown, string(releaseNotesJSON), nil
+	model.addDocument(doc)
+	model.ReleaseNotesJSON = string(releaseNotesJSON)
+	return nil
 }
 
 func (c *Changelog) writeMarkdown(
@@ -382,22 +418,42 @@ func addTocMarkers(toc string) string {
 	return fmt.Sprintf("%s\n\n%s\n%s\n", tocStart, toc, TocEnd)
 }
 
-func (c *Changelog) writeHTML(tag semver.Version, markdown string) error {
+// templates parses the changelog templates including the overrides from the
+// template file, if provided.
+func (c *Changelog) templates() (*template.Template, error) {
+	overrides := ""
+	if c.options.TemplateFile != "" {
+		content, err := c.impl.ReadFile(c.options.TemplateFile)
+		if err != nil {
+			return nil, fmt.Errorf("read template file: %w", err)
+		}
+		overrides = string(content)
+	}
+	return c.impl.ParseTemplates(overrides)
+}
+
+func (c *Changelog) render(tpl *template.Template, name string, data any) (string, error) {
+	output := &bytes.Buffer{}
+	if err := c.impl.TemplateExecute(tpl, output, name, data); err != nil {
+		return "", fmt.Errorf("execute %s template: %w", name, err)
+	}
+	return output.String(), nil
+}
+
+func (c *Changelog) writeHTML(
+	tpl *template.Template, tag semver.Version, model *Model, markdown string,
+) error {
 	content := &bytes.Buffer{}
 	if err := c.impl.MarkdownToHTML(markdown, content); err != nil {
 		return fmt.Errorf("render HTML from markdown: %w", err)
 	}
 
-	t, err := c.impl.ParseHTMLTemplate(htmlTemplate)
-	if err != nil {
-		return fmt.Errorf("parse HTML template: %w", err)
-	}
-
-	output := bytes.Buffer{}
-	if err := c.impl.TemplateExecute(t, &output, struct {
+	output, err := c.render(tpl, TemplateHTML, struct {
+		*Model
 		Title, Content string
-	}{util.SemverToTagString(tag), content.String()}); err != nil {
-		return fmt.Errorf("execute HTML template: %w", err)
+	}{model, util.SemverToTagString(tag), content.String()})
+	if err != nil {
+		return err
 	}
 
 	absOutputPath, err := c.impl.Abs(c.htmlChangelogFilename(tag))
@@ -405,26 +461,41 @@ func (c *Changelog) writeHTML(tag semver.Version, markdown string) error {
 		return fmt.Errorf("get absolute file path: %w", err)
 	}
 	logrus.Infof("Writing HTML file to %s", absOutputPath)
-	if err := c.impl.WriteFile(absOutputPath, output.Bytes(), os.FileMode(0o644)); err != nil {
+	if err := c.impl.WriteFile(absOutputPath, []byte(output), os.FileMode(0o644)); err != nil {
 		return fmt.Errorf("write template: %w", err)
 	}
 
 	return nil
 }
 
-func (c *Changelog) writeJSON(tag semver.Version, jsonStr string) error {
+func (c *Changelog) writeJSON(tpl *template.Template, tag semver.Version, model *Model) error {
+	output, err := c.render(tpl, TemplateJSON, model)
+	if err != nil {
+		return err
+	}
+
 	absOutputPath, err := c.impl.Abs(c.jsonChangelogFilename(tag))
 	if err != nil {
 		return fmt.Errorf("get absolute file path: %w", err)
 	}
 	logrus.Infof("Writing JSON file to %s", absOutputPath)
-	if err := c.impl.WriteFile(absOutputPath, []byte(jsonStr), os.FileMode(0o644)); err != nil {
+	if err := c.impl.WriteFile(absOutputPath, []byte(output), os.FileMode(0o644)); err != nil {
 		return fmt.Errorf("write JSON: %w", err)
 	}
 
 	return nil
 }
 
+func (c *Changelog) writeDependenciesJSON(report *notes.DependencyReport) error {
+	output, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal dependency report: %w", err)
+	}
+
+	logrus.Infof("Writing dependencies JSON file to %s", c.options.DependenciesJSONFile)
+	return c.impl.WriteFile(c.options.DependenciesJSONFile, output, os.FileMode(0o644))
+}
+
 func (c *Changelog) lookupRemoteReleaseNotes(
 	branch string,
 ) (markdownStr, jsonStr string, err error) {
@@ -103,9 +103,9 @@ func TestRun(t *testing.T) {
 			},
 			shouldErr: true,
 		},
-		{ // CreateDownloadsTable failed
+		{ // FetchDownloads failed
 			prepare: func(mock *changelogfakes.FakeImpl, _ *changelog.Options) {
-				mock.CreateDownloadsTableReturns(err)
+				mock.FetchDownloadsReturns(nil, nil, err)
 			},
 			shouldErr: true,
 		},
@@ -121,10 +121,28 @@ func TestRun(t *testing.T) {
 			},
 			shouldErr: true,
 		},
-		{ // DependencyChanges failed
+		{ // success with dependencies
 			prepare: func(mock *changelogfakes.FakeImpl, opts *changelog.Options) {
 				opts.Dependencies = true
-				mock.DependencyChangesReturns("", err)
+				opts.DependenciesJSONFile = "dependencies.json"
+				mock.ReadFileReturns([]byte(changelog.TocEnd), nil)
+				mock.DependencyReportReturns(&notes.DependencyReport{}, nil)
+			},
+			shouldErr: false,
+		},
+		{ // DependencyReport failed
+			prepare: func(mock *changelogfakes.FakeImpl, opts *changelog.Options) {
+				opts.Dependencies = true
+				mock.DependencyReportReturns(nil, err)
+			},
+			shouldErr: true,
+		},
+		{ // WriteFile for dependencies JSON failed
+			prepare: func(mock *changelogfakes.FakeImpl, opts *changelog.Options) {
+				opts.Dependencies = true
+				opts.DependenciesJSONFile = "dependencies.json"
+				mock.DependencyReportReturns(&notes.DependencyReport{}, nil)
+				mock.WriteFileReturnsOnCall(0, err)
 			},
 			shouldErr: true,
 		},
@@ -176,10 +194,10 @@ func TestRun(t *testing.T) {
 			},
 			shouldErr: true,
 		},
-		{ // ParseHTMLTemplate failed
+		{ // ParseTemplates failed
 			prepare: func(mock *changelogfakes.FakeImpl, _ *changelog.Options) {
 				mock.ReadFileReturns([]byte(changelog.TocEnd), nil)
-				mock.ParseHTMLTemplateReturns(nil, err)
+				mock.ParseTemplatesReturns(nil, err)
 			},
 			shouldErr: true,
 		},
@@ -190,6 +208,20 @@ func TestRun(t *testing.T) {
 			},
 			shouldErr: true,
 		},
+		{ // TemplateExecute for HTML failed
+			prepare: func(mock *changelogfakes.FakeImpl, _ *changelog.Options) {
+				mock.ReadFileReturns([]byte(changelog.TocEnd), nil)
+				mock.TemplateExecuteReturnsOnCall(1, err)
+			},
+			shouldErr: true,
+		},
+		{ // ReadFile for the template file failed
+			prepare: func(mock *changelogfakes.FakeImpl, opts *changelog.Options) {
+				opts.TemplateFile = "changelog.tmpl"
+				mock.ReadFileReturns(nil, err)
+			},
+			shouldErr: true,
+		},
 		{ // Abs failed
 			prepare: func(mock *changelogfakes.FakeImpl, _ *changelog.Options) {
 				mock.ReadFileReturns([]byte(changelog.TocEnd), nil)
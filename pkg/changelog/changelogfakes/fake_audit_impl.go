@@ -0,0 +1,369 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package changelogfakes
+
+import (
+	"sync"
+)
+
+type FakeAuditImpl struct {
+	CherryPickedPRsStub        func(string, string, string) ([]int, error)
+	cherryPickedPRsMutex       sync.RWMutex
+	cherryPickedPRsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	cherryPickedPRsReturns struct {
+		result1 []int
+		result2 error
+	}
+	cherryPickedPRsReturnsOnCall map[int]struct {
+		result1 []int
+		result2 error
+	}
+	GenerateTOCStub        func(string) (string, error)
+	generateTOCMutex       sync.RWMutex
+	generateTOCArgsForCall []struct {
+		arg1 string
+	}
+	generateTOCReturns struct {
+		result1 string
+		result2 error
+	}
+	generateTOCReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	TagsStub        func(string) ([]string, error)
+	tagsMutex       sync.RWMutex
+	tagsArgsForCall []struct {
+		arg1 string
+	}
+	tagsReturns struct {
+		result1 []string
+		result2 error
+	}
+	tagsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	URLExistsStub        func(string) (bool, error)
+	uRLExistsMutex       sync.RWMutex
+	uRLExistsArgsForCall []struct {
+		arg1 string
+	}
+	uRLExistsReturns struct {
+		result1 bool
+		result2 error
+	}
+	uRLExistsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuditImpl) CherryPickedPRs(arg1 string, arg2 string, arg3 string) ([]int, error) {
+	fake.cherryPickedPRsMutex.Lock()
+	ret, specificReturn := fake.cherryPickedPRsReturnsOnCall[len(fake.cherryPickedPRsArgsForCall)]
+	fake.cherryPickedPRsArgsForCall = append(fake.cherryPickedPRsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.CherryPickedPRsStub
+	fakeReturns := fake.cherryPickedPRsReturns
+	fake.recordInvocation("CherryPickedPRs", []interface{}{arg1, arg2, arg3})
+	fake.cherryPickedPRsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAuditImpl) CherryPickedPRsCallCount() int {
+	fake.cherryPickedPRsMutex.RLock()
+	defer fake.cherryPickedPRsMutex.RUnlock()
+	return len(fake.cherryPickedPRsArgsForCall)
+}
+
+func (fake *FakeAuditImpl) CherryPickedPRsCalls(stub func(string, string, string) ([]int, error)) {
+	fake.cherryPickedPRsMutex.Lock()
+	defer fake.cherryPickedPRsMutex.Unlock()
+	fake.CherryPickedPRsStub = stub
+}
+
+func (fake *FakeAuditImpl) CherryPickedPRsArgsForCall(i int) (string, string, string) {
+	fake.cherryPickedPRsMutex.RLock()
+	defer fake.cherryPickedPRsMutex.RUnlock()
+	argsForCall := fake.cherryPickedPRsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAuditImpl) CherryPickedPRsReturns(result1 []int, result2 error) {
+	fake.cherryPickedPRsMutex.Lock()
+	defer fake.cherryPickedPRsMutex.Unlock()
+	fake.CherryPickedPRsStub = nil
+	fake.cherryPickedPRsReturns = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuditImpl) CherryPickedPRsReturnsOnCall(i int, result1 []int, result2 error) {
+	fake.cherryPickedPRsMutex.Lock()
+	defer fake.cherryPickedPRsMutex.Unlock()
+	fake.CherryPickedPRsStub = nil
+	if fake.cherryPickedPRsReturnsOnCall == nil {
+		fake.cherryPickedPRsReturnsOnCall = make(map[int]struct {
+			result1 []int
+			result2 error
+		})
+	}
+	fake.cherryPickedPRsReturnsOnCall[i] = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuditImpl) GenerateTOC(arg1 string) (string, error) {
+	fake.generateTOCMutex.Lock()
+	ret, specificReturn := fake.generateTOCReturnsOnCall[len(fake.generateTOCArgsForCall)]
+	fake.generateTOCArgsForCall = append(fake.generateTOCArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GenerateTOCStub
+	fakeReturns := fake.generateTOCReturns
+	fake.recordInvocation("GenerateTOC", []interface{}{arg1})
+	fake.generateTOCMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAuditImpl) GenerateTOCCallCount() int {
+	fake.generateTOCMutex.RLock()
+	defer fake.generateTOCMutex.RUnlock()
+	return len(fake.generateTOCArgsForCall)
+}
+
+func (fake *FakeAuditImpl) GenerateTOCCalls(stub func(string) (string, error)) {
+	fake.generateTOCMutex.Lock()
+	defer fake.generateTOCMutex.Unlock()
+	fake.GenerateTOCStub = stub
+}
+
+func (fake *FakeAuditImpl) GenerateTOCArgsForCall(i int) string {
+	fake.generateTOCMutex.RLock()
+	defer fake.generateTOCMutex.RUnlock()
+	argsForCall := fake.generateTOCArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAuditImpl) GenerateTOCReturns(result1 string, result2 error) {
+	fake.generateTOCMutex.Lock()
+	defer fake.generateTOCMutex.Unlock()
+	fake.GenerateTOCStub = nil
+	fake.generateTOCReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuditImpl) GenerateTOCReturnsOnCall(i int, result1 string, result2 error) {
+	fake.generateTOCMutex.Lock()
+	defer fake.generateTOCMutex.Unlock()
+	fake.GenerateTOCStub = nil
+	if fake.generateTOCReturnsOnCall == nil {
+		fake.generateTOCReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.generateTOCReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuditImpl) Tags(arg1 string) ([]string, error) {
+	fake.tagsMutex.Lock()
+	ret, specificReturn := fake.tagsReturnsOnCall[len(fake.tagsArgsForCall)]
+	fake.tagsArgsForCall = append(fake.tagsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.TagsStub
+	fakeReturns := fake.tagsReturns
+	fake.recordInvocation("Tags", []interface{}{arg1})
+	fake.tagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAuditImpl) TagsCallCount() int {
+	fake.tagsMutex.RLock()
+	defer fake.tagsMutex.RUnlock()
+	return len(fake.tagsArgsForCall)
+}
+
+func (fake *FakeAuditImpl) TagsCalls(stub func(string) ([]string, error)) {
+	fake.tagsMutex.Lock()
+	defer fake.tagsMutex.Unlock()
+	fake.TagsStub = stub
+}
+
+func (fake *FakeAuditImpl) TagsArgsForCall(i int) string {
+	fake.tagsMutex.RLock()
+	defer fake.tagsMutex.RUnlock()
+	argsForCall := fake.tagsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAuditImpl) TagsReturns(result1 []string, result2 error) {
+	fake.tagsMutex.Lock()
+	defer fake.tagsMutex.Unlock()
+	fake.TagsStub = nil
+	fake.tagsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuditImpl) TagsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.tagsMutex.Lock()
+	defer fake.tagsMutex.Unlock()
+	fake.TagsStub = nil
+	if fake.tagsReturnsOnCall == nil {
+		fake.tagsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.tagsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuditImpl) URLExists(arg1 string) (bool, error) {
+	fake.uRLExistsMutex.Lock()
+	ret, specificReturn := fake.uRLExistsReturnsOnCall[len(fake.uRLExistsArgsForCall)]
+	fake.uRLExistsArgsForCall = append(fake.uRLExistsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.URLExistsStub
+	fakeReturns := fake.uRLExistsReturns
+	fake.recordInvocation("URLExists", []interface{}{arg1})
+	fake.uRLExistsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAuditImpl) URLExistsCallCount() int {
+	fake.uRLExistsMutex.RLock()
+	defer fake.uRLExistsMutex.RUnlock()
+	return len(fake.uRLExistsArgsForCall)
+}
+
+func (fake *FakeAuditImpl) URLExistsCalls(stub func(string) (bool, error)) {
+	fake.uRLExistsMutex.Lock()
+	defer fake.uRLExistsMutex.Unlock()
+	fake.URLExistsStub = stub
+}
+
+func (fake *FakeAuditImpl) URLExistsArgsForCall(i int) string {
+	fake.uRLExistsMutex.RLock()
+	defer fake.uRLExistsMutex.RUnlock()
+	argsForCall := fake.uRLExistsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAuditImpl) URLExistsReturns(result1 bool, result2 error) {
+	fake.uRLExistsMutex.Lock()
+	defer fake.uRLExistsMutex.Unlock()
+	fake.URLExistsStub = nil
+	fake.uRLExistsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuditImpl) URLExistsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.uRLExistsMutex.Lock()
+	defer fake.uRLExistsMutex.Unlock()
+	fake.URLExistsStub = nil
+	if fake.uRLExistsReturnsOnCall == nil {
+		fake.uRLExistsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.uRLExistsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuditImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.cherryPickedPRsMutex.RLock()
+	defer fake.cherryPickedPRsMutex.RUnlock()
+	fake.generateTOCMutex.RLock()
+	defer fake.generateTOCMutex.RUnlock()
+	fake.tagsMutex.RLock()
+	defer fake.tagsMutex.RUnlock()
+	fake.uRLExistsMutex.RLock()
+	defer fake.uRLExistsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAuditImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -95,22 +95,6 @@ type FakeImpl struct {
 	commitReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CreateDownloadsTableStub        func(io.Writer, string, string, string, string, string) error
-	createDownloadsTableMutex       sync.RWMutex
-	createDownloadsTableArgsForCall []struct {
-		arg1 io.Writer
-		arg2 string
-		arg3 string
-		arg4 string
-		arg5 string
-		arg6 string
-	}
-	createDownloadsTableReturns struct {
-		result1 error
-	}
-	createDownloadsTableReturnsOnCall map[int]struct {
-		result1 error
-	}
 	CurrentBranchStub        func(*git.Repo) (string, error)
 	currentBranchMutex       sync.RWMutex
 	currentBranchArgsForCall []struct {
@@ -124,20 +108,39 @@ type FakeImpl struct {
 		result1 string
 		result2 error
 	}
-	DependencyChangesStub        func(string, string) (string, error)
-	dependencyChangesMutex       sync.RWMutex
-	dependencyChangesArgsForCall []struct {
-		arg1 string
+	DependencyReportStub        func(*notes.DependencyOptions, string, string) (*notes.DependencyReport, error)
+	dependencyReportMutex       sync.RWMutex
+	dependencyReportArgsForCall []struct {
+		arg1 *notes.DependencyOptions
 		arg2 string
+		arg3 string
 	}
-	dependencyChangesReturns struct {
-		result1 string
+	dependencyReportReturns struct {
+		result1 *notes.DependencyReport
 		result2 error
 	}
-	dependencyChangesReturnsOnCall map[int]struct {
-		result1 string
+	dependencyReportReturnsOnCall map[int]struct {
+		result1 *notes.DependencyReport
 		result2 error
 	}
+	FetchDownloadsStub        func(string, string, string, string) (*document.FileMetadata, *document.ImageMetadata, error)
+	fetchDownloadsMutex       sync.RWMutex
+	fetchDownloadsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	fetchDownloadsReturns struct {
+		result1 *document.FileMetadata
+		result2 *document.ImageMetadata
+		result3 error
+	}
+	fetchDownloadsReturnsOnCall map[int]struct {
+		result1 *document.FileMetadata
+		result2 *document.ImageMetadata
+		result3 error
+	}
 	GatherReleaseNotesStub        func(*options.Options) (*notes.ReleaseNotes, error)
 	gatherReleaseNotesMutex       sync.RWMutex
 	gatherReleaseNotesArgsForCall []struct {
@@ -230,16 +233,16 @@ type FakeImpl struct {
 		result1 *git.Repo
 		result2 error
 	}
-	ParseHTMLTemplateStub        func(string) (*template.Template, error)
-	parseHTMLTemplateMutex       sync.RWMutex
-	parseHTMLTemplateArgsForCall []struct {
+	ParseTemplatesStub        func(string) (*template.Template, error)
+	parseTemplatesMutex       sync.RWMutex
+	parseTemplatesArgsForCall []struct {
 		arg1 string
 	}
-	parseHTMLTemplateReturns struct {
+	parseTemplatesReturns struct {
 		result1 *template.Template
 		result2 error
 	}
-	parseHTMLTemplateReturnsOnCall map[int]struct {
+	parseTemplatesReturnsOnCall map[int]struct {
 		result1 *template.Template
 		result2 error
 	}
@@ -256,23 +259,6 @@ type FakeImpl struct {
 		result1 []byte
 		result2 error
 	}
-	RenderMarkdownTemplateStub        func(*document.Document, string, string, string, string) (string, error)
-	renderMarkdownTemplateMutex       sync.RWMutex
-	renderMarkdownTemplateArgsForCall []struct {
-		arg1 *document.Document
-		arg2 string
-		arg3 string
-		arg4 string
-		arg5 string
-	}
-	renderMarkdownTemplateReturns struct {
-		result1 string
-		result2 error
-	}
-	renderMarkdownTemplateReturnsOnCall map[int]struct {
-		result1 string
-		result2 error
-	}
 	RepoDirStub        func(*git.Repo) string
 	repoDirMutex       sync.RWMutex
 	repoDirArgsForCall []struct {
@@ -351,12 +337,13 @@ type FakeImpl struct {
 		result1 semver.Version
 		result2 error
 	}
-	TemplateExecuteStub        func(*template.Template, io.Writer, interface{}) error
+	TemplateExecuteStub        func(*template.Template, io.Writer, string, interface{}) error
 	templateExecuteMutex       sync.RWMutex
 	templateExecuteArgsForCall []struct {
 		arg1 *template.Template
 		arg2 io.Writer
-		arg3 interface{}
+		arg3 string
+		arg4 interface{}
 	}
 	templateExecuteReturns struct {
 		result1 error
@@ -699,72 +686,6 @@ func (fake *FakeImpl) CommitReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeImpl) CreateDownloadsTable(arg1 io.Writer, arg2 string, arg3 string, arg4 string, arg5 string, arg6 string) error {
-	fake.createDownloadsTableMutex.Lock()
-	ret, specificReturn := fake.createDownloadsTableReturnsOnCall[len(fake.createDownloadsTableArgsForCall)]
-	fake.createDownloadsTableArgsForCall = append(fake.createDownloadsTableArgsForCall, struct {
-		arg1 io.Writer
-		arg2 string
-		arg3 string
-		arg4 string
-		arg5 string
-		arg6 string
-	}{arg1, arg2, arg3, arg4, arg5, arg6})
-	stub := fake.CreateDownloadsTableStub
-	fakeReturns := fake.createDownloadsTableReturns
-	fake.recordInvocation("CreateDownloadsTable", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
-	fake.createDownloadsTableMutex.Unlock()
-	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
-	}
-	if specificReturn {
-		return ret.result1
-	}
-	return fakeReturns.result1
-}
-
-func (fake *FakeImpl) CreateDownloadsTableCallCount() int {
-	fake.createDownloadsTableMutex.RLock()
-	defer fake.createDownloadsTableMutex.RUnlock()
-	return len(fake.createDownloadsTableArgsForCall)
-}
-
-func (fake *FakeImpl) CreateDownloadsTableCalls(stub func(io.Writer, string, string, string, string, string) error) {
-	fake.createDownloadsTableMutex.Lock()
-	defer fake.createDownloadsTableMutex.Unlock()
-	fake.CreateDownloadsTableStub = stub
-}
-
-func (fake *FakeImpl) CreateDownloadsTableArgsForCall(i int) (io.Writer, string, string, string, string, string) {
-	fake.createDownloadsTableMutex.RLock()
-	defer fake.createDownloadsTableMutex.RUnlock()
-	argsForCall := fake.createDownloadsTableArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
-}
-
-func (fake *FakeImpl) CreateDownloadsTableReturns(result1 error) {
-	fake.createDownloadsTableMutex.Lock()
-	defer fake.createDownloadsTableMutex.Unlock()
-	fake.CreateDownloadsTableStub = nil
-	fake.createDownloadsTableReturns = struct {
-		result1 error
-	}{result1}
-}
-
-func (fake *FakeImpl) CreateDownloadsTableReturnsOnCall(i int, result1 error) {
-	fake.createDownloadsTableMutex.Lock()
-	defer fake.createDownloadsTableMutex.Unlock()
-	fake.CreateDownloadsTableStub = nil
-	if fake.createDownloadsTableReturnsOnCall == nil {
-		fake.createDownloadsTableReturnsOnCall = make(map[int]struct {
-			result1 error
-		})
-	}
-	fake.createDownloadsTableReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
-}
-
 func (fake *FakeImpl) CurrentBranch(arg1 *git.Repo) (string, error) {
 	fake.currentBranchMutex.Lock()
 	ret, specificReturn := fake.currentBranchReturnsOnCall[len(fake.currentBranchArgsForCall)]
@@ -829,19 +750,20 @@ func (fake *FakeImpl) CurrentBranchReturnsOnCall(i int, result1 string, result2
 	}{result1, result2}
 }
 
-func (fake *FakeImpl) DependencyChanges(arg1 string, arg2 string) (string, error) {
-	fake.dependencyChangesMutex.Lock()
-	ret, specificReturn := fake.dependencyChangesReturnsOnCall[len(fake.dependencyChangesArgsForCall)]
-	fake.dependencyChangesArgsForCall = append(fake.dependencyChangesArgsForCall, struct {
-		arg1 string
+func (fake *FakeImpl) DependencyReport(arg1 *notes.DependencyOptions, arg2 string, arg3 string) (*notes.DependencyReport, error) {
+	fake.dependencyReportMutex.Lock()
+	ret, specificReturn := fake.dependencyReportReturnsOnCall[len(fake.dependencyReportArgsForCall)]
+	fake.dependencyReportArgsForCall = append(fake.dependencyReportArgsForCall, struct {
+		arg1 *notes.DependencyOptions
 		arg2 string
-	}{arg1, arg2})
-	stub := fake.DependencyChangesStub
-	fakeReturns := fake.dependencyChangesReturns
-	fake.recordInvocation("DependencyChanges", []interface{}{arg1, arg2})
-	fake.dependencyChangesMutex.Unlock()
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DependencyReportStub
+	fakeReturns := fake.dependencyReportReturns
+	fake.recordInvocation("DependencyReport", []interface{}{arg1, arg2, arg3})
+	fake.dependencyReportMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -849,51 +771,121 @@ func (fake *FakeImpl) DependencyChanges(arg1 string, arg2 string) (string, error
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeImpl) DependencyChangesCallCount() int {
-	fake.dependencyChangesMutex.RLock()
-	defer fake.dependencyChangesMutex.RUnlock()
-	return len(fake.dependencyChangesArgsForCall)
+func (fake *FakeImpl) DependencyReportCallCount() int {
+	fake.dependencyReportMutex.RLock()
+	defer fake.dependencyReportMutex.RUnlock()
+	return len(fake.dependencyReportArgsForCall)
 }
 
-func (fake *FakeImpl) DependencyChangesCalls(stub func(string, string) (string, error)) {
-	fake.dependencyChangesMutex.Lock()
-	defer fake.dependencyChangesMutex.Unlock()
-	fake.DependencyChangesStub = stub
+func (fake *FakeImpl) DependencyReportCalls(stub func(*notes.DependencyOptions, string, string) (*notes.DependencyReport, error)) {
+	fake.dependencyReportMutex.Lock()
+	defer fake.dependencyReportMutex.Unlock()
+	fake.DependencyReportStub = stub
 }
 
-func (fake *FakeImpl) DependencyChangesArgsForCall(i int) (string, string) {
-	fake.dependencyChangesMutex.RLock()
-	defer fake.dependencyChangesMutex.RUnlock()
-	argsForCall := fake.dependencyChangesArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+func (fake *FakeImpl) DependencyReportArgsForCall(i int) (*notes.DependencyOptions, string, string) {
+	fake.dependencyReportMutex.RLock()
+	defer fake.dependencyReportMutex.RUnlock()
+	argsForCall := fake.dependencyReportArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeImpl) DependencyChangesReturns(result1 string, result2 error) {
-	fake.dependencyChangesMutex.Lock()
-	defer fake.dependencyChangesMutex.Unlock()
-	fake.DependencyChangesStub = nil
-	fake.dependencyChangesReturns = struct {
-		result1 string
+func (fake *FakeImpl) DependencyReportReturns(result1 *notes.DependencyReport, result2 error) {
+	fake.dependencyReportMutex.Lock()
+	defer fake.dependencyReportMutex.Unlock()
+	fake.DependencyReportStub = nil
+	fake.dependencyReportReturns = struct {
+		result1 *notes.DependencyReport
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeImpl) DependencyChangesReturnsOnCall(i int, result1 string, result2 error) {
-	fake.dependencyChangesMutex.Lock()
-	defer fake.dependencyChangesMutex.Unlock()
-	fake.DependencyChangesStub = nil
-	if fake.dependencyChangesReturnsOnCall == nil {
-		fake.dependencyChangesReturnsOnCall = make(map[int]struct {
-			result1 string
+func (fake *FakeImpl) DependencyReportReturnsOnCall(i int, result1 *notes.DependencyReport, result2 error) {
+	fake.dependencyReportMutex.Lock()
+	defer fake.dependencyReportMutex.Unlock()
+	fake.DependencyReportStub = nil
+	if fake.dependencyReportReturnsOnCall == nil {
+		fake.dependencyReportReturnsOnCall = make(map[int]struct {
+			result1 *notes.DependencyReport
 			result2 error
 		})
 	}
-	fake.dependencyChangesReturnsOnCall[i] = struct {
-		result1 string
+	fake.dependencyReportReturnsOnCall[i] = struct {
+		result1 *notes.DependencyReport
 		result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeImpl) FetchDownloads(arg1 string, arg2 string, arg3 string, arg4 string) (*document.FileMetadata, *document.ImageMetadata, error) {
+	fake.fetchDownloadsMutex.Lock()
+	ret, specificReturn := fake.fetchDownloadsReturnsOnCall[len(fake.fetchDownloadsArgsForCall)]
+	fake.fetchDownloadsArgsForCall = append(fake.fetchDownloadsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.FetchDownloadsStub
+	fakeReturns := fake.fetchDownloadsReturns
+	fake.recordInvocation("FetchDownloads", []interface{}{arg1, arg2, arg3, arg4})
+	fake.fetchDownloadsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeImpl) FetchDownloadsCallCount() int {
+	fake.fetchDownloadsMutex.RLock()
+	defer fake.fetchDownloadsMutex.RUnlock()
+	return len(fake.fetchDownloadsArgsForCall)
+}
+
+func (fake *FakeImpl) FetchDownloadsCalls(stub func(string, string, string, string) (*document.FileMetadata, *document.ImageMetadata, error)) {
+	fake.fetchDownloadsMutex.Lock()
+	defer fake.fetchDownloadsMutex.Unlock()
+	fake.FetchDownloadsStub = stub
+}
+
+func (fake *FakeImpl) FetchDownloadsArgsForCall(i int) (string, string, string, string) {
+	fake.fetchDownloadsMutex.RLock()
+	defer fake.fetchDownloadsMutex.RUnlock()
+	argsForCall := fake.fetchDownloadsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeImpl) FetchDownloadsReturns(result1 *document.FileMetadata, result2 *document.ImageMetadata, result3 error) {
+	fake.fetchDownloadsMutex.Lock()
+	defer fake.fetchDownloadsMutex.Unlock()
+	fake.FetchDownloadsStub = nil
+	fake.fetchDownloadsReturns = struct {
+		result1 *document.FileMetadata
+		result2 *document.ImageMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeImpl) FetchDownloadsReturnsOnCall(i int, result1 *document.FileMetadata, result2 *document.ImageMetadata, result3 error) {
+	fake.fetchDownloadsMutex.Lock()
+	defer fake.fetchDownloadsMutex.Unlock()
+	fake.FetchDownloadsStub = nil
+	if fake.fetchDownloadsReturnsOnCall == nil {
+		fake.fetchDownloadsReturnsOnCall = make(map[int]struct {
+			result1 *document.FileMetadata
+			result2 *document.ImageMetadata
+			result3 error
+		})
+	}
+	fake.fetchDownloadsReturnsOnCall[i] = struct {
+		result1 *document.FileMetadata
+		result2 *document.ImageMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeImpl) GatherReleaseNotes(arg1 *options.Options) (*notes.ReleaseNotes, error) {
 	fake.gatherReleaseNotesMutex.Lock()
 	ret, specificReturn := fake.gatherReleaseNotesReturnsOnCall[len(fake.gatherReleaseNotesArgsForCall)]
@@ -1335,16 +1327,16 @@ func (fake *FakeImpl) OpenRepoReturnsOnCall(i int, result1 *git.Repo, result2 er
 	}{result1, result2}
 }
 
-func (fake *FakeImpl) ParseHTMLTemplate(arg1 string) (*template.Template, error) {
-	fake.parseHTMLTemplateMutex.Lock()
-	ret, specificReturn := fake.parseHTMLTemplateReturnsOnCall[len(fake.parseHTMLTemplateArgsForCall)]
-	fake.parseHTMLTemplateArgsForCall = append(fake.parseHTMLTemplateArgsForCall, struct {
+func (fake *FakeImpl) ParseTemplates(arg1 string) (*template.Template, error) {
+	fake.parseTemplatesMutex.Lock()
+	ret, specificReturn := fake.parseTemplatesReturnsOnCall[len(fake.parseTemplatesArgsForCall)]
+	fake.parseTemplatesArgsForCall = append(fake.parseTemplatesArgsForCall, struct {
 		arg1 string
 	}{arg1})
-	stub := fake.ParseHTMLTemplateStub
-	fakeReturns := fake.parseHTMLTemplateReturns
-	fake.recordInvocation("ParseHTMLTemplate", []interface{}{arg1})
-	fake.parseHTMLTemplateMutex.Unlock()
+	stub := fake.ParseTemplatesStub
+	fakeReturns := fake.parseTemplatesReturns
+	fake.recordInvocation("ParseTemplates", []interface{}{arg1})
+	fake.parseTemplatesMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
 	}
@@ -1354,46 +1346,46 @@ func (fake *FakeImpl) ParseHTMLTemplate(arg1 string) (*template.Template, error)
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeImpl) ParseHTMLTemplateCallCount() int {
-	fake.parseHTMLTemplateMutex.RLock()
-	defer fake.parseHTMLTemplateMutex.RUnlock()
-	return len(fake.parseHTMLTemplateArgsForCall)
+func (fake *FakeImpl) ParseTemplatesCallCount() int {
+	fake.parseTemplatesMutex.RLock()
+	defer fake.parseTemplatesMutex.RUnlock()
+	return len(fake.parseTemplatesArgsForCall)
 }
 
-func (fake *FakeImpl) ParseHTMLTemplateCalls(stub func(string) (*template.Template, error)) {
-	fake.parseHTMLTemplateMutex.Lock()
-	defer fake.parseHTMLTemplateMutex.Unlock()
-	fake.ParseHTMLTemplateStub = stub
+func (fake *FakeImpl) ParseTemplatesCalls(stub func(string) (*template.Template, error)) {
+	fake.parseTemplatesMutex.Lock()
+	defer fake.parseTemplatesMutex.Unlock()
+	fake.ParseTemplatesStub = stub
 }
 
-func (fake *FakeImpl) ParseHTMLTemplateArgsForCall(i int) string {
-	fake.parseHTMLTemplateMutex.RLock()
-	defer fake.parseHTMLTemplateMutex.RUnlock()
-	argsForCall := fake.parseHTMLTemplateArgsForCall[i]
+func (fake *FakeImpl) ParseTemplatesArgsForCall(i int) string {
+	fake.parseTemplatesMutex.RLock()
+	defer fake.parseTemplatesMutex.RUnlock()
+	argsForCall := fake.parseTemplatesArgsForCall[i]
 	return argsForCall.arg1
 }
 
-func (fake *FakeImpl) ParseHTMLTemplateReturns(result1 *template.Template, result2 error) {
-	fake.parseHTMLTemplateMutex.Lock()
-	defer fake.parseHTMLTemplateMutex.Unlock()
-	fake.ParseHTMLTemplateStub = nil
-	fake.parseHTMLTemplateReturns = struct {
+func (fake *FakeImpl) ParseTemplatesReturns(result1 *template.Template, result2 error) {
+	fake.parseTemplatesMutex.Lock()
+	defer fake.parseTemplatesMutex.Unlock()
+	fake.ParseTemplatesStub = nil
+	fake.parseTemplatesReturns = struct {
 		result1 *template.Template
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeImpl) ParseHTMLTemplateReturnsOnCall(i int, result1 *template.Template, result2 error) {
-	fake.parseHTMLTemplateMutex.Lock()
-	defer fake.parseHTMLTemplateMutex.Unlock()
-	fake.ParseHTMLTemplateStub = nil
-	if fake.parseHTMLTemplateReturnsOnCall == nil {
-		fake.parseHTMLTemplateReturnsOnCall = make(map[int]struct {
+func (fake *FakeImpl) ParseTemplatesReturnsOnCall(i int, result1 *template.Template, result2 error) {
+	fake.parseTemplatesMutex.Lock()
+	defer fake.parseTemplatesMutex.Unlock()
+	fake.ParseTemplatesStub = nil
+	if fake.parseTemplatesReturnsOnCall == nil {
+		fake.parseTemplatesReturnsOnCall = make(map[int]struct {
 			result1 *template.Template
 			result2 error
 		})
 	}
-	fake.parseHTMLTemplateReturnsOnCall[i] = struct {
+	fake.parseTemplatesReturnsOnCall[i] = struct {
 		result1 *template.Template
 		result2 error
 	}{result1, result2}
@@ -1463,74 +1455,6 @@ func (fake *FakeImpl) ReadFileReturnsOnCall(i int, result1 []byte, result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeImpl) RenderMarkdownTemplate(arg1 *document.Document, arg2 string, arg3 string, arg4 string, arg5 string) (string, error) {
-	fake.renderMarkdownTemplateMutex.Lock()
-	ret, specificReturn := fake.renderMarkdownTemplateReturnsOnCall[len(fake.renderMarkdownTemplateArgsForCall)]
-	fake.renderMarkdownTemplateArgsForCall = append(fake.renderMarkdownTemplateArgsForCall, struct {
-		arg1 *document.Document
-		arg2 string
-		arg3 string
-		arg4 string
-		arg5 string
-	}{arg1, arg2, arg3, arg4, arg5})
-	stub := fake.RenderMarkdownTemplateStub
-	fakeReturns := fake.renderMarkdownTemplateReturns
-	fake.recordInvocation("RenderMarkdownTemplate", []interface{}{arg1, arg2, arg3, arg4, arg5})
-	fake.renderMarkdownTemplateMutex.Unlock()
-	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5)
-	}
-	if specificReturn {
-		return ret.result1, ret.result2
-	}
-	return fakeReturns.result1, fakeReturns.result2
-}
-
-func (fake *FakeImpl) RenderMarkdownTemplateCallCount() int {
-	fake.renderMarkdownTemplateMutex.RLock()
-	defer fake.renderMarkdownTemplateMutex.RUnlock()
-	return len(fake.renderMarkdownTemplateArgsForCall)
-}
-
-func (fake *FakeImpl) RenderMarkdownTemplateCalls(stub func(*document.Document, string, string, string, string) (string, error)) {
-	fake.renderMarkdownTemplateMutex.Lock()
-	defer fake.renderMarkdownTemplateMutex.Unlock()
-	fake.RenderMarkdownTemplateStub = stub
-}
-
-func (fake *FakeImpl) RenderMarkdownTemplateArgsForCall(i int) (*document.Document, string, string, string, string) {
-	fake.renderMarkdownTemplateMutex.RLock()
-	defer fake.renderMarkdownTemplateMutex.RUnlock()
-	argsForCall := fake.renderMarkdownTemplateArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
-}
-
-func (fake *FakeImpl) RenderMarkdownTemplateReturns(result1 string, result2 error) {
-	fake.renderMarkdownTemplateMutex.Lock()
-	defer fake.renderMarkdownTemplateMutex.Unlock()
-	fake.RenderMarkdownTemplateStub = nil
-	fake.renderMarkdownTemplateReturns = struct {
-		result1 string
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakeImpl) RenderMarkdownTemplateReturnsOnCall(i int, result1 string, result2 error) {
-	fake.renderMarkdownTemplateMutex.Lock()
-	defer fake.renderMarkdownTemplateMutex.Unlock()
-	fake.RenderMarkdownTemplateStub = nil
-	if fake.renderMarkdownTemplateReturnsOnCall == nil {
-		fake.renderMarkdownTemplateReturnsOnCall = make(map[int]struct {
-			result1 string
-			result2 error
-		})
-	}
-	fake.renderMarkdownTemplateReturnsOnCall[i] = struct {
-		result1 string
-		result2 error
-	}{result1, result2}
-}
-
 func (fake *FakeImpl) RepoDir(arg1 *git.Repo) string {
 	fake.repoDirMutex.Lock()
 	ret, specificReturn := fake.repoDirReturnsOnCall[len(fake.repoDirArgsForCall)]
@@ -1913,20 +1837,21 @@ func (fake *FakeImpl) TagStringToSemverReturnsOnCall(i int, result1 semver.Versi
 	}{result1, result2}
 }
 
-func (fake *FakeImpl) TemplateExecute(arg1 *template.Template, arg2 io.Writer, arg3 interface{}) error {
+func (fake *FakeImpl) TemplateExecute(arg1 *template.Template, arg2 io.Writer, arg3 string, arg4 interface{}) error {
 	fake.templateExecuteMutex.Lock()
 	ret, specificReturn := fake.templateExecuteReturnsOnCall[len(fake.templateExecuteArgsForCall)]
 	fake.templateExecuteArgsForCall = append(fake.templateExecuteArgsForCall, struct {
 		arg1 *template.Template
 		arg2 io.Writer
-		arg3 interface{}
-	}{arg1, arg2, arg3})
+		arg3 string
+		arg4 interface{}
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.TemplateExecuteStub
 	fakeReturns := fake.templateExecuteReturns
-	fake.recordInvocation("TemplateExecute", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("TemplateExecute", []interface{}{arg1, arg2, arg3, arg4})
 	fake.templateExecuteMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1940,17 +1865,17 @@ func (fake *FakeImpl) TemplateExecuteCallCount() int {
 	return len(fake.templateExecuteArgsForCall)
 }
 
-func (fake *FakeImpl) TemplateExecuteCalls(stub func(*template.Template, io.Writer, interface{}) error) {
+func (fake *FakeImpl) TemplateExecuteCalls(stub func(*template.Template, io.Writer, string, interface{}) error) {
 	fake.templateExecuteMutex.Lock()
 	defer fake.templateExecuteMutex.Unlock()
 	fake.TemplateExecuteStub = stub
 }
 
-func (fake *FakeImpl) TemplateExecuteArgsForCall(i int) (*template.Template, io.Writer, interface{}) {
+func (fake *FakeImpl) TemplateExecuteArgsForCall(i int) (*template.Template, io.Writer, string, interface{}) {
 	fake.templateExecuteMutex.RLock()
 	defer fake.templateExecuteMutex.RUnlock()
 	argsForCall := fake.templateExecuteArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeImpl) TemplateExecuteReturns(result1 error) {
@@ -2118,12 +2043,12 @@ func (fake *FakeImpl) Invocations() map[string][][]interface{} {
 	defer fake.cloneCVEDataMutex.RUnlock()
 	fake.commitMutex.RLock()
 	defer fake.commitMutex.RUnlock()
-	fake.createDownloadsTableMutex.RLock()
-	defer fake.createDownloadsTableMutex.RUnlock()
 	fake.currentBranchMutex.RLock()
 	defer fake.currentBranchMutex.RUnlock()
-	fake.dependencyChangesMutex.RLock()
-	defer fake.dependencyChangesMutex.RUnlock()
+	fake.dependencyReportMutex.RLock()
+	defer fake.dependencyReportMutex.RUnlock()
+	fake.fetchDownloadsMutex.RLock()
+	defer fake.fetchDownloadsMutex.RUnlock()
 	fake.gatherReleaseNotesMutex.RLock()
 	defer fake.gatherReleaseNotesMutex.RUnlock()
 	fake.generateTOCMutex.RLock()
@@ -2138,12 +2063,10 @@ func (fake *FakeImpl) Invocations() map[string][][]interface{} {
 	defer fake.newDocumentMutex.RUnlock()
 	fake.openRepoMutex.RLock()
 	defer fake.openRepoMutex.RUnlock()
-	fake.parseHTMLTemplateMutex.RLock()
-	defer fake.parseHTMLTemplateMutex.RUnlock()
+	fake.parseTemplatesMutex.RLock()
+	defer fake.parseTemplatesMutex.RUnlock()
 	fake.readFileMutex.RLock()
 	defer fake.readFileMutex.RUnlock()
-	fake.renderMarkdownTemplateMutex.RLock()
-	defer fake.renderMarkdownTemplateMutex.RUnlock()
 	fake.repoDirMutex.RLock()
 	defer fake.repoDirMutex.RUnlock()
 	fake.revParseMutex.RLock()
@@ -22,16 +22,34 @@ const (
 	// The default CHANGELOG directory inside the k/k repository.
 	RepoChangelogDir = "CHANGELOG"
 
-	nl                   = "\n"
-	tocStart             = "<!-- BEGIN MUNGE: GENERATED_TOC -->"
-	TocEnd               = "<!-- END MUNGE: GENERATED_TOC -->"
-	releaseNotesTemplate = `
-{{- $CurrentRevision := .CurrentRevision -}}
-{{- $PreviousRevision := .PreviousRevision -}}
-# {{$CurrentRevision}}
+	nl       = "\n"
+	tocStart = "<!-- BEGIN MUNGE: GENERATED_TOC -->"
+	TocEnd   = "<!-- END MUNGE: GENERATED_TOC -->"
+
+	// defaultTemplates are the named templates all changelog outputs get
+	// rendered from, see ParseTemplates for overriding them.
+	defaultTemplates = `
+{{- define "markdown" -}}
+{{- template "header" . -}}
+{{- template "downloads" . -}}
+{{- template "changelog-since" . -}}
+{{- template "remote-notes" . -}}
+{{- template "cves" . -}}
+{{- template "action-required" . -}}
+{{- template "notes" . -}}
+{{- template "dependencies" . -}}
+{{- end -}}
+
+{{- define "header" -}}
+# {{.Release.Tag}}
+
+{{end -}}
 
+{{- define "downloads" -}}
 {{if or .FileDownloads .ImageDownloads}}
-## Downloads for {{$CurrentRevision}}
+{{- if .Release.NewMinor}}[Documentation](https://docs.k8s.io)
+{{end}}
+## Downloads for {{.Release.Tag}}
 
 {{if .FileDownloads}}
 {{- with .FileDownloads.Source }}
@@ -78,9 +96,19 @@ name | architectures
 {{end -}}
 {{end -}}
 {{- end -}}
-## Changelog since {{$PreviousRevision}}
+{{end -}}
+
+{{- define "changelog-since" -}}
+## Changelog since {{.Release.PreviousRevision}}
+
+{{end -}}
+
+{{- define "remote-notes" -}}
+{{with .RemoteNotes}}{{trimSpace .}}{{println}}{{end}}
+{{- end -}}
 
-{{with .CVEList -}}
+{{- define "cves" -}}
+{{with .CVEs -}}
 ## Important Security Information
 
 This release contains changes that address the following vulnerabilities:
@@ -97,15 +125,19 @@ This release contains changes that address the following vulnerabilities:
 
 {{ end }}
 {{- end -}}
+{{end -}}
 
-{{with .NotesWithActionRequired -}}
+{{- define "action-required" -}}
+{{with .ActionRequired -}}
 ## Urgent Upgrade Notes
 
 ### (No, really, you MUST read this before you upgrade)
 
 {{range .}} {{println "-" .}} {{end}}
 {{end}}
+{{- end -}}
 
+{{- define "notes" -}}
 {{- if .Notes -}}
 ## Changes by Kind
 {{ range .Notes}}
@@ -114,9 +146,19 @@ This release contains changes that address the following vulnerabilities:
 {{range $note := .NoteEntries }}{{println "-" $note}}{{end}}
 {{- end -}}
 {{- end -}}
-`
+{{- end -}}
+
+{{- define "dependencies" -}}
+{{with .Dependencies}}
+{{.}}{{end}}
+{{- end -}}
+
+{{- define "json" -}}
+{{.ReleaseNotesJSON}}
+{{- end -}}
 
-	htmlTemplate = `<!DOCTYPE html>
+{{- define "html" -}}
+<!DOCTYPE html>
 <html>
   <head>
     <meta charset="utf-8" />
@@ -136,5 +178,7 @@ This release contains changes that address the following vulnerabilities:
   <body>
     {{ .Content }}
   </body>
-</html>`
+</html>
+{{- end -}}
+`
 )
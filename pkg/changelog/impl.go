@@ -53,12 +53,12 @@ type impl interface {
 	CurrentBranch(repo *git.Repo) (branch string, err error)
 	RevParse(repo *git.Repo, rev string) (string, error)
 	RevParseTag(repo *git.Repo, rev string) (string, error)
-	CreateDownloadsTable(
-		w io.Writer, bucket, tars, images, prevTag, newTag string,
-	) error
+	FetchDownloads(
+		bucket, tars, images, tag string,
+	) (*document.FileMetadata, *document.ImageMetadata, error)
 	LatestGitHubTagsPerBranch() (github.TagsPerBranch, error)
 	GenerateTOC(markdown string) (string, error)
-	DependencyChanges(from, to string) (string, error)
+	DependencyReport(opts *notes.DependencyOptions, from, to string) (*notes.DependencyReport, error)
 	Checkout(repo *git.Repo, rev string, args ...string) error
 
 	// Used in `generateReleaseNotes()`
@@ -67,9 +67,6 @@ type impl interface {
 	NewDocument(
 		releaseNotes *notes.ReleaseNotes, previousRev, currentRev string,
 	) (*document.Document, error)
-	RenderMarkdownTemplate(
-		document *document.Document, bucket, tars, images, templateSpec string,
-	) (string, error)
 
 	// Used in `writeMarkdown()`
 	RepoDir(repo *git.Repo) string
@@ -77,14 +74,16 @@ type impl interface {
 	Stat(name string) (os.FileInfo, error)
 	ReadFile(filename string) ([]byte, error)
 
+	// Used in `render()`
+	ParseTemplates(overrides string) (*template.Template, error)
+	TemplateExecute(
+		tpl *template.Template, wr io.Writer, name string, data interface{},
+	) error
+
 	// Used in `writeHTML()`
 	MarkdownToHTML(
 		markdown string, writer io.Writer, opts ...parser.ParseOption,
 	) error
-	ParseHTMLTemplate(text string) (*template.Template, error)
-	TemplateExecute(
-		tpl *template.Template, wr io.Writer, data interface{},
-	) error
 	Abs(path string) (string, error)
 
 	// Used in `lookupRemoteReleaseNotes()`
@@ -119,10 +118,10 @@ func (*defaultImpl) RevParseTag(repo *git.Repo, rev string) (string, error) {
 	return repo.RevParseTag(rev)
 }
 
-func (*defaultImpl) CreateDownloadsTable(
-	w io.Writer, bucket, tars, images, prevTag, newTag string,
-) error {
-	return document.CreateDownloadsTable(w, bucket, tars, images, prevTag, newTag)
+func (*defaultImpl) FetchDownloads(
+	bucket, tars, images, tag string,
+) (*document.FileMetadata, *document.ImageMetadata, error) {
+	return document.FetchDownloads(bucket, tars, images, tag)
 }
 
 func (*defaultImpl) LatestGitHubTagsPerBranch() (github.TagsPerBranch, error) {
@@ -137,8 +136,10 @@ func (*defaultImpl) GenerateTOC(markdown string) (string, error) {
 	})
 }
 
-func (*defaultImpl) DependencyChanges(from, to string) (string, error) {
-	return notes.NewDependencies().Changes(from, to)
+func (*defaultImpl) DependencyReport(
+	opts *notes.DependencyOptions, from, to string,
+) (*notes.DependencyReport, error) {
+	return notes.NewDependencies().Report(opts, from, to)
 }
 
 func (*defaultImpl) Checkout(repo *git.Repo, rev string, args ...string) error {
@@ -161,12 +162,6 @@ func (*defaultImpl) NewDocument(
 	return document.New(releaseNotes, previousRev, currentRev)
 }
 
-func (*defaultImpl) RenderMarkdownTemplate(
-	doc *document.Document, bucket, tars, images, templateSpec string,
-) (string, error) {
-	return doc.RenderMarkdownTemplate(bucket, tars, images, templateSpec)
-}
-
 func (*defaultImpl) RepoDir(repo *git.Repo) string {
 	return repo.Dir()
 }
@@ -193,14 +188,14 @@ func (*defaultImpl) MarkdownToHTML(
 	).Convert([]byte(markdown), writer, opts...)
 }
 
-func (*defaultImpl) ParseHTMLTemplate(text string) (*template.Template, error) {
-	return template.New("html").Parse(text)
+func (*defaultImpl) ParseTemplates(overrides string) (*template.Template, error) {
+	return ParseTemplates(overrides)
 }
 
 func (*defaultImpl) TemplateExecute(
-	tpl *template.Template, wr io.Writer, data interface{},
+	tpl *template.Template, wr io.Writer, name string, data interface{},
 ) error {
-	return tpl.Execute(wr, data)
+	return tpl.ExecuteTemplate(wr, name, data)
 }
 
 func (*defaultImpl) Abs(path string) (string, error) {
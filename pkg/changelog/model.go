@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package changelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"k8s.io/release/pkg/cve"
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/document"
+)
+
+const (
+	// TemplateMarkdown is the name of the template rendering the markdown
+	// section of a release. It composes the section templates in the order
+	// they appear in the changelog:
+	// header, downloads, changelog-since, remote-notes, cves,
+	// action-required, notes and dependencies.
+	TemplateMarkdown = "markdown"
+
+	// TemplateHTML is the name of the template wrapping the HTML rendered
+	// from the markdown into a standalone page.
+	TemplateHTML = "html"
+
+	// TemplateJSON is the name of the template rendering the JSON output.
+	TemplateJSON = "json"
+)
+
+// Model is the typed representation of the changelog of a single release,
+// from which all outputs get rendered.
+type Model struct {
+	// Release is the header of the changelog section.
+	Release Release `json:"release"`
+
+	// FileDownloads are the released tarballs, nil if not available.
+	FileDownloads *document.FileMetadata `json:"downloads,omitempty"`
+
+	// ImageDownloads are the released container images, nil if not
+	// available.
+	ImageDownloads *document.ImageMetadata `json:"images,omitempty"`
+
+	// CVEs are the vulnerabilities addressed by the release.
+	CVEs []cve.CVE `json:"cves,omitempty"`
+
+	// ActionRequired are the notes users have to read before upgrading.
+	ActionRequired notes.Notes `json:"action_required,omitempty"`
+
+	// Notes are the release notes grouped by their kind.
+	Notes document.NoteCollection `json:"notes,omitempty"`
+
+	// RemoteNotes are pre-rendered markdown release notes, like the ones
+	// curated by the release notes team for new minor releases.
+	RemoteNotes string `json:"remote_notes,omitempty"`
+
+	// Dependencies is the markdown of the dependency changes.
+	Dependencies string `json:"dependencies,omitempty"`
+
+	// DependencyReport are the annotated dependency changes, nil if not
+	// requested.
+	DependencyReport *notes.DependencyReport `json:"dependency_report,omitempty"`
+
+	// ReleaseNotesJSON is the JSON representation of the release notes by
+	// their PR.
+	ReleaseNotesJSON string `json:"-"`
+}
+
+// Release is the header of a changelog section.
+type Release struct {
+	// Tag is the released version, for example v1.30.1.
+	Tag string `json:"tag"`
+
+	// PreviousRevision is the revision the changelog starts from.
+	PreviousRevision string `json:"previous_revision"`
+
+	// NewMinor is set for final x.y.0 releases.
+	NewMinor bool `json:"new_minor,omitempty"`
+}
+
+// addDocument takes over the release notes of the document.
+func (m *Model) addDocument(doc *document.Document) {
+	if doc == nil {
+		return
+	}
+	m.CVEs = doc.CVEList
+	m.ActionRequired = doc.NotesWithActionRequired
+	m.Notes = doc.Notes
+}
+
+// ParseTemplates parses the default changelog templates and applies the
+// overrides on top of them. The overrides can redefine any of the named
+// templates, for example to change the layout of the downloads by:
+//
+//	{{define "downloads"}}...{{end}}
+//
+// or to reorder the sections by redefining the "markdown" template.
+func ParseTemplates(overrides string) (*template.Template, error) {
+	tpl, err := template.New("changelog").Funcs(template.FuncMap{
+		"prettyKind": document.PrettyKind,
+		"trimSpace":  strings.TrimSpace,
+		"toJSON": func(v any) (string, error) {
+			res, err := json.MarshalIndent(v, "", "  ")
+			return string(res), err
+		},
+	}).Parse(defaultTemplates)
+	if err != nil {
+		return nil, fmt.Errorf("parse default templates: %w", err)
+	}
+
+	if overrides == "" {
+		return tpl, nil
+	}
+	if _, err := tpl.Parse(overrides); err != nil {
+		return nil, fmt.Errorf("parse template overrides: %w", err)
+	}
+	return tpl, nil
+}
+
+// Render executes the named template of the set with the model, which is the
+// data of the markdown and JSON templates.
+func (m *Model) Render(tpl *template.Template, name string) (string, error) {
+	res := &strings.Builder{}
+	if err := tpl.ExecuteTemplate(res, name, m); err != nil {
+		return "", fmt.Errorf("execute template %s: %w", name, err)
+	}
+	return res.String(), nil
+}
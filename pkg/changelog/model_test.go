@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package changelog_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/changelog"
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/document"
+)
+
+func testModel() *changelog.Model {
+	bugs := notes.Notes{"Fixed the kubelet", "Fixed the scheduler"}
+	return &changelog.Model{
+		Release: changelog.Release{Tag: "v1.30.1", PreviousRevision: "v1.30.0"},
+		FileDownloads: &document.FileMetadata{
+			Source: []document.File{{
+				Name:     "kubernetes.tar.gz",
+				URL:      "https://dl.k8s.io/v1.30.1/kubernetes.tar.gz",
+				Checksum: "abc",
+			}},
+		},
+		ActionRequired:   notes.Notes{"Removed the flag"},
+		Notes:            document.NoteCollection{{Kind: notes.KindBug, NoteEntries: &bugs}},
+		Dependencies:     "## Dependencies\n\n### Added\n_Nothing has changed._",
+		ReleaseNotesJSON: `{"1": {}}`,
+	}
+}
+
+func TestRenderDefaultTemplates(t *testing.T) {
+	tpl, err := changelog.ParseTemplates("")
+	require.NoError(t, err)
+	model := testModel()
+
+	markdown, err := model.Render(tpl, changelog.TemplateMarkdown)
+	require.NoError(t, err)
+	require.Equal(t, `# v1.30.1
+
+
+## Downloads for v1.30.1
+
+
+
+### Source Code
+
+filename | sha512 hash
+-------- | -----------
+[kubernetes.tar.gz](https://dl.k8s.io/v1.30.1/kubernetes.tar.gz) | abc
+
+## Changelog since v1.30.0
+
+## Urgent Upgrade Notes
+
+### (No, really, you MUST read this before you upgrade)
+
+ - Removed the flag
+`+" "+`
+## Changes by Kind
+
+### Bug or Regression
+
+- Fixed the kubelet
+- Fixed the scheduler
+
+## Dependencies
+
+### Added
+_Nothing has changed._`, markdown)
+
+	res, err := model.Render(tpl, changelog.TemplateJSON)
+	require.NoError(t, err)
+	require.Equal(t, model.ReleaseNotesJSON, res)
+}
+
+func TestRenderRemoteNotes(t *testing.T) {
+	tpl, err := changelog.ParseTemplates("")
+	require.NoError(t, err)
+
+	markdown, err := (&changelog.Model{
+		Release:     changelog.Release{Tag: "v1.30.0", PreviousRevision: "v1.29.0"},
+		RemoteNotes: "\n# Changelog since v1.29.0\n\n## What's New (Major Themes)\n",
+	}).Render(tpl, changelog.TemplateMarkdown)
+	require.NoError(t, err)
+	require.Equal(t,
+		"# v1.30.0\n\n## Changelog since v1.29.0\n\n"+
+			"# Changelog since v1.29.0\n\n## What's New (Major Themes)\n",
+		markdown,
+	)
+}
+
+func TestRenderNewMinorDownloads(t *testing.T) {
+	tpl, err := changelog.ParseTemplates("")
+	require.NoError(t, err)
+	model := testModel()
+	model.Release = changelog.Release{Tag: "v1.30.0", PreviousRevision: "v1.29.0", NewMinor: true}
+
+	markdown, err := model.Render(tpl, changelog.TemplateMarkdown)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(markdown,
+		"# v1.30.0\n\n[Documentation](https://docs.k8s.io)\n\n## Downloads for v1.30.0\n",
+	), markdown)
+
+	// Patch releases do not link the documentation
+	markdown, err = testModel().Render(tpl, changelog.TemplateMarkdown)
+	require.NoError(t, err)
+	require.NotContains(t, markdown, "[Documentation]")
+}
+
+func TestRenderOverriddenTemplates(t *testing.T) {
+	tpl, err := changelog.ParseTemplates(`
+{{- define "markdown" -}}
+{{- template "header" . -}}
+{{- template "notes" . -}}
+{{- template "downloads" . -}}
+{{- end -}}
+{{- define "downloads" -}}
+{{range .FileDownloads.Source}}{{println "*" .Name}}{{end}}
+{{- end -}}
+{{- define "json" -}}
+{{toJSON .Release}}
+{{- end -}}`)
+	require.NoError(t, err)
+	model := testModel()
+
+	markdown, err := model.Render(tpl, changelog.TemplateMarkdown)
+	require.NoError(t, err)
+	require.Equal(t, `# v1.30.1
+
+## Changes by Kind
+
+### Bug or Regression
+
+- Fixed the kubelet
+- Fixed the scheduler
+* kubernetes.tar.gz
+`, markdown)
+
+	res, err := model.Render(tpl, changelog.TemplateJSON)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"tag":"v1.30.1","previous_revision":"v1.30.0"}`, res)
+}
+
+func TestParseTemplatesFailure(t *testing.T) {
+	_, err := changelog.ParseTemplates(`{{define "notes"}}{{.Unclosed`)
+	require.Error(t, err)
+
+	tpl, err := changelog.ParseTemplates(`{{define "notes"}}{{.Unknown}}{{end}}`)
+	require.NoError(t, err)
+	_, err = testModel().Render(tpl, changelog.TemplateMarkdown)
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "Unknown"))
+}
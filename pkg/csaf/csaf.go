@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package csaf contains the subset of the Common Security Advisory Framework
+// 2.0 required to publish security advisories, see
+// https://docs.oasis-open.org/csaf/csaf/v2.0/csaf-v2.0.html
+package csaf
+
+import "time"
+
+const (
+	// Version is the CSAF version written by this package.
+	Version = "2.0"
+
+	// CategorySecurityAdvisory is the profile of security advisories.
+	CategorySecurityAdvisory = "csaf_security_advisory"
+
+	// PublisherVendor denotes the developer of the affected products.
+	PublisherVendor = "vendor"
+
+	// StatusFinal denotes a published, non changing document.
+	StatusFinal = "final"
+
+	// BranchVendor is the branch category of the product vendor.
+	BranchVendor = "vendor"
+
+	// BranchProductName is the branch category of the product name.
+	BranchProductName = "product_name"
+
+	// BranchProductVersion is the branch category of a single version.
+	BranchProductVersion = "product_version"
+
+	// BranchProductVersionRange is the branch category of a version range,
+	// preferably in the vers format, for example `vers:semver/<1.2.3`.
+	BranchProductVersionRange = "product_version_range"
+
+	// NoteSummary is the note category of a short summary.
+	NoteSummary = "summary"
+
+	// NoteDescription is the note category of the full description.
+	NoteDescription = "description"
+
+	// ReferenceExternal denotes references to other documents.
+	ReferenceExternal = "external"
+
+	// RemediationVendorFix denotes a fix provided by the vendor.
+	RemediationVendorFix = "vendor_fix"
+)
+
+// Document is a CSAF document.
+type Document struct {
+	Document        DocumentMetadata `json:"document"`
+	ProductTree     *ProductTree     `json:"product_tree,omitempty"`
+	Vulnerabilities []Vulnerability  `json:"vulnerabilities,omitempty"`
+}
+
+// DocumentMetadata describes the document itself.
+type DocumentMetadata struct {
+	Category    string      `json:"category"`
+	CSAFVersion string      `json:"csaf_version"`
+	Publisher   Publisher   `json:"publisher"`
+	Title       string      `json:"title"`
+	Tracking    Tracking    `json:"tracking"`
+	Notes       []Note      `json:"notes,omitempty"`
+	References  []Reference `json:"references,omitempty"`
+}
+
+// Publisher is the issuing party of the document.
+type Publisher struct {
+	Category  string `json:"category"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// Tracking contains the versioning information of the document.
+type Tracking struct {
+	ID                 string     `json:"id"`
+	Status             string     `json:"status"`
+	Version            string     `json:"version"`
+	InitialReleaseDate time.Time  `json:"initial_release_date"`
+	CurrentReleaseDate time.Time  `json:"current_release_date"`
+	RevisionHistory    []Revision `json:"revision_history"`
+}
+
+// Revision is a single version of the document.
+type Revision struct {
+	Date    time.Time `json:"date"`
+	Number  string    `json:"number"`
+	Summary string    `json:"summary"`
+}
+
+// Note is a textual note of the document or a vulnerability.
+type Note struct {
+	Category string `json:"category"`
+	Text     string `json:"text"`
+	Title    string `json:"title,omitempty"`
+}
+
+// Reference is a link to further information.
+type Reference struct {
+	Category string `json:"category,omitempty"`
+	Summary  string `json:"summary"`
+	URL      string `json:"url"`
+}
+
+// ProductTree contains all products referenced by the vulnerabilities.
+type ProductTree struct {
+	Branches []Branch `json:"branches"`
+}
+
+// Branch is a node of the product tree. Leaf branches define a product.
+type Branch struct {
+	Category string   `json:"category"`
+	Name     string   `json:"name"`
+	Branches []Branch `json:"branches,omitempty"`
+	Product  *Product `json:"product,omitempty"`
+}
+
+// Product is a single product identified by its ID.
+type Product struct {
+	Name      string `json:"name"`
+	ProductID string `json:"product_id"`
+}
+
+// Vulnerability is a single vulnerability and its impact on the products.
+type Vulnerability struct {
+	CVE           string         `json:"cve,omitempty"`
+	Title         string         `json:"title,omitempty"`
+	Notes         []Note         `json:"notes,omitempty"`
+	ProductStatus *ProductStatus `json:"product_status,omitempty"`
+	Scores        []Score        `json:"scores,omitempty"`
+	Remediations  []Remediation  `json:"remediations,omitempty"`
+	References    []Reference    `json:"references,omitempty"`
+}
+
+// ProductStatus lists the product IDs by their vulnerability status.
+type ProductStatus struct {
+	Fixed         []string `json:"fixed,omitempty"`
+	KnownAffected []string `json:"known_affected,omitempty"`
+}
+
+// Score is a vulnerability score applying to the products.
+type Score struct {
+	CVSSV3   CVSSV3   `json:"cvss_v3"`
+	Products []string `json:"products"`
+}
+
+// CVSSV3 is a CVSS v3 score.
+type CVSSV3 struct {
+	Version      string  `json:"version"`
+	VectorString string  `json:"vectorString"`
+	BaseScore    float32 `json:"baseScore"`
+	BaseSeverity string  `json:"baseSeverity"`
+}
+
+// Remediation describes how to resolve the vulnerability.
+type Remediation struct {
+	Category   string   `json:"category"`
+	Details    string   `json:"details"`
+	ProductIDs []string `json:"product_ids,omitempty"`
+	URL        string   `json:"url,omitempty"`
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cve
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+// ResolveVersions looks up the release tags containing the merge commits of
+// the linked PRs in the local repository, across all release branches, and
+// stores the first fixed release of every minor version as well as the
+// resulting vulnerable ranges in the CVE. Released minor versions without
+// the fix result in an open vulnerable range.
+func (c *Client) ResolveVersions(repoPath string, cve *CVE) error {
+	tags := []string{}
+	for _, pr := range cve.LinkedPRs {
+		prTags, err := c.impl.PullRequestTags(repoPath, pr)
+		if err != nil {
+			return fmt.Errorf("finding tags of PR #%d: %w", pr, err)
+		}
+		tags = append(tags, prTags...)
+	}
+
+	releases, err := c.impl.Tags(repoPath)
+	if err != nil {
+		return fmt.Errorf("listing release tags: %w", err)
+	}
+
+	fixedIn := FixedVersions(tags)
+	affected, err := AffectedRanges(fixedIn, releases)
+	if err != nil {
+		return fmt.Errorf("computing affected versions: %w", err)
+	}
+
+	cve.FixedIn = fixedIn
+	cve.Affected = affected
+	return nil
+}
+
+// AnnotateMap resolves the versions of every CVE in the map file and writes
+// them into its `fixedIn` and `affected` fields. The file is only rewritten
+// if the computed versions differ from the stored ones, which is indicated
+// by the returned bool.
+func (c *Client) AnnotateMap(repoPath, mapPath string) (changed bool, err error) {
+	content, err := os.ReadFile(mapPath)
+	if err != nil {
+		return false, fmt.Errorf("reading CVE map: %w", err)
+	}
+
+	// MapSlices preserve the order of the keys written by the user
+	docs := []yaml.MapSlice{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		doc := yaml.MapSlice{}
+		if err := decoder.Decode(&doc); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return false, fmt.Errorf("decoding CVE map: %w", err)
+		}
+		docs = append(docs, doc)
+	}
+
+	for i := range docs {
+		dataFields, ok := mapSliceValue(docs[i], "datafields").(yaml.MapSlice)
+		if !ok {
+			continue
+		}
+		cveData, ok := mapSliceValue(dataFields, "cve").(yaml.MapSlice)
+		if !ok {
+			continue
+		}
+
+		// Nested values are MapSlices as well, which is why the data gets
+		// decoded again into the format expected by ReadRawInterface
+		out, err := yaml.Marshal(cveData)
+		if err != nil {
+			return false, fmt.Errorf("marshalling CVE data: %w", err)
+		}
+		raw := map[interface{}]interface{}{}
+		if err := yaml.Unmarshal(out, &raw); err != nil {
+			return false, fmt.Errorf("unmarshalling CVE data: %w", err)
+		}
+		stored := CVE{}
+		if err := stored.ReadRawInterface(raw); err != nil {
+			return false, fmt.Errorf("reading CVE data from YAML file: %w", err)
+		}
+		if len(stored.LinkedPRs) == 0 {
+			continue
+		}
+
+		computed := stored
+		if err := c.ResolveVersions(repoPath, &computed); err != nil {
+			return false, fmt.Errorf("resolving versions of %s: %w", stored.ID, err)
+		}
+		if slices.Equal(stored.FixedIn, computed.FixedIn) &&
+			slices.Equal(stored.Affected, computed.Affected) {
+			continue
+		}
+
+		logrus.Infof(
+			"%s is fixed in %v, affected are %v", computed.ID, computed.FixedIn, computed.Affected,
+		)
+		cveData = setMapSliceValue(cveData, "fixedIn", computed.FixedIn)
+		cveData = setMapSliceValue(cveData, "affected", computed.Affected)
+		dataFields = setMapSliceValue(dataFields, "cve", cveData)
+		docs[i] = setMapSliceValue(docs[i], "datafields", dataFields)
+		changed = true
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	buf := &bytes.Buffer{}
+	for _, doc := range docs {
+		out, err := yaml.Marshal(doc)
+		if err != nil {
+			return false, fmt.Errorf("marshalling CVE map: %w", err)
+		}
+		buf.WriteString("---\n")
+		buf.Write(out)
+	}
+
+	if err := os.WriteFile(mapPath, buf.Bytes(), os.FileMode(0o644)); err != nil {
+		return false, fmt.Errorf("writing CVE map: %w", err)
+	}
+	return true, nil
+}
+
+func mapSliceValue(slice yaml.MapSlice, key string) interface{} {
+	for _, item := range slice {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return nil
+}
+
+// setMapSliceValue replaces the value of the key or appends it if it does
+// not exist yet.
+func setMapSliceValue(slice yaml.MapSlice, key string, value interface{}) yaml.MapSlice {
+	for i := range slice {
+		if slice[i].Key == key {
+			slice[i].Value = value
+			return slice
+		}
+	}
+	return append(slice, yaml.MapItem{Key: key, Value: value})
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cve
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/release-utils/command"
+)
+
+func TestResolveVersions(t *testing.T) {
+	sut := NewClient()
+	sut.impl = &tagsImpl{tags: map[int][]string{
+		92941: {"v1.19.0-rc.1", "v1.19.0", "v1.19.1"},
+		92970: {"v1.18.6", "v1.18.7"},
+		92971: {"v1.17.9"},
+	}}
+
+	cve := &CVE{LinkedPRs: []int{92941, 92970, 92971}}
+	require.NoError(t, sut.ResolveVersions("/repo", cve))
+	require.Equal(t, []string{"v1.17.9", "v1.18.6", "v1.19.0"}, cve.FixedIn)
+	require.Equal(t, []VersionRange{
+		{Fixed: "v1.17.9"},
+		{Introduced: "v1.18.0", Fixed: "v1.18.6"},
+	}, cve.Affected)
+}
+
+func TestResolveVersionsUnfixedMinor(t *testing.T) {
+	sut := NewClient()
+	sut.impl = &tagsImpl{
+		tags:     map[int][]string{92970: {"v1.18.6", "v1.18.7"}},
+		releases: []string{"v1.18.0", "v1.18.6", "v1.18.7", "v1.19.0-rc.1", "v1.19.0"},
+	}
+
+	cve := &CVE{LinkedPRs: []int{92970}}
+	require.NoError(t, sut.ResolveVersions("/repo", cve))
+	require.Equal(t, []string{"v1.18.6"}, cve.FixedIn)
+	require.Equal(t, []VersionRange{{Fixed: "v1.18.6"}, {Introduced: "v1.19.0"}}, cve.Affected)
+}
+
+func TestResolveVersionsUnfixed(t *testing.T) {
+	sut := NewClient()
+	sut.impl = &tagsImpl{releases: []string{"v1.18.0", "v1.19.0"}}
+
+	cve := &CVE{LinkedPRs: []int{92970}}
+	require.NoError(t, sut.ResolveVersions("/repo", cve))
+	require.Empty(t, cve.FixedIn)
+	require.Equal(t, []VersionRange{{}}, cve.Affected)
+}
+
+func TestPullRequestTags(t *testing.T) {
+	repo := t.TempDir()
+	git := func(args ...string) {
+		_, err := command.NewWithWorkDir(repo, "git", append([]string{
+			"-c", "user.name=test", "-c", "user.email=test@example.com",
+		}, args...)...).RunSilentSuccessOutput()
+		require.NoError(t, err)
+	}
+	commit := func(message, tag string) {
+		git("commit", "--allow-empty", "-m", message)
+		git("tag", tag)
+	}
+
+	git("init", "--initial-branch=master")
+	commit("Initial commit", "v1.17.0")
+	git("branch", "release-1.17")
+	commit("Merge pull request #90 from user/feature", "v1.18.0")
+	git("branch", "release-1.18")
+	commit("Merge pull request #100 from user/fix\n\nFix the kubelet", "v1.19.0")
+
+	// Cherry picks of the fix and of unrelated PRs
+	git("checkout", "release-1.18")
+	commit("Merge pull request #101 from user/automated-cherry-pick-of-#100-upstream-release-1.18", "v1.18.1")
+	git("checkout", "release-1.17")
+	commit("Merge pull request #102 from user/automated-cherry-pick-of-#1000-upstream-release-1.17", "v1.17.1")
+	commit("Merge pull request #103 from user/automated-cherry-pick-of-#99-#100-upstream-release-1.17", "v1.17.2")
+
+	tags, err := (&defaultClientImplementation{}).PullRequestTags(repo, 100)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"v1.17.2", "v1.18.1", "v1.19.0"}, tags)
+	require.Equal(t, []string{"v1.17.2", "v1.18.1", "v1.19.0"}, FixedVersions(tags))
+
+	tags, err = (&defaultClientImplementation{}).PullRequestTags(repo, 1)
+	require.NoError(t, err)
+	require.Empty(t, tags)
+}
+
+func TestResolveVersionsFailure(t *testing.T) {
+	sut := NewClient()
+	sut.impl = &tagsImpl{err: errors.New("")}
+	require.Error(t, sut.ResolveVersions("/repo", &CVE{LinkedPRs: []int{1}}))
+}
+
+func TestAnnotateMap(t *testing.T) {
+	mapsDir := t.TempDir()
+	mapPath := filepath.Join(mapsDir, "CVE-2020-8559.yaml")
+	require.NoError(t, os.WriteFile(mapPath, []byte(testMap), 0o600))
+
+	sut := NewClient()
+	sut.impl = &tagsImpl{tags: map[int][]string{
+		92941: {"v1.19.0"},
+		92970: {"v1.18.6"},
+	}}
+
+	changed, err := sut.AnnotateMap("/repo", mapPath)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	content, err := os.ReadFile(mapPath)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(content), "---\npr: 92941\n"))
+
+	cves, err := ReadMaps(mapsDir)
+	require.NoError(t, err)
+	require.Len(t, cves, 1)
+	require.Equal(t, []int{92941, 92970}, cves[0].LinkedPRs)
+	require.Equal(t, []string{"v1.18.6", "v1.19.0"}, cves[0].FixedIn)
+	require.Equal(t, []VersionRange{{Fixed: "v1.18.6"}}, cves[0].Affected)
+
+	// Unchanged versions do not modify the map
+	changed, err = sut.AnnotateMap("/repo", mapPath)
+	require.NoError(t, err)
+	require.False(t, changed)
+}
+
+func TestValidateComputedVersions(t *testing.T) {
+	cve := CVE{
+		ID:          "CVE-2020-8559",
+		Title:       "Privilege escalation from compromised node to cluster",
+		Description: "If an attacker is able to intercept certain requests to the Kubelet, they",
+		CVSSVector:  "CVSS:3.1/AV:N/AC:H/PR:H/UI:R/S:U/C:H/I:H/A:H",
+		CVSSScore:   6.4,
+		CVSSRating:  "Medium",
+		FixedIn:     []string{"v1.18.6"},
+		Affected:    []VersionRange{{Fixed: "v1.18.6"}},
+	}
+	require.NoError(t, cve.Validate())
+
+	sut := cve
+	sut.FixedIn = []string{"latest"}
+	require.Error(t, sut.Validate())
+
+	sut = cve
+	sut.Affected = []VersionRange{{Introduced: "1.18"}}
+	require.Error(t, sut.Validate())
+}
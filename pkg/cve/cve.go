@@ -20,8 +20,11 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"time"
 
 	cvss "github.com/goark/go-cvss/v3/metric"
+
+	"sigs.k8s.io/release-utils/util"
 )
 
 // CVE Information of a linked CVE vulnerability.
@@ -35,6 +38,13 @@ type CVE struct {
 	CVSSRating    string  `json:"rating"             yaml:"rating"`             // Severity bucket (eg Medium)
 	CalcLink      string  `json:"calclink,omitempty" yaml:"calclink,omitempty"` // Link to the CVE calculator (automatic)
 	LinkedPRs     []int   `json:"pullrequests"`                                 // List of linked PRs (to remove them from the release notes doc)
+
+	// Date the vulnerability got disclosed, eg 2020-07-15 (optional)
+	Published string `json:"published,omitempty" yaml:"published,omitempty"`
+
+	// Computed from the releases containing the linked PRs, see Client.ResolveVersions
+	FixedIn  []string       `json:"fixedIn,omitempty"  yaml:"fixedIn,omitempty"`  // First release of every minor version fixing the vulnerability
+	Affected []VersionRange `json:"affected,omitempty" yaml:"affected,omitempty"` // Ranges of vulnerable releases
 }
 
 // ReadRawInterface populates the CVE data struct from the raw array
@@ -61,6 +71,12 @@ func (cve *CVE) ReadRawInterface(cvedata interface{}) error {
 	if val, ok := cvedata.(map[interface{}]interface{})["description"].(string); ok {
 		cve.Description = val
 	}
+	switch val := cvedata.(map[interface{}]interface{})["published"].(type) {
+	case string:
+		cve.Published = val
+	case time.Time:
+		cve.Published = val.UTC().Format(time.DateOnly)
+	}
 	// Linked PRs is a list of the PR IDs
 	if val, ok := cvedata.(map[interface{}]interface{})["linkedPRs"].([]interface{}); ok {
 		cve.LinkedPRs = []int{}
@@ -69,6 +85,31 @@ func (cve *CVE) ReadRawInterface(cvedata interface{}) error {
 		}
 	}
 
+	// Fixed versions is a list of release tags
+	if val, ok := cvedata.(map[interface{}]interface{})["fixedIn"].([]interface{}); ok {
+		cve.FixedIn = []string{}
+		for _, version := range val {
+			if v, ok := version.(string); ok {
+				cve.FixedIn = append(cve.FixedIn, v)
+			}
+		}
+	}
+
+	// Affected is a list of version ranges
+	if val, ok := cvedata.(map[interface{}]interface{})["affected"].([]interface{}); ok {
+		cve.Affected = []VersionRange{}
+		for _, r := range val {
+			bounds, ok := r.(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+			versionRange := VersionRange{}
+			versionRange.Introduced, _ = bounds["introduced"].(string)
+			versionRange.Fixed, _ = bounds["fixed"].(string)
+			cve.Affected = append(cve.Affected, versionRange)
+		}
+	}
+
 	return nil
 }
 
@@ -112,6 +153,10 @@ func (cve *CVE) Validate() (err error) {
 		return errors.New("out of range CVSS score, should be 0.0 - 10.0")
 	}
 
+	if _, err := cve.publishedDate(); err != nil {
+		return fmt.Errorf("parsing published date: %w", err)
+	}
+
 	if err := ValidateID(cve.ID); err != nil {
 		return fmt.Errorf("checking CVE ID: %w", err)
 	}
@@ -125,6 +170,19 @@ func (cve *CVE) Validate() (err error) {
 		return errors.New("missing CVE description from CVE data")
 	}
 
+	// Computed versions must be release tags
+	for _, version := range cve.FixedIn {
+		if _, err := util.TagStringToSemver(version); err != nil {
+			return fmt.Errorf("invalid fixed version %q: %w", version, err)
+		}
+	}
+
+	for _, r := range cve.Affected {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("invalid affected range %q: %w", r, err)
+		}
+	}
+
 	return nil
 }
 
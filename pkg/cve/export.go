@@ -0,0 +1,433 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cve
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/csaf"
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/osv"
+)
+
+const (
+	// ExportModule is the Go module the exported vulnerabilities affect.
+	ExportModule = "k8s.io/kubernetes"
+
+	// OSVDirectory is the sub directory of the exported OSV entries.
+	OSVDirectory = "osv"
+
+	// CSAFDirectory is the sub directory of the exported CSAF advisories.
+	CSAFDirectory = "csaf"
+
+	pullRequestURL = "https://github.com/kubernetes/kubernetes/pull/%d"
+)
+
+// ExportOptions are the settings for exporting the CVE maps.
+type ExportOptions struct {
+	// OutputDir is the directory to write the OSV entries and CSAF
+	// advisories to, in their OSVDirectory and CSAFDirectory.
+	OutputDir string
+
+	// MapsDir is an optional local directory containing the CVE maps. The
+	// maps are downloaded from the bucket if not set.
+	MapsDir string
+
+	// RepoPath is an optional local kubernetes repository to find the
+	// releases containing the linked pull requests in. Without it, the
+	// versions stored in the maps are exported.
+	RepoPath string
+
+	// Date is used as modification date of the exported documents whose
+	// content changed compared to the previous export in OutputDir,
+	// defaults to now.
+	Date time.Time
+}
+
+// Export writes an OSV entry and a CSAF advisory for every stored CVE map.
+func (c *Client) Export(opts *ExportOptions) error {
+	mapsDir := opts.MapsDir
+	if mapsDir == "" {
+		dir, err := c.impl.CopyAllToTemp(&c.options)
+		if err != nil {
+			return fmt.Errorf("copying CVE maps: %w", err)
+		}
+		defer os.RemoveAll(dir)
+		mapsDir = dir
+	}
+
+	cves, err := ReadMaps(mapsDir)
+	if err != nil {
+		return fmt.Errorf("reading CVE maps: %w", err)
+	}
+
+	date := opts.Date
+	if date.IsZero() {
+		date = time.Now().UTC()
+	}
+
+	for _, dir := range []string{OSVDirectory, CSAFDirectory} {
+		if err := os.MkdirAll(filepath.Join(opts.OutputDir, dir), os.FileMode(0o755)); err != nil {
+			return fmt.Errorf("creating output directory: %w", err)
+		}
+	}
+
+	for i := range cves {
+		cve := &cves[i]
+		if opts.RepoPath != "" {
+			if err := c.ResolveVersions(opts.RepoPath, cve); err != nil {
+				return fmt.Errorf("resolving versions of %s: %w", cve.ID, err)
+			}
+		}
+		if len(cve.Affected) == 0 {
+			logrus.Warnf("No affected versions known for %s", cve.ID)
+		}
+
+		if err := writeOSVEntry(
+			filepath.Join(opts.OutputDir, OSVDirectory, cve.ID+".json"), cve, date,
+		); err != nil {
+			return fmt.Errorf("writing OSV entry: %w", err)
+		}
+
+		if err := writeCSAFAdvisory(
+			filepath.Join(opts.OutputDir, CSAFDirectory, strings.ToLower(cve.ID)+".json"), cve, date,
+		); err != nil {
+			return fmt.Errorf("writing CSAF advisory: %w", err)
+		}
+	}
+
+	logrus.Infof("Exported %d CVEs to %s", len(cves), opts.OutputDir)
+	return nil
+}
+
+// writeOSVEntry writes the OSV entry of the CVE. The modification and
+// publication dates of a previously exported entry are kept, unless its
+// content changed.
+func writeOSVEntry(path string, cve *CVE, date time.Time) error {
+	entry := cve.OSVEntry(date)
+
+	previous := &osv.Entry{}
+	found, err := readJSON(path, previous)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return writeJSON(path, entry)
+	}
+
+	if cve.Published == "" && previous.Published != nil {
+		entry.Published = previous.Published
+	}
+	previous.Modified = entry.Modified
+	if equalJSON(previous, entry) {
+		return nil
+	}
+	return writeJSON(path, entry)
+}
+
+// writeCSAFAdvisory writes the CSAF advisory of the CVE. The tracking of a
+// previously exported advisory is kept if its content did not change,
+// otherwise a new revision gets added.
+func writeCSAFAdvisory(path string, cve *CVE, date time.Time) error {
+	doc := cve.CSAFAdvisory(date)
+
+	previous := &csaf.Document{}
+	found, err := readJSON(path, previous)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return writeJSON(path, doc)
+	}
+
+	tracking := previous.Document.Tracking
+	previous.Document.Tracking = doc.Document.Tracking
+	if equalJSON(previous, doc) {
+		return nil
+	}
+
+	version, err := strconv.Atoi(tracking.Version)
+	if err != nil {
+		return fmt.Errorf("parsing tracking version of %s: %w", path, err)
+	}
+	doc.Document.Tracking.Version = strconv.Itoa(version + 1)
+	if cve.Published == "" {
+		doc.Document.Tracking.InitialReleaseDate = tracking.InitialReleaseDate
+	}
+	doc.Document.Tracking.RevisionHistory = append(tracking.RevisionHistory, csaf.Revision{
+		Date: date, Number: doc.Document.Tracking.Version, Summary: "Updated from the CVE map",
+	})
+	return writeJSON(path, doc)
+}
+
+// readJSON unmarshals the file into data and returns false if it does not
+// exist.
+func readJSON(path string, data any) (bool, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if err := json.Unmarshal(content, data); err != nil {
+		return false, fmt.Errorf("unmarshalling %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// equalJSON returns true if both values have the same JSON representation.
+func equalJSON(a, b any) bool {
+	contentA, errA := json.Marshal(a)
+	contentB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(contentA, contentB)
+}
+
+func writeJSON(path string, data any) error {
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling %s: %w", path, err)
+	}
+	return os.WriteFile(path, content, os.FileMode(0o644))
+}
+
+// ReadMaps parses the CVE data of all maps in the directory, sorted by their
+// ID.
+func ReadMaps(dir string) ([]CVE, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+mapExt))
+	if err != nil {
+		return nil, fmt.Errorf("listing CVE maps: %w", err)
+	}
+
+	res := []CVE{}
+	for _, path := range paths {
+		maps, err := notes.ParseReleaseNotesMap(path)
+		if err != nil {
+			return nil, fmt.Errorf("parsing CVE data map: %w", err)
+		}
+
+		for i, dataMap := range *maps {
+			data, ok := dataMap.DataFields["cve"]
+			if !ok {
+				return nil, fmt.Errorf("data map #%d in file %s has no CVE data", i, path)
+			}
+
+			cve := CVE{}
+			if err := cve.ReadRawInterface(data); err != nil {
+				return nil, fmt.Errorf("reading CVE data from YAML file: %w", err)
+			}
+			if err := cve.Validate(); err != nil {
+				return nil, fmt.Errorf("validating map #%d in file %s: %w", i, path, err)
+			}
+			res = append(res, cve)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res, nil
+}
+
+// OSVEntry converts the CVE into the OSV format.
+func (cve *CVE) OSVEntry(date time.Time) *osv.Entry {
+	published := cve.publishedOr(date)
+	entry := &osv.Entry{
+		SchemaVersion: osv.SchemaVersion,
+		ID:            cve.ID,
+		Modified:      date,
+		Published:     &published,
+		Summary:       cve.Title,
+		Details:       cve.Description,
+		Severity: []osv.Severity{{
+			Type: osv.SeverityCVSSv3, Score: cve.CVSSVector,
+		}},
+		References: cve.references(),
+	}
+
+	if len(cve.Affected) > 0 {
+		events := []osv.Event{}
+		for _, r := range cve.Affected {
+			introduced := strings.TrimPrefix(r.Introduced, "v")
+			if introduced == "" {
+				introduced = "0"
+			}
+			events = append(events, osv.Event{Introduced: introduced})
+			if r.Fixed != "" {
+				events = append(events, osv.Event{Fixed: strings.TrimPrefix(r.Fixed, "v")})
+			}
+		}
+
+		entry.Affected = []osv.Affected{{
+			Package: osv.Package{Ecosystem: osv.EcosystemGo, Name: ExportModule},
+			Ranges:  []osv.Range{{Type: osv.RangeSemver, Events: events}},
+		}}
+	}
+	return entry
+}
+
+func (cve *CVE) references() []osv.Reference {
+	res := []osv.Reference{}
+	if cve.TrackingIssue != "" {
+		res = append(res, osv.Reference{Type: osv.ReferenceReport, URL: cve.TrackingIssue})
+	}
+	for _, pr := range cve.LinkedPRs {
+		res = append(res, osv.Reference{Type: osv.ReferenceFix, URL: fmt.Sprintf(pullRequestURL, pr)})
+	}
+	return res
+}
+
+// CSAFAdvisory converts the CVE into a CSAF security advisory.
+func (cve *CVE) CSAFAdvisory(date time.Time) *csaf.Document {
+	const productName = "kubernetes"
+
+	versions := []csaf.Branch{}
+	status := &csaf.ProductStatus{}
+	for i, r := range cve.Affected {
+		id := fmt.Sprintf("%s-affected-%d", productName, i+1)
+		versions = append(versions, csaf.Branch{
+			Category: csaf.BranchProductVersionRange,
+			Name:     r.Vers(),
+			Product:  &csaf.Product{Name: fmt.Sprintf("Kubernetes %s", r), ProductID: id},
+		})
+		status.KnownAffected = append(status.KnownAffected, id)
+	}
+	for _, version := range cve.FixedIn {
+		id := fmt.Sprintf("%s-%s", productName, version)
+		versions = append(versions, csaf.Branch{
+			Category: csaf.BranchProductVersion,
+			Name:     version,
+			Product:  &csaf.Product{Name: "Kubernetes " + version, ProductID: id},
+		})
+		status.Fixed = append(status.Fixed, id)
+	}
+
+	references := []csaf.Reference{}
+	for _, r := range cve.references() {
+		summary := "Fix"
+		if r.Type == osv.ReferenceReport {
+			summary = "Tracking issue"
+		}
+		references = append(references, csaf.Reference{
+			Category: csaf.ReferenceExternal, Summary: summary, URL: r.URL,
+		})
+	}
+
+	vulnerability := csaf.Vulnerability{
+		CVE:        cve.ID,
+		Title:      cve.Title,
+		Notes:      []csaf.Note{{Category: csaf.NoteDescription, Text: cve.Description}},
+		References: references,
+	}
+	if len(versions) > 0 {
+		vulnerability.ProductStatus = status
+	}
+	if len(status.KnownAffected) > 0 {
+		vulnerability.Scores = []csaf.Score{{
+			CVSSV3: csaf.CVSSV3{
+				Version:      cvssVersion(cve.CVSSVector),
+				VectorString: cve.CVSSVector,
+				BaseScore:    cve.CVSSScore,
+				BaseSeverity: strings.ToUpper(cve.CVSSRating),
+			},
+			Products: status.KnownAffected,
+		}}
+	}
+	if len(status.Fixed) > 0 {
+		vulnerability.Remediations = []csaf.Remediation{{
+			Category:   csaf.RemediationVendorFix,
+			Details:    "Upgrade to " + strings.Join(cve.FixedIn, ", ") + " or later",
+			ProductIDs: status.KnownAffected,
+		}}
+	}
+
+	doc := &csaf.Document{
+		Document: csaf.DocumentMetadata{
+			Category:    csaf.CategorySecurityAdvisory,
+			CSAFVersion: csaf.Version,
+			Publisher: csaf.Publisher{
+				Category:  csaf.PublisherVendor,
+				Name:      "Kubernetes",
+				Namespace: "https://kubernetes.io",
+			},
+			Title: cve.Title,
+			Tracking: csaf.Tracking{
+				ID:                 cve.ID,
+				Status:             csaf.StatusFinal,
+				Version:            "1",
+				InitialReleaseDate: cve.publishedOr(date),
+				CurrentReleaseDate: date,
+				RevisionHistory: []csaf.Revision{{
+					Date: date, Number: "1", Summary: "Exported from the CVE map",
+				}},
+			},
+			Notes:      []csaf.Note{{Category: csaf.NoteSummary, Text: cve.Title}},
+			References: references,
+		},
+		Vulnerabilities: []csaf.Vulnerability{vulnerability},
+	}
+
+	if len(versions) > 0 {
+		doc.ProductTree = &csaf.ProductTree{Branches: []csaf.Branch{{
+			Category: csaf.BranchVendor,
+			Name:     "Kubernetes",
+			Branches: []csaf.Branch{{
+				Category: csaf.BranchProductName,
+				Name:     productName,
+				Branches: versions,
+			}},
+		}}}
+	}
+	return doc
+}
+
+// publishedDate returns the parsed published date of the CVE, which is zero
+// if not set.
+func (cve *CVE) publishedDate() (time.Time, error) {
+	if cve.Published == "" {
+		return time.Time{}, nil
+	}
+	if date, err := time.Parse(time.DateOnly, cve.Published); err == nil {
+		return date, nil
+	}
+	return time.Parse(time.RFC3339, cve.Published)
+}
+
+// publishedOr returns the published date of the CVE or the fallback if not
+// set.
+func (cve *CVE) publishedOr(fallback time.Time) time.Time {
+	if date, err := cve.publishedDate(); err == nil && !date.IsZero() {
+		return date.UTC()
+	}
+	return fallback
+}
+
+// cvssVersion returns the version of the CVSS vector, for example 3.1 for
+// `CVSS:3.1/AV:N/…`.
+func cvssVersion(vector string) string {
+	version, _, _ := strings.Cut(strings.TrimPrefix(vector, "CVSS:"), "/")
+	return version
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cve
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/csaf"
+	"k8s.io/release/pkg/osv"
+)
+
+const testMap = `---
+pr: 92941
+datafields:
+  cve:
+    id: CVE-2020-8559
+    title: Privilege escalation from compromised node to cluster
+    description: If an attacker is able to intercept certain requests to the Kubelet
+    issue: https://github.com/kubernetes/kubernetes/issues/92914
+    vector: CVSS:3.1/AV:N/AC:H/PR:H/UI:R/S:U/C:H/I:H/A:H
+    score: 6.4
+    rating: Medium
+    linkedPRs:
+    - 92941
+    - 92970
+`
+
+// tagsImpl resolves the pull request and release tags from static data.
+type tagsImpl struct {
+	ClientImplementation
+	tags     map[int][]string
+	releases []string
+	err      error
+}
+
+func (impl *tagsImpl) PullRequestTags(_ string, pr int) ([]string, error) {
+	return impl.tags[pr], impl.err
+}
+
+func (impl *tagsImpl) Tags(string) ([]string, error) {
+	return impl.releases, nil
+}
+
+func TestFixedVersions(t *testing.T) {
+	require.Equal(t, []string{"v1.17.9", "v1.18.6", "v1.19.0"}, FixedVersions([]string{
+		"v1.19.0", "v1.19.1", "v1.19.0-beta.1", "v1.18.7", "v1.18.6",
+		"v1.17.10", "v1.17.9", "invalid",
+	}))
+	require.Empty(t, FixedVersions(nil))
+}
+
+func TestAffectedRanges(t *testing.T) {
+	for _, tc := range []struct {
+		fixedIn  []string
+		releases []string
+		expected []VersionRange
+	}{
+		{nil, []string{"v1.19.0"}, []VersionRange{{}}},
+		{
+			[]string{"v1.17.9", "v1.18.6", "v1.19.0"},
+			[]string{"v1.17.0", "v1.18.0", "v1.19.0", "v1.19.1", "v1.20.0-alpha.1"},
+			[]VersionRange{{Fixed: "v1.17.9"}, {Introduced: "v1.18.0", Fixed: "v1.18.6"}},
+		},
+		{ // v1.17 did not receive the fix
+			[]string{"v1.16.13", "v1.18.6"},
+			nil,
+			[]VersionRange{{Fixed: "v1.16.13"}, {Introduced: "v1.17.0", Fixed: "v1.18.6"}},
+		},
+		{ // v1.19 got released without the fix
+			[]string{"v1.17.9", "v1.18.6"},
+			[]string{"invalid", "v1.18.0", "v1.19.0", "v1.19.1"},
+			[]VersionRange{{Fixed: "v1.17.9"}, {Introduced: "v1.18.0", Fixed: "v1.18.6"}, {Introduced: "v1.19.0"}},
+		},
+	} {
+		res, err := AffectedRanges(tc.fixedIn, tc.releases)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, res)
+	}
+
+	_, err := AffectedRanges([]string{"invalid"}, nil)
+	require.Error(t, err)
+
+	require.Equal(t, ">=v1.19.0", VersionRange{Introduced: "v1.19.0"}.String())
+
+	require.Equal(t, ">=v1.18.0, <v1.18.6", VersionRange{Introduced: "v1.18.0", Fixed: "v1.18.6"}.String())
+	require.Equal(t, "vers:semver/<1.17.9", VersionRange{Fixed: "v1.17.9"}.Vers())
+}
+
+func TestExport(t *testing.T) {
+	mapsDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(mapsDir, "CVE-2020-8559.yaml"), []byte(testMap), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(mapsDir, "README.md"), []byte("ignored"), 0o600))
+
+	sut := NewClient()
+	sut.impl = &tagsImpl{tags: map[int][]string{
+		92941: {"v1.19.0", "v1.19.1"},
+		92970: {"v1.18.6", "v1.18.7"},
+	}}
+
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	outputDir := t.TempDir()
+	require.NoError(t, sut.Export(&ExportOptions{
+		OutputDir: outputDir,
+		MapsDir:   mapsDir,
+		RepoPath:  "/repo",
+		Date:      date,
+	}))
+
+	db, err := osv.LoadDatabase(filepath.Join(outputDir, OSVDirectory))
+	require.NoError(t, err)
+	for version, affected := range map[string]bool{
+		"v1.17.0": true,
+		"v1.18.5": true,
+		"v1.18.6": false,
+		"v1.19.0": false,
+	} {
+		require.Equal(t, affected, len(db.Vulnerabilities(osv.EcosystemGo, ExportModule, version)) == 1, version)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, CSAFDirectory, "cve-2020-8559.json"))
+	require.NoError(t, err)
+	doc := &csaf.Document{}
+	require.NoError(t, json.Unmarshal(content, doc))
+	require.Equal(t, csaf.Version, doc.Document.CSAFVersion)
+	require.Equal(t, "CVE-2020-8559", doc.Document.Tracking.ID)
+	require.Equal(t, date, doc.Document.Tracking.CurrentReleaseDate)
+	require.Len(t, doc.Vulnerabilities, 1)
+	require.Equal(t, &csaf.ProductStatus{
+		Fixed:         []string{"kubernetes-v1.18.6", "kubernetes-v1.19.0"},
+		KnownAffected: []string{"kubernetes-affected-1"},
+	}, doc.Vulnerabilities[0].ProductStatus)
+	require.Equal(t, "3.1", doc.Vulnerabilities[0].Scores[0].CVSSV3.Version)
+	require.Equal(t, "MEDIUM", doc.Vulnerabilities[0].Scores[0].CVSSV3.BaseSeverity)
+	require.Equal(t,
+		"vers:semver/<1.18.6",
+		doc.ProductTree.Branches[0].Branches[0].Branches[0].Name,
+	)
+}
+
+func TestExportStableDates(t *testing.T) {
+	mapsDir := t.TempDir()
+	mapPath := filepath.Join(mapsDir, "CVE-2020-8559.yaml")
+	require.NoError(t, os.WriteFile(mapPath, []byte(testMap), 0o600))
+
+	outputDir := t.TempDir()
+	osvPath := filepath.Join(outputDir, OSVDirectory, "CVE-2020-8559.json")
+	csafPath := filepath.Join(outputDir, CSAFDirectory, "cve-2020-8559.json")
+	export := func(date time.Time) (*osv.Entry, *csaf.Document) {
+		require.NoError(t, NewClient().Export(&ExportOptions{
+			OutputDir: outputDir, MapsDir: mapsDir, Date: date,
+		}))
+		entry := &osv.Entry{}
+		content, err := os.ReadFile(osvPath)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(content, entry))
+		doc := &csaf.Document{}
+		content, err = os.ReadFile(csafPath)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(content, doc))
+		return entry, doc
+	}
+
+	first := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	export(first)
+
+	// Unchanged content keeps the previous export
+	entry, doc := export(first.AddDate(0, 1, 0))
+	require.Equal(t, first, entry.Modified)
+	require.Equal(t, first, *entry.Published)
+	require.Equal(t, "1", doc.Document.Tracking.Version)
+	require.Equal(t, first, doc.Document.Tracking.CurrentReleaseDate)
+
+	// Changed content adds a revision
+	require.NoError(t, os.WriteFile(mapPath, []byte(strings.Replace(
+		testMap, "compromised node", "a compromised node", 1,
+	)), 0o600))
+	third := first.AddDate(0, 2, 0)
+	entry, doc = export(third)
+	require.Equal(t, third, entry.Modified)
+	require.Equal(t, first, *entry.Published)
+	require.Equal(t, "2", doc.Document.Tracking.Version)
+	require.Equal(t, first, doc.Document.Tracking.InitialReleaseDate)
+	require.Equal(t, third, doc.Document.Tracking.CurrentReleaseDate)
+	require.Len(t, doc.Document.Tracking.RevisionHistory, 2)
+	require.Equal(t, "2", doc.Document.Tracking.RevisionHistory[1].Number)
+
+	// The published date of the map takes precedence
+	require.NoError(t, os.WriteFile(mapPath, []byte(strings.Replace(
+		testMap, "rating: Medium", "rating: Medium\n    published: 2020-07-15", 1,
+	)), 0o600))
+	published := time.Date(2020, 7, 15, 0, 0, 0, 0, time.UTC)
+	entry, doc = export(third)
+	require.Equal(t, published, *entry.Published)
+	require.Equal(t, published, doc.Document.Tracking.InitialReleaseDate)
+	require.Equal(t, "3", doc.Document.Tracking.Version)
+}
+
+func TestExportUnfixed(t *testing.T) {
+	mapsDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(mapsDir, "CVE-2020-8559.yaml"), []byte(testMap), 0o600))
+
+	sut := NewClient()
+	sut.impl = &tagsImpl{releases: []string{"v1.18.0", "v1.19.0"}}
+
+	outputDir := t.TempDir()
+	require.NoError(t, sut.Export(&ExportOptions{
+		OutputDir: outputDir,
+		MapsDir:   mapsDir,
+		RepoPath:  "/repo",
+	}))
+
+	db, err := osv.LoadDatabase(filepath.Join(outputDir, OSVDirectory))
+	require.NoError(t, err)
+	for _, version := range []string{"v1.0.0", "v1.18.0", "v1.19.0"} {
+		require.Len(t, db.Vulnerabilities(osv.EcosystemGo, ExportModule, version), 1, version)
+	}
+}
+
+func TestExportInvalidMap(t *testing.T) {
+	mapsDir := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(mapsDir, "CVE-2020-8559.yaml"), []byte("datafields:\n  other: {}\n"), 0o600,
+	))
+
+	require.Error(t, NewClient().Export(&ExportOptions{
+		OutputDir: t.TempDir(),
+		MapsDir:   mapsDir,
+	}))
+}
@@ -30,6 +30,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"sigs.k8s.io/release-sdk/object"
+	"sigs.k8s.io/release-utils/command"
 
 	"k8s.io/release/pkg/notes"
 )
@@ -45,6 +46,9 @@ type ClientImplementation interface {
 	ValidateCVEMap(string, string, *ClientOptions) error
 	CreateEmptyFile(string, *ClientOptions) (*os.File, error)
 	EntryExists(string, *ClientOptions) (bool, error)
+	CopyAllToTemp(*ClientOptions) (string, error)
+	PullRequestTags(string, int) ([]string, error)
+	Tags(string) ([]string, error)
 }
 
 // defaultClientImplementation.
@@ -288,3 +292,66 @@ func (impl *defaultClientImplementation) EntryExists(
 	}
 	return gcs.PathExists(path)
 }
+
+// CopyAllToTemp synchronizes all CVE maps of the bucket into a new temporary
+// directory.
+func (impl *defaultClientImplementation) CopyAllToTemp(opts *ClientOptions) (string, error) {
+	dir, err := os.MkdirTemp(os.TempDir(), "cve-maps-")
+	if err != nil {
+		return "", fmt.Errorf("creating temp dir: %w", err)
+	}
+
+	gcs := object.NewGCS()
+	remoteSrc, err := gcs.NormalizePath(
+		object.GcsPrefix + filepath.Join(opts.Bucket, opts.Directory),
+	)
+	if err != nil {
+		return "", fmt.Errorf("normalizing CVE bucket path: %w", err)
+	}
+
+	if err := gcs.RsyncRecursive(remoteSrc, dir); err != nil {
+		return "", fmt.Errorf("copying CVE maps from bucket: %w", err)
+	}
+	return dir, nil
+}
+
+// PullRequestTags returns the tags of the local repository which contain the
+// merge commit of the pull request or of one of its automated cherry picks
+// into the release branches.
+func (impl *defaultClientImplementation) PullRequestTags(repoPath string, pr int) ([]string, error) {
+	commits, err := command.NewWithWorkDir(
+		repoPath, "git", "log", "--all", "--format=%H", "--extended-regexp",
+		fmt.Sprintf("--grep=^Merge pull request #%d from", pr),
+		fmt.Sprintf("--grep=automated-cherry-pick-of-(#[0-9]+-)*#%d([^0-9]|$)", pr),
+	).RunSilentSuccessOutput()
+	if err != nil {
+		return nil, fmt.Errorf("finding merge commits: %w", err)
+	}
+
+	tags := []string{}
+	for _, commit := range strings.Fields(commits.Output()) {
+		res, err := command.NewWithWorkDir(
+			repoPath, "git", "tag", "--list", "v*", "--contains", commit,
+		).RunSilentSuccessOutput()
+		if err != nil {
+			return nil, fmt.Errorf("finding tags containing %s: %w", commit, err)
+		}
+		tags = append(tags, strings.Fields(res.Output())...)
+	}
+
+	if len(tags) == 0 {
+		logrus.Warnf("No release contains PR #%d yet", pr)
+	}
+	return tags, nil
+}
+
+// Tags returns the release tags of the local repository.
+func (impl *defaultClientImplementation) Tags(repoPath string) ([]string, error) {
+	res, err := command.NewWithWorkDir(
+		repoPath, "git", "tag", "--list", "v*",
+	).RunSilentSuccessOutput()
+	if err != nil {
+		return nil, fmt.Errorf("listing tags: %w", err)
+	}
+	return strings.Fields(res.Output()), nil
+}
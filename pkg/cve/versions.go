@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cve
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+
+	"sigs.k8s.io/release-utils/util"
+)
+
+// VersionRange is a range of vulnerable releases. The introduced version is
+// inclusive and the fixed version exclusive, an empty bound is unlimited.
+type VersionRange struct {
+	Introduced string `json:"introduced,omitempty" yaml:"introduced,omitempty"`
+	Fixed      string `json:"fixed,omitempty"      yaml:"fixed,omitempty"`
+}
+
+// String returns the human readable range, for example `>=v1.17.0, <v1.17.9`.
+func (r VersionRange) String() string {
+	return strings.Join(r.constraints("v"), ", ")
+}
+
+// Vers returns the range in the vers notation, for example
+// `vers:semver/>=1.17.0|<1.17.9`.
+func (r VersionRange) Vers() string {
+	return "vers:semver/" + strings.Join(r.constraints(""), "|")
+}
+
+func (r VersionRange) constraints(prefix string) []string {
+	res := []string{}
+	if r.Introduced != "" {
+		res = append(res, ">="+prefix+strings.TrimPrefix(r.Introduced, "v"))
+	}
+	if r.Fixed != "" {
+		res = append(res, "<"+prefix+strings.TrimPrefix(r.Fixed, "v"))
+	}
+	if len(res) == 0 {
+		res = append(res, "*")
+	}
+	return res
+}
+
+// Validate checks that both bounds of the range are release tags.
+func (r VersionRange) Validate() error {
+	for _, bound := range []string{r.Introduced, r.Fixed} {
+		if bound == "" {
+			continue
+		}
+		if _, err := util.TagStringToSemver(bound); err != nil {
+			return fmt.Errorf("parse version %s: %w", bound, err)
+		}
+	}
+	return nil
+}
+
+// FixedVersions reduces the release tags containing a fix to the first
+// final release of every minor version, sorted ascending. Pre-releases and
+// tags which are no semantic versions are ignored.
+func FixedVersions(tags []string) []string {
+	first := map[string]semver.Version{}
+	for _, tag := range tags {
+		version, err := util.TagStringToSemver(tag)
+		if err != nil || len(version.Pre) > 0 || len(version.Build) > 0 {
+			continue
+		}
+
+		minor := fmt.Sprintf("%d.%d", version.Major, version.Minor)
+		if current, ok := first[minor]; !ok || version.LT(current) {
+			first[minor] = version
+		}
+	}
+
+	versions := []semver.Version{}
+	for _, version := range first {
+		versions = append(versions, version)
+	}
+	semver.Sort(versions)
+
+	res := []string{}
+	for _, version := range versions {
+		res = append(res, util.SemverToTagString(version))
+	}
+	return res
+}
+
+// AffectedRanges derives the vulnerable version ranges from the sorted fixed
+// versions of FixedVersions. Every release before the first fix is
+// vulnerable, as well as all minor versions between two fixes which did not
+// receive a fix on their own. Released minor versions newer than the last
+// fix, according to the release tags of the repository, are vulnerable
+// without a known fix. Without any fixed version, all releases are
+// vulnerable.
+func AffectedRanges(fixedIn, releases []string) ([]VersionRange, error) {
+	if len(fixedIn) == 0 {
+		return []VersionRange{{}}, nil
+	}
+
+	res := []VersionRange{}
+	introduced := ""
+	var lastFixed semver.Version
+	for _, fixed := range fixedIn {
+		version, err := util.TagStringToSemver(fixed)
+		if err != nil {
+			return nil, fmt.Errorf("parse fixed version %s: %w", fixed, err)
+		}
+
+		if introduced != fixed {
+			res = append(res, VersionRange{Introduced: introduced, Fixed: fixed})
+		}
+		introduced = util.SemverToTagString(semver.Version{
+			Major: version.Major, Minor: version.Minor + 1,
+		})
+		lastFixed = version
+	}
+
+	for _, release := range releases {
+		version, err := util.TagStringToSemver(release)
+		if err != nil || len(version.Pre) > 0 || len(version.Build) > 0 {
+			continue
+		}
+		if version.Major > lastFixed.Major ||
+			(version.Major == lastFixed.Major && version.Minor > lastFixed.Minor) {
+			res = append(res, VersionRange{Introduced: introduced})
+			break
+		}
+	}
+	return res, nil
+}
@@ -23,10 +23,13 @@ package gcb
 //go:generate /usr/bin/env bash -c "cat ../../../hack/boilerplate/boilerplate.generatego.txt gcbfakes/fake_repository.go > gcbfakes/_fake_repository.go  && mv gcbfakes/_fake_repository.go gcbfakes/fake_repository.go"
 //go:generate /usr/bin/env bash -c "cat ../../../hack/boilerplate/boilerplate.generatego.txt gcbfakes/fake_version.go > gcbfakes/_fake_version.go  && mv gcbfakes/_fake_version.go gcbfakes/fake_version.go"
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -61,11 +64,15 @@ type GCB struct {
 
 // New creates a new `*GCB` instance.
 func New(options *Options) *GCB {
+	policy := options.versionPolicy()
+	versionClient := release.NewVersion()
+	versionClient.SetVersionPolicy(policy)
+
 	return &GCB{
 		repoClient:     release.NewRepo(),
-		versionClient:  release.NewVersion(),
+		versionClient:  versionClient,
 		listJobsClient: &defaultListJobsClient{},
-		releaseClient:  &defaultReleaseClient{},
+		releaseClient:  &defaultReleaseClient{policy},
 		options:        options,
 	}
 }
@@ -121,6 +128,11 @@ type Options struct {
 	OBSProject       string
 	PackageSource    string
 	OBSWait          bool
+
+	// VersionPolicy defines the release types and release branch names
+	// of stage and release jobs. The Kubernetes version policy is used if
+	// not set.
+	VersionPolicy *release.VersionPolicy
 }
 
 // NewDefaultOptions returns a new default `*Options` instance.
@@ -164,37 +176,50 @@ type Release interface {
 	) (*release.Versions, error)
 }
 
-type defaultReleaseClient struct{}
+type defaultReleaseClient struct {
+	policy *release.VersionPolicy
+}
 
-func (*defaultReleaseClient) NeedsCreation(
+func (d *defaultReleaseClient) NeedsCreation(
 	branch, releaseType string, buildVersion semver.Version,
 ) (createReleaseBranch bool, err error) {
-	return release.NewBranchChecker().NeedsCreation(
+	checker := release.NewBranchChecker()
+	checker.SetVersionPolicy(d.policy)
+	return checker.NeedsCreation(
 		branch, releaseType, buildVersion,
 	)
 }
 
-func (*defaultReleaseClient) GenerateReleaseVersion(
+func (d *defaultReleaseClient) GenerateReleaseVersion(
 	releaseType, version, branch string, branchFromMaster bool,
 ) (*release.Versions, error) {
-	return release.GenerateReleaseVersion(
+	return d.policy.GenerateReleaseVersion(
 		releaseType, version, branch, branchFromMaster,
 	)
 }
 
+// versionPolicy returns the configured or the default version policy.
+func (o *Options) versionPolicy() *release.VersionPolicy {
+	if o.VersionPolicy == nil {
+		return release.DefaultVersionPolicy()
+	}
+	return o.VersionPolicy
+}
+
 // Validate checks if the Options are valid.
 func (o *Options) Validate() error {
 	if o.Stage && o.Release {
 		return errors.New("cannot specify both the 'stage' and 'release' flag; resubmit with only one build type selected")
 	}
 
+	policy := o.versionPolicy()
 	if o.Branch == git.DefaultBranch {
-		if o.ReleaseType == release.ReleaseTypeRC || o.ReleaseType == release.ReleaseTypeOfficial {
+		if slices.Contains(policy.ReleaseBranchLabels, o.ReleaseType) || o.ReleaseType == release.ReleaseTypeOfficial {
 			return fmt.Errorf("cannot cut a release candidate or an official release from %s", git.DefaultBranch)
 		}
 	} else {
-		if o.ReleaseType == release.ReleaseTypeAlpha || o.ReleaseType == release.ReleaseTypeBeta {
-			return errors.New("cannot cut an alpha or beta release from a release branch")
+		if slices.Contains(policy.PreReleaseLabels, o.ReleaseType) {
+			return fmt.Errorf("cannot cut a %q release from a release branch", o.ReleaseType)
 		}
 	}
 
@@ -441,6 +466,18 @@ func (g *GCB) SetGCBSubstitutions(toolOrg, toolRepo, toolRef, gcsBucket, forceBu
 		return gcbSubs, nil
 	}
 
+	// The version policy gets passed base64 encoded to not interfere with
+	// the substitutions separator.
+	versionPolicy := ""
+	if g.options.VersionPolicy != nil {
+		content, err := json.Marshal(g.options.VersionPolicy)
+		if err != nil {
+			return gcbSubs, fmt.Errorf("marshal version policy: %w", err)
+		}
+		versionPolicy = base64.StdEncoding.EncodeToString(content)
+	}
+	gcbSubs["VERSION_POLICY"] = versionPolicy
+
 	buildVersion := g.options.BuildVersion
 	if g.options.Release && buildVersion == "" {
 		return gcbSubs, errors.New("build version must be specified when sending a release GCB run")
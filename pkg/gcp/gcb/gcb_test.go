@@ -194,6 +194,7 @@ func TestSetGCBSubstitutionsSuccess(t *testing.T) {
 				"K8S_ORG":                git.DefaultGithubOrg,
 				"K8S_REPO":               git.DefaultGithubRepo,
 				"K8S_REF":                git.DefaultRef,
+				"VERSION_POLICY":         "",
 			},
 		},
 		{
@@ -224,6 +225,7 @@ func TestSetGCBSubstitutionsSuccess(t *testing.T) {
 				"K8S_ORG":                git.DefaultGithubOrg,
 				"K8S_REPO":               git.DefaultGithubRepo,
 				"K8S_REF":                git.DefaultRef,
+				"VERSION_POLICY":         "",
 			},
 		},
 		{
@@ -252,6 +254,7 @@ func TestSetGCBSubstitutionsSuccess(t *testing.T) {
 				"K8S_ORG":                git.DefaultGithubOrg,
 				"K8S_REPO":               git.DefaultGithubRepo,
 				"K8S_REF":                git.DefaultRef,
+				"VERSION_POLICY":         "",
 			},
 		},
 		{
@@ -280,6 +283,7 @@ func TestSetGCBSubstitutionsSuccess(t *testing.T) {
 				"K8S_ORG":                git.DefaultGithubOrg,
 				"K8S_REPO":               git.DefaultGithubRepo,
 				"K8S_REF":                git.DefaultRef,
+				"VERSION_POLICY":         "",
 			},
 		},
 		{
@@ -312,6 +316,7 @@ func TestSetGCBSubstitutionsSuccess(t *testing.T) {
 				"K8S_ORG":                git.DefaultGithubOrg,
 				"K8S_REPO":               git.DefaultGithubRepo,
 				"K8S_REF":                git.DefaultRef,
+				"VERSION_POLICY":         "",
 			},
 		},
 		{
@@ -344,6 +349,7 @@ func TestSetGCBSubstitutionsSuccess(t *testing.T) {
 				"K8S_ORG":                git.DefaultGithubOrg,
 				"K8S_REPO":               git.DefaultGithubRepo,
 				"K8S_REF":                git.DefaultRef,
+				"VERSION_POLICY":         "",
 			},
 		},
 		{
@@ -373,6 +379,7 @@ func TestSetGCBSubstitutionsSuccess(t *testing.T) {
 				"K8S_ORG":                git.DefaultGithubOrg,
 				"K8S_REPO":               git.DefaultGithubRepo,
 				"K8S_REF":                git.DefaultRef,
+				"VERSION_POLICY":         "",
 			},
 		},
 		{
@@ -401,6 +408,7 @@ func TestSetGCBSubstitutionsSuccess(t *testing.T) {
 				"K8S_ORG":                git.DefaultGithubOrg,
 				"K8S_REPO":               git.DefaultGithubRepo,
 				"K8S_REF":                git.DefaultRef,
+				"VERSION_POLICY":         "",
 			},
 		},
 	}
@@ -512,6 +520,15 @@ func TestValidateSuccess(t *testing.T) {
 				ReleaseType: release.ReleaseTypeOfficial,
 			},
 		},
+		{
+			name: "main branch custom label with version policy",
+			gcbOpts: &gcb.Options{
+				Stage:         true,
+				Branch:        git.DefaultBranch,
+				ReleaseType:   "dev",
+				VersionPolicy: &release.VersionPolicy{PreReleaseLabels: []string{"dev"}},
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -526,6 +543,7 @@ func TestValidateFailure(t *testing.T) {
 	testcases := []struct {
 		name    string
 		gcbOpts *gcb.Options
+		err     string
 	}{
 		{
 			name: "RC on main branch",
@@ -554,6 +572,16 @@ func TestValidateFailure(t *testing.T) {
 				Branch:      "release-1.19",
 				ReleaseType: release.ReleaseTypeBeta,
 			},
+			err: `cannot cut a "beta" release from a release branch`,
+		},
+		{
+			name: "custom label on release branch with version policy",
+			gcbOpts: &gcb.Options{
+				Branch:        "release-1.19",
+				ReleaseType:   "dev",
+				VersionPolicy: &release.VersionPolicy{PreReleaseLabels: []string{"dev"}},
+			},
+			err: `cannot cut a "dev" release from a release branch`,
 		},
 	}
 
@@ -562,6 +590,9 @@ func TestValidateFailure(t *testing.T) {
 
 		err := tc.gcbOpts.Validate()
 		require.Error(t, err)
+		if tc.err != "" {
+			require.ErrorContains(t, err, tc.err)
+		}
 	}
 }
 
@@ -0,0 +1,288 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localstore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-sdk/object"
+)
+
+// Store is an `object.Store` which maps Google Cloud Storage paths to a
+// local directory. It can be used to run the release process without any
+// access to GCS.
+//
+// A path like `gs://bucket/path/file` will be stored in
+// `<root>/bucket/path/file`.
+type Store struct {
+	root string
+	gcs  *object.GCS
+
+	noClobber    bool
+	allowMissing bool
+}
+
+var _ object.Store = &Store{}
+
+// New creates a new local `Store` for the provided root directory.
+func New(root string) *Store {
+	return &Store{
+		root:         root,
+		gcs:          object.NewGCS(),
+		noClobber:    true,
+		allowMissing: true,
+	}
+}
+
+// Root returns the root directory of the store.
+func (s *Store) Root() string {
+	return s.root
+}
+
+// SetOptions applies the provided options to the store.
+func (s *Store) SetOptions(opts ...object.OptFn) {
+	for _, f := range opts {
+		f(s)
+	}
+}
+
+// WithNoClobber can be used to skip copying files which already exist in the
+// destination.
+func (s *Store) WithNoClobber(noClobber bool) object.OptFn {
+	return func(object.Store) {
+		s.noClobber = noClobber
+	}
+}
+
+// WithAllowMissing can be used to skip copy operations for not existing
+// sources instead of failing.
+func (s *Store) WithAllowMissing(allowMissing bool) object.OptFn {
+	return func(object.Store) {
+		s.allowMissing = allowMissing
+	}
+}
+
+// NoClobber returns true if existing files will not be overwritten.
+func (s *Store) NoClobber() bool {
+	return s.noClobber
+}
+
+// AllowMissing returns true if missing sources will be skipped on copy.
+func (s *Store) AllowMissing() bool {
+	return s.allowMissing
+}
+
+// NormalizePath ensures that the provided path parts result in a `gs://`
+// prefixed path, exactly like it would for GCS.
+func (s *Store) NormalizePath(pathParts ...string) (string, error) {
+	return s.gcs.NormalizePath(pathParts...)
+}
+
+// IsPathNormalized determines if a path is prefixed with `gs://`.
+func (s *Store) IsPathNormalized(path string) bool {
+	return s.gcs.IsPathNormalized(path)
+}
+
+// LocalPath returns the local file system location for the provided GCS
+// path.
+func (s *Store) LocalPath(gcsPath string) (string, error) {
+	normalized, err := s.NormalizePath(gcsPath)
+	if err != nil {
+		return "", fmt.Errorf("normalize path: %w", err)
+	}
+	return filepath.Join(
+		s.root, filepath.FromSlash(strings.TrimPrefix(normalized, object.GcsPrefix)),
+	), nil
+}
+
+// PathExists returns true if the provided GCS path exists in the store.
+func (s *Store) PathExists(gcsPath string) (bool, error) {
+	if !s.IsPathNormalized(gcsPath) {
+		return false, fmt.Errorf(
+			"path %s does not begin with %s", gcsPath, object.GcsPrefix,
+		)
+	}
+
+	localPath, err := s.LocalPath(gcsPath)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := os.Stat(localPath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("stat %s: %w", localPath, err)
+	}
+
+	logrus.Infof("Found %s", gcsPath)
+	return true, nil
+}
+
+// CopyToRemote copies a local file or directory into the store.
+func (s *Store) CopyToRemote(src, gcsPath string) error {
+	logrus.Infof("Copying %s to local store (%s)", src, gcsPath)
+	dst, err := s.LocalPath(gcsPath)
+	if err != nil {
+		return err
+	}
+	return s.copy(src, dst)
+}
+
+// CopyToLocal copies a file or directory from the store to a local path.
+func (s *Store) CopyToLocal(gcsPath, dst string) error {
+	logrus.Infof("Copying local store path (%s) to %s", gcsPath, dst)
+	src, err := s.LocalPath(gcsPath)
+	if err != nil {
+		return err
+	}
+	return s.copy(src, dst)
+}
+
+// CopyBucketToBucket copies between two locations of the store.
+func (s *Store) CopyBucketToBucket(src, dst string) error {
+	logrus.Infof("Copying %s to %s", src, dst)
+	srcPath, err := s.LocalPath(src)
+	if err != nil {
+		return err
+	}
+	dstPath, err := s.LocalPath(dst)
+	if err != nil {
+		return err
+	}
+	return s.copy(srcPath, dstPath)
+}
+
+// RsyncRecursive synchronizes the contents of the src directory into the dst
+// directory. Both can be either local or `gs://` prefixed paths.
+func (s *Store) RsyncRecursive(src, dst string) error {
+	srcPath, err := s.resolve(src)
+	if err != nil {
+		return err
+	}
+	dstPath, err := s.resolve(dst)
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("Syncing %s to %s", srcPath, dstPath)
+	return copyTree(srcPath, dstPath, false)
+}
+
+// GetReleasePath returns the `gs://` prefixed path to retrieve builds from or
+// push builds to.
+func (s *Store) GetReleasePath(
+	bucket, gcsRoot, version string, fast bool,
+) (string, error) {
+	return s.gcs.GetReleasePath(bucket, gcsRoot, version, fast)
+}
+
+// GetMarkerPath returns the `gs://` prefixed path where version markers
+// should be stored.
+func (s *Store) GetMarkerPath(bucket, gcsRoot string, fast bool) (string, error) {
+	return s.gcs.GetMarkerPath(bucket, gcsRoot, fast)
+}
+
+// resolve maps `gs://` prefixed paths into the store and keeps all other
+// paths unchanged.
+func (s *Store) resolve(path string) (string, error) {
+	if strings.HasPrefix(path, object.GcsPrefix) {
+		return s.LocalPath(path)
+	}
+	return path, nil
+}
+
+// copy behaves like `gsutil cp -r`: if dst is an existing directory, then
+// src will be copied into it.
+func (s *Store) copy(src, dst string) error {
+	if _, err := os.Stat(src); err != nil {
+		if errors.Is(err, os.ErrNotExist) && s.allowMissing {
+			logrus.Infof("Source %s does not exist, skipping copy", src)
+			return nil
+		}
+		return fmt.Errorf("stat source %s: %w", src, err)
+	}
+
+	if info, err := os.Stat(dst); err == nil && info.IsDir() {
+		dst = filepath.Join(dst, filepath.Base(src))
+	}
+
+	return copyTree(src, dst, s.noClobber)
+}
+
+// copyTree copies the file or directory src to dst. Existing files will not
+// be overwritten if noClobber is set.
+func copyTree(src, dst string, noClobber bool) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return fmt.Errorf("get relative path: %w", err)
+		}
+		target := filepath.Join(dst, rel)
+
+		if d.IsDir() {
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return fmt.Errorf("create directory %s: %w", target, err)
+			}
+			return nil
+		}
+
+		if noClobber {
+			if _, err := os.Stat(target); err == nil {
+				logrus.Infof("Skipping existing file %s", target)
+				return nil
+			}
+		}
+
+		return copyFile(path, target)
+	})
+}
+
+func copyFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return fmt.Errorf("create directory for %s: %w", dst, err)
+	}
+
+	source, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("open source file %s: %w", src, err)
+	}
+	defer source.Close()
+
+	destination, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("create destination file %s: %w", dst, err)
+	}
+	defer destination.Close()
+
+	if _, err := io.Copy(destination, source); err != nil {
+		return fmt.Errorf("copy %s to %s: %w", src, dst, err)
+	}
+	return nil
+}
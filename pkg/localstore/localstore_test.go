@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localstore_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/localstore"
+)
+
+func TestLocalPath(t *testing.T) {
+	t.Parallel()
+
+	sut := localstore.New("/tmp/store")
+	for _, tc := range []struct {
+		path        string
+		expected    string
+		shouldError bool
+	}{
+		{path: "gs://bucket/stage/file", expected: "/tmp/store/bucket/stage/file"},
+		{path: "bucket/stage", expected: "/tmp/store/bucket/stage"},
+		{path: "", shouldError: true},
+	} {
+		res, err := sut.LocalPath(tc.path)
+		if tc.shouldError {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, res)
+	}
+}
+
+func TestCopy(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "dir", "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "dir", "sub", "a"), []byte("a"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "file"), []byte("file"), 0o644))
+
+	sut := localstore.New(root)
+	sut.SetOptions(sut.WithNoClobber(false), sut.WithAllowMissing(false))
+
+	// Single file
+	require.NoError(t, sut.CopyToRemote(filepath.Join(src, "file"), "gs://bucket/file"))
+	content, err := os.ReadFile(filepath.Join(root, "bucket", "file"))
+	require.NoError(t, err)
+	require.Equal(t, "file", string(content))
+
+	exists, err := sut.PathExists("gs://bucket/file")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	exists, err = sut.PathExists("gs://bucket/missing")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	_, err = sut.PathExists("bucket/file")
+	require.Error(t, err)
+
+	// Directory rsync
+	require.NoError(t, sut.RsyncRecursive(filepath.Join(src, "dir"), "gs://bucket/dir"))
+	require.FileExists(t, filepath.Join(root, "bucket", "dir", "sub", "a"))
+
+	// Copy back into an existing directory
+	dst := t.TempDir()
+	require.NoError(t, sut.CopyToLocal("gs://bucket/dir", dst))
+	require.FileExists(t, filepath.Join(dst, "dir", "sub", "a"))
+
+	// Bucket to bucket
+	require.NoError(t, sut.CopyBucketToBucket("gs://bucket/dir", "gs://other/dir"))
+	require.FileExists(t, filepath.Join(root, "other", "dir", "sub", "a"))
+
+	// Missing sources
+	require.Error(t, sut.CopyToRemote(filepath.Join(src, "missing"), "gs://bucket/missing"))
+	sut.SetOptions(sut.WithAllowMissing(true))
+	require.NoError(t, sut.CopyToRemote(filepath.Join(src, "missing"), "gs://bucket/missing"))
+}
+
+func TestCopyNoClobber(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	src := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(src, []byte("new"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "bucket"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "bucket", "file"), []byte("old"), 0o644))
+
+	sut := localstore.New(root)
+	require.True(t, sut.NoClobber())
+	require.NoError(t, sut.CopyToRemote(src, "gs://bucket/file"))
+	content, err := os.ReadFile(filepath.Join(root, "bucket", "file"))
+	require.NoError(t, err)
+	require.Equal(t, "old", string(content))
+
+	sut.SetOptions(sut.WithNoClobber(false))
+	require.NoError(t, sut.CopyToRemote(src, "gs://bucket/file"))
+	content, err = os.ReadFile(filepath.Join(root, "bucket", "file"))
+	require.NoError(t, err)
+	require.Equal(t, "new", string(content))
+}
@@ -23,14 +23,16 @@ import (
 	"github.com/saschagrunert/go-modiff/pkg/modiff"
 
 	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-utils/command"
 )
 
 type Dependencies struct {
-	moDiff MoDiff
+	moDiff        MoDiff
+	modFileReader ModFileReader
 }
 
 func NewDependencies() *Dependencies {
-	return &Dependencies{&moDiff{}}
+	return &Dependencies{&moDiff{}, &modFileReader{}}
 }
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -51,6 +53,30 @@ func (d *Dependencies) SetMoDiff(moDiff MoDiff) {
 	d.moDiff = moDiff
 }
 
+//counterfeiter:generate . ModFileReader
+//go:generate /usr/bin/env bash -c "cat ../../hack/boilerplate/boilerplate.generatego.txt notesfakes/fake_mod_file_reader.go > notesfakes/_fake_mod_file_reader.go && mv notesfakes/_fake_mod_file_reader.go notesfakes/fake_mod_file_reader.go"
+type ModFileReader interface {
+	ReadModFile(repoPath, rev string) ([]byte, error)
+}
+
+type modFileReader struct{}
+
+func (*modFileReader) ReadModFile(repoPath, rev string) ([]byte, error) {
+	res, err := command.NewWithWorkDir(
+		repoPath, "git", "show", rev+":go.mod",
+	).RunSilentSuccessOutput()
+	if err != nil {
+		return nil, fmt.Errorf("read go.mod of %s: %w", rev, err)
+	}
+	return []byte(res.Output()), nil
+}
+
+// SetModFileReader can be used to set the internal ModFileReader
+// implementation.
+func (d *Dependencies) SetModFileReader(reader ModFileReader) {
+	d.modFileReader = reader
+}
+
 // Changes collects the dependency change report as markdown between
 // both provided revisions. The function errors if anything went wrong.
 func (d *Dependencies) Changes(from, to string) (string, error) {
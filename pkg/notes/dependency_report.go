@@ -0,0 +1,461 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"fmt"
+	"go/build"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+
+	"k8s.io/release/pkg/osv"
+)
+
+// LicenseUnknown is the license of modules whose license file could not be
+// classified.
+const LicenseUnknown = "unknown"
+
+// DependencyOptions are the settings for the dependency report.
+type DependencyOptions struct {
+	// RepoPath is the local repository to read the go.mod files from.
+	RepoPath string
+
+	// ModuleCacheDir is the directory to detect the module licenses from.
+	// It defaults to the Go module cache, but any local mirror using the
+	// same `<module>@<version>` layout works as well.
+	ModuleCacheDir string
+
+	// OSVDatabase is an optional offline OSV database snapshot, either a
+	// directory of JSON entries or a zip archive of them, to cross reference
+	// the module versions against.
+	OSVDatabase string
+}
+
+// DependencyReport contains the Go module changes between two revisions.
+type DependencyReport struct {
+	Added   []*DependencyChange `json:"added"`
+	Changed []*DependencyChange `json:"changed"`
+	Removed []*DependencyChange `json:"removed"`
+}
+
+// DependencyChange is a single added, changed or removed Go module.
+type DependencyChange struct {
+	// Module is the path of the module.
+	Module string `json:"module"`
+
+	// From is the previous version, empty for added modules.
+	From string `json:"from,omitempty"`
+
+	// To is the new version, empty for removed modules.
+	To string `json:"to,omitempty"`
+
+	// Replace is the replacement of the module, either a module path and
+	// version or a local directory.
+	Replace string `json:"replace,omitempty"`
+
+	// MajorBump is true if the major version of the module changed, either
+	// by the version itself or the major version suffix of its path.
+	MajorBump bool `json:"major_bump,omitempty"`
+
+	// License is the detected SPDX identifier of the module license, empty
+	// if the module is not available locally.
+	License string `json:"license,omitempty"`
+
+	// Vulnerabilities are the OSV IDs affecting the new version.
+	Vulnerabilities []string `json:"vulnerabilities,omitempty"`
+
+	// FixedVulnerabilities are the OSV IDs affecting the previous but not
+	// the new version.
+	FixedVulnerabilities []string `json:"fixed_vulnerabilities,omitempty"`
+}
+
+// moduleVersion is a required module of a go.mod file.
+type moduleVersion struct {
+	version, replace string
+
+	// replacement is the module to look up instead, if replaced by another
+	// module version
+	replacement *module.Version
+}
+
+// Report creates the dependency report between both provided revisions by
+// comparing the go.mod files of the local repository.
+func (d *Dependencies) Report(opts *DependencyOptions, from, to string) (*DependencyReport, error) {
+	before, err := d.readModules(opts.RepoPath, from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := d.readModules(opts.RepoPath, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var db *osv.Database
+	if opts.OSVDatabase != "" {
+		db, err = osv.LoadDatabase(opts.OSVDatabase)
+		if err != nil {
+			return nil, fmt.Errorf("load OSV database: %w", err)
+		}
+	}
+
+	cacheDir := opts.ModuleCacheDir
+	if cacheDir == "" {
+		cacheDir = defaultModuleCacheDir()
+	}
+
+	report := &DependencyReport{
+		Added:   []*DependencyChange{},
+		Changed: []*DependencyChange{},
+		Removed: []*DependencyChange{},
+	}
+	for path, a := range after {
+		b, ok := before[path]
+		change := &DependencyChange{Module: path, To: a.version, Replace: a.replace}
+		switch {
+		case !ok:
+			change.MajorBump = replacesMajorVersion(path, before)
+			report.Added = append(report.Added, change)
+		case b.version != a.version || b.replace != a.replace:
+			change.From = b.version
+			change.MajorBump = semver.Major(b.version) != semver.Major(a.version)
+			report.Changed = append(report.Changed, change)
+		default:
+			continue
+		}
+
+		change.License = detectModuleLicense(cacheDir, path, a)
+		if db != nil {
+			change.Vulnerabilities = vulnerabilities(db, path, a.version)
+			if ok {
+				change.FixedVulnerabilities = fixedVulnerabilities(
+					vulnerabilities(db, path, b.version), change.Vulnerabilities,
+				)
+			}
+		}
+	}
+
+	for path, b := range before {
+		if _, ok := after[path]; ok {
+			continue
+		}
+		change := &DependencyChange{Module: path, From: b.version, Replace: b.replace}
+		if db != nil {
+			change.FixedVulnerabilities = vulnerabilities(db, path, b.version)
+		}
+		report.Removed = append(report.Removed, change)
+	}
+
+	for _, changes := range [][]*DependencyChange{report.Added, report.Changed, report.Removed} {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].Module < changes[j].Module })
+	}
+	logrus.Infof(
+		"Found %d added, %d changed and %d removed modules",
+		len(report.Added), len(report.Changed), len(report.Removed),
+	)
+	return report, nil
+}
+
+// readModules returns the required modules of the go.mod file at the
+// revision, indexed by their path.
+func (d *Dependencies) readModules(repoPath, rev string) (map[string]*moduleVersion, error) {
+	content, err := d.modFileReader.ReadModFile(repoPath, rev)
+	if err != nil {
+		return nil, fmt.Errorf("read go.mod: %w", err)
+	}
+
+	file, err := modfile.Parse("go.mod", content, nil)
+	if err != nil {
+		return nil, fmt.Errorf("parse go.mod of %s: %w", rev, err)
+	}
+
+	res := map[string]*moduleVersion{}
+	for _, r := range file.Require {
+		res[r.Mod.Path] = &moduleVersion{version: r.Mod.Version}
+	}
+
+	for _, r := range file.Replace {
+		mod, ok := res[r.Old.Path]
+		if !ok || (r.Old.Version != "" && r.Old.Version != mod.version) {
+			continue
+		}
+		if r.New.Version == "" {
+			// Local directory replacement
+			mod.replace = r.New.Path
+			continue
+		}
+		mod.replace = r.New.Path + " " + r.New.Version
+		mod.replacement = &module.Version{Path: r.New.Path, Version: r.New.Version}
+	}
+	return res, nil
+}
+
+// replacesMajorVersion returns true if a module with the same path but
+// another major version suffix was previously required.
+func replacesMajorVersion(path string, before map[string]*moduleVersion) bool {
+	prefix, _, ok := module.SplitPathVersion(path)
+	if !ok {
+		return false
+	}
+	for previous := range before {
+		if previousPrefix, _, ok := module.SplitPathVersion(previous); ok && previousPrefix == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+func vulnerabilities(db *osv.Database, path, version string) []string {
+	var res []string
+	for _, entry := range db.Vulnerabilities(osv.EcosystemGo, path, version) {
+		res = append(res, entry.ID)
+	}
+	return res
+}
+
+func fixedVulnerabilities(before, after []string) []string {
+	var res []string
+	for _, id := range before {
+		if !slices.Contains(after, id) {
+			res = append(res, id)
+		}
+	}
+	return res
+}
+
+func defaultModuleCacheDir() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	return filepath.Join(build.Default.GOPATH, "pkg", "mod")
+}
+
+// detectModuleLicense classifies the license of the module version within
+// the module cache directory.
+func detectModuleLicense(cacheDir, path string, mod *moduleVersion) string {
+	version := module.Version{Path: path, Version: mod.version}
+	if mod.replacement != nil {
+		version = *mod.replacement
+	}
+
+	escapedPath, err := module.EscapePath(version.Path)
+	if err != nil {
+		return ""
+	}
+	escapedVersion, err := module.EscapeVersion(version.Version)
+	if err != nil {
+		return ""
+	}
+
+	dir := filepath.Join(cacheDir, escapedPath+"@"+escapedVersion)
+	if _, err := os.Stat(dir); err != nil {
+		logrus.Debugf("Module %s not found in %s", version, cacheDir)
+		return ""
+	}
+	return detectLicense(dir)
+}
+
+// licensePhrases map distinctive phrases of the license texts to their SPDX
+// identifiers, ordered from the most to the least specific.
+var licensePhrases = []struct {
+	phrases []string
+	spdx    string
+}{
+	{[]string{"apache license", "version 2.0"}, "Apache-2.0"},
+	{[]string{"mozilla public license", "2.0"}, "MPL-2.0"},
+	{[]string{"gnu lesser general public license", "version 3"}, "LGPL-3.0"},
+	{[]string{"gnu lesser general public license", "version 2.1"}, "LGPL-2.1"},
+	{[]string{"gnu general public license", "version 3"}, "GPL-3.0"},
+	{[]string{"gnu general public license", "version 2"}, "GPL-2.0"},
+	{[]string{"redistribution and use in source and binary forms", "neither the name"}, "BSD-3-Clause"},
+	{[]string{"redistribution and use in source and binary forms"}, "BSD-2-Clause"},
+	{[]string{"permission is hereby granted, free of charge"}, "MIT"},
+	{[]string{"permission to use, copy, modify, and/or distribute this software for any purpose"}, "ISC"},
+	{[]string{"this is free and unencumbered software released into the public domain"}, "Unlicense"},
+}
+
+// detectLicense classifies the license files in the root of the directory.
+// Multiple licenses are joined by " AND ".
+func detectLicense(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+
+	licenses := []string{}
+	for _, entry := range entries {
+		name := strings.ToUpper(entry.Name())
+		if entry.IsDir() || !(strings.HasPrefix(name, "LICENSE") ||
+			strings.HasPrefix(name, "LICENCE") || strings.HasPrefix(name, "COPYING")) {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		text := strings.Join(strings.Fields(strings.ToLower(string(content))), " ")
+
+		license := LicenseUnknown
+		for _, candidate := range licensePhrases {
+			if containsAll(text, candidate.phrases) {
+				license = candidate.spdx
+				break
+			}
+		}
+		if !slices.Contains(licenses, license) {
+			licenses = append(licenses, license)
+		}
+	}
+
+	if len(licenses) == 0 {
+		return LicenseUnknown
+	}
+	sort.Strings(licenses)
+	return strings.Join(licenses, " AND ")
+}
+
+func containsAll(text string, phrases []string) bool {
+	for _, phrase := range phrases {
+		if !strings.Contains(text, phrase) {
+			return false
+		}
+	}
+	return true
+}
+
+// Markdown renders the report in the same layout as the plain dependency
+// changes, with the annotations appended to every module.
+func (r *DependencyReport) Markdown() string {
+	builder := &strings.Builder{}
+	builder.WriteString("## Dependencies\n")
+
+	for _, section := range []struct {
+		title   string
+		changes []*DependencyChange
+	}{
+		{"Added", r.Added},
+		{"Changed", r.Changed},
+		{"Removed", r.Removed},
+	} {
+		fmt.Fprintf(builder, "\n### %s\n", section.title)
+		if len(section.changes) == 0 {
+			builder.WriteString("_Nothing has changed._\n")
+			continue
+		}
+		for _, change := range section.changes {
+			fmt.Fprintf(builder, "- %s: %s%s\n", change.Module, change.versionLink(), change.annotations())
+		}
+	}
+	return builder.String()
+}
+
+// versionLink returns the versions of the change, linked to GitHub if
+// possible.
+func (c *DependencyChange) versionLink() string {
+	from, to := prettyModuleVersion(c.From), prettyModuleVersion(c.To)
+
+	repo, tagPrefix, ok := githubRepo(c.Module)
+	switch {
+	case c.From == "":
+		if !ok {
+			return to
+		}
+		return fmt.Sprintf("[%s](https://%s/tree/%s%s)", to, repo, tagPrefix, sanitizeModuleTag(to))
+	case c.To == "":
+		return from
+	case c.From == c.To:
+		// Only the replacement changed
+		return to
+	default:
+		if !ok {
+			return fmt.Sprintf("%s → %s", from, to)
+		}
+		return fmt.Sprintf(
+			"[%s → %s](https://%s/compare/%s%s...%s%s)", from, to, repo,
+			tagPrefix, sanitizeModuleTag(from), tagPrefix, sanitizeModuleTag(to),
+		)
+	}
+}
+
+// annotations returns the additional information of the change.
+func (c *DependencyChange) annotations() string {
+	res := []string{}
+	if c.License != "" {
+		res = append(res, "license: "+c.License)
+	}
+	if c.MajorBump {
+		res = append(res, "**major version bump**")
+	}
+	if c.Replace != "" {
+		res = append(res, "replaced by "+c.Replace)
+	}
+	if len(c.Vulnerabilities) > 0 {
+		res = append(res, "**vulnerable to "+strings.Join(c.Vulnerabilities, ", ")+"**")
+	}
+	if len(c.FixedVulnerabilities) > 0 {
+		res = append(res, "fixes "+strings.Join(c.FixedVulnerabilities, ", "))
+	}
+
+	if len(res) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(res, "; ") + ")"
+}
+
+// githubRepo returns the GitHub repository of the module as well as the tag
+// prefix for modules in sub directories.
+func githubRepo(path string) (repo, tagPrefix string, ok bool) {
+	if !strings.HasPrefix(path, "github.com/") {
+		return "", "", false
+	}
+	if prefix, _, ok := module.SplitPathVersion(path); ok {
+		path = prefix
+	}
+
+	parts := strings.Split(path, "/")
+	if len(parts) < 3 {
+		return "", "", false
+	}
+	if len(parts) > 3 {
+		tagPrefix = strings.Join(parts[3:], "/") + "/"
+	}
+	return strings.Join(parts[:3], "/"), tagPrefix, true
+}
+
+// prettyModuleVersion shortens pseudo versions to their commit.
+func prettyModuleVersion(version string) string {
+	if !module.IsPseudoVersion(version) {
+		return version
+	}
+	rev, err := module.PseudoVersionRev(version)
+	if err != nil || len(rev) < 7 {
+		return version
+	}
+	return rev[:7]
+}
+
+func sanitizeModuleTag(tag string) string {
+	return strings.TrimSuffix(tag, "+incompatible")
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/notesfakes"
+	"k8s.io/release/pkg/osv"
+)
+
+const (
+	goModFrom = `module k8s.io/example
+
+go 1.22
+
+require (
+	github.com/foo/bar v1.2.0
+	github.com/foo/baz v1.0.0
+	github.com/old/gone v0.1.0
+	golang.org/x/net v0.17.0
+	gopkg.in/yaml.v2 v2.4.0
+)
+`
+
+	goModTo = `module k8s.io/example
+
+go 1.22
+
+require (
+	github.com/foo/bar v1.3.0
+	github.com/foo/baz/v2 v2.0.1
+	github.com/new/mod/sub v0.0.0-20240102150405-abcdef123456
+	golang.org/x/net v0.23.0
+	gopkg.in/yaml.v2 v2.4.0
+)
+
+replace gopkg.in/yaml.v2 => ../yaml
+`
+)
+
+const expectedReport = `## Dependencies
+
+### Added
+- github.com/foo/baz/v2: [v2.0.1](https://github.com/foo/baz/tree/v2.0.1) (license: MIT; **major version bump**)
+- github.com/new/mod/sub: [abcdef1](https://github.com/new/mod/tree/sub/abcdef1) (license: Apache-2.0 AND BSD-3-Clause)
+
+### Changed
+- github.com/foo/bar: [v1.2.0 → v1.3.0](https://github.com/foo/bar/compare/v1.2.0...v1.3.0) (license: unknown)
+- golang.org/x/net: v0.17.0 → v0.23.0 (**vulnerable to GO-2024-0002**; fixes GO-2024-0001)
+- gopkg.in/yaml.v2: v2.4.0 (replaced by ../yaml)
+
+### Removed
+- github.com/foo/baz: v1.0.0
+- github.com/old/gone: v0.1.0
+`
+
+func writeLicense(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+}
+
+func TestDependencyReportSuccess(t *testing.T) {
+	cacheDir := t.TempDir()
+	writeLicense(t, filepath.Join(cacheDir, "github.com", "foo", "baz", "v2@v2.0.1"), "LICENSE",
+		"MIT License\n\nPermission is hereby granted, free of charge,\nto any person")
+	writeLicense(t, filepath.Join(cacheDir, "github.com", "foo", "bar@v1.3.0"), "LICENSE.txt",
+		"All rights reserved.")
+	newMod := filepath.Join(cacheDir, "github.com", "new", "mod", "sub@v0.0.0-20240102150405-abcdef123456")
+	writeLicense(t, newMod, "LICENSE", "Apache License\n  Version 2.0, January 2004")
+	writeLicense(t, newMod, "COPYING", "Redistribution and use in source and binary forms ... "+
+		"Neither the name of the copyright holder")
+
+	osvDir := t.TempDir()
+	for id, events := range map[string][]osv.Event{
+		"GO-2024-0001": {{Introduced: "0"}, {Fixed: "0.20.0"}},
+		"GO-2024-0002": {{Introduced: "0"}, {Fixed: "0.25.0"}},
+	} {
+		content, err := json.Marshal(&osv.Entry{
+			ID: id,
+			Affected: []osv.Affected{{
+				Package: osv.Package{Ecosystem: osv.EcosystemGo, Name: "golang.org/x/net"},
+				Ranges:  []osv.Range{{Type: osv.RangeSemver, Events: events}},
+			}},
+		})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(osvDir, id+".json"), content, 0o600))
+	}
+
+	reader := &notesfakes.FakeModFileReader{}
+	reader.ReadModFileReturnsOnCall(0, []byte(goModFrom), nil)
+	reader.ReadModFileReturnsOnCall(1, []byte(goModTo), nil)
+	sut := notes.NewDependencies()
+	sut.SetModFileReader(reader)
+
+	res, err := sut.Report(&notes.DependencyOptions{
+		RepoPath:       "/repo",
+		ModuleCacheDir: cacheDir,
+		OSVDatabase:    osvDir,
+	}, "v1.29.0", "v1.30.0")
+	require.NoError(t, err)
+	require.Equal(t, expectedReport, res.Markdown())
+
+	repoPath, rev := reader.ReadModFileArgsForCall(1)
+	require.Equal(t, "/repo", repoPath)
+	require.Equal(t, "v1.30.0", rev)
+
+	content, err := json.Marshal(res.Changed[1])
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"module": "golang.org/x/net",
+		"from": "v0.17.0",
+		"to": "v0.23.0",
+		"vulnerabilities": ["GO-2024-0002"],
+		"fixed_vulnerabilities": ["GO-2024-0001"]
+	}`, string(content))
+}
+
+func TestDependencyReportNothingChanged(t *testing.T) {
+	reader := &notesfakes.FakeModFileReader{}
+	reader.ReadModFileReturns([]byte(goModFrom), nil)
+	sut := notes.NewDependencies()
+	sut.SetModFileReader(reader)
+
+	res, err := sut.Report(&notes.DependencyOptions{ModuleCacheDir: t.TempDir()}, "v1.29.0", "v1.30.0")
+	require.NoError(t, err)
+	require.Equal(t, `## Dependencies
+
+### Added
+_Nothing has changed._
+
+### Changed
+_Nothing has changed._
+
+### Removed
+_Nothing has changed._
+`, res.Markdown())
+}
+
+func TestDependencyReportFailure(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		prepare func(*notesfakes.FakeModFileReader, *notes.DependencyOptions)
+	}{
+		{
+			name: "ReadModFile failed",
+			prepare: func(reader *notesfakes.FakeModFileReader, _ *notes.DependencyOptions) {
+				reader.ReadModFileReturnsOnCall(1, nil, errors.New(""))
+			},
+		},
+		{
+			name: "go.mod invalid",
+			prepare: func(reader *notesfakes.FakeModFileReader, _ *notes.DependencyOptions) {
+				reader.ReadModFileReturnsOnCall(0, []byte("require ("), nil)
+			},
+		},
+		{
+			name: "OSV database missing",
+			prepare: func(_ *notesfakes.FakeModFileReader, opts *notes.DependencyOptions) {
+				opts.OSVDatabase = filepath.Join(t.TempDir(), "missing")
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			reader := &notesfakes.FakeModFileReader{}
+			reader.ReadModFileReturns([]byte(goModFrom), nil)
+			opts := &notes.DependencyOptions{ModuleCacheDir: t.TempDir()}
+			tc.prepare(reader, opts)
+
+			sut := notes.NewDependencies()
+			sut.SetModFileReader(reader)
+			res, err := sut.Report(opts, "v1.29.0", "v1.30.0")
+			require.Error(t, err)
+			require.Nil(t, res)
+		})
+	}
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Fields of a ReleaseNote which are compared by DiffReleaseNotes.
+const (
+	DiffFieldText           = "text"
+	DiffFieldKinds          = "kinds"
+	DiffFieldSIGs           = "sigs"
+	DiffFieldAreas          = "areas"
+	DiffFieldActionRequired = "action_required"
+	DiffFieldDoNotPublish   = "do_not_publish"
+)
+
+// NotesDiff contains the differences between two sets of release notes.
+type NotesDiff struct {
+	Added    []*ReleaseNote     `json:"added"`
+	Removed  []*ReleaseNote     `json:"removed"`
+	Modified []NoteModification `json:"modified"`
+}
+
+// NoteModification contains all field changes of a single PR release note.
+type NoteModification struct {
+	PrNumber int           `json:"pr_number"`
+	Changes  []FieldChange `json:"changes"`
+}
+
+// FieldChange is the change of a single ReleaseNote field. Scalar fields use
+// `Old` and `New`, while list fields use `Added` and `Removed`.
+type FieldChange struct {
+	Field   string   `json:"field"`
+	Old     string   `json:"old,omitempty"`
+	New     string   `json:"new,omitempty"`
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// ReadReleaseNotesJSON reads release notes from a JSON file as written by
+// `release-notes generate --format=json`.
+func ReadReleaseNotesJSON(path string) (*ReleaseNotes, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read release notes: %w", err)
+	}
+
+	byPR := ReleaseNotesByPR{}
+	if err := json.Unmarshal(content, &byPR); err != nil {
+		return nil, fmt.Errorf("unmarshal release notes from %s: %w", path, err)
+	}
+
+	prs := []int{}
+	for pr := range byPR {
+		prs = append(prs, pr)
+	}
+	sort.Ints(prs)
+
+	releaseNotes := NewReleaseNotes()
+	for _, pr := range prs {
+		releaseNotes.Set(pr, byPR[pr])
+	}
+	return releaseNotes, nil
+}
+
+// DiffReleaseNotes compares two sets of release notes by their PR number.
+func DiffReleaseNotes(oldNotes, newNotes *ReleaseNotes) *NotesDiff {
+	diff := &NotesDiff{
+		Added:    []*ReleaseNote{},
+		Removed:  []*ReleaseNote{},
+		Modified: []NoteModification{},
+	}
+
+	for _, pr := range sortedPRs(oldNotes) {
+		oldNote := oldNotes.Get(pr)
+		newNote := newNotes.Get(pr)
+		if newNote == nil {
+			diff.Removed = append(diff.Removed, oldNote)
+			continue
+		}
+
+		if changes := diffReleaseNote(oldNote, newNote); len(changes) > 0 {
+			diff.Modified = append(diff.Modified, NoteModification{
+				PrNumber: pr, Changes: changes,
+			})
+		}
+	}
+
+	for _, pr := range sortedPRs(newNotes) {
+		if oldNotes.Get(pr) == nil {
+			diff.Added = append(diff.Added, newNotes.Get(pr))
+		}
+	}
+
+	return diff
+}
+
+// Empty returns true if there are no differences.
+func (d *NotesDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// Text returns a plain text representation of the diff.
+func (d *NotesDiff) Text() string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb,
+		"%d added, %d removed, %d modified\n",
+		len(d.Added), len(d.Removed), len(d.Modified),
+	)
+	for _, note := range d.Added {
+		fmt.Fprintf(sb, "\n+ #%d: %s\n", note.PrNumber, note.Text)
+	}
+	for _, note := range d.Removed {
+		fmt.Fprintf(sb, "\n- #%d: %s\n", note.PrNumber, note.Text)
+	}
+	for _, modification := range d.Modified {
+		fmt.Fprintf(sb, "\n~ #%d\n", modification.PrNumber)
+		for _, change := range modification.Changes {
+			fmt.Fprintf(sb, "    %s: %s\n", change.Field, change.String())
+		}
+	}
+	return sb.String()
+}
+
+// Markdown returns a markdown representation of the diff.
+func (d *NotesDiff) Markdown() string {
+	sb := &strings.Builder{}
+	sb.WriteString("# Release Notes Diff\n\n")
+	fmt.Fprintf(sb,
+		"%d added, %d removed, %d modified\n",
+		len(d.Added), len(d.Removed), len(d.Modified),
+	)
+
+	if len(d.Added) > 0 {
+		sb.WriteString("\n## Added\n\n")
+		for _, note := range d.Added {
+			fmt.Fprintf(sb, "- #%d: %s\n", note.PrNumber, indentMarkdown(note.Text))
+		}
+	}
+
+	if len(d.Removed) > 0 {
+		sb.WriteString("\n## Removed\n\n")
+		for _, note := range d.Removed {
+			fmt.Fprintf(sb, "- #%d: %s\n", note.PrNumber, indentMarkdown(note.Text))
+		}
+	}
+
+	if len(d.Modified) > 0 {
+		sb.WriteString("\n## Modified\n")
+		for _, modification := range d.Modified {
+			fmt.Fprintf(sb, "\n### #%d\n\n", modification.PrNumber)
+			sb.WriteString("field | change\n----- | ------\n")
+			for _, change := range modification.Changes {
+				fmt.Fprintf(sb, "%s | %s\n",
+					change.Field,
+					strings.ReplaceAll(strings.ReplaceAll(change.String(), "|", `\|`), "\n", " "),
+				)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// JSON returns the indented JSON representation of the diff.
+func (d *NotesDiff) JSON() (string, error) {
+	content, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal notes diff: %w", err)
+	}
+	return string(content), nil
+}
+
+// String returns a short human readable representation of the change.
+func (c *FieldChange) String() string {
+	if len(c.Added) > 0 || len(c.Removed) > 0 {
+		parts := []string{}
+		for _, value := range c.Added {
+			parts = append(parts, "+"+value)
+		}
+		for _, value := range c.Removed {
+			parts = append(parts, "-"+value)
+		}
+		return strings.Join(parts, ", ")
+	}
+	return fmt.Sprintf("%q -> %q", c.Old, c.New)
+}
+
+func diffReleaseNote(oldNote, newNote *ReleaseNote) []FieldChange {
+	changes := []FieldChange{}
+	if oldNote.Text != newNote.Text {
+		changes = append(changes, FieldChange{
+			Field: DiffFieldText, Old: oldNote.Text, New: newNote.Text,
+		})
+	}
+
+	for _, field := range []struct {
+		name     string
+		old, new []string
+	}{
+		{DiffFieldKinds, oldNote.Kinds, newNote.Kinds},
+		{DiffFieldSIGs, oldNote.SIGs, newNote.SIGs},
+		{DiffFieldAreas, oldNote.Areas, newNote.Areas},
+	} {
+		added, removed := diffStrings(field.old, field.new)
+		if len(added) > 0 || len(removed) > 0 {
+			changes = append(changes, FieldChange{
+				Field: field.name, Added: added, Removed: removed,
+			})
+		}
+	}
+
+	for _, field := range []struct {
+		name     string
+		old, new bool
+	}{
+		{DiffFieldActionRequired, oldNote.ActionRequired, newNote.ActionRequired},
+		{DiffFieldDoNotPublish, oldNote.DoNotPublish, newNote.DoNotPublish},
+	} {
+		if field.old != field.new {
+			changes = append(changes, FieldChange{
+				Field: field.name,
+				Old:   strconv.FormatBool(field.old),
+				New:   strconv.FormatBool(field.new),
+			})
+		}
+	}
+	return changes
+}
+
+// diffStrings returns the sorted values which have been added to or removed
+// from `oldValues`.
+func diffStrings(oldValues, newValues []string) (added, removed []string) {
+	oldSet := map[string]bool{}
+	for _, value := range oldValues {
+		oldSet[value] = true
+	}
+	newSet := map[string]bool{}
+	for _, value := range newValues {
+		newSet[value] = true
+		if !oldSet[value] {
+			added = append(added, value)
+		}
+	}
+	for _, value := range oldValues {
+		if !newSet[value] {
+			removed = append(removed, value)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+func sortedPRs(releaseNotes *ReleaseNotes) []int {
+	prs := []int{}
+	for pr := range releaseNotes.ByPR() {
+		prs = append(prs, pr)
+	}
+	sort.Ints(prs)
+	return prs
+}
+
+func indentMarkdown(text string) string {
+	return strings.ReplaceAll(text, "\n", "\n  ")
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func releaseNotesFrom(notes ...*ReleaseNote) *ReleaseNotes {
+	releaseNotes := NewReleaseNotes()
+	for _, note := range notes {
+		releaseNotes.Set(note.PrNumber, note)
+	}
+	return releaseNotes
+}
+
+func TestDiffReleaseNotes(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		old, new *ReleaseNotes
+		expected *NotesDiff
+	}{
+		{
+			name:     "no changes",
+			old:      releaseNotesFrom(&ReleaseNote{PrNumber: 1, Text: "a", SIGs: []string{"node"}}),
+			new:      releaseNotesFrom(&ReleaseNote{PrNumber: 1, Text: "a", SIGs: []string{"node"}}),
+			expected: &NotesDiff{Added: []*ReleaseNote{}, Removed: []*ReleaseNote{}, Modified: []NoteModification{}},
+		},
+		{
+			name: "added and removed",
+			old:  releaseNotesFrom(&ReleaseNote{PrNumber: 1, Text: "a"}),
+			new:  releaseNotesFrom(&ReleaseNote{PrNumber: 2, Text: "b"}),
+			expected: &NotesDiff{
+				Added:    []*ReleaseNote{{PrNumber: 2, Text: "b"}},
+				Removed:  []*ReleaseNote{{PrNumber: 1, Text: "a"}},
+				Modified: []NoteModification{},
+			},
+		},
+		{
+			name: "modified fields",
+			old: releaseNotesFrom(&ReleaseNote{
+				PrNumber: 1,
+				Text:     "a",
+				Kinds:    []string{"bug"},
+				SIGs:     []string{"node", "apps"},
+				Areas:    []string{"kubelet"},
+			}),
+			new: releaseNotesFrom(&ReleaseNote{
+				PrNumber:       1,
+				Text:           "b",
+				Kinds:          []string{"bug"},
+				SIGs:           []string{"node", "storage"},
+				ActionRequired: true,
+			}),
+			expected: &NotesDiff{
+				Added:   []*ReleaseNote{},
+				Removed: []*ReleaseNote{},
+				Modified: []NoteModification{{
+					PrNumber: 1,
+					Changes: []FieldChange{
+						{Field: DiffFieldText, Old: "a", New: "b"},
+						{Field: DiffFieldSIGs, Added: []string{"storage"}, Removed: []string{"apps"}},
+						{Field: DiffFieldAreas, Removed: []string{"kubelet"}},
+						{Field: DiffFieldActionRequired, Old: "false", New: "true"},
+					},
+				}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			diff := DiffReleaseNotes(tc.old, tc.new)
+			require.Equal(t, tc.expected, diff)
+			require.Equal(t, len(tc.expected.Added)+len(tc.expected.Removed)+len(tc.expected.Modified) == 0, diff.Empty())
+		})
+	}
+}
+
+func TestNotesDiffOutput(t *testing.T) {
+	diff := DiffReleaseNotes(
+		releaseNotesFrom(
+			&ReleaseNote{PrNumber: 1, Text: "removed"},
+			&ReleaseNote{PrNumber: 2, Text: "a | b", SIGs: []string{"node"}},
+		),
+		releaseNotesFrom(
+			&ReleaseNote{PrNumber: 2, Text: "a | c", SIGs: []string{"apps"}},
+			&ReleaseNote{PrNumber: 3, Text: "added"},
+		),
+	)
+
+	require.Equal(t, `1 added, 1 removed, 1 modified
+
++ #3: added
+
+- #1: removed
+
+~ #2
+    text: "a | b" -> "a | c"
+    sigs: +apps, -node
+`, diff.Text())
+
+	require.Equal(t, `# Release Notes Diff
+
+1 added, 1 removed, 1 modified
+
+## Added
+
+- #3: added
+
+## Removed
+
+- #1: removed
+
+## Modified
+
+### #2
+
+field | change
+----- | ------
+text | "a \| b" -> "a \| c"
+sigs | +apps, -node
+`, diff.Markdown())
+
+	res, err := diff.JSON()
+	require.NoError(t, err)
+	decoded := &NotesDiff{}
+	require.NoError(t, json.Unmarshal([]byte(res), decoded))
+	require.Equal(t, diff, decoded)
+}
+
+func TestReadReleaseNotesJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.json")
+
+	_, err := ReadReleaseNotesJSON(path)
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("invalid"), 0o600))
+	_, err = ReadReleaseNotesJSON(path)
+	require.Error(t, err)
+
+	content, err := json.Marshal(ReleaseNotesByPR{
+		2: {PrNumber: 2, Text: "b"},
+		1: {PrNumber: 1, Text: "a"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+
+	releaseNotes, err := ReadReleaseNotesJSON(path)
+	require.NoError(t, err)
+	require.Equal(t, ReleaseNotesHistory{1, 2}, releaseNotes.History())
+	require.Equal(t, "a", releaseNotes.Get(1).Text)
+	require.Equal(t, "b", releaseNotes.Get(2).Text)
+}
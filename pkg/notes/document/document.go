@@ -359,26 +359,16 @@ func New(
 // `templateSpec`. If `templateSpec` is set to `options.GoTemplateDefault`,
 // then it renders in the default template markdown format.
 func (d *Document) RenderMarkdownTemplate(bucket, tars, images, templateSpec string) (string, error) {
-	urlPrefix := release.URLPrefixForBucket(bucket)
-
-	fileMetadata, err := fetchFileMetadata(tars, urlPrefix, d.CurrentRevision)
-	if err != nil {
-		return "", fmt.Errorf("fetching file downloads metadata: %w", err)
-	}
-	d.FileDownloads = fileMetadata
-
-	imageMetadata, err := fetchImageMetadata(images, d.CurrentRevision)
-	if err != nil {
-		return "", fmt.Errorf("fetching image downloads metadata: %w", err)
+	if err := d.fetchDownloadsMetadata(bucket, tars, images); err != nil {
+		return "", err
 	}
-	d.ImageDownloads = imageMetadata
 
 	goTemplate, err := d.template(templateSpec)
 	if err != nil {
 		return "", fmt.Errorf("fetching template: %w", err)
 	}
 	tmpl, err := template.New("markdown").
-		Funcs(template.FuncMap{"prettyKind": prettyKind}).
+		Funcs(template.FuncMap{"prettyKind": PrettyKind}).
 		Parse(goTemplate)
 	if err != nil {
 		return "", fmt.Errorf("parsing template: %w", err)
@@ -391,6 +381,36 @@ func (d *Document) RenderMarkdownTemplate(bucket, tars, images, templateSpec str
 	return strings.TrimSpace(s.String()), nil
 }
 
+// fetchDownloadsMetadata populates the file and image downloads of the
+// document.
+func (d *Document) fetchDownloadsMetadata(bucket, tars, images string) error {
+	fileMetadata, imageMetadata, err := FetchDownloads(bucket, tars, images, d.CurrentRevision)
+	if err != nil {
+		return err
+	}
+	d.FileDownloads = fileMetadata
+	d.ImageDownloads = imageMetadata
+	return nil
+}
+
+// FetchDownloads collects the file and image downloads of the release tag
+// from the local `tars` and `images` directories. Both results are nil if the
+// directories are not given or do not contain any release artifacts.
+func FetchDownloads(bucket, tars, images, tag string) (*FileMetadata, *ImageMetadata, error) {
+	urlPrefix := release.URLPrefixForBucket(bucket)
+
+	fileMetadata, err := fetchFileMetadata(tars, urlPrefix, tag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetching file downloads metadata: %w", err)
+	}
+
+	imageMetadata, err := fetchImageMetadata(images, tag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetching image downloads metadata: %w", err)
+	}
+	return fileMetadata, imageMetadata, nil
+}
+
 // template returns either the default template, a template from file or an
 // inline string template. The `templateSpec` must be in the format of
 // `go-template:{default|path/to/template.ext}` or
@@ -532,7 +552,7 @@ func mapKind(kind notes.Kind) notes.Kind {
 	return kind
 }
 
-func prettyKind(kind notes.Kind) string {
+func PrettyKind(kind notes.Kind) string {
 	switch kind {
 	case notes.KindAPIChange:
 		return "API Change"
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package document
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8s.io/release/pkg/notes"
+)
+
+// SplitBySIG is the only currently supported mode to split release notes
+// into multiple documents.
+const SplitBySIG = "sig"
+
+// NoSIG is the key of the release notes which do not belong to any SIG when
+// splitting them by SIG.
+const NoSIG = "none"
+
+// Filter restricts the release notes of a document to a component. Every
+// non-empty criterion has to match a note, whereas a single matching value
+// within a criterion is sufficient.
+type Filter struct {
+	// SIGs restricts the notes to the ones of the provided SIGs, for example
+	// "node" or "sig/node".
+	SIGs []string
+
+	// Areas restricts the notes to the ones of the provided areas, for
+	// example "kubeadm" or "area/kubeadm".
+	Areas []string
+
+	// Kinds restricts the notes to the ones of the provided kinds, for
+	// example "bug" or "kind/bug".
+	Kinds []string
+
+	// PathPrefixes restricts the notes to the ones of PRs touching at least
+	// one file below the provided prefixes, for example "staging/src/k8s.io/client-go".
+	// Requires the files of the notes to be recorded.
+	PathPrefixes []string
+}
+
+// IsEmpty returns true if the filter does not restrict the notes at all.
+func (f *Filter) IsEmpty() bool {
+	return f == nil ||
+		(len(f.SIGs) == 0 && len(f.Areas) == 0 && len(f.Kinds) == 0 && len(f.PathPrefixes) == 0)
+}
+
+// Matches returns true if the note fulfills all criteria of the filter.
+func (f *Filter) Matches(note *notes.ReleaseNote) bool {
+	if f.IsEmpty() {
+		return true
+	}
+
+	return matchesLabels(f.SIGs, note.SIGs, "sig") &&
+		matchesLabels(f.Areas, note.Areas, "area") &&
+		matchesLabels(f.Kinds, note.Kinds, "kind") &&
+		matchesPaths(f.PathPrefixes, note.Files)
+}
+
+// Apply returns the release notes which match the filter, while preserving
+// their history order.
+func (f *Filter) Apply(releaseNotes *notes.ReleaseNotes) *notes.ReleaseNotes {
+	if f.IsEmpty() {
+		return releaseNotes
+	}
+
+	res := notes.NewReleaseNotes()
+	for _, pr := range releaseNotes.History() {
+		if note := releaseNotes.Get(pr); f.Matches(note) {
+			res.Set(pr, note)
+		}
+	}
+	return res
+}
+
+// NewFiltered assembles an organized document from the release notes which
+// match the provided filter.
+func NewFiltered(
+	releaseNotes *notes.ReleaseNotes, filter *Filter,
+	previousRev, currentRev string,
+) (*Document, error) {
+	return New(filter.Apply(releaseNotes), previousRev, currentRev)
+}
+
+// Split groups the release notes by the provided mode. Notes belonging to
+// multiple groups are part of each of them, whereas notes which do not
+// belong to any group end up in the NoSIG group.
+func Split(releaseNotes *notes.ReleaseNotes, mode string) (map[string]*notes.ReleaseNotes, error) {
+	if mode != SplitBySIG {
+		return nil, fmt.Errorf("unsupported split mode %q, supported is only %q", mode, SplitBySIG)
+	}
+
+	res := map[string]*notes.ReleaseNotes{}
+	add := func(key string, pr int, note *notes.ReleaseNote) {
+		if _, ok := res[key]; !ok {
+			res[key] = notes.NewReleaseNotes()
+		}
+		res[key].Set(pr, note)
+	}
+
+	for _, pr := range releaseNotes.History() {
+		note := releaseNotes.Get(pr)
+
+		sigs := []string{}
+		for _, sig := range note.SIGs {
+			sig = strings.TrimPrefix(sig, "sig/")
+			if !slices.Contains(sigs, sig) {
+				sigs = append(sigs, sig)
+			}
+		}
+		if len(sigs) == 0 {
+			sigs = append(sigs, NoSIG)
+		}
+
+		for _, sig := range sigs {
+			add(sig, pr, note)
+		}
+	}
+	return res, nil
+}
+
+// SplitKeys returns the sorted keys of split release notes.
+func SplitKeys(split map[string]*notes.ReleaseNotes) []string {
+	keys := make([]string, 0, len(split))
+	for key := range split {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func matchesLabels(wanted, labels []string, prefix string) bool {
+	if len(wanted) == 0 {
+		return true
+	}
+
+	for _, w := range wanted {
+		w = strings.TrimPrefix(w, prefix+"/")
+		for _, label := range labels {
+			if strings.TrimPrefix(label, prefix+"/") == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchesPaths(prefixes, files []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+
+	for _, prefix := range prefixes {
+		prefix = strings.TrimPrefix(strings.TrimPrefix(prefix, "./"), "/")
+		for _, file := range files {
+			if strings.HasPrefix(file, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package document
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/notes"
+)
+
+func filterTestNotes() *notes.ReleaseNotes {
+	n := notes.NewReleaseNotes()
+	n.Set(1, &notes.ReleaseNote{
+		PrNumber: 1,
+		Markdown: "kubeadm bug fix",
+		SIGs:     []string{"cluster-lifecycle"},
+		Areas:    []string{"kubeadm"},
+		Kinds:    []string{"bug"},
+		Files:    []string{"cmd/kubeadm/app/cmd/init.go"},
+	})
+	n.Set(2, &notes.ReleaseNote{
+		PrNumber: 2,
+		Markdown: "client-go feature",
+		SIGs:     []string{"api-machinery", "cli"},
+		Kinds:    []string{"feature"},
+		Files:    []string{"staging/src/k8s.io/client-go/rest/client.go"},
+	})
+	n.Set(3, &notes.ReleaseNote{
+		PrNumber: 3,
+		Markdown: "kubectl cleanup",
+		SIGs:     []string{"cli"},
+		Areas:    []string{"kubectl"},
+		Kinds:    []string{"cleanup"},
+	})
+	n.Set(4, &notes.ReleaseNote{
+		PrNumber: 4,
+		Markdown: "unowned change",
+	})
+	return n
+}
+
+func TestFilterApply(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		filter   *Filter
+		expected []int
+	}{
+		{
+			name:     "nil filter",
+			filter:   nil,
+			expected: []int{1, 2, 3, 4},
+		},
+		{
+			name:     "empty filter",
+			filter:   &Filter{},
+			expected: []int{1, 2, 3, 4},
+		},
+		{
+			name:     "by SIG",
+			filter:   &Filter{SIGs: []string{"cli"}},
+			expected: []int{2, 3},
+		},
+		{
+			name:     "by SIG label with prefix",
+			filter:   &Filter{SIGs: []string{"sig/cluster-lifecycle"}},
+			expected: []int{1},
+		},
+		{
+			name:     "by area",
+			filter:   &Filter{Areas: []string{"kubectl", "area/kubeadm"}},
+			expected: []int{1, 3},
+		},
+		{
+			name:     "by kind",
+			filter:   &Filter{Kinds: []string{"kind/feature"}},
+			expected: []int{2},
+		},
+		{
+			name:     "by path prefix",
+			filter:   &Filter{PathPrefixes: []string{"./staging/src/k8s.io/client-go"}},
+			expected: []int{2},
+		},
+		{
+			name:     "combined criteria",
+			filter:   &Filter{SIGs: []string{"cli"}, Kinds: []string{"cleanup"}},
+			expected: []int{3},
+		},
+		{
+			name:     "no match",
+			filter:   &Filter{SIGs: []string{"node"}},
+			expected: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res := tc.filter.Apply(filterTestNotes())
+			require.Equal(t, notes.ReleaseNotesHistory(tc.expected), res.History())
+		})
+	}
+}
+
+func TestNewFiltered(t *testing.T) {
+	doc, err := NewFiltered(filterTestNotes(), &Filter{SIGs: []string{"cli"}}, "v1.0.0", "v1.1.0")
+	require.NoError(t, err)
+	require.Len(t, doc.Notes, 2)
+	require.Equal(t, "v1.1.0", doc.CurrentRevision)
+}
+
+func TestSplit(t *testing.T) {
+	split, err := Split(filterTestNotes(), SplitBySIG)
+	require.NoError(t, err)
+	require.Equal(t, []string{"api-machinery", "cli", "cluster-lifecycle", NoSIG}, SplitKeys(split))
+	require.Equal(t, notes.ReleaseNotesHistory{2, 3}, split["cli"].History())
+	require.Equal(t, notes.ReleaseNotesHistory{2}, split["api-machinery"].History())
+	require.Equal(t, notes.ReleaseNotesHistory{4}, split[NoSIG].History())
+
+	_, err = Split(filterTestNotes(), "area")
+	require.Error(t, err)
+}
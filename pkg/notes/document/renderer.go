@@ -0,0 +1,425 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package document
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/options"
+)
+
+// Renderer renders a release notes document into a specific output format.
+type Renderer interface {
+	// Render returns the rendered document.
+	Render(doc *Document) (string, error)
+
+	// FileExtension returns the file extension of the output format,
+	// including the leading dot.
+	FileExtension() string
+}
+
+var (
+	renderersMu sync.RWMutex
+	renderers   = map[string]Renderer{
+		options.FormatAsciiDoc: &templateRenderer{
+			name:      options.FormatAsciiDoc,
+			template:  asciiDocTemplate,
+			extension: ".adoc",
+			convert:   markdownToAsciiDoc,
+		},
+		options.FormatRST: &templateRenderer{
+			name:      options.FormatRST,
+			template:  rstTemplate,
+			extension: ".rst",
+			convert:   markdownToRST,
+		},
+		options.FormatKeepAChangelog: &templateRenderer{
+			name:      options.FormatKeepAChangelog,
+			template:  keepAChangelogTemplate,
+			extension: ".md",
+			convert:   func(s string) string { return s },
+		},
+	}
+
+	// now is used to date the rendered documents.
+	now = time.Now
+)
+
+func init() {
+	for format := range renderers {
+		options.RegisterFormat(format)
+	}
+}
+
+// RegisterRenderer registers a renderer for the provided format, which
+// replaces any existing renderer of the same format. The format becomes a
+// valid release notes output format.
+func RegisterRenderer(format string, renderer Renderer) {
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	renderers[format] = renderer
+	options.RegisterFormat(format)
+}
+
+// RendererFor returns the renderer of the provided format.
+func RendererFor(format string) (Renderer, error) {
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+	renderer, ok := renderers[format]
+	if !ok {
+		return nil, fmt.Errorf("no renderer registered for format %q", format)
+	}
+	return renderer, nil
+}
+
+// Formats returns the sorted list of formats which have a renderer.
+func Formats() []string {
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+	formats := []string{}
+	for format := range renderers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Render renders the document into the provided format by using its
+// registered renderer. The downloads metadata is populated from the `tars`
+// and `images` directories.
+func (d *Document) Render(format, bucket, tars, images string) (string, error) {
+	renderer, err := RendererFor(format)
+	if err != nil {
+		return "", err
+	}
+
+	if err := d.fetchDownloadsMetadata(bucket, tars, images); err != nil {
+		return "", err
+	}
+
+	res, err := renderer.Render(d)
+	if err != nil {
+		return "", fmt.Errorf("rendering document as %s: %w", format, err)
+	}
+	return res, nil
+}
+
+// templateRenderer is a Renderer based on a go template.
+type templateRenderer struct {
+	name      string
+	template  string
+	extension string
+
+	// convert translates the markdown of a single note into the output
+	// format.
+	convert func(string) string
+}
+
+func (r *templateRenderer) Render(doc *Document) (string, error) {
+	tmpl, err := template.New(r.name).Funcs(template.FuncMap{
+		"prettyKind":      PrettyKind,
+		"convert":         r.convert,
+		"fileSections":    fileSections,
+		"underline":       underline,
+		"join":            strings.Join,
+		"date":            func() string { return now().Format(time.DateOnly) },
+		"changelogGroups": changelogGroups,
+	}).Parse(r.template)
+	if err != nil {
+		return "", fmt.Errorf("parsing template: %w", err)
+	}
+
+	var s strings.Builder
+	if err := tmpl.Execute(&s, doc); err != nil {
+		return "", fmt.Errorf("rendering with template: %w", err)
+	}
+	res := blankLinesRE.ReplaceAllString(s.String(), "\n\n")
+	return strings.TrimSpace(res) + "\n", nil
+}
+
+func (r *templateRenderer) FileExtension() string {
+	return r.extension
+}
+
+// fileSection is a titled list of downloadable files.
+type fileSection struct {
+	Name  string
+	Files []File
+}
+
+// fileSections returns all non empty sections of the file metadata.
+func fileSections(m *FileMetadata) []fileSection {
+	if m == nil {
+		return nil
+	}
+	sections := []fileSection{}
+	for _, section := range []fileSection{
+		{Name: "Source Code", Files: m.Source},
+		{Name: "Client Binaries", Files: m.Client},
+		{Name: "Server Binaries", Files: m.Server},
+		{Name: "Node Binaries", Files: m.Node},
+	} {
+		if len(section.Files) > 0 {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// underline returns the text followed by a line of `char` with the same
+// length, which is used for reStructuredText section titles.
+func underline(char, text string) string {
+	return text + "\n" + strings.Repeat(char, len(text))
+}
+
+// changelogGroup is a Keep-a-Changelog section with its entries.
+type changelogGroup struct {
+	Name    string
+	Entries []string
+}
+
+// Keep-a-Changelog section names.
+const (
+	changelogAdded      = "Added"
+	changelogChanged    = "Changed"
+	changelogDeprecated = "Deprecated"
+	changelogFixed      = "Fixed"
+	changelogSecurity   = "Security"
+)
+
+// changelogSectionForKind maps the note kinds to Keep-a-Changelog sections.
+// Unlisted kinds belong to the `Changed` section.
+var changelogSectionForKind = map[notes.Kind]string{
+	notes.KindFeature:     changelogAdded,
+	notes.KindDeprecation: changelogDeprecated,
+	notes.KindBug:         changelogFixed,
+	notes.KindRegression:  changelogFixed,
+}
+
+// changelogGroups groups the notes of the document into the Keep-a-Changelog
+// sections in their conventional order, omitting empty ones.
+func changelogGroups(doc *Document) []changelogGroup {
+	entries := map[string][]string{}
+
+	for _, note := range doc.NotesWithActionRequired {
+		entries[changelogChanged] = append(
+			entries[changelogChanged], "**Action required:** "+note,
+		)
+	}
+
+	for _, category := range doc.Notes {
+		section, ok := changelogSectionForKind[category.Kind]
+		if !ok {
+			section = changelogChanged
+		}
+		if category.NoteEntries != nil {
+			entries[section] = append(entries[section], *category.NoteEntries...)
+		}
+	}
+
+	for i := range doc.CVEList {
+		cve := &doc.CVEList[i]
+		entries[changelogSecurity] = append(entries[changelogSecurity], fmt.Sprintf(
+			"%s: %s (CVSS %s %.1f)", cve.ID, cve.Title, cve.CVSSRating, cve.CVSSScore,
+		))
+	}
+
+	groups := []changelogGroup{}
+	for _, section := range []string{
+		changelogAdded,
+		changelogChanged,
+		changelogDeprecated,
+		changelogFixed,
+		changelogSecurity,
+	} {
+		if len(entries[section]) > 0 {
+			groups = append(groups, changelogGroup{Name: section, Entries: entries[section]})
+		}
+	}
+	return groups
+}
+
+var (
+	blankLinesRE   = regexp.MustCompile(`\n{3,}`)
+	markdownLinkRE = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	markdownCodeRE = regexp.MustCompile("`([^`]+)`")
+	markdownBoldRE = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+)
+
+// markdownToAsciiDoc converts the inline markdown of a note to AsciiDoc.
+func markdownToAsciiDoc(s string) string {
+	s = markdownBoldRE.ReplaceAllString(s, "*$1*")
+	s = markdownLinkRE.ReplaceAllString(s, "link:$2[$1]")
+
+	// Indented lines would be rendered as literal paragraphs and paragraphs
+	// within list items require an explicit continuation.
+	lines := strings.Split(s, "\n")
+	for i := 1; i < len(lines); i++ {
+		lines[i] = strings.TrimPrefix(lines[i], "  ")
+		if strings.TrimSpace(lines[i]) == "" {
+			lines[i] = "+"
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// markdownToRST converts the inline markdown of a note to reStructuredText.
+func markdownToRST(s string) string {
+	s = markdownCodeRE.ReplaceAllString(s, "``$1``")
+	return markdownLinkRE.ReplaceAllString(s, "`$1 <$2>`__")
+}
+
+const asciiDocTemplate = `
+{{- $CurrentRevision := .CurrentRevision -}}
+{{- if or .FileDownloads .ImageDownloads }}
+== Downloads for {{$CurrentRevision}}
+{{range fileSections .FileDownloads}}
+=== {{.Name}}
+
+[options="header"]
+|===
+| filename | sha512 hash
+{{range .Files}}| link:{{.URL}}[{{.Name}}] | {{.Checksum}}
+{{end}}|===
+{{end}}
+{{- with .ImageDownloads}}
+=== Container Images
+
+All container images are available as manifest lists and support the described
+architectures. It is also possible to pull a specific architecture directly by
+adding the "-$ARCH" suffix  to the container image name.
+
+[options="header"]
+|===
+| name | architectures
+{{range .}}| {{.Name}} | {{join .Architectures ", "}}
+{{end}}|===
+{{end}}
+{{end}}
+{{- with .CVEList}}
+== Important Security Information
+
+This release contains changes that address the following vulnerabilities:
+{{range .}}
+=== {{.ID}}: {{.Title}}
+
+{{.Description}}
+
+*CVSS Rating:* {{.CVSSRating}} ({{.CVSSScore}}) link:{{.CalcLink}}[{{.CVSSVector}}]
+{{- if .TrackingIssue}} +
+*Tracking Issue:* {{.TrackingIssue}}
+{{- end}}
+{{end}}
+{{end}}
+{{- with .NotesWithActionRequired}}
+== Urgent Upgrade Notes
+
+=== (No, really, you MUST read this before you upgrade)
+
+{{range .}}* {{convert .}}
+{{end}}
+{{end}}
+{{- if .Notes}}
+== Changes by Kind
+{{range .Notes}}
+=== {{.Kind | prettyKind}}
+
+{{range .NoteEntries}}* {{convert .}}
+{{end}}{{end}}
+{{- end}}
+`
+
+const rstTemplate = `
+{{- $CurrentRevision := .CurrentRevision -}}
+{{- if or .FileDownloads .ImageDownloads }}
+{{underline "=" (printf "Downloads for %s" $CurrentRevision)}}
+{{range fileSections .FileDownloads}}
+{{underline "-" .Name}}
+
+.. list-table::
+   :header-rows: 1
+
+   * - filename
+     - sha512 hash
+{{range .Files}}   * - ` + "`" + `{{.Name}} <{{.URL}}>` + "`" + `__
+     - {{.Checksum}}
+{{end}}{{end}}
+{{- with .ImageDownloads}}
+{{underline "-" "Container Images"}}
+
+All container images are available as manifest lists and support the described
+architectures. It is also possible to pull a specific architecture directly by
+adding the "-$ARCH" suffix  to the container image name.
+
+.. list-table::
+   :header-rows: 1
+
+   * - name
+     - architectures
+{{range .}}   * - {{.Name}}
+     - {{join .Architectures ", "}}
+{{end}}{{end}}
+{{end}}
+{{- with .CVEList}}
+{{underline "=" "Important Security Information"}}
+
+This release contains changes that address the following vulnerabilities:
+{{range .}}
+{{underline "-" (printf "%s: %s" .ID .Title)}}
+
+{{.Description}}
+
+**CVSS Rating:** {{.CVSSRating}} ({{.CVSSScore}}) ` + "`" + `{{.CVSSVector}} <{{.CalcLink}}>` + "`" + `__
+{{- if .TrackingIssue}}
+
+**Tracking Issue:** {{.TrackingIssue}}
+{{- end}}
+{{end}}
+{{end}}
+{{- with .NotesWithActionRequired}}
+{{underline "=" "Urgent Upgrade Notes"}}
+
+{{underline "-" "(No, really, you MUST read this before you upgrade)"}}
+
+{{range .}}- {{convert .}}
+{{end}}
+{{end}}
+{{- if .Notes}}
+{{underline "=" "Changes by Kind"}}
+{{range .Notes}}
+{{underline "-" (prettyKind .Kind)}}
+
+{{range .NoteEntries}}- {{convert .}}
+{{end}}{{end}}
+{{- end}}
+`
+
+const keepAChangelogTemplate = `
+## [{{.CurrentRevision}}] - {{date}}
+{{range changelogGroups .}}
+### {{.Name}}
+
+{{range .Entries}}- {{convert .}}
+{{end}}{{end}}
+`
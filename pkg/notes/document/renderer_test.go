@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package document
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/cve"
+	"k8s.io/release/pkg/notes"
+	"k8s.io/release/pkg/notes/options"
+	"k8s.io/release/pkg/release"
+)
+
+func TestDocument_Render(t *testing.T) {
+	now = func() time.Time { return time.Date(2024, 4, 17, 0, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	testNotes := notes.NewReleaseNotes()
+	testNotes.Set(0, makeReleaseNote(notes.KindDeprecation, "Deprecation #1."))
+	testNotes.Set(1, makeReleaseNote(notes.KindBug, "Fixed `kubectl` output ([#1](https://github.com/kubernetes/kubernetes/pull/1), [@user](https://github.com/user))"))
+	testNotes.Set(2, makeReleaseNote(notes.KindCleanup, "Clean up."))
+	testNotes.Set(3, makeReleaseNote(notes.KindFeature, "A feature.\n  \n  With a **second** paragraph."))
+	testNotes.Set(4, makeReleaseNote("", "Uncategorized note."))
+
+	actionNeeded := makeReleaseNote(notes.KindAPIChange, "Action required note.")
+	actionNeeded.ActionRequired = true
+	testNotes.Set(5, actionNeeded)
+
+	for _, tc := range []struct {
+		format         string
+		wantGoldenFile string
+	}{
+		{options.FormatAsciiDoc, "document.adoc.golden"},
+		{options.FormatRST, "document.rst.golden"},
+		{options.FormatKeepAChangelog, "document_keepachangelog.md.golden"},
+	} {
+		t.Run(tc.format, func(t *testing.T) {
+			doc, err := New(testNotes, "v1.29.0", "v1.30.0")
+			require.NoError(t, err)
+			doc.CVEList = []cve.CVE{{
+				ID:          "CVE-2024-0001",
+				Title:       "Vulnerability",
+				Description: "Description of the vulnerability.",
+				CVSSVector:  "CVSS:3.1/AV:N/AC:H/PR:H/UI:R/S:U/C:H/I:H/A:H",
+				CVSSScore:   6.2,
+				CVSSRating:  "Medium",
+				CalcLink:    "https://www.first.org/cvss/calculator/3.1",
+			}}
+
+			got, err := doc.Render(tc.format, release.ProductionBucket, "", "")
+			require.NoError(t, err)
+			expected := readFile(t, filepath.Join("testdata", tc.wantGoldenFile))
+			require.Equal(t, expected+"\n", got)
+		})
+	}
+}
+
+func TestRendererFor(t *testing.T) {
+	for _, format := range []string{
+		options.FormatAsciiDoc, options.FormatRST, options.FormatKeepAChangelog,
+	} {
+		renderer, err := RendererFor(format)
+		require.NoError(t, err)
+		require.NotEmpty(t, renderer.FileExtension())
+		require.Contains(t, Formats(), format)
+		require.True(t, options.IsValidFormat(format))
+	}
+
+	_, err := RendererFor("invalid")
+	require.Error(t, err)
+
+	doc, err := New(notes.NewReleaseNotes(), "v1.29.0", "v1.30.0")
+	require.NoError(t, err)
+	_, err = doc.Render("invalid", "", "", "")
+	require.Error(t, err)
+}
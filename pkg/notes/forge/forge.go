@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	gogithub "github.com/google/go-github/v60/github"
+)
+
+const (
+	// GitHub is the default forge, served by the release-sdk GitHub client.
+	GitHub = "github"
+
+	// GitLab uses merge requests of a GitLab instance.
+	GitLab = "gitlab"
+
+	// Gitea uses pull requests of a Gitea (or Forgejo) instance.
+	Gitea = "gitea"
+
+	// GitLabTokenEnvKey is the environment variable containing the GitLab
+	// access token.
+	GitLabTokenEnvKey = "GITLAB_TOKEN"
+
+	// GiteaTokenEnvKey is the environment variable containing the Gitea
+	// access token.
+	GiteaTokenEnvKey = "GITEA_TOKEN"
+
+	// DefaultGitLabURL is the GitLab instance used if no URL is provided.
+	DefaultGitLabURL = "https://gitlab.com"
+)
+
+// Client is the forge API used by the release notes gatherer.
+//
+// The method set is a subset of the release-sdk GitHub client, which means
+// that it can be used directly. Other forges convert their merge or pull
+// requests into the GitHub types, which allows reusing the release note
+// parsing, label handling and map logic of the gatherer. Labels are passed
+// through unchanged, which means that they have to follow the Kubernetes
+// naming like kind/bug or sig/node to be picked up.
+type Client interface {
+	GetCommit(
+		context.Context, string, string, string,
+	) (*gogithub.Commit, *gogithub.Response, error)
+	GetPullRequest(
+		context.Context, string, string, int,
+	) (*gogithub.PullRequest, *gogithub.Response, error)
+	GetRepoCommit(
+		context.Context, string, string, string,
+	) (*gogithub.RepositoryCommit, *gogithub.Response, error)
+	ListCommits(
+		context.Context, string, string, *gogithub.CommitsListOptions,
+	) ([]*gogithub.RepositoryCommit, *gogithub.Response, error)
+	ListPullRequestsWithCommit(
+		context.Context, string, string, string, *gogithub.ListOptions,
+	) ([]*gogithub.PullRequest, *gogithub.Response, error)
+}
+
+// IsSupported returns true if the forge is known.
+func IsSupported(forge string) bool {
+	switch forge {
+	case GitHub, GitLab, Gitea:
+		return true
+	}
+	return false
+}
+
+// New creates a new client for a non GitHub forge, which is either GitLab or
+// Gitea. GitHub clients are created by the release-sdk.
+func New(forge, baseURL, token string) (Client, error) {
+	switch forge {
+	case GitLab:
+		return NewGitLab(baseURL, token)
+	case Gitea:
+		return NewGitea(baseURL, token)
+	}
+	return nil, fmt.Errorf("unsupported forge %q", forge)
+}
+
+// newResponse creates a GitHub API response containing the pagination of
+// other forges. The HTTP response is always set, because callers inspect the
+// status code for rate limits.
+func newResponse(resp *http.Response, nextPage, lastPage int) *gogithub.Response {
+	if resp == nil {
+		resp = &http.Response{StatusCode: http.StatusOK}
+	}
+	return &gogithub.Response{
+		Response: resp,
+		NextPage: nextPage,
+		LastPage: lastPage,
+	}
+}
+
+// labels converts label names into GitHub labels.
+func labels(names []string) []*gogithub.Label {
+	res := make([]*gogithub.Label, 0, len(names))
+	for _, name := range names {
+		res = append(res, &gogithub.Label{Name: gogithub.String(name)})
+	}
+	return res
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forge
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fixture is a recorded API response.
+type fixture struct {
+	file    string
+	headers map[string]string
+}
+
+// newFixtureServer serves the recorded responses below testdata/<dir> by
+// their escaped request path and answers all other requests with 404.
+func newFixtureServer(t *testing.T, dir string, routes map[string]fixture) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, ok := routes[r.URL.EscapedPath()]
+		if !ok {
+			http.Error(w, `{"message":"404 Not Found"}`, http.StatusNotFound)
+			return
+		}
+
+		content, err := os.ReadFile(filepath.Join("testdata", dir, f.file))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		for key, value := range f.headers {
+			w.Header().Set(key, value)
+		}
+		if _, err := w.Write(content); err != nil {
+			t.Errorf("writing fixture %s: %v", f.file, err)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNew(t *testing.T) {
+	for _, tc := range []struct {
+		forge   string
+		baseURL string
+		mustErr bool
+	}{
+		{forge: GitLab},
+		{forge: Gitea, baseURL: "https://gitea.example.com"},
+		{forge: Gitea, mustErr: true},
+		{forge: GitHub, mustErr: true},
+		{forge: "bitbucket", mustErr: true},
+	} {
+		client, err := New(tc.forge, tc.baseURL, "")
+		if tc.mustErr {
+			require.Error(t, err, tc.forge)
+			continue
+		}
+		require.NoError(t, err, tc.forge)
+		require.NotNil(t, client)
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	require.True(t, IsSupported(GitHub))
+	require.True(t, IsSupported(GitLab))
+	require.True(t, IsSupported(Gitea))
+	require.False(t, IsSupported("bitbucket"))
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	gogithub "github.com/google/go-github/v60/github"
+)
+
+// GiteaClient is a Client for the pull requests of a Gitea repository, which
+// uses the Gitea REST API in version 1.
+type GiteaClient struct {
+	client  *http.Client
+	baseURL string
+	token   string
+}
+
+// NewGitea creates a new Gitea client for the instance at the base URL. The
+// token is optional for public repositories.
+func NewGitea(baseURL, token string) (*GiteaClient, error) {
+	if baseURL == "" {
+		return nil, errors.New("the Gitea client requires a base URL")
+	}
+	if _, err := url.ParseRequestURI(baseURL); err != nil {
+		return nil, fmt.Errorf("parsing Gitea base URL: %w", err)
+	}
+
+	return &GiteaClient{
+		client:  http.DefaultClient,
+		baseURL: strings.TrimSuffix(baseURL, "/") + "/api/v1",
+		token:   token,
+	}, nil
+}
+
+type giteaUser struct {
+	Login   string `json:"login"`
+	HTMLURL string `json:"html_url"`
+}
+
+type giteaCommitUser struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+}
+
+type giteaCommit struct {
+	SHA     string     `json:"sha"`
+	HTMLURL string     `json:"html_url"`
+	Author  *giteaUser `json:"author"`
+	Commit  struct {
+		Message   string           `json:"message"`
+		Author    *giteaCommitUser `json:"author"`
+		Committer *giteaCommitUser `json:"committer"`
+	} `json:"commit"`
+	Files []struct {
+		Filename string `json:"filename"`
+	} `json:"files"`
+}
+
+type giteaPullRequest struct {
+	Number         int        `json:"number"`
+	Title          string     `json:"title"`
+	Body           string     `json:"body"`
+	State          string     `json:"state"`
+	HTMLURL        string     `json:"html_url"`
+	User           *giteaUser `json:"user"`
+	Merged         bool       `json:"merged"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+	MergedAt       *time.Time `json:"merged_at"`
+	MergeCommitSHA string     `json:"merge_commit_sha"`
+	Labels         []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+// GetCommit returns the commit for the provided SHA.
+func (g *GiteaClient) GetCommit(
+	ctx context.Context, owner, repo, sha string,
+) (*gogithub.Commit, *gogithub.Response, error) {
+	commit, resp, err := g.GetRepoCommit(ctx, owner, repo, sha)
+	if err != nil {
+		return nil, resp, err
+	}
+	return commit.Commit, resp, nil
+}
+
+// GetRepoCommit returns the commit for the provided SHA including the touched
+// files.
+func (g *GiteaClient) GetRepoCommit(
+	ctx context.Context, owner, repo, sha string,
+) (*gogithub.RepositoryCommit, *gogithub.Response, error) {
+	commit := &giteaCommit{}
+	resp, err := g.get(ctx, repoPath(owner, repo, "git", "commits", sha), nil, commit)
+	if err != nil {
+		return nil, newResponse(resp, 0, 0), fmt.Errorf("getting commit %s: %w", sha, err)
+	}
+	return commit.convert(), newResponse(resp, 0, 0), nil
+}
+
+// ListCommits lists the commits of the repository.
+func (g *GiteaClient) ListCommits(
+	ctx context.Context, owner, repo string, opts *gogithub.CommitsListOptions,
+) ([]*gogithub.RepositoryCommit, *gogithub.Response, error) {
+	page, limit := max(opts.Page, 1), opts.PerPage
+	if limit == 0 {
+		limit = 50
+	}
+
+	query := url.Values{
+		"page":         {strconv.Itoa(page)},
+		"limit":        {strconv.Itoa(limit)},
+		"stat":         {"false"},
+		"verification": {"false"},
+		"files":        {"false"},
+	}
+	if opts.SHA != "" {
+		query.Set("sha", opts.SHA)
+	}
+	if !opts.Since.IsZero() {
+		query.Set("since", opts.Since.Format(time.RFC3339))
+	}
+	if !opts.Until.IsZero() {
+		query.Set("until", opts.Until.Format(time.RFC3339))
+	}
+
+	commits := []*giteaCommit{}
+	resp, err := g.get(ctx, repoPath(owner, repo, "commits"), query, &commits)
+	if err != nil {
+		return nil, newResponse(resp, 0, 0), fmt.Errorf("listing commits: %w", err)
+	}
+
+	res := make([]*gogithub.RepositoryCommit, 0, len(commits))
+	for _, commit := range commits {
+		res = append(res, commit.convert())
+	}
+
+	lastPage := page
+	if total, err := strconv.Atoi(resp.Header.Get("X-Total-Count")); err == nil {
+		lastPage = max((total+limit-1)/limit, 1)
+	}
+	nextPage := 0
+	if page < lastPage {
+		nextPage = page + 1
+	}
+	return res, newResponse(resp, nextPage, lastPage), nil
+}
+
+// ListPullRequestsWithCommit returns the pull request which merged the commit.
+// The list options are ignored, because Gitea returns a single pull request.
+func (g *GiteaClient) ListPullRequestsWithCommit(
+	ctx context.Context, owner, repo, sha string, _ *gogithub.ListOptions,
+) ([]*gogithub.PullRequest, *gogithub.Response, error) {
+	pr := &giteaPullRequest{}
+	resp, err := g.get(ctx, repoPath(owner, repo, "commits", sha, "pull"), nil, pr)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return []*gogithub.PullRequest{}, newResponse(resp, 0, 1), nil
+	}
+	if err != nil {
+		return nil, newResponse(resp, 0, 0), fmt.Errorf("getting pull request of commit %s: %w", sha, err)
+	}
+	return []*gogithub.PullRequest{pr.convert()}, newResponse(resp, 0, 1), nil
+}
+
+// GetPullRequest returns the pull request for the provided number.
+func (g *GiteaClient) GetPullRequest(
+	ctx context.Context, owner, repo string, number int,
+) (*gogithub.PullRequest, *gogithub.Response, error) {
+	pr := &giteaPullRequest{}
+	resp, err := g.get(ctx, repoPath(owner, repo, "pulls", strconv.Itoa(number)), nil, pr)
+	if err != nil {
+		return nil, newResponse(resp, 0, 0), fmt.Errorf("getting pull request #%d: %w", number, err)
+	}
+	return pr.convert(), newResponse(resp, 0, 0), nil
+}
+
+// get requests the API path and decodes the JSON response into the provided
+// value. The HTTP response is returned for all completed requests.
+func (g *GiteaClient) get(ctx context.Context, path string, query url.Values, v any) (*http.Response, error) {
+	u := g.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if g.token != "" {
+		req.Header.Set("Authorization", "token "+g.token)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, fmt.Errorf("requesting %s: %s", u, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return resp, fmt.Errorf("decoding response of %s: %w", u, err)
+	}
+	return resp, nil
+}
+
+func repoPath(owner, repo string, elems ...string) string {
+	parts := []string{"repos", url.PathEscape(owner), url.PathEscape(repo)}
+	for _, elem := range elems {
+		parts = append(parts, url.PathEscape(elem))
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+func (c *giteaCommit) convert() *gogithub.RepositoryCommit {
+	res := &gogithub.RepositoryCommit{
+		SHA:     gogithub.String(c.SHA),
+		HTMLURL: gogithub.String(c.HTMLURL),
+		Commit: &gogithub.Commit{
+			SHA:       gogithub.String(c.SHA),
+			Message:   gogithub.String(c.Commit.Message),
+			Author:    c.Commit.Author.convert(),
+			Committer: c.Commit.Committer.convert(),
+		},
+	}
+	if c.Author != nil {
+		res.Author = c.Author.convert()
+	}
+	for _, file := range c.Files {
+		res.Files = append(res.Files, &gogithub.CommitFile{Filename: gogithub.String(file.Filename)})
+	}
+	return res
+}
+
+func (u *giteaCommitUser) convert() *gogithub.CommitAuthor {
+	if u == nil {
+		return nil
+	}
+	return &gogithub.CommitAuthor{
+		Name:  gogithub.String(u.Name),
+		Email: gogithub.String(u.Email),
+		Date:  &gogithub.Timestamp{Time: u.Date},
+	}
+}
+
+func (u *giteaUser) convert() *gogithub.User {
+	return &gogithub.User{
+		Login:   gogithub.String(u.Login),
+		HTMLURL: gogithub.String(u.HTMLURL),
+	}
+}
+
+func (pr *giteaPullRequest) convert() *gogithub.PullRequest {
+	names := make([]string, 0, len(pr.Labels))
+	for _, label := range pr.Labels {
+		names = append(names, label.Name)
+	}
+
+	res := &gogithub.PullRequest{
+		Number:         gogithub.Int(pr.Number),
+		Title:          gogithub.String(pr.Title),
+		Body:           gogithub.String(pr.Body),
+		State:          gogithub.String(pr.State),
+		HTMLURL:        gogithub.String(pr.HTMLURL),
+		Labels:         labels(names),
+		Merged:         gogithub.Bool(pr.Merged),
+		MergeCommitSHA: gogithub.String(pr.MergeCommitSHA),
+		Base:           &gogithub.PullRequestBranch{Ref: gogithub.String(pr.Base.Ref)},
+	}
+	if pr.User != nil {
+		res.User = pr.User.convert()
+	}
+	if pr.UpdatedAt != nil {
+		res.UpdatedAt = &gogithub.Timestamp{Time: *pr.UpdatedAt}
+	}
+	if pr.MergedAt != nil {
+		res.MergedAt = &gogithub.Timestamp{Time: *pr.MergedAt}
+	}
+	return res
+}
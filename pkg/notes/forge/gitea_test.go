@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gogithub "github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/require"
+)
+
+const testGiteaAPI = "/api/v1/repos/owner/repo"
+
+func newTestGitea(t *testing.T) *GiteaClient {
+	t.Helper()
+
+	server := newFixtureServer(t, "gitea", map[string]fixture{
+		testGiteaAPI + "/git/commits/" + testMergeSHA:       {file: "commit.json"},
+		testGiteaAPI + "/commits/" + testMergeSHA + "/pull": {file: "commit_pull.json"},
+		testGiteaAPI + "/pulls/7":                           {file: "pull.json"},
+		testGiteaAPI + "/commits": {
+			file:    "commits.json",
+			headers: map[string]string{"X-Total-Count": "5"},
+		},
+	})
+
+	client, err := NewGitea(server.URL+"/", "token")
+	require.NoError(t, err)
+	return client
+}
+
+func TestGiteaGetCommit(t *testing.T) {
+	client := newTestGitea(t)
+
+	commit, _, err := client.GetCommit(context.Background(), "owner", "repo", testMergeSHA)
+	require.NoError(t, err)
+	require.Equal(t, testMergeSHA, commit.GetSHA())
+	require.Contains(t, commit.GetMessage(), "(#7)")
+	require.Equal(t, "2024-03-12T09:00:00Z", commit.GetCommitter().GetDate().UTC().Format("2006-01-02T15:04:05Z"))
+
+	repoCommit, _, err := client.GetRepoCommit(context.Background(), "owner", "repo", testMergeSHA)
+	require.NoError(t, err)
+	require.Equal(t, "jane", repoCommit.GetAuthor().GetLogin())
+	require.Len(t, repoCommit.Files, 2)
+	require.Equal(t, "pkg/kubelet/kubelet_test.go", repoCommit.Files[1].GetFilename())
+
+	_, resp, err := client.GetCommit(context.Background(), "owner", "repo", "unknown")
+	require.Error(t, err)
+	require.Equal(t, 404, resp.StatusCode)
+}
+
+func TestGiteaListCommits(t *testing.T) {
+	client := newTestGitea(t)
+
+	opts := &gogithub.CommitsListOptions{SHA: "main", ListOptions: gogithub.ListOptions{Page: 1, PerPage: 2}}
+	commits, resp, err := client.ListCommits(context.Background(), "owner", "repo", opts)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	require.Equal(t, "jane", commits[0].GetAuthor().GetLogin())
+	require.Nil(t, commits[1].Author)
+	require.Equal(t, 2, resp.NextPage)
+	require.Equal(t, 3, resp.LastPage)
+
+	opts.Page = 3
+	_, resp, err = client.ListCommits(context.Background(), "owner", "repo", opts)
+	require.NoError(t, err)
+	require.Equal(t, 0, resp.NextPage)
+}
+
+func TestGiteaPullRequests(t *testing.T) {
+	client := newTestGitea(t)
+
+	pr, _, err := client.GetPullRequest(context.Background(), "owner", "repo", 7)
+	require.NoError(t, err)
+	require.Equal(t, 7, pr.GetNumber())
+	require.Equal(t, "closed", pr.GetState())
+	require.True(t, pr.GetMerged())
+	require.Equal(t, "john", pr.GetUser().GetLogin())
+	require.Equal(t, testMergeSHA, pr.GetMergeCommitSHA())
+	require.Equal(t, time.Date(2024, 3, 12, 9, 5, 0, 0, time.UTC), pr.GetUpdatedAt().UTC())
+	require.Equal(t, []*gogithub.Label{
+		{Name: gogithub.String("kind/bug")},
+		{Name: gogithub.String("sig/node")},
+	}, pr.Labels)
+
+	prs, _, err := client.ListPullRequestsWithCommit(
+		context.Background(), "owner", "repo", testMergeSHA, &gogithub.ListOptions{},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []*gogithub.PullRequest{pr}, prs)
+
+	// Commits without pull request
+	prs, _, err = client.ListPullRequestsWithCommit(
+		context.Background(), "owner", "repo", "unknown", &gogithub.ListOptions{},
+	)
+	require.NoError(t, err)
+	require.Empty(t, prs)
+}
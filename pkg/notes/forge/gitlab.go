@@ -0,0 +1,230 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forge
+
+import (
+	"context"
+	"fmt"
+
+	gogithub "github.com/google/go-github/v60/github"
+	"github.com/xanzy/go-gitlab"
+)
+
+// GitLabClient is a Client for the merge requests of a GitLab project. The
+// owner and repository of the requests get joined to the project path, for
+// example "group/subgroup" and "project".
+type GitLabClient struct {
+	client *gitlab.Client
+}
+
+// NewGitLab creates a new GitLab client for the instance at the base URL,
+// which defaults to DefaultGitLabURL. The token is optional for public
+// projects.
+func NewGitLab(baseURL, token string) (*GitLabClient, error) {
+	if baseURL == "" {
+		baseURL = DefaultGitLabURL
+	}
+
+	client, err := gitlab.NewClient(token, gitlab.WithBaseURL(baseURL))
+	if err != nil {
+		return nil, fmt.Errorf("creating GitLab client: %w", err)
+	}
+	return &GitLabClient{client: client}, nil
+}
+
+// GetCommit returns the commit for the provided SHA.
+func (g *GitLabClient) GetCommit(
+	ctx context.Context, owner, repo, sha string,
+) (*gogithub.Commit, *gogithub.Response, error) {
+	commit, resp, err := g.client.Commits.GetCommit(
+		projectPath(owner, repo), sha, gitlab.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, gitLabResponse(resp), fmt.Errorf("getting commit %s: %w", sha, err)
+	}
+	return gitLabCommit(commit).Commit, gitLabResponse(resp), nil
+}
+
+// GetRepoCommit returns the commit for the provided SHA including the touched
+// files.
+func (g *GitLabClient) GetRepoCommit(
+	ctx context.Context, owner, repo, sha string,
+) (*gogithub.RepositoryCommit, *gogithub.Response, error) {
+	pid := projectPath(owner, repo)
+	commit, resp, err := g.client.Commits.GetCommit(pid, sha, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, gitLabResponse(resp), fmt.Errorf("getting commit %s: %w", sha, err)
+	}
+	res := gitLabCommit(commit)
+
+	opt := &gitlab.GetCommitDiffOptions{ListOptions: gitlab.ListOptions{Page: 1, PerPage: 100}}
+	for {
+		diffs, diffResp, err := g.client.Commits.GetCommitDiff(pid, sha, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, gitLabResponse(diffResp), fmt.Errorf("getting diff of commit %s: %w", sha, err)
+		}
+		for _, diff := range diffs {
+			res.Files = append(res.Files, &gogithub.CommitFile{Filename: gogithub.String(diff.NewPath)})
+		}
+		if diffResp.NextPage == 0 {
+			break
+		}
+		opt.Page = diffResp.NextPage
+	}
+
+	return res, gitLabResponse(resp), nil
+}
+
+// ListCommits lists the commits of the project.
+func (g *GitLabClient) ListCommits(
+	ctx context.Context, owner, repo string, opts *gogithub.CommitsListOptions,
+) ([]*gogithub.RepositoryCommit, *gogithub.Response, error) {
+	opt := &gitlab.ListCommitsOptions{
+		ListOptions: gitlab.ListOptions{Page: opts.Page, PerPage: opts.PerPage},
+	}
+	if opts.SHA != "" {
+		opt.RefName = gitlab.Ptr(opts.SHA)
+	}
+	if !opts.Since.IsZero() {
+		opt.Since = gitlab.Ptr(opts.Since)
+	}
+	if !opts.Until.IsZero() {
+		opt.Until = gitlab.Ptr(opts.Until)
+	}
+
+	commits, resp, err := g.client.Commits.ListCommits(
+		projectPath(owner, repo), opt, gitlab.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, gitLabResponse(resp), fmt.Errorf("listing commits: %w", err)
+	}
+
+	res := make([]*gogithub.RepositoryCommit, 0, len(commits))
+	for _, commit := range commits {
+		res = append(res, gitLabCommit(commit))
+	}
+	return res, gitLabResponse(resp), nil
+}
+
+// ListPullRequestsWithCommit lists the merge requests containing the commit.
+// The list options are ignored, because GitLab returns all of them at once.
+func (g *GitLabClient) ListPullRequestsWithCommit(
+	ctx context.Context, owner, repo, sha string, _ *gogithub.ListOptions,
+) ([]*gogithub.PullRequest, *gogithub.Response, error) {
+	mrs, resp, err := g.client.Commits.ListMergeRequestsByCommit(
+		projectPath(owner, repo), sha, gitlab.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, gitLabResponse(resp), fmt.Errorf("listing merge requests of commit %s: %w", sha, err)
+	}
+
+	res := make([]*gogithub.PullRequest, 0, len(mrs))
+	for _, mr := range mrs {
+		res = append(res, gitLabMergeRequest(mr))
+	}
+	return res, gitLabResponse(resp), nil
+}
+
+// GetPullRequest returns the merge request for the provided IID.
+func (g *GitLabClient) GetPullRequest(
+	ctx context.Context, owner, repo string, number int,
+) (*gogithub.PullRequest, *gogithub.Response, error) {
+	mr, resp, err := g.client.MergeRequests.GetMergeRequest(
+		projectPath(owner, repo), number, nil, gitlab.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, gitLabResponse(resp), fmt.Errorf("getting merge request !%d: %w", number, err)
+	}
+	return gitLabMergeRequest(mr), gitLabResponse(resp), nil
+}
+
+func projectPath(owner, repo string) string {
+	return owner + "/" + repo
+}
+
+func gitLabResponse(resp *gitlab.Response) *gogithub.Response {
+	if resp == nil {
+		return newResponse(nil, 0, 0)
+	}
+	return newResponse(resp.Response, resp.NextPage, resp.TotalPages)
+}
+
+// gitLabCommit converts a GitLab commit. The author login is not available,
+// because GitLab only provides the name and e-mail of commit authors.
+func gitLabCommit(commit *gitlab.Commit) *gogithub.RepositoryCommit {
+	res := &gogithub.RepositoryCommit{
+		SHA:     gogithub.String(commit.ID),
+		HTMLURL: gogithub.String(commit.WebURL),
+		Commit: &gogithub.Commit{
+			SHA:     gogithub.String(commit.ID),
+			Message: gogithub.String(commit.Message),
+			Author: &gogithub.CommitAuthor{
+				Name:  gogithub.String(commit.AuthorName),
+				Email: gogithub.String(commit.AuthorEmail),
+			},
+			Committer: &gogithub.CommitAuthor{
+				Name:  gogithub.String(commit.CommitterName),
+				Email: gogithub.String(commit.CommitterEmail),
+			},
+		},
+	}
+	if commit.AuthoredDate != nil {
+		res.Commit.Author.Date = &gogithub.Timestamp{Time: *commit.AuthoredDate}
+	}
+	if commit.CommittedDate != nil {
+		res.Commit.Committer.Date = &gogithub.Timestamp{Time: *commit.CommittedDate}
+	}
+	return res
+}
+
+// gitLabMergeRequest converts a GitLab merge request. Merged merge requests
+// are considered closed, like GitHub does for merged pull requests.
+func gitLabMergeRequest(mr *gitlab.MergeRequest) *gogithub.PullRequest {
+	state := mr.State
+	if state == "merged" {
+		state = "closed"
+	}
+
+	mergeCommitSHA := mr.MergeCommitSHA
+	if mergeCommitSHA == "" {
+		mergeCommitSHA = mr.SquashCommitSHA
+	}
+
+	res := &gogithub.PullRequest{
+		Number:         gogithub.Int(mr.IID),
+		Title:          gogithub.String(mr.Title),
+		Body:           gogithub.String(mr.Description),
+		State:          gogithub.String(state),
+		HTMLURL:        gogithub.String(mr.WebURL),
+		Labels:         labels(mr.Labels),
+		MergeCommitSHA: gogithub.String(mergeCommitSHA),
+		Base:           &gogithub.PullRequestBranch{Ref: gogithub.String(mr.TargetBranch)},
+	}
+	if mr.Author != nil {
+		res.User = &gogithub.User{
+			Login:   gogithub.String(mr.Author.Username),
+			HTMLURL: gogithub.String(mr.Author.WebURL),
+		}
+	}
+	if mr.UpdatedAt != nil {
+		res.UpdatedAt = &gogithub.Timestamp{Time: *mr.UpdatedAt}
+	}
+	if mr.MergedAt != nil {
+		res.MergedAt = &gogithub.Timestamp{Time: *mr.MergedAt}
+	}
+	return res
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gogithub "github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testMergeSHA  = "6104942438c14ec7bd21c6cd5bd995272b3faff6"
+	testGitLabAPI = "/api/v4/projects/group%2Fproject"
+)
+
+func newTestGitLab(t *testing.T) *GitLabClient {
+	t.Helper()
+
+	server := newFixtureServer(t, "gitlab", map[string]fixture{
+		testGitLabAPI + "/repository/commits/" + testMergeSHA:                     {file: "commit.json"},
+		testGitLabAPI + "/repository/commits/" + testMergeSHA + "/diff":           {file: "commit_diff.json"},
+		testGitLabAPI + "/repository/commits/" + testMergeSHA + "/merge_requests": {file: "commit_merge_requests.json"},
+		testGitLabAPI + "/merge_requests/42":                                      {file: "merge_request.json"},
+		testGitLabAPI + "/repository/commits": {
+			file:    "commits.json",
+			headers: map[string]string{"X-Total-Pages": "3", "X-Next-Page": "2", "X-Page": "1"},
+		},
+	})
+
+	client, err := NewGitLab(server.URL, "token")
+	require.NoError(t, err)
+	return client
+}
+
+func TestGitLabGetCommit(t *testing.T) {
+	client := newTestGitLab(t)
+
+	commit, _, err := client.GetCommit(context.Background(), "group", "project", testMergeSHA)
+	require.NoError(t, err)
+	require.Equal(t, testMergeSHA, commit.GetSHA())
+	require.Contains(t, commit.GetMessage(), "See merge request group/project!42")
+	require.Equal(t,
+		time.Date(2024, 3, 12, 9, 0, 0, 0, time.UTC),
+		commit.GetCommitter().GetDate().UTC(),
+	)
+
+	_, resp, err := client.GetCommit(context.Background(), "group", "project", "unknown")
+	require.Error(t, err)
+	require.Equal(t, 404, resp.StatusCode)
+}
+
+func TestGitLabGetRepoCommit(t *testing.T) {
+	client := newTestGitLab(t)
+
+	commit, _, err := client.GetRepoCommit(context.Background(), "group", "project", testMergeSHA)
+	require.NoError(t, err)
+	require.Len(t, commit.Files, 2)
+	require.Equal(t, "pkg/kubelet/kubelet.go", commit.Files[0].GetFilename())
+}
+
+func TestGitLabListCommits(t *testing.T) {
+	client := newTestGitLab(t)
+
+	commits, resp, err := client.ListCommits(
+		context.Background(), "group", "project",
+		&gogithub.CommitsListOptions{SHA: "main", ListOptions: gogithub.ListOptions{Page: 1, PerPage: 2}},
+	)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	require.Equal(t, testMergeSHA, commits[0].GetSHA())
+	require.Equal(t, "John Doe", commits[1].GetCommit().GetAuthor().GetName())
+	require.Equal(t, 2, resp.NextPage)
+	require.Equal(t, 3, resp.LastPage)
+}
+
+func TestGitLabPullRequests(t *testing.T) {
+	client := newTestGitLab(t)
+
+	pr, _, err := client.GetPullRequest(context.Background(), "group", "project", 42)
+	require.NoError(t, err)
+	require.Equal(t, 42, pr.GetNumber())
+	require.Equal(t, "closed", pr.GetState())
+	require.Equal(t, "john", pr.GetUser().GetLogin())
+	require.Equal(t, "https://gitlab.example.com/john", pr.GetUser().GetHTMLURL())
+	require.Equal(t, testMergeSHA, pr.GetMergeCommitSHA())
+	require.Equal(t, "main", pr.GetBase().GetRef())
+	require.Equal(t, time.Date(2024, 3, 12, 9, 5, 0, 0, time.UTC), pr.GetUpdatedAt().UTC())
+	require.Contains(t, pr.GetBody(), "```release-note")
+	require.Len(t, pr.Labels, 2)
+	require.Equal(t, "sig/node", pr.Labels[1].GetName())
+
+	prs, _, err := client.ListPullRequestsWithCommit(
+		context.Background(), "group", "project", testMergeSHA, &gogithub.ListOptions{},
+	)
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	require.Equal(t, pr, prs[0])
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"sigs.k8s.io/release-utils/command"
+
+	"k8s.io/release/pkg/notes/options"
+)
+
+// incrementalState is the persisted result of a previous gatherer run.
+type incrementalState struct {
+	// StartSHA is the start revision of the gathered notes.
+	StartSHA string `json:"start_sha"`
+
+	// EndSHA is the last processed end revision.
+	EndSHA string `json:"end_sha"`
+
+	// Notes are the gathered release notes.
+	Notes ReleaseNotesByPR `json:"notes"`
+
+	// History is the PR history of the gathered release notes.
+	History ReleaseNotesHistory `json:"history"`
+
+	// DataFields are the map data fields of the notes by PR, which are
+	// not part of the JSON representation of a ReleaseNote.
+	DataFields map[int]map[string]ReleaseNotesDataField `json:"data_fields,omitempty"`
+}
+
+// gatherReleaseNotesIncremental gathers the release notes by reusing the
+// state of a previous run in `opts.StateDir`. Only the commits between the
+// previously processed end SHA and the current one are taken into account
+// and their notes get merged into the previous ones. Changing the contents
+// of the release notes maps invalidates the previous state.
+func gatherReleaseNotesIncremental(opts *options.Options) (*ReleaseNotes, error) {
+	if err := os.MkdirAll(opts.StateDir, os.FileMode(0o755)); err != nil {
+		return nil, fmt.Errorf("create notes state directory: %w", err)
+	}
+	digest, err := mapsDigest(opts.MapProviderStrings)
+	if err != nil {
+		return nil, err
+	}
+	statePath := filepath.Join(opts.StateDir, stateFileName(opts, digest))
+
+	state, err := readIncrementalState(statePath)
+	if err != nil {
+		return nil, err
+	}
+
+	gatherAll := func() (*ReleaseNotes, error) {
+		releaseNotes, err := gatherReleaseNotes(opts)
+		if err != nil {
+			return nil, err
+		}
+		return releaseNotes, writeIncrementalState(statePath, opts, releaseNotes)
+	}
+
+	if state == nil {
+		logrus.Infof("No previous notes state found in %s", statePath)
+		return gatherAll()
+	}
+
+	releaseNotes := state.releaseNotes()
+	if state.EndSHA == opts.EndSHA {
+		logrus.Infof(
+			"Reusing %d previously gathered notes up to %s",
+			len(releaseNotes.ByPR()), state.EndSHA,
+		)
+		return releaseNotes, nil
+	}
+
+	// The previous end has to be part of the history of the new one,
+	// otherwise the previous notes may contain commits which got removed,
+	// for example by a force push.
+	ancestor, err := isAncestor(opts.RepoPath, state.EndSHA, opts.EndSHA)
+	if err != nil {
+		logrus.Warnf("Unable to verify previous end %s, gathering all notes: %v", state.EndSHA, err)
+		return gatherAll()
+	}
+	if !ancestor {
+		logrus.Warnf(
+			"Previous end %s is not an ancestor of %s, gathering all notes",
+			state.EndSHA, opts.EndSHA,
+		)
+		return gatherAll()
+	}
+
+	logrus.Infof(
+		"Gathering new release notes from previous end %s to %s",
+		state.EndSHA, opts.EndSHA,
+	)
+	newOpts := *opts
+	newOpts.StartSHA = state.EndSHA
+	newReleaseNotes, err := gatherReleaseNotes(&newOpts)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof(
+		"Merging %d new notes into %d previous ones",
+		len(newReleaseNotes.ByPR()), len(releaseNotes.ByPR()),
+	)
+	releaseNotes.Merge(newReleaseNotes)
+
+	return releaseNotes, writeIncrementalState(statePath, opts, releaseNotes)
+}
+
+// isAncestor returns true if `ancestor` is part of the history of `rev` in
+// the local repository.
+func isAncestor(repoPath, ancestor, rev string) (bool, error) {
+	status, err := command.NewWithWorkDir(
+		repoPath, "git", "merge-base", "--is-ancestor", ancestor, rev,
+	).RunSilent()
+	if err != nil {
+		return false, fmt.Errorf("run git merge-base: %w", err)
+	}
+	if status.Success() {
+		return true, nil
+	}
+	if status.ExitCode() == 1 {
+		return false, nil
+	}
+	return false, fmt.Errorf("git merge-base failed: %s", status.Error())
+}
+
+// mapsDigest returns a digest of the contents of all release notes maps of
+// the provided map providers, which is empty without any map provider.
+func mapsDigest(providerStrings []string) (string, error) {
+	if len(providerStrings) == 0 {
+		return "", nil
+	}
+
+	hash := sha256.New()
+	for _, initString := range providerStrings {
+		provider, err := NewProviderFromInitString(initString)
+		if err != nil {
+			return "", fmt.Errorf("while getting release notes map providers: %w", err)
+		}
+		maps, err := providerMaps(provider)
+		if err != nil {
+			return "", fmt.Errorf("reading release notes maps of %s: %w", initString, err)
+		}
+
+		// The data fields may contain YAML maps, which cannot be marshalled
+		// into JSON. YAML sorts the keys, which keeps the digest stable.
+		content, err := yaml.Marshal(maps)
+		if err != nil {
+			return "", fmt.Errorf("marshal release notes maps of %s: %w", initString, err)
+		}
+		hash.Write(content)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// stateFileName returns the name of the state file for the provided options.
+// It contains a hash of all options which influence the gathered notes as
+// well as the digest of the release notes maps.
+func stateFileName(opts *options.Options, mapsDigest string) string {
+	key := strings.Join([]string{
+		opts.Forge,
+		opts.ForgeURL,
+		opts.GithubOrg,
+		opts.GithubRepo,
+		opts.Branch,
+		opts.StartSHA,
+		opts.RequiredAuthor,
+		strings.Join(opts.MapProviderStrings, ","),
+		mapsDigest,
+		fmt.Sprint(opts.AddMarkdownLinks),
+		fmt.Sprint(opts.ListReleaseNotesV2),
+		fmt.Sprint(opts.InferLabels),
+		opts.OwnershipMapPath,
+		fmt.Sprint(opts.RecordFiles),
+	}, "\n")
+	sum := sha256.Sum256([]byte(key))
+	return fmt.Sprintf("notes-%s.json", hex.EncodeToString(sum[:])[:16])
+}
+
+func readIncrementalState(path string) (*incrementalState, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read notes state: %w", err)
+	}
+
+	state := &incrementalState{}
+	if err := json.Unmarshal(content, state); err != nil {
+		return nil, fmt.Errorf("unmarshal notes state: %w", err)
+	}
+	return state, nil
+}
+
+func writeIncrementalState(
+	path string, opts *options.Options, releaseNotes *ReleaseNotes,
+) error {
+	state := &incrementalState{
+		StartSHA:   opts.StartSHA,
+		EndSHA:     opts.EndSHA,
+		Notes:      releaseNotes.ByPR(),
+		History:    releaseNotes.History(),
+		DataFields: map[int]map[string]ReleaseNotesDataField{},
+	}
+	for pr, note := range releaseNotes.ByPR() {
+		if len(note.DataFields) > 0 {
+			state.DataFields[pr] = note.DataFields
+		}
+	}
+
+	content, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal notes state: %w", err)
+	}
+	if err := os.WriteFile(path, content, os.FileMode(0o644)); err != nil {
+		return fmt.Errorf("write notes state: %w", err)
+	}
+	logrus.Infof("Wrote notes state up to %s to %s", opts.EndSHA, path)
+	return nil
+}
+
+// releaseNotes converts the state into ReleaseNotes.
+func (s *incrementalState) releaseNotes() *ReleaseNotes {
+	releaseNotes := NewReleaseNotes()
+	for _, pr := range s.History {
+		note, ok := s.Notes[pr]
+		if !ok || releaseNotes.Get(pr) != nil {
+			continue
+		}
+		note.DataFields = map[string]ReleaseNotesDataField{}
+		if dataFields, ok := s.DataFields[pr]; ok {
+			note.DataFields = dataFields
+		}
+		releaseNotes.Set(pr, note)
+	}
+	return releaseNotes
+}
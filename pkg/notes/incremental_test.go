@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/release-utils/command"
+
+	"k8s.io/release/pkg/notes/options"
+)
+
+func TestReleaseNotesMerge(t *testing.T) {
+	sut := NewReleaseNotes()
+	sut.Set(1, &ReleaseNote{Text: "one"})
+	sut.Set(2, &ReleaseNote{Text: "two"})
+
+	other := NewReleaseNotes()
+	other.Set(2, &ReleaseNote{Text: "two updated"})
+	other.Set(3, &ReleaseNote{Text: "three"})
+
+	sut.Merge(other)
+	require.Equal(t, ReleaseNotesHistory{1, 2, 3}, sut.History())
+	require.Equal(t, "two updated", sut.Get(2).Text)
+	require.Equal(t, "three", sut.Get(3).Text)
+}
+
+func TestIncrementalState(t *testing.T) {
+	opts := options.New()
+	opts.StartSHA = "start"
+	opts.EndSHA = "end"
+	opts.StateDir = t.TempDir()
+
+	notes := NewReleaseNotes()
+	notes.Set(1, &ReleaseNote{
+		Text:       "one",
+		PrNumber:   1,
+		DataFields: map[string]ReleaseNotesDataField{"cve": map[string]any{"id": "CVE-2024-0001"}},
+	})
+	notes.Set(2, &ReleaseNote{Text: "two", PrNumber: 2})
+
+	path := filepath.Join(opts.StateDir, stateFileName(opts, ""))
+	require.NoError(t, writeIncrementalState(path, opts, notes))
+
+	state, err := readIncrementalState(path)
+	require.NoError(t, err)
+	require.Equal(t, "start", state.StartSHA)
+	require.Equal(t, "end", state.EndSHA)
+
+	res := state.releaseNotes()
+	require.Equal(t, ReleaseNotesHistory{1, 2}, res.History())
+	require.Contains(t, res.Get(1).DataFields, "cve")
+	require.NotNil(t, res.Get(2).DataFields)
+
+	// Unchanged end SHA reuses the state without gathering
+	res, err = GatherReleaseNotes(opts)
+	require.NoError(t, err)
+	require.Len(t, res.ByPR(), 2)
+
+	// Different start SHA results in a different state
+	other := *opts
+	other.StartSHA = "other"
+	require.NotEqual(t, stateFileName(opts, ""), stateFileName(&other, ""))
+
+	// Different map contents result in a different state
+	require.NotEqual(t, stateFileName(opts, ""), stateFileName(opts, "digest"))
+
+	missing, err := readIncrementalState(filepath.Join(opts.StateDir, "missing"))
+	require.NoError(t, err)
+	require.Nil(t, missing)
+}
+
+func TestMapsDigest(t *testing.T) {
+	mapsDir := t.TempDir()
+	mapPath := filepath.Join(mapsDir, "pr-1.yaml")
+	writeMap := func(text string) {
+		require.NoError(t, os.WriteFile(mapPath, []byte(
+			"pr: 1\nreleasenote:\n  text: "+text+"\ndatafields:\n  cve:\n    id: CVE-2024-0001\n",
+		), 0o600))
+	}
+
+	writeMap("one")
+	digest, err := mapsDigest([]string{mapsDir})
+	require.NoError(t, err)
+
+	same, err := mapsDigest([]string{mapsDir})
+	require.NoError(t, err)
+	require.Equal(t, digest, same)
+
+	writeMap("edited")
+	edited, err := mapsDigest([]string{mapsDir})
+	require.NoError(t, err)
+	require.NotEqual(t, digest, edited)
+
+	_, err = mapsDigest([]string{filepath.Join(mapsDir, "missing")})
+	require.Error(t, err)
+}
+
+func TestIsAncestor(t *testing.T) {
+	repo := t.TempDir()
+	git := func(args ...string) string {
+		res, err := command.NewWithWorkDir(repo, "git", append([]string{
+			"-c", "user.name=test", "-c", "user.email=test@example.com",
+		}, args...)...).RunSilentSuccessOutput()
+		require.NoError(t, err)
+		return res.OutputTrimNL()
+	}
+	git("init")
+	git("commit", "--allow-empty", "-m", "first")
+	first := git("rev-parse", "HEAD")
+	git("commit", "--allow-empty", "-m", "second")
+	second := git("rev-parse", "HEAD")
+	git("checkout", "-b", "rewritten", first)
+	git("commit", "--allow-empty", "-m", "rewritten")
+	rewritten := git("rev-parse", "HEAD")
+
+	ancestor, err := isAncestor(repo, first, second)
+	require.NoError(t, err)
+	require.True(t, ancestor)
+
+	// The previous end got removed by a force push
+	ancestor, err = isAncestor(repo, second, rewritten)
+	require.NoError(t, err)
+	require.False(t, ancestor)
+
+	_, err = isAncestor(repo, "invalid", rewritten)
+	require.Error(t, err)
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	gogithub "github.com/google/go-github/v60/github"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/command"
+	"sigs.k8s.io/yaml"
+)
+
+// Fields of a ReleaseNote which can be inferred.
+const (
+	InferredSIGs  = "sigs"
+	InferredKinds = "kinds"
+	InferredAreas = "areas"
+)
+
+// OwnershipMap maps repository paths to labels. It uses the same format as
+// the `filters` section of OWNERS files, for example:
+//
+//	filters:
+//	  "^pkg/kubelet/":
+//	    labels:
+//	    - sig/node
+//	    - area/kubelet
+type OwnershipMap struct {
+	Filters map[string]OwnershipFilter `json:"filters"`
+
+	compiled []compiledOwnershipFilter
+}
+
+// OwnershipFilter contains the labels of all paths matching a filter.
+type OwnershipFilter struct {
+	Labels []string `json:"labels"`
+}
+
+type compiledOwnershipFilter struct {
+	re     *regexp.Regexp
+	labels []string
+}
+
+// ReadOwnershipMap reads and compiles an ownership map file.
+func ReadOwnershipMap(path string) (*OwnershipMap, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read ownership map: %w", err)
+	}
+
+	ownershipMap := &OwnershipMap{}
+	if err := yaml.UnmarshalStrict(content, ownershipMap); err != nil {
+		return nil, fmt.Errorf("unmarshal ownership map: %w", err)
+	}
+
+	for expression, filter := range ownershipMap.Filters {
+		re, err := regexp.Compile(expression)
+		if err != nil {
+			return nil, fmt.Errorf("compile ownership filter %q: %w", expression, err)
+		}
+		ownershipMap.compiled = append(ownershipMap.compiled, compiledOwnershipFilter{
+			re: re, labels: filter.Labels,
+		})
+	}
+	return ownershipMap, nil
+}
+
+// LabelsForFiles returns the sorted and deduplicated sig and area labels
+// of all filters matching any of the files.
+func (m *OwnershipMap) LabelsForFiles(files []string) (sigs, areas []string) {
+	sigSet := map[string]bool{}
+	areaSet := map[string]bool{}
+	for _, filter := range m.compiled {
+		for _, file := range files {
+			if !filter.re.MatchString(file) {
+				continue
+			}
+			for _, label := range filter.labels {
+				if sig, ok := strings.CutPrefix(label, "sig/"); ok {
+					sigSet[sig] = true
+				} else if area, ok := strings.CutPrefix(label, "area/"); ok {
+					areaSet[area] = true
+				}
+			}
+			break
+		}
+	}
+	return sortedKeys(sigSet), sortedKeys(areaSet)
+}
+
+// kindKeywords are the patterns which propose a kind, in order of their
+// priority. Conventional commit prefixes take precedence over keywords.
+var kindKeywords = []struct {
+	kind Kind
+	re   *regexp.Regexp
+}{
+	{KindFeature, regexp.MustCompile(`(?i)^feat(\([^)]*\))?!?:`)},
+	{KindBug, regexp.MustCompile(`(?i)^fix(\([^)]*\))?!?:`)},
+	{KindDocumentation, regexp.MustCompile(`(?i)^docs?(\([^)]*\))?!?:`)},
+	{KindCleanup, regexp.MustCompile(`(?i)^(chore|refactor)(\([^)]*\))?!?:`)},
+	{KindDeprecation, regexp.MustCompile(`(?i)\bdeprecat(e|ed|es|ing|ion)\b`)},
+	{KindRegression, regexp.MustCompile(`(?i)\bregression\b`)},
+	{KindBug, regexp.MustCompile(`(?i)\b(fix|fixes|fixed|fixing|bug|panic|crash(es|ed)?)\b`)},
+	{KindFlake, regexp.MustCompile(`(?i)\bflak(e|es|y|iness)\b`)},
+	{KindFeature, regexp.MustCompile(`(?i)\b(add|adds|added|introduce|introduces|introduced|new|support|supports)\b`)},
+	{KindCleanup, regexp.MustCompile(`(?i)\b(remove|removes|removed|cleanup|clean up|refactor|refactors|refactored)\b`)},
+}
+
+// inferKind returns the kind proposed by the conventional keywords of the
+// texts or an empty string if none matches.
+func inferKind(texts ...string) Kind {
+	for _, keyword := range kindKeywords {
+		for _, text := range texts {
+			if keyword.re.MatchString(strings.TrimSpace(text)) {
+				return keyword.kind
+			}
+		}
+	}
+	return ""
+}
+
+// inferredLabels are the labels proposed for a PR with missing labels.
+type inferredLabels struct {
+	sigs, kinds, areas []string
+}
+
+// fields returns the note fields which got inferred or nil if none.
+func (l *inferredLabels) fields() []string {
+	var fields []string
+	if len(l.sigs) > 0 {
+		fields = append(fields, InferredSIGs)
+	}
+	if len(l.kinds) > 0 {
+		fields = append(fields, InferredKinds)
+	}
+	if len(l.areas) > 0 {
+		fields = append(fields, InferredAreas)
+	}
+	return fields
+}
+
+// inferLabels proposes the missing labels of the PR if label inference is
+// enabled. The SIGs and areas are only proposed if an ownership map is
+// configured and the touched files of the PR merge commit are available.
+func (g *Gatherer) inferLabels(
+	changedFiles func() ([]string, error), pr *gogithub.PullRequest, text string, sigs, kinds, areas []string,
+) *inferredLabels {
+	res := &inferredLabels{}
+	if !g.options.InferLabels {
+		return res
+	}
+
+	if len(kinds) == 0 {
+		if kind := inferKind(pr.GetTitle(), text); kind != "" {
+			res.kinds = []string{string(kind)}
+		}
+	}
+
+	if g.ownershipMap != nil && (len(sigs) == 0 || len(areas) == 0) {
+		files, err := changedFiles()
+		if err != nil {
+			logrus.Warnf("Unable to infer SIGs and areas for PR #%d: %v", pr.GetNumber(), err)
+			return res
+		}
+		inferredSIGs, inferredAreas := g.ownershipMap.LabelsForFiles(files)
+		if len(sigs) == 0 {
+			res.sigs = inferredSIGs
+		}
+		if len(areas) == 0 {
+			res.areas = inferredAreas
+		}
+	}
+
+	if fields := res.fields(); len(fields) > 0 {
+		logrus.WithFields(logrus.Fields{
+			"pr":     pr.GetNumber(),
+			"fields": fields,
+		}).Debug("Inferred missing labels")
+	}
+	return res
+}
+
+// IsInferred returns true if the field of the note has been inferred and not
+// confirmed yet.
+func (rn *ReleaseNote) IsInferred(field string) bool {
+	return slices.Contains(rn.Inferred, field)
+}
+
+// confirmInferred marks the field of the note as no longer being inferred.
+func (rn *ReleaseNote) confirmInferred(field string) {
+	rn.Inferred = slices.DeleteFunc(rn.Inferred, func(f string) bool { return f == field })
+	if len(rn.Inferred) == 0 {
+		rn.Inferred = nil
+	}
+}
+
+// commitFiles returns a function which retrieves the files touched by the
+// commit on first use and returns the same result on subsequent calls. This
+// allows sharing them between all consumers of a single PR.
+func (g *Gatherer) commitFiles(sha string) func() ([]string, error) {
+	var (
+		files     []string
+		err       error
+		retrieved bool
+	)
+	return func() ([]string, error) {
+		if !retrieved {
+			files, err = g.changedFiles(sha)
+			retrieved = true
+		}
+		return files, err
+	}
+}
+
+// changedFiles returns the files touched by the commit. The files are
+// retrieved from the local repository in offline mode and for large commits,
+// because the forge only returns their first page of files.
+func (g *Gatherer) changedFiles(sha string) ([]string, error) {
+	if g.client == nil {
+		logrus.Debugf("Retrieving the files of commit %s from the local repository", sha)
+		return localChangedFiles(g.options.RepoPath, sha)
+	}
+
+	commit, resp, err := g.client.GetRepoCommit(
+		g.context, g.options.GithubOrg, g.options.GithubRepo, sha,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve commit %s: %w", sha, err)
+	}
+
+	if resp != nil && resp.NextPage != 0 {
+		logrus.Debugf("Retrieving the files of commit %s from the local repository", sha)
+		return localChangedFiles(g.options.RepoPath, sha)
+	}
+
+	files := []string{}
+	for _, file := range commit.Files {
+		files = append(files, file.GetFilename())
+	}
+	return files, nil
+}
+
+// localChangedFiles returns the files touched by the commit compared to its
+// first parent in the local repository.
+func localChangedFiles(repoPath, sha string) ([]string, error) {
+	res, err := command.NewWithWorkDir(
+		repoPath, "git", "diff", "--name-only", "--no-renames", sha+"^1", sha,
+	).RunSilentSuccessOutput()
+	if err != nil {
+		return nil, fmt.Errorf("list files of commit %s: %w", sha, err)
+	}
+
+	files := []string{}
+	for _, file := range strings.Split(res.OutputTrimNL(), "\n") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := []string{}
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,333 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	gogithub "github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/release-sdk/github/githubfakes"
+	"sigs.k8s.io/release-utils/command"
+
+	"k8s.io/release/pkg/notes/options"
+)
+
+const testOwnershipMap = `filters:
+  "^pkg/kubelet/":
+    labels:
+    - sig/node
+    - area/kubelet
+  "^staging/src/k8s.io/kubectl/":
+    labels:
+    - sig/cli
+    - area/kubectl
+  "\\.md$":
+    labels:
+    - kind/documentation
+`
+
+func writeOwnershipMap(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "ownership.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestInferKind(t *testing.T) {
+	for _, tc := range []struct {
+		title, text string
+		expected    Kind
+	}{
+		{"feat(kubelet): add swap support", "", KindFeature},
+		{"fix: nil pointer", "Added a check.", KindBug},
+		{"docs: update README", "", KindDocumentation},
+		{"refactor!: move package", "", KindCleanup},
+		{"Update kubelet", "Deprecated the --foo flag.", KindDeprecation},
+		{"Fix regression in scheduler", "", KindRegression},
+		{"Update scheduler", "Fixed a panic when the node is missing.", KindBug},
+		{"Deflake e2e test", "Reduced test flakiness.", KindFlake},
+		{"Update kubectl", "Added the --bar flag to kubectl.", KindFeature},
+		{"Update kubectl", "Removed the unused --baz flag.", KindCleanup},
+		{"Bump dependency", "Updated the dependency to v1.2.", ""},
+	} {
+		require.Equal(t, tc.expected, inferKind(tc.title, tc.text), tc.title)
+	}
+}
+
+func TestReadOwnershipMap(t *testing.T) {
+	ownershipMap, err := ReadOwnershipMap(writeOwnershipMap(t, testOwnershipMap))
+	require.NoError(t, err)
+
+	sigs, areas := ownershipMap.LabelsForFiles([]string{
+		"pkg/kubelet/kubelet.go",
+		"pkg/kubelet/types.go",
+		"staging/src/k8s.io/kubectl/README.md",
+	})
+	require.Equal(t, []string{"cli", "node"}, sigs)
+	require.Equal(t, []string{"kubectl", "kubelet"}, areas)
+
+	sigs, areas = ownershipMap.LabelsForFiles([]string{"hack/verify.sh"})
+	require.Empty(t, sigs)
+	require.Empty(t, areas)
+
+	_, err = ReadOwnershipMap(writeOwnershipMap(t, "filters:\n  \"[\":\n    labels: [sig/node]\n"))
+	require.Error(t, err)
+
+	_, err = ReadOwnershipMap(writeOwnershipMap(t, "owners: []\n"))
+	require.Error(t, err)
+
+	_, err = ReadOwnershipMap(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
+
+func TestReleaseNoteFromCommitInference(t *testing.T) {
+	newPR := func(title string, labels ...string) *gogithub.PullRequest {
+		pr := &gogithub.PullRequest{
+			Number: gogithub.Int(1),
+			Title:  gogithub.String(title),
+			Body:   gogithub.String("```release-note\nFixed a crash of the kubelet.\n```"),
+			User:   &gogithub.User{Login: gogithub.String("user")},
+		}
+		for _, label := range labels {
+			pr.Labels = append(pr.Labels, &gogithub.Label{Name: gogithub.String(label)})
+		}
+		return pr
+	}
+	commit := &gogithub.RepositoryCommit{SHA: gogithub.String("sha")}
+
+	ownershipMap, err := ReadOwnershipMap(writeOwnershipMap(t, testOwnershipMap))
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name             string
+		inferLabels      bool
+		pr               *gogithub.PullRequest
+		filesErr         error
+		expectedSIGs     []string
+		expectedKinds    []string
+		expectedAreas    []string
+		expectedInferred []string
+	}{
+		{
+			name:          "disabled",
+			pr:            newPR("Update kubelet"),
+			expectedSIGs:  nil,
+			expectedKinds: nil,
+			expectedAreas: nil,
+		},
+		{
+			name:             "all inferred",
+			inferLabels:      true,
+			pr:               newPR("Update kubelet"),
+			expectedSIGs:     []string{"node"},
+			expectedKinds:    []string{"bug"},
+			expectedAreas:    []string{"kubelet"},
+			expectedInferred: []string{InferredSIGs, InferredKinds, InferredAreas},
+		},
+		{
+			name:             "labels take precedence",
+			inferLabels:      true,
+			pr:               newPR("Update kubelet", "sig/scheduling", "kind/cleanup"),
+			expectedSIGs:     []string{"scheduling"},
+			expectedKinds:    []string{"cleanup"},
+			expectedAreas:    []string{"kubelet"},
+			expectedInferred: []string{InferredAreas},
+		},
+		{
+			name:             "files not available",
+			inferLabels:      true,
+			pr:               newPR("Update kubelet"),
+			filesErr:         errors.New("error"),
+			expectedKinds:    []string{"bug"},
+			expectedInferred: []string{InferredKinds},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &githubfakes.FakeClient{}
+			client.GetRepoCommitReturns(&gogithub.RepositoryCommit{
+				Files: []*gogithub.CommitFile{{Filename: gogithub.String("pkg/kubelet/kubelet.go")}},
+			}, nil, tc.filesErr)
+
+			gatherer := NewGathererWithClient(context.Background(), client)
+			gatherer.options.InferLabels = tc.inferLabels
+			gatherer.ownershipMap = ownershipMap
+
+			note, err := gatherer.ReleaseNoteFromCommit(&Result{commit: commit, pullRequest: tc.pr})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedSIGs, note.SIGs)
+			require.Equal(t, tc.expectedKinds, note.Kinds)
+			require.Equal(t, tc.expectedAreas, note.Areas)
+			require.Equal(t, tc.expectedInferred, note.Inferred)
+			if slices.Contains(tc.expectedInferred, InferredSIGs) {
+				require.NotContains(t, note.Markdown, "[SIG ")
+			} else if len(tc.expectedSIGs) > 0 {
+				require.Contains(t, note.Markdown, "[SIG ")
+			}
+		})
+	}
+}
+
+func TestReleaseNoteFromCommitRecordFiles(t *testing.T) {
+	pr := &gogithub.PullRequest{
+		Number: gogithub.Int(1),
+		Body:   gogithub.String("```release-note\nFixed a crash of the kubelet.\n```"),
+		User:   &gogithub.User{Login: gogithub.String("user")},
+	}
+	commit := &gogithub.RepositoryCommit{SHA: gogithub.String("sha")}
+
+	for _, tc := range []struct {
+		name          string
+		recordFiles   bool
+		filesErr      error
+		expectedFiles []string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:          "enabled",
+			recordFiles:   true,
+			expectedFiles: []string{"pkg/kubelet/kubelet.go"},
+		},
+		{
+			name:        "files not available",
+			recordFiles: true,
+			filesErr:    errors.New("error"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &githubfakes.FakeClient{}
+			client.GetRepoCommitReturns(&gogithub.RepositoryCommit{
+				Files: []*gogithub.CommitFile{{Filename: gogithub.String("pkg/kubelet/kubelet.go")}},
+			}, nil, tc.filesErr)
+
+			gatherer := NewGathererWithClient(context.Background(), client)
+			gatherer.options.RecordFiles = tc.recordFiles
+
+			note, err := gatherer.ReleaseNoteFromCommit(&Result{commit: commit, pullRequest: pr})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedFiles, note.Files)
+		})
+	}
+}
+
+func TestApplyMapConfirmsInferred(t *testing.T) {
+	note := &ReleaseNote{
+		Text:     "Fixed a crash.",
+		Markdown: "Fixed a crash. (#1, @user)",
+		Author:   "user",
+		PrNumber: 1,
+		SIGs:     []string{"node", "scheduling"},
+		Kinds:    []string{"bug"},
+		Inferred: []string{InferredSIGs, InferredKinds},
+	}
+
+	// Inferred SIGs are not rendered when the text changes
+	noteMap := &ReleaseNotesMap{PR: 1}
+	noteMap.ReleaseNote.Text = &note.Text
+	require.NoError(t, note.ApplyMap(noteMap, false))
+	require.Equal(t, "Fixed a crash. (#1, @user)", note.Markdown)
+
+	noteMap = &ReleaseNotesMap{PR: 1}
+	noteMap.ReleaseNote.SIGs = &[]string{"node"}
+	require.NoError(t, note.ApplyMap(noteMap, false))
+	require.False(t, note.IsInferred(InferredSIGs))
+	require.True(t, note.IsInferred(InferredKinds))
+	require.Equal(t, "Fixed a crash. (#1, @user) [SIG Node]", note.Markdown)
+
+	noteMap.ReleaseNote.Kinds = &[]string{"feature"}
+	require.NoError(t, note.ApplyMap(noteMap, false))
+	require.Nil(t, note.Inferred)
+	require.Equal(t, []string{"feature"}, note.Kinds)
+}
+
+func TestReleaseNoteFromCommitChangedFilesOnce(t *testing.T) {
+	pr := &gogithub.PullRequest{
+		Number: gogithub.Int(1),
+		Body:   gogithub.String("```release-note\nFixed a crash of the kubelet.\n```"),
+		User:   &gogithub.User{Login: gogithub.String("user")},
+	}
+	commit := &gogithub.RepositoryCommit{SHA: gogithub.String("sha")}
+
+	ownershipMap, err := ReadOwnershipMap(writeOwnershipMap(t, testOwnershipMap))
+	require.NoError(t, err)
+
+	client := &githubfakes.FakeClient{}
+	client.GetRepoCommitReturns(&gogithub.RepositoryCommit{
+		Files: []*gogithub.CommitFile{{Filename: gogithub.String("pkg/kubelet/kubelet.go")}},
+	}, nil, nil)
+
+	gatherer := NewGathererWithClient(context.Background(), client)
+	gatherer.options.InferLabels = true
+	gatherer.options.RecordFiles = true
+	gatherer.ownershipMap = ownershipMap
+
+	note, err := gatherer.ReleaseNoteFromCommit(&Result{commit: commit, pullRequest: pr})
+	require.NoError(t, err)
+	require.Equal(t, []string{"node"}, note.SIGs)
+	require.Equal(t, []string{"pkg/kubelet/kubelet.go"}, note.Files)
+	require.Equal(t, 1, client.GetRepoCommitCallCount())
+}
+
+func TestChangedFilesPaginated(t *testing.T) {
+	repo := t.TempDir()
+	git := func(args ...string) string {
+		res, err := command.NewWithWorkDir(repo, "git", append([]string{
+			"-c", "user.name=test", "-c", "user.email=test@example.com",
+		}, args...)...).RunSilentSuccessOutput()
+		require.NoError(t, err)
+		return res.OutputTrimNL()
+	}
+	git("init")
+	git("commit", "--allow-empty", "-m", "first")
+	for _, file := range []string{"a.go", "pkg/b.go"} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(repo, file)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(repo, file), []byte("package a\n"), 0o600))
+	}
+	git("add", "-A")
+	git("commit", "-m", "second")
+	sha := git("rev-parse", "HEAD")
+
+	// The forge only returns the first page of files
+	client := &githubfakes.FakeClient{}
+	client.GetRepoCommitReturns(&gogithub.RepositoryCommit{
+		Files: []*gogithub.CommitFile{{Filename: gogithub.String("a.go")}},
+	}, &gogithub.Response{NextPage: 2}, nil)
+
+	gatherer := NewGathererWithClient(context.Background(), client)
+	gatherer.options.RepoPath = repo
+
+	files, err := gatherer.changedFiles(sha)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.go", "pkg/b.go"}, files)
+
+	// Offline runs have no client and use the local repository
+	offline := &Gatherer{options: &options.Options{RepoPath: repo, Offline: true}}
+	files, err = offline.changedFiles(sha)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.go", "pkg/b.go"}, files)
+
+	gatherer.options.RepoPath = t.TempDir()
+	_, err = gatherer.changedFiles(sha)
+	require.Error(t, err)
+}
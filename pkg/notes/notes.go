@@ -43,8 +43,7 @@ import (
 	"golang.org/x/text/language"
 	"gopkg.in/yaml.v2"
 
-	"sigs.k8s.io/release-sdk/github"
-
+	"k8s.io/release/pkg/notes/forge"
 	"k8s.io/release/pkg/notes/options"
 )
 
@@ -120,6 +119,10 @@ type ReleaseNote struct {
 	// SIGs is a list of the labels beginning with sig/
 	SIGs []string `json:"sigs,omitempty"`
 
+	// Files is a list of the files touched by the PR, which is only
+	// populated if recording them is enabled in the options
+	Files []string `json:"files,omitempty"`
+
 	// Indicates whether or not a note will appear as a new feature
 	Feature bool `json:"feature,omitempty"`
 
@@ -142,6 +145,10 @@ type ReleaseNote struct {
 	// IsMapped is set if the note got modified from a map
 	IsMapped bool `json:"is_mapped,omitempty"`
 
+	// Inferred lists the fields which have been proposed by the label
+	// inference instead of being set from the PR labels.
+	Inferred []string `json:"inferred,omitempty"`
+
 	// PRBody is the full PR body of the release note
 	PRBody string `json:"pr_body,omitempty"`
 }
@@ -208,33 +215,72 @@ func (r *ReleaseNotes) Set(prNumber int, note *ReleaseNote) {
 	r.history = append(r.history, prNumber)
 }
 
+// Merge adds all notes of `other` to the ReleaseNotes. Already existing notes
+// for the same PR get replaced without changing their position in the history.
+func (r *ReleaseNotes) Merge(other *ReleaseNotes) {
+	for _, prNumber := range other.History() {
+		if _, ok := r.byPR[prNumber]; !ok {
+			r.history = append(r.history, prNumber)
+		}
+		r.byPR[prNumber] = other.Get(prNumber)
+	}
+}
+
 type Result struct {
 	commit      *gogithub.RepositoryCommit
 	pullRequest *gogithub.PullRequest
 }
 
 type Gatherer struct {
-	client       github.Client
+	client       forge.Client
 	context      context.Context
 	options      *options.Options
+	prCache      *PRCache
+	ownershipMap *OwnershipMap
 	MapProviders []*MapProvider
 }
 
 // NewGatherer creates a new notes gatherer.
 func NewGatherer(ctx context.Context, opts *options.Options) (*Gatherer, error) {
-	client, err := opts.Client()
+	gatherer := &Gatherer{
+		context: ctx,
+		options: opts,
+	}
+
+	if opts.PRCacheDir != "" {
+		prCache, err := NewPRCache(
+			opts.PRCacheDir, opts.Forge, opts.GithubOrg, opts.GithubRepo,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create PR cache: %w", err)
+		}
+		gatherer.prCache = prCache
+	}
+
+	if opts.InferLabels && opts.OwnershipMapPath != "" {
+		ownershipMap, err := ReadOwnershipMap(opts.OwnershipMapPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load ownership map: %w", err)
+		}
+		gatherer.ownershipMap = ownershipMap
+	}
+
+	// The offline mode works purely from the PR cache and the local
+	// repository, which means that we do not need any client.
+	if opts.Offline {
+		return gatherer, nil
+	}
+
+	client, err := opts.ForgeClient()
 	if err != nil {
 		return nil, fmt.Errorf("unable to create notes client: %w", err)
 	}
-	return &Gatherer{
-		client:  client,
-		context: ctx,
-		options: opts,
-	}, nil
+	gatherer.client = client
+	return gatherer, nil
 }
 
 // NewGathererWithClient creates a new notes gatherer with a specific client.
-func NewGathererWithClient(ctx context.Context, c github.Client) *Gatherer {
+func NewGathererWithClient(ctx context.Context, c forge.Client) *Gatherer {
 	return &Gatherer{
 		client:  c,
 		context: ctx,
@@ -243,8 +289,16 @@ func NewGathererWithClient(ctx context.Context, c github.Client) *Gatherer {
 }
 
 // GatherReleaseNotes creates a new gatherer and collects the release notes
-// afterwards.
+// afterwards. If `StateDir` is set in the options, then the notes of a
+// previous run will be reused and only new commits get processed.
 func GatherReleaseNotes(opts *options.Options) (*ReleaseNotes, error) {
+	if opts.StateDir != "" {
+		return gatherReleaseNotesIncremental(opts)
+	}
+	return gatherReleaseNotes(opts)
+}
+
+func gatherReleaseNotes(opts *options.Options) (*ReleaseNotes, error) {
 	logrus.Info("Gathering release notes")
 	gatherer, err := NewGatherer(context.Background(), opts)
 	if err != nil {
@@ -253,7 +307,7 @@ func GatherReleaseNotes(opts *options.Options) (*ReleaseNotes, error) {
 
 	var releaseNotes *ReleaseNotes
 	startTime := time.Now()
-	if gatherer.options.ListReleaseNotesV2 {
+	if gatherer.options.ListReleaseNotesV2 || gatherer.options.Offline {
 		logrus.Warn("EXPERIMENTAL IMPLEMENTATION ListReleaseNotesV2 ENABLED")
 		releaseNotes, err = gatherer.ListReleaseNotesV2()
 	} else {
@@ -270,6 +324,12 @@ func GatherReleaseNotes(opts *options.Options) (*ReleaseNotes, error) {
 // ListReleaseNotes produces a list of fully contextualized release notes
 // starting from a given commit SHA and ending at starting a given commit SHA.
 func (g *Gatherer) ListReleaseNotes() (*ReleaseNotes, error) {
+	if g.options.Offline {
+		return nil, errors.New(
+			"listing commits requires the GitHub API, use ListReleaseNotesV2 in offline mode",
+		)
+	}
+
 	// Load map providers
 	mapProviders := []MapProvider{}
 	for _, initString := range g.options.MapProviderStrings {
@@ -487,10 +547,21 @@ func (g *Gatherer) ReleaseNoteFromCommit(result *Result) (*ReleaseNote, error) {
 	author := pr.GetUser().GetLogin()
 	authorURL := pr.GetUser().GetHTMLURL()
 	prURL := pr.GetHTMLURL()
-	isFeature := hasString(labelsWithPrefix(pr, "kind"), "feature")
 	sigLabels := labelsWithPrefix(pr, "sig")
+	kindLabels := labelsWithPrefix(pr, "kind")
+	areaLabels := labelsWithPrefix(pr, "area")
+
+	// Inferred SIGs are not part of the markdown until confirmed by a map
 	noteSuffix := prettifySIGList(sigLabels)
 
+	changedFiles := g.commitFiles(result.commit.GetSHA())
+	inferred := g.inferLabels(changedFiles, pr, text, sigLabels, kindLabels, areaLabels)
+	sigLabels = append(sigLabels, inferred.sigs...)
+	kindLabels = append(kindLabels, inferred.kinds...)
+	areaLabels = append(areaLabels, inferred.areas...)
+
+	isFeature := hasString(kindLabels, "feature")
+
 	isDuplicateSIG := false
 	if len(labelsWithPrefix(pr, "sig")) > 1 {
 		isDuplicateSIG = true
@@ -527,14 +598,16 @@ func (g *Gatherer) ReleaseNoteFromCommit(result *Result) (*ReleaseNote, error) {
 		PrURL:          prURL,
 		PrNumber:       pr.GetNumber(),
 		SIGs:           sigLabels,
-		Kinds:          labelsWithPrefix(pr, "kind"),
-		Areas:          labelsWithPrefix(pr, "area"),
+		Kinds:          kindLabels,
+		Areas:          areaLabels,
 		Feature:        isFeature,
 		Duplicate:      isDuplicateSIG,
 		DuplicateKind:  isDuplicateKind,
 		ActionRequired: labelExactMatch(pr, "release-note-action-required"),
 		DoNotPublish:   labelExactMatch(pr, "release-note-none"),
 		PRBody:         prBody,
+		Inferred:       inferred.fields(),
+		Files:          g.recordFiles(changedFiles, pr.GetNumber()),
 	}, nil
 }
 
@@ -724,7 +797,7 @@ func (g *Gatherer) gatherNotes(commits []*gogithub.RepositoryCommit) (filtered [
 // ReleaseNoteForPullRequest returns a release note from a pull request number.
 // If the release note is blank or.
 func (g *Gatherer) ReleaseNoteForPullRequest(prNr int) (*ReleaseNote, error) {
-	pr, _, err := g.client.GetPullRequest(g.context, g.options.GithubOrg, g.options.GithubRepo, prNr)
+	pr, err := g.pullRequest(prNr)
 	if err != nil {
 		return nil, fmt.Errorf("reading PR from GitHub: %w", err)
 	}
@@ -882,6 +955,21 @@ func labelsWithPrefix(pr *gogithub.PullRequest, prefix string) []string {
 	return labels
 }
 
+// recordFiles returns the files touched by the PR merge commit if recording
+// them is enabled, otherwise nil.
+func (g *Gatherer) recordFiles(changedFiles func() ([]string, error), prNumber int) []string {
+	if !g.options.RecordFiles {
+		return nil
+	}
+
+	files, err := changedFiles()
+	if err != nil {
+		logrus.Warnf("Unable to record the files of PR #%d: %v", prNumber, err)
+		return nil
+	}
+	return files
+}
+
 // labelExactMatch indicates whether or not a matching label was found on PR.
 func labelExactMatch(pr *gogithub.PullRequest, labelToFind string) bool {
 	for _, label := range pr.Labels {
@@ -1017,6 +1105,9 @@ func (g *Gatherer) prsForCommitFromSHA(sha string) (prs []*gogithub.PullRequest,
 			}
 		}
 
+		// Refresh the cache with the more recent PR data
+		g.cachePullRequests(pResult...)
+
 		for _, result := range pResult {
 			if result.GetState() == "closed" {
 				prs = append(prs, result)
@@ -1044,26 +1135,74 @@ func (g *Gatherer) prsForCommitFromMessage(commitMessage string) (prs []*gogithu
 	if err != nil {
 		return nil, err
 	}
-	var res *gogithub.PullRequest
-	var resp *gogithub.Response
-
 	for _, pr := range prsNum {
 		// Given the PR number that we've now converted to an integer, get the PR from
-		// the API
-		for {
-			res, resp, err = g.client.GetPullRequest(g.context, g.options.GithubOrg, g.options.GithubRepo, pr)
-			if err != nil {
-				if !canWaitAndRetry(resp, err) {
-					return nil, err
-				}
-			} else {
-				break
-			}
+		// the cache or API
+		res, err := g.pullRequest(pr)
+		if err != nil {
+			return nil, err
 		}
 		prs = append(prs, res)
 	}
 
-	return prs, err
+	return prs, nil
+}
+
+// pullRequest returns the pull request for the provided number. The PR cache
+// is consulted first, if configured, before falling back to the API. Cached
+// entries get refreshed whenever the pull requests of a commit are listed,
+// while offline runs fail on cache misses.
+func (g *Gatherer) pullRequest(number int) (*gogithub.PullRequest, error) {
+	if g.prCache != nil {
+		pr, err := g.prCache.Get(number)
+		if err != nil {
+			return nil, err
+		}
+		if pr != nil {
+			return pr, nil
+		}
+	}
+
+	if g.options.Offline {
+		if g.prCache == nil {
+			return nil, errors.New("offline mode requires a PR cache")
+		}
+		return nil, fmt.Errorf("PR #%d not found in offline PR cache", number)
+	}
+
+	var (
+		pr   *gogithub.PullRequest
+		resp *gogithub.Response
+		err  error
+	)
+	for {
+		pr, resp, err = g.client.GetPullRequest(
+			g.context, g.options.GithubOrg, g.options.GithubRepo, number,
+		)
+		if err != nil {
+			if !canWaitAndRetry(resp, err) {
+				return nil, err
+			}
+		} else {
+			break
+		}
+	}
+
+	g.cachePullRequests(pr)
+	return pr, nil
+}
+
+// cachePullRequests adds the provided pull requests to the PR cache, if
+// configured. Failing to cache is not considered to be critical.
+func (g *Gatherer) cachePullRequests(prs ...*gogithub.PullRequest) {
+	if g.prCache == nil {
+		return
+	}
+	for _, pr := range prs {
+		if err := g.prCache.Put(pr); err != nil {
+			logrus.Warnf("Unable to cache PR #%d: %v", pr.GetNumber(), err)
+		}
+	}
 }
 
 func prsNumForCommitFromMessage(commitMessage string) (prs []int, err error) {
@@ -1088,6 +1227,13 @@ func prsNumForCommitFromMessage(commitMessage string) (prs []int, err error) {
 		prs = append(prs, pr)
 	}
 
+	// GitLab merge commits reference the merge request by its project path
+	regex = regexp.MustCompile(`See merge request \S*!(?P<number>\d+)`)
+	pr = prForRegex(regex, commitMessage)
+	if pr != 0 {
+		prs = append(prs, pr)
+	}
+
 	if prs == nil {
 		return nil, errNoPRIDFoundInCommitMessage
 	}
@@ -1177,7 +1323,7 @@ func (rn *ReleaseNote) ApplyMap(noteMap *ReleaseNotesMap, markdownLinks bool) er
 		logrus.Warnf("The diff between actual release note body and mapped one is:\n%s", dmp.DiffPrettyText(diffs))
 	}
 
-	reRenderMarkdown := false
+	reRenderMarkdown, confirmedSIGs := false, false
 	if noteMap.ReleaseNote.Author != nil {
 		rn.Author = *noteMap.ReleaseNote.Author
 		rn.AuthorURL = "https://github.com/" + *noteMap.ReleaseNote.Author
@@ -1195,14 +1341,22 @@ func (rn *ReleaseNote) ApplyMap(noteMap *ReleaseNotesMap, markdownLinks bool) er
 
 	if noteMap.ReleaseNote.Areas != nil {
 		rn.Areas = *noteMap.ReleaseNote.Areas
+		rn.confirmInferred(InferredAreas)
 	}
 
 	if noteMap.ReleaseNote.Kinds != nil {
 		rn.Kinds = *noteMap.ReleaseNote.Kinds
+		rn.confirmInferred(InferredKinds)
 	}
 
 	if noteMap.ReleaseNote.SIGs != nil {
 		rn.SIGs = *noteMap.ReleaseNote.SIGs
+		if rn.IsInferred(InferredSIGs) {
+			// Confirmed SIGs become part of the markdown
+			rn.confirmInferred(InferredSIGs)
+			confirmedSIGs = true
+			reRenderMarkdown = true
+		}
 	}
 
 	if noteMap.ReleaseNote.Feature != nil {
@@ -1236,7 +1390,7 @@ func (rn *ReleaseNote) ApplyMap(noteMap *ReleaseNotesMap, markdownLinks bool) er
 				indented, rn.PrNumber, rn.PrURL, rn.Author, rn.AuthorURL)
 		}
 		// Add sig labels to markdown
-		if len(rn.SIGs) > 1 {
+		if (len(rn.SIGs) > 1 || (confirmedSIGs && len(rn.SIGs) > 0)) && !rn.IsInferred(InferredSIGs) {
 			markdown = fmt.Sprintf("%s [%s]", markdown, prettifySIGList(rn.SIGs))
 		}
 		// Uppercase the first character of the markdown to make it look uniform
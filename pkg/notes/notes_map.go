@@ -22,6 +22,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
@@ -36,10 +37,14 @@ type MapProvider interface {
 
 // NewProviderFromInitString creates a new map provider from an initialization string.
 func NewProviderFromInitString(initString string) (MapProvider, error) {
-	// If init string starts with gs:// return a CloudStorageProvider
-	if len(initString) >= 5 && initString[0:5] == object.GcsPrefix {
-		// Currently for illustration purposes
-		return nil, errors.New("CloudStorageProvider is not yet implemented")
+	// If init string starts with gs:// return a CloudStorageMapProvider
+	if strings.HasPrefix(initString, object.GcsPrefix) {
+		return NewCloudStorageMapProvider(initString)
+	}
+
+	// If init string starts with git+ return a GitMapProvider
+	if strings.HasPrefix(initString, GitMapPrefix) {
+		return NewGitMapProvider(initString)
 	}
 
 	// Otherwise, build a DirectoryMapProvider using the
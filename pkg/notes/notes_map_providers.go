@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-sdk/object"
+)
+
+// GitMapPrefix is the prefix of map provider init strings which refer to a
+// git repository.
+const GitMapPrefix = "git+"
+
+// CloudStorageMapProvider is a provider that gets maps from a Google Cloud
+// Storage path. The maps are downloaded once on first use.
+type CloudStorageMapProvider struct {
+	// Path is the GCS path containing the maps, prefixed with gs://
+	Path string
+
+	store object.Store
+
+	once      sync.Once
+	directory *DirectoryMapProvider
+	err       error
+}
+
+// NewCloudStorageMapProvider creates a new map provider for the GCS path.
+func NewCloudStorageMapProvider(gcsPath string) (*CloudStorageMapProvider, error) {
+	store := object.NewGCS()
+	normalizedPath, err := store.NormalizePath(gcsPath)
+	if err != nil {
+		return nil, fmt.Errorf("normalize release notes map path: %w", err)
+	}
+	return &CloudStorageMapProvider{Path: normalizedPath, store: store}, nil
+}
+
+// GetMapsForPR get the release notes maps for a specific PR number.
+// It is safe for concurrent use.
+func (mp *CloudStorageMapProvider) GetMapsForPR(pr int) ([]*ReleaseNotesMap, error) {
+	mp.once.Do(func() {
+		mp.directory, mp.err = mp.readMaps()
+	})
+	if mp.err != nil {
+		return nil, fmt.Errorf("while reading release notes maps: %w", mp.err)
+	}
+	return mp.directory.GetMapsForPR(pr)
+}
+
+// readMaps downloads the maps into a temporary directory and parses them.
+func (mp *CloudStorageMapProvider) readMaps() (*DirectoryMapProvider, error) {
+	tempDir, err := os.MkdirTemp("", "release-notes-maps-")
+	if err != nil {
+		return nil, fmt.Errorf("create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	logrus.Infof("Downloading release notes maps from %s", mp.Path)
+	if err := mp.store.RsyncRecursive(mp.Path, tempDir); err != nil {
+		return nil, fmt.Errorf("download release notes maps: %w", err)
+	}
+
+	return readDirectoryMaps(tempDir)
+}
+
+// GitMapProvider is a provider that gets maps from a git repository. The
+// repository is cloned once on first use.
+type GitMapProvider struct {
+	// URL is the git repository URL to be cloned.
+	URL string
+
+	// Ref is the branch, tag or commit to read the maps from. The default
+	// branch of the repository is used if empty.
+	Ref string
+
+	// Path is the directory containing the maps relative to the repository
+	// root.
+	Path string
+
+	once      sync.Once
+	directory *DirectoryMapProvider
+	err       error
+}
+
+// NewGitMapProvider creates a new map provider from an init string in the
+// format `git+<url>[#<ref>[:<path>]]`, for example:
+// `git+https://github.com/kubernetes/sig-release#master:releases/release-1.30/release-notes/maps`
+func NewGitMapProvider(initString string) (*GitMapProvider, error) {
+	if !strings.HasPrefix(initString, GitMapPrefix) {
+		return nil, fmt.Errorf("git map provider has to start with %s", GitMapPrefix)
+	}
+
+	provider := &GitMapProvider{}
+	location, fragment, _ := strings.Cut(strings.TrimPrefix(initString, GitMapPrefix), "#")
+	provider.URL = location
+	provider.Ref, provider.Path, _ = strings.Cut(fragment, ":")
+
+	if provider.URL == "" {
+		return nil, errors.New("git map provider requires a repository URL")
+	}
+	if filepath.IsAbs(provider.Path) || strings.HasPrefix(filepath.Clean(provider.Path), "..") {
+		return nil, fmt.Errorf("git map provider path %q is not within the repository", provider.Path)
+	}
+	return provider, nil
+}
+
+// GetMapsForPR get the release notes maps for a specific PR number.
+// It is safe for concurrent use.
+func (mp *GitMapProvider) GetMapsForPR(pr int) ([]*ReleaseNotesMap, error) {
+	mp.once.Do(func() {
+		mp.directory, mp.err = mp.readMaps()
+	})
+	if mp.err != nil {
+		return nil, fmt.Errorf("while reading release notes maps: %w", mp.err)
+	}
+	return mp.directory.GetMapsForPR(pr)
+}
+
+// readMaps clones the repository into a temporary directory, checks out the
+// configured ref and parses the maps.
+func (mp *GitMapProvider) readMaps() (*DirectoryMapProvider, error) {
+	logrus.Infof("Cloning release notes maps from %s", mp.URL)
+	repo, err := git.CloneOrOpenRepo("", mp.URL, false, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("clone release notes maps repository: %w", err)
+	}
+	defer func() {
+		if err := repo.Cleanup(); err != nil {
+			logrus.Warnf("Unable to cleanup release notes maps repository: %v", err)
+		}
+	}()
+
+	if mp.Ref != "" {
+		if err := repo.Checkout(mp.Ref); err != nil {
+			return nil, fmt.Errorf("checkout %s: %w", mp.Ref, err)
+		}
+	}
+
+	return readDirectoryMaps(filepath.Join(repo.Dir(), mp.Path))
+}
+
+// providerMaps returns all release notes maps of the provider by PR.
+func providerMaps(provider MapProvider) (map[int][]*ReleaseNotesMap, error) {
+	// Retrieving the maps of any PR loads all of them
+	if _, err := provider.GetMapsForPR(0); err != nil {
+		return nil, err
+	}
+	switch p := provider.(type) {
+	case *DirectoryMapProvider:
+		return p.Maps, nil
+	case *CloudStorageMapProvider:
+		return p.directory.Maps, nil
+	case *GitMapProvider:
+		return p.directory.Maps, nil
+	default:
+		return nil, fmt.Errorf("unsupported release notes map provider %T", provider)
+	}
+}
+
+// readDirectoryMaps eagerly parses the maps of a directory, which allows
+// removing it afterwards.
+func readDirectoryMaps(path string) (*DirectoryMapProvider, error) {
+	fileStat, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("release notes map path: %w", err)
+	}
+	if !fileStat.IsDir() {
+		return nil, errors.New("release notes map path is not a directory")
+	}
+
+	directory := &DirectoryMapProvider{Path: path}
+	if err := directory.readMaps(); err != nil {
+		return nil, err
+	}
+	return directory, nil
+}
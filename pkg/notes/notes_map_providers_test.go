@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/release-sdk/object/objectfakes"
+)
+
+const testMap = `pr: 123
+releasenote:
+  text: %s
+`
+
+func TestNewGitMapProvider(t *testing.T) {
+	for _, tc := range []struct {
+		initString string
+		expected   *GitMapProvider
+		shouldErr  bool
+	}{
+		{
+			initString: "git+https://github.com/kubernetes/sig-release#master:releases/maps",
+			expected: &GitMapProvider{
+				URL:  "https://github.com/kubernetes/sig-release",
+				Ref:  "master",
+				Path: "releases/maps",
+			},
+		},
+		{
+			initString: "git+git@github.com:kubernetes/sig-release.git#v1.0.0",
+			expected: &GitMapProvider{
+				URL: "git@github.com:kubernetes/sig-release.git",
+				Ref: "v1.0.0",
+			},
+		},
+		{
+			initString: "git+https://github.com/kubernetes/sig-release#:maps",
+			expected: &GitMapProvider{
+				URL:  "https://github.com/kubernetes/sig-release",
+				Path: "maps",
+			},
+		},
+		{initString: "https://github.com/kubernetes/sig-release", shouldErr: true},
+		{initString: "git+#master", shouldErr: true},
+		{initString: "git+https://github.com/kubernetes/sig-release#master:/maps", shouldErr: true},
+	} {
+		provider, err := NewGitMapProvider(tc.initString)
+		if tc.shouldErr {
+			require.Error(t, err, tc.initString)
+			continue
+		}
+		require.NoError(t, err, tc.initString)
+		require.Equal(t, tc.expected, provider)
+	}
+}
+
+func TestGitMapProviderGetMapsForPR(t *testing.T) {
+	repoDir := t.TempDir()
+	repo, err := gogit.PlainInit(repoDir, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	commitMap := func(text string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(repoDir, "maps"), 0o755))
+		require.NoError(t, os.WriteFile(
+			filepath.Join(repoDir, "maps", "pr-123.yaml"),
+			[]byte(fmt.Sprintf(testMap, text)), 0o600,
+		))
+		_, err := worktree.Add("maps")
+		require.NoError(t, err)
+		_, err = worktree.Commit(text, &gogit.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+	}
+
+	commitMap("first")
+	head, err := repo.Head()
+	require.NoError(t, err)
+	_, err = repo.CreateTag("v1.0.0", head.Hash(), nil)
+	require.NoError(t, err)
+	commitMap("second")
+
+	for ref, expected := range map[string]string{"": "second", "v1.0.0": "first"} {
+		provider, err := NewGitMapProvider(GitMapPrefix + repoDir + "#" + ref + ":maps")
+		require.NoError(t, err)
+
+		maps, err := provider.GetMapsForPR(123)
+		require.NoError(t, err)
+		require.Len(t, maps, 1)
+		require.Equal(t, expected, *maps[0].ReleaseNote.Text)
+
+		maps, err = provider.GetMapsForPR(456)
+		require.NoError(t, err)
+		require.Empty(t, maps)
+	}
+
+	provider, err := NewGitMapProvider(GitMapPrefix + repoDir + "#invalid")
+	require.NoError(t, err)
+	_, err = provider.GetMapsForPR(123)
+	require.Error(t, err)
+}
+
+func TestCloudStorageMapProviderGetMapsForPR(t *testing.T) {
+	store := &objectfakes.FakeStore{}
+	store.RsyncRecursiveCalls(func(_, dst string) error {
+		return os.WriteFile(
+			filepath.Join(dst, "maps.yaml"), []byte(fmt.Sprintf(testMap, "text")), 0o600,
+		)
+	})
+	provider := &CloudStorageMapProvider{Path: "gs://bucket/maps", store: store}
+
+	maps, err := provider.GetMapsForPR(123)
+	require.NoError(t, err)
+	require.Len(t, maps, 1)
+	require.Equal(t, "text", *maps[0].ReleaseNote.Text)
+
+	// The maps are only downloaded once, even on concurrent use
+	var wg sync.WaitGroup
+	for pr := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := provider.GetMapsForPR(pr)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 1, store.RsyncRecursiveCallCount())
+	src, _ := store.RsyncRecursiveArgsForCall(0)
+	require.Equal(t, "gs://bucket/maps", src)
+
+	store.RsyncRecursiveReturns(errors.New("error"))
+	provider = &CloudStorageMapProvider{Path: "gs://bucket/maps", store: store}
+	_, err = provider.GetMapsForPR(123)
+	require.Error(t, err)
+
+	// The download error is kept for subsequent calls
+	_, err = provider.GetMapsForPR(456)
+	require.Error(t, err)
+	require.Equal(t, 2, store.RsyncRecursiveCallCount())
+}
@@ -29,7 +29,11 @@ func TestNewProviderFromInitString(t *testing.T) {
 	}{
 		{initString: "maps/testdata/applymap-unit-test/", returnsError: false},
 		{initString: "/this/shoud/not/really.exist/as/a/d33rect0ree", returnsError: true},
-		{initString: "gs://bucket-name/map/path/", returnsError: true},
+		{initString: "gs://bucket-name/map/path/", returnsError: false},
+		{initString: "git+https://github.com/kubernetes/sig-release#master:maps", returnsError: false},
+		{initString: "git+https://github.com/kubernetes/sig-release", returnsError: false},
+		{initString: "git+", returnsError: true},
+		{initString: "git+https://github.com/kubernetes/sig-release#master:../maps", returnsError: true},
 		{initString: "github://kubernetes/sig-release/maps", returnsError: true},
 	}
 	for _, testCase := range testCases {
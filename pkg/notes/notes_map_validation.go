@@ -0,0 +1,447 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ReleaseNotesMapSchema is the JSON schema of a single ReleaseNotesMap
+// document.
+//
+//go:embed notes_map.schema.json
+var ReleaseNotesMapSchema []byte
+
+// MapValidationSeverity is the severity of a MapValidationIssue.
+type MapValidationSeverity string
+
+const (
+	MapValidationError   MapValidationSeverity = "error"
+	MapValidationWarning MapValidationSeverity = "warning"
+)
+
+// MapLabels are the known label values of release notes maps. Label values
+// are not validated if the corresponding list is empty.
+type MapLabels struct {
+	Kinds []string
+	SIGs  []string
+	Areas []string
+}
+
+// DefaultMapLabels returns the kinds of the release notes and the current
+// Kubernetes SIGs as known labels. Areas are not validated by default.
+func DefaultMapLabels() *MapLabels {
+	return &MapLabels{
+		Kinds: []string{
+			string(KindAPIChange),
+			string(KindBug),
+			string(KindCleanup),
+			string(KindDeprecation),
+			string(KindDesign),
+			string(KindDocumentation),
+			string(KindFailingTest),
+			string(KindFeature),
+			string(KindFlake),
+			string(KindRegression),
+		},
+		SIGs: []string{
+			"api-machinery",
+			"apps",
+			"architecture",
+			"auth",
+			"autoscaling",
+			"cli",
+			"cloud-provider",
+			"cluster-lifecycle",
+			"contributor-experience",
+			"docs",
+			"etcd",
+			"instrumentation",
+			"k8s-infra",
+			"multicluster",
+			"network",
+			"node",
+			"release",
+			"scalability",
+			"scheduling",
+			"security",
+			"storage",
+			"testing",
+			"ui",
+			"windows",
+		},
+	}
+}
+
+// MapValidationIssue is a single finding of the MapValidator.
+type MapValidationIssue struct {
+	// File is the path of the map file.
+	File string
+
+	// Line is the first line of the YAML document within the file.
+	Line int
+
+	// PR is the pull request number of the map, if known.
+	PR int
+
+	Severity MapValidationSeverity
+	Message  string
+}
+
+// String returns the issue in the `file:line: severity: message` format.
+func (i *MapValidationIssue) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s", i.File, i.Line, i.Severity, i.Message)
+}
+
+// GitHubAnnotation returns the issue as GitHub Actions workflow command,
+// which annotates the file within the pull request.
+func (i *MapValidationIssue) GitHubAnnotation() string {
+	// Workflow command data has to escape %, \r and \n
+	escaper := strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	return fmt.Sprintf(
+		"::%s file=%s,line=%d,title=Release notes map::%s",
+		i.Severity, i.File, i.Line, escaper.Replace(i.Message),
+	)
+}
+
+// MapValidator validates release notes map files against the
+// ReleaseNotesMapSchema and the known labels. It also reports maps for PRs
+// outside of the release and duplicate maps across all validated files.
+type MapValidator struct {
+	labels     *MapLabels
+	releasePRs map[int]bool
+	seen       map[int]*mapLocation
+	issues     []MapValidationIssue
+}
+
+// mapLocation is the location of a previously validated map.
+type mapLocation struct {
+	file    string
+	line    int
+	hasText bool
+}
+
+// NewMapValidator creates a new validator for the provided known labels.
+func NewMapValidator(labels *MapLabels) *MapValidator {
+	return &MapValidator{
+		labels: labels,
+		seen:   map[int]*mapLocation{},
+		issues: []MapValidationIssue{},
+	}
+}
+
+// SetReleasePRs sets the PRs which are part of the release. If set, maps for
+// other PRs are reported.
+func (v *MapValidator) SetReleasePRs(prs []int) {
+	v.releasePRs = map[int]bool{}
+	for _, pr := range prs {
+		v.releasePRs[pr] = true
+	}
+}
+
+// Issues returns all issues found so far.
+func (v *MapValidator) Issues() []MapValidationIssue {
+	return v.issues
+}
+
+// HasErrors returns true if any issue has the MapValidationError severity.
+func (v *MapValidator) HasErrors() bool {
+	for i := range v.issues {
+		if v.issues[i].Severity == MapValidationError {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateFile validates all YAML documents of the map file. The returned
+// error indicates that the file could not be read, while validation findings
+// are collected as issues.
+func (v *MapValidator) ValidateFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading map file: %w", err)
+	}
+
+	for _, doc := range splitYAMLDocuments(string(content)) {
+		v.validateDocument(path, doc.line, doc.content)
+	}
+	return nil
+}
+
+func (v *MapValidator) validateDocument(file string, line int, content string) {
+	report := func(pr int, severity MapValidationSeverity, format string, args ...any) {
+		v.issues = append(v.issues, MapValidationIssue{
+			File:     file,
+			Line:     line,
+			PR:       pr,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	jsonContent, err := yaml.YAMLToJSON([]byte(content))
+	if err != nil {
+		report(0, MapValidationError, "parsing map: %v", err)
+		return
+	}
+
+	var value any
+	if err := json.Unmarshal(jsonContent, &value); err != nil {
+		report(0, MapValidationError, "parsing map: %v", err)
+		return
+	}
+
+	if errs := mapSchema.validate("", value); len(errs) > 0 {
+		pr := 0
+		if object, ok := value.(map[string]any); ok {
+			if number, ok := object["pr"].(float64); ok {
+				pr = int(number)
+			}
+		}
+		for _, err := range errs {
+			report(pr, MapValidationError, "schema: %s", err)
+		}
+		return
+	}
+
+	noteMap := &ReleaseNotesMap{}
+	if err := yaml.Unmarshal([]byte(content), noteMap); err != nil {
+		report(0, MapValidationError, "parsing map: %v", err)
+		return
+	}
+	pr := noteMap.PR
+
+	note := &noteMap.ReleaseNote
+	for _, field := range []struct {
+		name   string
+		values *[]string
+		known  []string
+	}{
+		{"kinds", note.Kinds, v.labels.Kinds},
+		{"sigs", note.SIGs, v.labels.SIGs},
+		{"areas", note.Areas, v.labels.Areas},
+	} {
+		if field.values == nil || len(field.known) == 0 {
+			continue
+		}
+		for _, value := range *field.values {
+			if !slices.Contains(field.known, value) {
+				report(pr, MapValidationError, "unknown %s label value: %s", field.name, value)
+			}
+		}
+	}
+
+	if v.releasePRs != nil && !v.releasePRs[pr] {
+		report(pr, MapValidationError, "PR #%d is not part of the release", pr)
+	}
+
+	hasText := note.Text != nil
+	if previous, ok := v.seen[pr]; ok {
+		severity := MapValidationWarning
+		if hasText && previous.hasText {
+			// Only one of the texts will end up in the release notes
+			severity = MapValidationError
+		}
+		report(pr, severity,
+			"duplicate map for PR #%d, first defined in %s:%d",
+			pr, previous.file, previous.line,
+		)
+		previous.hasText = previous.hasText || hasText
+		return
+	}
+	v.seen[pr] = &mapLocation{file: file, line: line, hasText: hasText}
+}
+
+var (
+	yamlDocStartRE = regexp.MustCompile(`^---\s*$`)
+	yamlEmptyRE    = regexp.MustCompile(`^\s*(#.*)?$`)
+)
+
+// yamlDocument is a single document of a YAML stream.
+type yamlDocument struct {
+	line    int
+	content string
+}
+
+// splitYAMLDocuments splits a YAML stream into its non empty documents,
+// recording the line number where each document starts.
+func splitYAMLDocuments(content string) []yamlDocument {
+	docs := []yamlDocument{}
+	var (
+		lines   []string
+		start   int
+		isEmpty = true
+	)
+	flush := func() {
+		if !isEmpty {
+			docs = append(docs, yamlDocument{line: start, content: strings.Join(lines, "\n")})
+		}
+		lines = nil
+		isEmpty = true
+	}
+
+	for i, line := range strings.Split(content, "\n") {
+		if yamlDocStartRE.MatchString(line) {
+			flush()
+			continue
+		}
+		if isEmpty && !yamlEmptyRE.MatchString(line) {
+			isEmpty = false
+			start = i + 1
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return docs
+}
+
+// mapSchema is the parsed ReleaseNotesMapSchema.
+var mapSchema = mustParseSchema(ReleaseNotesMapSchema)
+
+// jsonSchema is the subset of JSON schema used by the ReleaseNotesMapSchema.
+type jsonSchema struct {
+	Type                 string                 `json:"type"`
+	Required             []string               `json:"required"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	Items                *jsonSchema            `json:"items"`
+	Enum                 []string               `json:"enum"`
+	Minimum              *float64               `json:"minimum"`
+	MinLength            *int                   `json:"minLength"`
+	Pattern              string                 `json:"pattern"`
+}
+
+func mustParseSchema(content []byte) *jsonSchema {
+	schema := &jsonSchema{}
+	if err := json.Unmarshal(content, schema); err != nil {
+		panic(fmt.Sprintf("invalid release notes map schema: %v", err))
+	}
+	return schema
+}
+
+// validate returns the violations of the schema by the decoded JSON value,
+// prefixed by their path within the document.
+func (s *jsonSchema) validate(path string, value any) []string {
+	violation := func(format string, args ...any) []string {
+		location := path
+		if location == "" {
+			location = "map"
+		}
+		return []string{location + ": " + fmt.Sprintf(format, args...)}
+	}
+
+	if len(s.Enum) > 0 {
+		str, ok := value.(string)
+		if !ok || !slices.Contains(s.Enum, str) {
+			return violation("must be one of %s", strings.Join(s.Enum, ", "))
+		}
+		return nil
+	}
+
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return violation("must be an object")
+		}
+		return s.validateObject(path, object)
+
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			return violation("must be a list")
+		}
+		res := []string{}
+		for i, item := range array {
+			if s.Items != nil {
+				res = append(res, s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item)...)
+			}
+		}
+		return res
+
+	case "integer":
+		number, ok := value.(float64)
+		if !ok || number != float64(int64(number)) {
+			return violation("must be an integer")
+		}
+		if s.Minimum != nil && number < *s.Minimum {
+			return violation("must be at least %v", *s.Minimum)
+		}
+
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return violation("must be a string")
+		}
+		if s.MinLength != nil && len(strings.TrimSpace(str)) < *s.MinLength {
+			return violation("must not be empty")
+		}
+		if s.Pattern != "" && !regexp.MustCompile(s.Pattern).MatchString(str) {
+			return violation("must match %s", s.Pattern)
+		}
+
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return violation("must be a boolean")
+		}
+	}
+	return nil
+}
+
+func (s *jsonSchema) validateObject(path string, object map[string]any) []string {
+	res := []string{}
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+
+	for _, key := range s.Required {
+		if _, ok := object[key]; !ok {
+			res = append(res, join(key)+": is required")
+		}
+	}
+
+	keys := []string{}
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		property, ok := s.Properties[key]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				res = append(res, join(key)+": unknown field")
+			}
+			continue
+		}
+		res = append(res, property.validate(join(key), object[key])...)
+	}
+	return res
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func jsonFieldNames(t reflect.Type) []string {
+	names := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func schemaPropertyNames(schema *jsonSchema) []string {
+	names := []string{}
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestReleaseNotesMapSchemaMatchesType(t *testing.T) {
+	mapType := reflect.TypeOf(ReleaseNotesMap{})
+	require.Equal(t, jsonFieldNames(mapType), schemaPropertyNames(mapSchema))
+
+	noteField, ok := mapType.FieldByName("ReleaseNote")
+	require.True(t, ok)
+	require.Equal(t,
+		jsonFieldNames(noteField.Type),
+		schemaPropertyNames(mapSchema.Properties["releasenote"]),
+	)
+
+	require.Equal(t,
+		jsonFieldNames(reflect.TypeOf(Documentation{})),
+		schemaPropertyNames(mapSchema.Properties["releasenote"].Properties["documentation"].Items),
+	)
+}
+
+func TestMapValidator(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		files      []string
+		releasePRs []int
+		expected   []MapValidationIssue
+	}{
+		{
+			name: "valid maps",
+			files: []string{
+				"pr: 1\nreleasenote:\n  text: Text.\n  kinds: [bug]\n  sigs: [node]\n  areas: [kubelet]\n" +
+					"  documentation:\n  - url: https://kubernetes.io/docs\n    type: official\n" +
+					"---\npr: 2\ncommit: 1a89038915\ndatafields:\n  cve:\n    id: CVE-1\n",
+			},
+			releasePRs: []int{1, 2},
+			expected:   []MapValidationIssue{},
+		},
+		{
+			name: "schema violations",
+			files: []string{
+				"# comment\n\npr: 0\nunknown: true\nreleasenote:\n  text: ' '\n  feature: 'yes'\n" +
+					"  documentation:\n  - type: kep\n",
+			},
+			expected: []MapValidationIssue{
+				{Line: 3, Severity: MapValidationError, Message: "schema: pr: must be at least 1"},
+				{Line: 3, Severity: MapValidationError, Message: "schema: releasenote.documentation[0].url: is required"},
+				{Line: 3, Severity: MapValidationError, Message: "schema: releasenote.documentation[0].type: must be one of external, KEP, official"},
+				{Line: 3, Severity: MapValidationError, Message: "schema: releasenote.feature: must be a boolean"},
+				{Line: 3, Severity: MapValidationError, Message: "schema: releasenote.text: must not be empty"},
+				{Line: 3, Severity: MapValidationError, Message: "schema: unknown: unknown field"},
+			},
+		},
+		{
+			name:  "missing pr",
+			files: []string{"releasenote:\n  text: Text.\n"},
+			expected: []MapValidationIssue{
+				{Line: 1, Severity: MapValidationError, Message: "schema: pr: is required"},
+			},
+		},
+		{
+			name:  "unknown labels",
+			files: []string{"pr: 1\nreleasenote:\n  kinds: [bugfix]\n  sigs: [node, nodes]\n  areas: [kubelet, kubectl]\n"},
+			expected: []MapValidationIssue{
+				{Line: 1, PR: 1, Severity: MapValidationError, Message: "unknown kinds label value: bugfix"},
+				{Line: 1, PR: 1, Severity: MapValidationError, Message: "unknown sigs label value: nodes"},
+				{Line: 1, PR: 1, Severity: MapValidationError, Message: "unknown areas label value: kubectl"},
+			},
+		},
+		{
+			name:       "PR not in release",
+			files:      []string{"pr: 1\n---\npr: 3\n"},
+			releasePRs: []int{1, 2},
+			expected: []MapValidationIssue{
+				{Line: 3, PR: 3, Severity: MapValidationError, Message: "PR #3 is not part of the release"},
+			},
+		},
+		{
+			name: "duplicate maps",
+			files: []string{
+				"pr: 1\nreleasenote:\n  text: First.\n",
+				"pr: 1\ndatafields:\n  cve:\n    id: CVE-1\n---\npr: 1\nreleasenote:\n  text: Second.\n",
+			},
+			expected: []MapValidationIssue{
+				{File: "1.yaml", Line: 1, PR: 1, Severity: MapValidationWarning, Message: "duplicate map for PR #1, first defined in 0.yaml:1"},
+				{File: "1.yaml", Line: 6, PR: 1, Severity: MapValidationError, Message: "duplicate map for PR #1, first defined in 0.yaml:1"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			labels := DefaultMapLabels()
+			labels.Areas = []string{"kubelet"}
+			validator := NewMapValidator(labels)
+			if tc.releasePRs != nil {
+				validator.SetReleasePRs(tc.releasePRs)
+			}
+
+			for i, content := range tc.files {
+				path := filepath.Join(dir, fmt.Sprint(i)+".yaml")
+				require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+				require.NoError(t, validator.ValidateFile(path))
+			}
+
+			issues := validator.Issues()
+			for i := range issues {
+				issues[i].File = strings.ReplaceAll(issues[i].File, dir+string(filepath.Separator), "")
+				issues[i].Message = strings.ReplaceAll(issues[i].Message, dir+string(filepath.Separator), "")
+			}
+			for i := range tc.expected {
+				if tc.expected[i].File == "" {
+					tc.expected[i].File = "0.yaml"
+				}
+			}
+			require.Equal(t, tc.expected, issues)
+
+			hasErrors := false
+			for _, issue := range tc.expected {
+				hasErrors = hasErrors || issue.Severity == MapValidationError
+			}
+			require.Equal(t, hasErrors, validator.HasErrors())
+		})
+	}
+
+	require.Error(t, NewMapValidator(DefaultMapLabels()).ValidateFile("/does/not/exist.yaml"))
+}
+
+func TestMapValidationIssueFormat(t *testing.T) {
+	issue := &MapValidationIssue{
+		File:     "maps/pr-1.yaml",
+		Line:     3,
+		PR:       1,
+		Severity: MapValidationWarning,
+		Message:  "100% wrong\nsecond line",
+	}
+	require.Equal(t, "maps/pr-1.yaml:3: warning: 100% wrong\nsecond line", issue.String())
+	require.Equal(t,
+		"::warning file=maps/pr-1.yaml,line=3,title=Release notes map::100%25 wrong%0Asecond line",
+		issue.GitHubAnnotation(),
+	)
+}
@@ -219,6 +219,20 @@ func TestGetPRNumberFromCommitMessage(t *testing.T) {
 			commitMessage:    "Add swapoff to centos so kubelet starts (#504)",
 			expectedPRNumber: 504,
 		},
+		{
+			name: "Get merge request number from GitLab merge commit",
+			commitMessage: `Merge branch 'fix-kubelet-crash' into 'main'
+
+Fix kubelet crash
+
+See merge request group/subgroup/project!42`,
+			expectedPRNumber: 42,
+		},
+		{
+			name:             "Get PR number from Gitea merge commit",
+			commitMessage:    "Merge pull request 'Fix kubelet crash' (#7) from fix-kubelet-crash into main",
+			expectedPRNumber: 7,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -85,7 +85,8 @@ func (g *Gatherer) ListReleaseNotesV2() (*ReleaseNotes, error) {
 		go func(pair *commitPrPair) {
 			noteMaps := []*ReleaseNotesMap{}
 			for _, provider := range mapProviders {
-				noteMaps, err = provider.GetMapsForPR(pair.PrNum)
+				maps, err := provider.GetMapsForPR(pair.PrNum)
+				noteMaps = maps
 				if err != nil {
 					logrus.WithFields(logrus.Fields{
 						"pr": pair.PrNum,
@@ -141,7 +142,7 @@ func (g *Gatherer) ListReleaseNotesV2() (*ReleaseNotes, error) {
 }
 
 func (g *Gatherer) buildReleaseNote(pair *commitPrPair) (*ReleaseNote, error) {
-	pr, _, err := g.client.GetPullRequest(g.context, g.options.GithubOrg, g.options.GithubRepo, pair.PrNum)
+	pr, err := g.pullRequest(pair.PrNum)
 	if err != nil {
 		return nil, err
 	}
@@ -166,10 +167,21 @@ func (g *Gatherer) buildReleaseNote(pair *commitPrPair) (*ReleaseNote, error) {
 	author := pr.GetUser().GetLogin()
 	authorURL := pr.GetUser().GetHTMLURL()
 	prURL := pr.GetHTMLURL()
-	isFeature := hasString(labelsWithPrefix(pr, "kind"), "feature")
 	sigLabels := labelsWithPrefix(pr, "sig")
+	kindLabels := labelsWithPrefix(pr, "kind")
+	areaLabels := labelsWithPrefix(pr, "area")
+
+	// Inferred SIGs are not part of the markdown until confirmed by a map
 	noteSuffix := prettifySIGList(sigLabels)
 
+	changedFiles := g.commitFiles(pair.Commit.Hash.String())
+	inferred := g.inferLabels(changedFiles, pr, text, sigLabels, kindLabels, areaLabels)
+	sigLabels = append(sigLabels, inferred.sigs...)
+	kindLabels = append(kindLabels, inferred.kinds...)
+	areaLabels = append(areaLabels, inferred.areas...)
+
+	isFeature := hasString(kindLabels, "feature")
+
 	isDuplicateSIG := false
 	if len(labelsWithPrefix(pr, "sig")) > 1 {
 		isDuplicateSIG = true
@@ -206,13 +218,15 @@ func (g *Gatherer) buildReleaseNote(pair *commitPrPair) (*ReleaseNote, error) {
 		PrURL:          prURL,
 		PrNumber:       pr.GetNumber(),
 		SIGs:           sigLabels,
-		Kinds:          labelsWithPrefix(pr, "kind"),
-		Areas:          labelsWithPrefix(pr, "area"),
+		Kinds:          kindLabels,
+		Areas:          areaLabels,
 		Feature:        isFeature,
 		Duplicate:      isDuplicateSIG,
 		DuplicateKind:  isDuplicateKind,
 		ActionRequired: labelExactMatch(pr, "release-note-action-required"),
 		DoNotPublish:   labelExactMatch(pr, "release-note-none"),
+		Inferred:       inferred.fields(),
+		Files:          g.recordFiles(changedFiles, pr.GetNumber()),
 	}, nil
 }
 
@@ -0,0 +1,134 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package notesfakes
+
+import (
+	"sync"
+
+	"k8s.io/release/pkg/notes"
+)
+
+type FakeModFileReader struct {
+	ReadModFileStub        func(string, string) ([]byte, error)
+	readModFileMutex       sync.RWMutex
+	readModFileArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	readModFileReturns struct {
+		result1 []byte
+		result2 error
+	}
+	readModFileReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeModFileReader) ReadModFile(arg1 string, arg2 string) ([]byte, error) {
+	fake.readModFileMutex.Lock()
+	ret, specificReturn := fake.readModFileReturnsOnCall[len(fake.readModFileArgsForCall)]
+	fake.readModFileArgsForCall = append(fake.readModFileArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ReadModFileStub
+	fakeReturns := fake.readModFileReturns
+	fake.recordInvocation("ReadModFile", []interface{}{arg1, arg2})
+	fake.readModFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeModFileReader) ReadModFileCallCount() int {
+	fake.readModFileMutex.RLock()
+	defer fake.readModFileMutex.RUnlock()
+	return len(fake.readModFileArgsForCall)
+}
+
+func (fake *FakeModFileReader) ReadModFileCalls(stub func(string, string) ([]byte, error)) {
+	fake.readModFileMutex.Lock()
+	defer fake.readModFileMutex.Unlock()
+	fake.ReadModFileStub = stub
+}
+
+func (fake *FakeModFileReader) ReadModFileArgsForCall(i int) (string, string) {
+	fake.readModFileMutex.RLock()
+	defer fake.readModFileMutex.RUnlock()
+	argsForCall := fake.readModFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeModFileReader) ReadModFileReturns(result1 []byte, result2 error) {
+	fake.readModFileMutex.Lock()
+	defer fake.readModFileMutex.Unlock()
+	fake.ReadModFileStub = nil
+	fake.readModFileReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeModFileReader) ReadModFileReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.readModFileMutex.Lock()
+	defer fake.readModFileMutex.Unlock()
+	fake.ReadModFileStub = nil
+	if fake.readModFileReturnsOnCall == nil {
+		fake.readModFileReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.readModFileReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeModFileReader) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.readModFileMutex.RLock()
+	defer fake.readModFileMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeModFileReader) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ notes.ModFileReader = new(FakeModFileReader)
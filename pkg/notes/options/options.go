@@ -22,16 +22,29 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/release-sdk/git"
 	"sigs.k8s.io/release-sdk/github"
+
+	"k8s.io/release/pkg/notes/forge"
 )
 
 // Options is the global options structure which can be used to build release
 // notes generator options.
 type Options struct {
+	// Forge is the type of the code forge hosting the repository, which is
+	// either forge.GitHub (default), forge.GitLab or forge.Gitea. GithubOrg
+	// and GithubRepo refer to the namespace and project of other forges.
+	Forge string
+
+	// ForgeURL is the base URL of the GitLab or Gitea instance, for example
+	// https://gitlab.example.com. Defaults to forge.DefaultGitLabURL for
+	// GitLab and is required for Gitea.
+	ForgeURL string
+
 	// GithubBaseURL specifies the Github base URL.
 	GithubBaseURL string
 
@@ -76,7 +89,7 @@ type Options struct {
 	SkipFirstCommit bool
 
 	// Format specifies the format of the release notes. Can be either
-	// `json` or `markdown`.
+	// `json`, `markdown`, `asciidoc`, `rst` or `keepachangelog`.
 	Format string
 
 	// If the `Format` is `markdown`, then this specifies the selected go
@@ -123,7 +136,39 @@ type Options struct {
 	// API. Cannot be used together with RecordDir.
 	ReplayDir string
 
+	// PRCacheDir specifies the directory of the persistent pull request
+	// metadata cache. Online runs refresh the cached pull requests from the
+	// API, while offline runs use them as they are.
+	PRCacheDir string
+
+	// Offline generates the release notes purely from the local repository
+	// and the PR cache without talking to the GitHub API. Requires
+	// PRCacheDir to be set and implies ListReleaseNotesV2.
+	Offline bool
+
+	// StateDir specifies a directory to persist the gathered release notes
+	// together with the last processed end SHA. Subsequent runs for the same
+	// start SHA only process the new commits and merge them into the
+	// previous notes.
+	StateDir string
+
+	// InferLabels proposes a kind for notes without kind labels based on
+	// conventional keywords of the PR title and note. If OwnershipMapPath is
+	// set, SIGs and areas get proposed from the files touched by the PR as
+	// well. Inferred fields are recorded in the note for later confirmation.
+	InferLabels bool
+
+	// OwnershipMapPath is the path to an OWNERS-style file, which maps
+	// repository paths to sig/ and area/ labels by using regular expression
+	// filters. Requires InferLabels to be set.
+	OwnershipMapPath string
+
+	// RecordFiles stores the files touched by each PR in its release note,
+	// which allows filtering release notes documents by path prefix.
+	RecordFiles bool
+
 	githubToken string
+	forgeToken  string
 	gitCloneFn  func(string, string, string, bool) (*git.Repo, error)
 
 	// MapProviders list of release notes map providers to query during generations
@@ -146,8 +191,11 @@ const (
 )
 
 const (
-	FormatJSON     = "json"
-	FormatMarkdown = "markdown"
+	FormatJSON           = "json"
+	FormatMarkdown       = "markdown"
+	FormatAsciiDoc       = "asciidoc"
+	FormatRST            = "rst"
+	FormatKeepAChangelog = "keepachangelog"
 
 	GoTemplatePrefix       = "go-template:"
 	GoTemplatePrefixInline = "inline:"
@@ -158,6 +206,7 @@ const (
 // New creates a new Options instance with the default values.
 func New() *Options {
 	return &Options{
+		Forge:              forge.GitHub,
 		DiscoverMode:       RevisionDiscoveryModeNONE,
 		GithubOrg:          git.DefaultGithubOrg,
 		GithubRepo:         git.DefaultGithubRepo,
@@ -183,17 +232,46 @@ func (o *Options) ValidateAndFinish() (err error) {
 		return errors.New("please do not use record and replay together")
 	}
 
+	if err := o.checkForgeOptions(); err != nil {
+		return fmt.Errorf("while checking forge flags: %w", err)
+	}
+
 	// Recover for replay if needed
 	if o.ReplayDir != "" {
 		logrus.Info("Using replay mode")
 		return nil
 	}
 
-	// The GitHub Token is required if replay is not specified
+	if o.Offline {
+		if o.PRCacheDir == "" {
+			return errors.New("offline mode requires a PR cache directory")
+		}
+		if o.RecordDir != "" {
+			return errors.New("please do not use record and offline mode together")
+		}
+		logrus.Info("Using offline mode, not pulling the repository")
+		o.Pull = false
+		o.ListReleaseNotesV2 = true
+	}
+
+	if o.RecordFiles && o.Offline {
+		return errors.New("recording the files of PRs requires the GitHub API and does not work in offline mode")
+	}
+
+	if o.OwnershipMapPath != "" {
+		if !o.InferLabels {
+			return errors.New("the ownership map requires label inference to be enabled")
+		}
+		if _, err := os.Stat(o.OwnershipMapPath); err != nil {
+			return fmt.Errorf("checking ownership map: %w", err)
+		}
+	}
+
+	// The GitHub Token is required if replay or offline is not specified
 	token, ok := os.LookupEnv(github.TokenEnvKey)
 	if ok {
 		o.githubToken = token
-	} else if o.ReplayDir == "" {
+	} else if !o.Offline && o.isGitHub() {
 		return fmt.Errorf(
 			"neither environment variable `%s` nor `replay` option is set",
 			github.TokenEnvKey,
@@ -278,6 +356,42 @@ func (o *Options) ValidateAndFinish() (err error) {
 	return nil
 }
 
+// checkForgeOptions verifies the forge related options and looks up the
+// access token of forges other than GitHub.
+func (o *Options) checkForgeOptions() error {
+	if o.Forge == "" {
+		o.Forge = forge.GitHub
+	}
+	if !forge.IsSupported(o.Forge) {
+		return fmt.Errorf("unsupported forge: %s", o.Forge)
+	}
+	if o.isGitHub() {
+		return nil
+	}
+
+	if o.ReplayDir != "" || o.RecordDir != "" {
+		return fmt.Errorf("record and replay are only supported for %s", forge.GitHub)
+	}
+
+	switch o.Forge {
+	case forge.GitLab:
+		if o.ForgeURL == "" {
+			o.ForgeURL = forge.DefaultGitLabURL
+		}
+		o.forgeToken = os.Getenv(forge.GitLabTokenEnvKey)
+	case forge.Gitea:
+		if o.ForgeURL == "" {
+			return errors.New("the Gitea forge requires a forge URL")
+		}
+		o.forgeToken = os.Getenv(forge.GiteaTokenEnvKey)
+	}
+	return nil
+}
+
+func (o *Options) isGitHub() bool {
+	return o.Forge == "" || o.Forge == forge.GitHub
+}
+
 // checkFormatOptions verifies that template related options are sane.
 func (o *Options) checkFormatOptions() error {
 	// Validate the output format and template
@@ -299,15 +413,39 @@ func (o *Options) checkFormatOptions() error {
 			}
 		}
 	}
-	if o.Format == FormatJSON && o.GoTemplate != GoTemplateDefault {
-		return errors.New("go-template cannot be defined when in JSON mode")
+	if o.Format != FormatMarkdown && o.GoTemplate != GoTemplateDefault {
+		return fmt.Errorf("go-template cannot be defined when in %s mode", o.Format)
 	}
-	if o.Format != FormatJSON && o.Format != FormatMarkdown {
+	if !IsValidFormat(o.Format) {
 		return fmt.Errorf("invalid format: %s", o.Format)
 	}
 	return nil
 }
 
+var (
+	formatsMu sync.RWMutex
+
+	// formats are the supported output formats. JSON and markdown are
+	// built-in, all other formats get registered together with their
+	// document renderer.
+	formats = map[string]bool{FormatJSON: true, FormatMarkdown: true}
+)
+
+// RegisterFormat marks the provided release notes output format as supported.
+func RegisterFormat(format string) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	formats[format] = true
+}
+
+// IsValidFormat returns true if the provided release notes output format is
+// supported.
+func IsValidFormat(format string) bool {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	return formats[format]
+}
+
 func (o *Options) resolveDiscoverMode() error {
 	repo, err := o.repo()
 	if err != nil {
@@ -348,7 +486,11 @@ func (o *Options) resolveDiscoverMode() error {
 }
 
 func (o *Options) repo() (repo *git.Repo, err error) {
-	if o.Pull {
+	if o.Pull && !o.isGitHub() {
+		repoURL := fmt.Sprintf("%s/%s/%s.git", strings.TrimSuffix(o.ForgeURL, "/"), o.GithubOrg, o.GithubRepo)
+		logrus.Infof("Cloning/updating repository %s", repoURL)
+		repo, err = git.CloneOrOpenRepo(o.RepoPath, repoURL, false, true, nil)
+	} else if o.Pull {
 		logrus.Infof("Cloning/updating repository %s/%s", o.GithubOrg, o.GithubRepo)
 		repo, err = o.gitCloneFn(
 			o.RepoPath,
@@ -366,6 +508,15 @@ func (o *Options) repo() (repo *git.Repo, err error) {
 	return repo, nil
 }
 
+// ForgeClient returns the forge client to be used by the Gatherer, which is
+// the GitHub Client unless another forge is configured.
+func (o *Options) ForgeClient() (forge.Client, error) {
+	if o.isGitHub() {
+		return o.Client()
+	}
+	return forge.New(o.Forge, o.ForgeURL, o.forgeToken)
+}
+
 // Client returns a Client to be used by the Gatherer. Depending on
 // the provided options this is either a real client talking to the GitHub API,
 // a Client which in addition records the responses from Github and stores them
@@ -32,6 +32,8 @@ import (
 	kgit "sigs.k8s.io/release-sdk/git"
 	"sigs.k8s.io/release-sdk/github"
 	"sigs.k8s.io/release-utils/command"
+
+	"k8s.io/release/pkg/notes/forge"
 )
 
 type testOptions struct {
@@ -401,3 +403,107 @@ func TestValidateAndFinishFailureGoTemplate(t *testing.T) {
 	// When
 	require.NotNil(t, options.ValidateAndFinish())
 }
+
+func TestValidateAndFinishSuccessOffline(t *testing.T) {
+	options := newTestOptions(t)
+	defer options.testRepo.cleanup(t)
+
+	t.Setenv(github.TokenEnvKey, "")
+	require.Nil(t, os.Unsetenv(github.TokenEnvKey))
+
+	options.Offline = true
+	options.PRCacheDir = t.TempDir()
+	require.Nil(t, options.ValidateAndFinish())
+	require.False(t, options.Pull)
+	require.True(t, options.ListReleaseNotesV2)
+}
+
+func TestValidateAndFinishFailureOfflineNoCache(t *testing.T) {
+	options := newTestOptions(t)
+	defer options.testRepo.cleanup(t)
+
+	options.Offline = true
+	require.NotNil(t, options.ValidateAndFinish())
+}
+
+func TestValidateAndFinishSuccessAdditionalFormats(t *testing.T) {
+	for _, format := range []string{FormatAsciiDoc, FormatRST, FormatKeepAChangelog} {
+		RegisterFormat(format)
+		options := newTestOptions(t)
+		options.Format = format
+		require.Nil(t, options.ValidateAndFinish())
+		options.testRepo.cleanup(t)
+	}
+}
+
+func TestValidateAndFinishFailureUnregisteredFormat(t *testing.T) {
+	options := newTestOptions(t)
+	defer options.testRepo.cleanup(t)
+
+	options.Format = "unregistered"
+	require.NotNil(t, options.ValidateAndFinish())
+
+	RegisterFormat(options.Format)
+	require.Nil(t, options.ValidateAndFinish())
+}
+
+func TestValidateAndFinishFailureGoTemplateAdditionalFormat(t *testing.T) {
+	options := newTestOptions(t)
+	defer options.testRepo.cleanup(t)
+
+	options.Format = FormatRST
+	options.GoTemplate = GoTemplateInline + "{{.}}"
+	require.NotNil(t, options.ValidateAndFinish())
+}
+
+func TestValidateAndFinishOwnershipMap(t *testing.T) {
+	options := newTestOptions(t)
+	defer options.testRepo.cleanup(t)
+
+	// Requires label inference
+	options.OwnershipMapPath = filepath.Join(t.TempDir(), "ownership.yaml")
+	require.NotNil(t, options.ValidateAndFinish())
+
+	// Requires an existing file
+	options.InferLabels = true
+	require.NotNil(t, options.ValidateAndFinish())
+
+	require.Nil(t, os.WriteFile(options.OwnershipMapPath, []byte("filters: {}"), 0o600))
+	require.Nil(t, options.ValidateAndFinish())
+}
+
+func TestValidateAndFinishFailureRecordFilesOffline(t *testing.T) {
+	options := newTestOptions(t)
+	defer options.testRepo.cleanup(t)
+
+	options.Offline = true
+	options.PRCacheDir = t.TempDir()
+	options.RecordFiles = true
+	require.NotNil(t, options.ValidateAndFinish())
+}
+
+func TestValidateAndFinishForge(t *testing.T) {
+	options := newTestOptions(t)
+	defer options.testRepo.cleanup(t)
+
+	options.Forge = "bitbucket"
+	require.NotNil(t, options.ValidateAndFinish())
+
+	// Gitea requires a URL
+	options.Forge = forge.Gitea
+	require.NotNil(t, options.ValidateAndFinish())
+
+	options.ForgeURL = "https://gitea.example.com"
+	options.ReplayDir = t.TempDir()
+	require.NotNil(t, options.ValidateAndFinish())
+
+	options.ReplayDir = ""
+	options.Forge = forge.GitLab
+	options.ForgeURL = ""
+	require.Nil(t, options.ValidateAndFinish())
+	require.Equal(t, forge.DefaultGitLabURL, options.ForgeURL)
+
+	client, err := options.ForgeClient()
+	require.Nil(t, err)
+	require.IsType(t, &forge.GitLabClient{}, client)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	gogithub "github.com/google/go-github/v60/github"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/release/pkg/notes/forge"
+)
+
+// PRCache is a persistent pull request metadata cache. Every pull request is
+// stored as a single JSON file named by its number below a directory per
+// forge, organization and repository, and entries only get replaced by pull
+// requests which have been updated more recently.
+type PRCache struct {
+	sync.RWMutex
+	dir string
+}
+
+// NewPRCache creates a new pull request cache for the repository in the
+// provided directory, which will be created if it does not exist.
+func NewPRCache(dir, forgeName, org, repo string) (*PRCache, error) {
+	if forgeName == "" {
+		forgeName = forge.GitHub
+	}
+	repoDir := filepath.Join(dir, forgeName, org, repo)
+	if err := os.MkdirAll(repoDir, os.FileMode(0o755)); err != nil {
+		return nil, fmt.Errorf("create PR cache directory: %w", err)
+	}
+	return &PRCache{dir: repoDir}, nil
+}
+
+// Get returns the cached pull request for the provided number or nil if it
+// is not part of the cache.
+func (c *PRCache) Get(number int) (*gogithub.PullRequest, error) {
+	c.RLock()
+	defer c.RUnlock()
+	return c.read(number)
+}
+
+// Put adds the pull request to the cache. An existing entry is only replaced
+// if the provided pull request has a more recent `updated_at` timestamp.
+func (c *PRCache) Put(pr *gogithub.PullRequest) error {
+	c.Lock()
+	defer c.Unlock()
+
+	cached, err := c.read(pr.GetNumber())
+	if err != nil {
+		return err
+	}
+	if cached != nil && !pr.GetUpdatedAt().After(cached.GetUpdatedAt().Time) {
+		logrus.Debugf("PR #%d is already up to date in cache", pr.GetNumber())
+		return nil
+	}
+
+	content, err := json.Marshal(pr)
+	if err != nil {
+		return fmt.Errorf("marshal PR #%d: %w", pr.GetNumber(), err)
+	}
+	if err := os.WriteFile(
+		c.path(pr.GetNumber()), content, os.FileMode(0o644),
+	); err != nil {
+		return fmt.Errorf("write PR #%d to cache: %w", pr.GetNumber(), err)
+	}
+	return nil
+}
+
+func (c *PRCache) read(number int) (*gogithub.PullRequest, error) {
+	content, err := os.ReadFile(c.path(number))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read PR #%d from cache: %w", number, err)
+	}
+
+	pr := &gogithub.PullRequest{}
+	if err := json.Unmarshal(content, pr); err != nil {
+		return nil, fmt.Errorf("unmarshal cached PR #%d: %w", number, err)
+	}
+	return pr, nil
+}
+
+func (c *PRCache) path(number int) string {
+	return filepath.Join(c.dir, fmt.Sprintf("pr-%d.json", number))
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogithub "github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/release-sdk/github/githubfakes"
+
+	"k8s.io/release/pkg/notes/options"
+)
+
+func cachedPR(number int, body string, updated time.Time) *gogithub.PullRequest {
+	return &gogithub.PullRequest{
+		Number:    &number,
+		Body:      &body,
+		UpdatedAt: &gogithub.Timestamp{Time: updated},
+		User:      &gogithub.User{Login: gogithub.String("user")},
+	}
+}
+
+func TestPRCache(t *testing.T) {
+	dir := t.TempDir()
+	sut, err := NewPRCache(dir, "", "kubernetes", "kubernetes")
+	require.NoError(t, err)
+
+	res, err := sut.Get(1)
+	require.NoError(t, err)
+	require.Nil(t, res)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, sut.Put(cachedPR(1, "first", now)))
+	res, err = sut.Get(1)
+	require.NoError(t, err)
+	require.Equal(t, "first", res.GetBody())
+
+	// Older data does not replace the entry
+	require.NoError(t, sut.Put(cachedPR(1, "older", now.Add(-time.Hour))))
+	res, err = sut.Get(1)
+	require.NoError(t, err)
+	require.Equal(t, "first", res.GetBody())
+
+	// Newer data replaces the entry
+	require.NoError(t, sut.Put(cachedPR(1, "newer", now.Add(time.Hour))))
+	res, err = sut.Get(1)
+	require.NoError(t, err)
+	require.Equal(t, "newer", res.GetBody())
+	require.FileExists(t, filepath.Join(dir, "github", "kubernetes", "kubernetes", "pr-1.json"))
+
+	// Entries are separated per repository
+	other, err := NewPRCache(dir, "gitlab", "kubernetes", "kubernetes")
+	require.NoError(t, err)
+	res, err = other.Get(1)
+	require.NoError(t, err)
+	require.Nil(t, res)
+}
+
+func TestGathererPullRequestCache(t *testing.T) {
+	prCache, err := NewPRCache(t.TempDir(), "", "kubernetes", "kubernetes")
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	client := &githubfakes.FakeClient{}
+	client.GetPullRequestReturns(cachedPR(2, "remote", now), nil, nil)
+
+	sut := NewGathererWithClient(context.Background(), client)
+	sut.prCache = prCache
+
+	// Cache miss queries the API and fills the cache
+	pr, err := sut.pullRequest(2)
+	require.NoError(t, err)
+	require.Equal(t, "remote", pr.GetBody())
+	require.Equal(t, 1, client.GetPullRequestCallCount())
+
+	// Cache hits do not query the API
+	pr, err = sut.pullRequest(2)
+	require.NoError(t, err)
+	require.Equal(t, "remote", pr.GetBody())
+	require.Equal(t, 1, client.GetPullRequestCallCount())
+
+	// Listing the pull requests of a commit refreshes the cache
+	sut.cachePullRequests(cachedPR(2, "edited", now.Add(time.Hour)))
+	pr, err = sut.pullRequest(2)
+	require.NoError(t, err)
+	require.Equal(t, "edited", pr.GetBody())
+	require.Equal(t, 1, client.GetPullRequestCallCount())
+
+	// Offline mode uses the cache without querying the API
+	sut.options = &options.Options{Offline: true}
+	pr, err = sut.pullRequest(2)
+	require.NoError(t, err)
+	require.Equal(t, "edited", pr.GetBody())
+	require.Equal(t, 1, client.GetPullRequestCallCount())
+
+	// Offline mode fails on cache miss
+	_, err = sut.pullRequest(3)
+	require.Error(t, err)
+	require.Equal(t, 1, client.GetPullRequestCallCount())
+}
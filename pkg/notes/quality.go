@@ -0,0 +1,315 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Checks of the release notes quality scoring.
+const (
+	QualityCheckLength      = "length"
+	QualityCheckTense       = "tense"
+	QualityCheckVerb        = "verb"
+	QualityCheckPunctuation = "punctuation"
+	QualityCheckComponent   = "component"
+	QualityCheckJargon      = "jargon"
+	QualityCheckLinks       = "links"
+)
+
+// MaxQualityScore is the score of a note without any suggestions.
+const MaxQualityScore = 100
+
+const (
+	minNoteWords = 5
+	maxNoteChars = 1000
+)
+
+// NoteQuality is the quality score of a single release note.
+type NoteQuality struct {
+	PrNumber    int                 `json:"pr_number"`
+	Text        string              `json:"text"`
+	Score       int                 `json:"score"`
+	Suggestions []QualitySuggestion `json:"suggestions"`
+}
+
+// QualitySuggestion is a single suggestion to improve a release note.
+type QualitySuggestion struct {
+	Check   string `json:"check"`
+	Message string `json:"message"`
+	Penalty int    `json:"penalty"`
+}
+
+// QualityReport contains the quality scores of a set of release notes.
+type QualityReport []*NoteQuality
+
+var (
+	// componentPrefixRE matches the conventional component prefix of a note,
+	// like "kubeadm: " or "`kubectl`: ".
+	componentPrefixRE = regexp.MustCompile("^`?[A-Za-z0-9][A-Za-z0-9./_-]*`?:\\s+")
+	firstWordRE       = regexp.MustCompile(`^[A-Za-z]+`)
+	prLinkRE          = regexp.MustCompile(`https?://github\.com/[^/\s]+/[^/\s]+/(pull|issues)/\d+`)
+	codeSpanRE        = regexp.MustCompile("`[^`]+`")
+	jargonRE          = regexp.MustCompile(
+		`(?i)\b(this PR|this pull request|LGTM|WIP|TODO|nits?|cherry[- ]pick(ed)?|` +
+			`per review|presubmits?|postsubmits?|prow|tide|e2e|flakes?|CI)\b`,
+	)
+)
+
+// presentTenseVerbs maps commonly used imperative or present tense verbs to
+// the preferred past tense.
+var presentTenseVerbs = map[string]string{
+	"add": "Added", "adds": "Added",
+	"allow": "Allowed", "allows": "Allowed",
+	"avoid": "Avoided", "avoids": "Avoided",
+	"bump": "Bumped", "bumps": "Bumped",
+	"change": "Changed", "changes": "Changed",
+	"deprecate": "Deprecated", "deprecates": "Deprecated",
+	"disable": "Disabled", "disables": "Disabled",
+	"drop": "Dropped", "drops": "Dropped",
+	"enable": "Enabled", "enables": "Enabled",
+	"fix": "Fixed", "fixes": "Fixed",
+	"graduate": "Graduated", "graduates": "Graduated",
+	"improve": "Improved", "improves": "Improved",
+	"introduce": "Introduced", "introduces": "Introduced",
+	"make": "Made", "makes": "Made",
+	"move": "Moved", "moves": "Moved",
+	"prevent": "Prevented", "prevents": "Prevented",
+	"promote": "Promoted", "promotes": "Promoted",
+	"remove": "Removed", "removes": "Removed",
+	"rename": "Renamed", "renames": "Renamed",
+	"replace": "Replaced", "replaces": "Replaced",
+	"revert": "Reverted", "reverts": "Reverted",
+	"support": "Supported", "supports": "Supported",
+	"update": "Updated", "updates": "Updated",
+	"upgrade": "Upgraded", "upgrades": "Upgraded",
+	"use": "Used", "uses": "Used",
+}
+
+// irregularPastTenseVerbs are past tense verbs which do not end with "ed".
+var irregularPastTenseVerbs = map[string]bool{
+	"built": true, "brought": true, "cut": true, "got": true, "kept": true,
+	"left": true, "made": true, "put": true, "ran": true, "reset": true,
+	"rewrote": true, "set": true, "split": true, "went": true, "wrote": true,
+}
+
+// pastTenseVerbs are the known past tense verbs a note can start with. Words
+// like "need" or "embed" end with "ed" as well, which is why the suffix
+// alone does not identify a past tense verb.
+var pastTenseVerbs = func() map[string]bool {
+	verbs := maps.Clone(irregularPastTenseVerbs)
+	for _, verb := range presentTenseVerbs {
+		verbs[strings.ToLower(verb)] = true
+	}
+	return verbs
+}()
+
+// knownComponents are the component names which identify the affected part
+// of Kubernetes within a note.
+var knownComponents = []string{
+	"api", "apiserver", "cli", "client-go", "cloud-controller-manager",
+	"conformance", "controller", "cri", "csi", "dra", "etcd", "feature gate",
+	"kube-apiserver", "kube-controller-manager", "kube-proxy",
+	"kube-scheduler", "kubeadm", "kubectl", "kubelet", "metrics", "scheduler",
+	"storage", "webhook",
+}
+
+// knownComponentsRE matches any of the known components as a whole word.
+var knownComponentsRE = wordsRE(knownComponents)
+
+// ScoreReleaseNote scores the quality of the release note text and returns
+// suggestions for the found issues. Notes which will not be published get
+// the maximum score.
+func ScoreReleaseNote(note *ReleaseNote) *NoteQuality {
+	quality := &NoteQuality{
+		PrNumber:    note.PrNumber,
+		Text:        note.Text,
+		Suggestions: []QualitySuggestion{},
+	}
+	suggest := func(check string, penalty int, format string, args ...any) {
+		quality.Suggestions = append(quality.Suggestions, QualitySuggestion{
+			Check: check, Message: fmt.Sprintf(format, args...), Penalty: penalty,
+		})
+	}
+
+	text := strings.TrimSpace(note.Text)
+	if note.DoNotPublish || text == "" {
+		quality.Score = MaxQualityScore
+		return quality
+	}
+
+	words := strings.Fields(text)
+	if len(words) < minNoteWords {
+		suggest(QualityCheckLength, 20,
+			"the note has only %d words, describe the change and its impact for users", len(words),
+		)
+	}
+	if len(text) > maxNoteChars {
+		suggest(QualityCheckLength, 10,
+			"the note has %d characters, consider summarizing it and linking to the documentation", len(text),
+		)
+	}
+
+	firstWord := firstWordRE.FindString(componentPrefixRE.ReplaceAllString(text, ""))
+	lowerFirstWord := strings.ToLower(firstWord)
+	switch {
+	case presentTenseVerbs[lowerFirstWord] != "":
+		suggest(QualityCheckTense, 10,
+			"use the past tense, for example %q instead of %q", presentTenseVerbs[lowerFirstWord], firstWord,
+		)
+	case pastTenseVerbs[lowerFirstWord]:
+	default:
+		suggest(QualityCheckVerb, 10,
+			"start the note with a verb describing the change, like \"Fixed\" or \"Added\"",
+		)
+	}
+
+	if !strings.ContainsAny(text[len(text)-1:], ".!?") {
+		suggest(QualityCheckPunctuation, 10, "end the note with a punctuation mark")
+	}
+
+	if !mentionsComponent(note, text) {
+		suggest(QualityCheckComponent, 15,
+			"mention the affected component, for example \"kubelet: ...\"",
+		)
+	}
+
+	if matches := uniqueMatches(jargonRE, codeSpanRE.ReplaceAllString(text, "")); len(matches) > 0 {
+		suggest(QualityCheckJargon, 10,
+			"avoid project internal jargon: %s", strings.Join(matches, ", "),
+		)
+	}
+
+	if prLinkRE.MatchString(text) {
+		suggest(QualityCheckLinks, 10,
+			"remove raw pull request or issue links, the notes link their PR automatically",
+		)
+	}
+
+	quality.Score = MaxQualityScore
+	for _, suggestion := range quality.Suggestions {
+		quality.Score -= suggestion.Penalty
+	}
+	quality.Score = max(quality.Score, 0)
+	return quality
+}
+
+// ScoreReleaseNotes scores all release notes and returns the report sorted by
+// ascending score, which puts the notes to be fixed first.
+func ScoreReleaseNotes(releaseNotes *ReleaseNotes) QualityReport {
+	report := QualityReport{}
+	for _, note := range releaseNotes.ByPR() {
+		report = append(report, ScoreReleaseNote(note))
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Score == report[j].Score {
+			return report[i].PrNumber < report[j].PrNumber
+		}
+		return report[i].Score < report[j].Score
+	})
+	return report
+}
+
+// Filter returns the notes with a score below the provided minimum.
+func (r QualityReport) Filter(minScore int) QualityReport {
+	res := QualityReport{}
+	for _, quality := range r {
+		if quality.Score < minScore {
+			res = append(res, quality)
+		}
+	}
+	return res
+}
+
+// AverageScore returns the average score of all notes in the report.
+func (r QualityReport) AverageScore() float64 {
+	if len(r) == 0 {
+		return MaxQualityScore
+	}
+	sum := 0
+	for _, quality := range r {
+		sum += quality.Score
+	}
+	return float64(sum) / float64(len(r))
+}
+
+// Text returns a plain text representation of the report.
+func (r QualityReport) Text() string {
+	sb := &strings.Builder{}
+	for _, quality := range r {
+		fmt.Fprintf(sb, "#%d: score %d/%d\n", quality.PrNumber, quality.Score, MaxQualityScore)
+		for _, suggestion := range quality.Suggestions {
+			fmt.Fprintf(sb, "    %s: %s\n", suggestion.Check, suggestion.Message)
+		}
+	}
+	fmt.Fprintf(sb, "\n%d notes, average score %.1f\n", len(r), r.AverageScore())
+	return sb.String()
+}
+
+// JSON returns the indented JSON representation of the report.
+func (r QualityReport) JSON() (string, error) {
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal quality report: %w", err)
+	}
+	return string(content), nil
+}
+
+// mentionsComponent returns true if the note mentions a known component, one
+// of its areas or SIGs, a code identifier or uses a component prefix.
+func mentionsComponent(note *ReleaseNote, text string) bool {
+	if componentPrefixRE.MatchString(text) || codeSpanRE.MatchString(text) {
+		return true
+	}
+
+	lowerText := strings.ToLower(text)
+	if knownComponentsRE.MatchString(lowerText) {
+		return true
+	}
+
+	candidates := append([]string{}, note.Areas...)
+	candidates = append(candidates, note.SIGs...)
+	return len(candidates) > 0 && wordsRE(candidates).MatchString(lowerText)
+}
+
+// wordsRE returns an expression which matches any of the lower cased words as
+// a whole word.
+func wordsRE(words []string) *regexp.Regexp {
+	quoted := make([]string, 0, len(words))
+	for _, word := range words {
+		quoted = append(quoted, regexp.QuoteMeta(strings.ToLower(word)))
+	}
+	return regexp.MustCompile(`\b(` + strings.Join(quoted, "|") + `)\b`)
+}
+
+// uniqueMatches returns all distinct matches of the expression in the text.
+func uniqueMatches(re *regexp.Regexp, text string) []string {
+	seen := map[string]bool{}
+	matches := []string{}
+	for _, match := range re.FindAllString(text, -1) {
+		if !seen[strings.ToLower(match)] {
+			seen[strings.ToLower(match)] = true
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notes
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreReleaseNote(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		note           *ReleaseNote
+		expectedChecks []string
+		expectedScore  int
+	}{
+		{
+			name:          "good note",
+			note:          &ReleaseNote{Text: "kubelet: Fixed a crash when the node has no pods."},
+			expectedScore: MaxQualityScore,
+		},
+		{
+			name:          "good note with code and irregular verb",
+			note:          &ReleaseNote{Text: "Made the `--foo` flag of the proxy configurable at runtime!"},
+			expectedScore: MaxQualityScore,
+		},
+		{
+			name:          "component from areas",
+			note:          &ReleaseNote{Text: "Improved the performance of the informer caches.", Areas: []string{"informer"}},
+			expectedScore: MaxQualityScore,
+		},
+		{
+			name:          "component from SIGs",
+			note:          &ReleaseNote{Text: "Reverted the default of the node swap feature.", SIGs: []string{"node"}},
+			expectedScore: MaxQualityScore,
+		},
+		{
+			name:          "not published",
+			note:          &ReleaseNote{Text: "wip", DoNotPublish: true},
+			expectedScore: MaxQualityScore,
+		},
+		{
+			name:           "short present tense note",
+			note:           &ReleaseNote{Text: "Fix kubelet crash"},
+			expectedChecks: []string{QualityCheckLength, QualityCheckTense, QualityCheckPunctuation},
+			expectedScore:  60,
+		},
+		{
+			name:           "no verb and no component",
+			note:           &ReleaseNote{Text: "The default value is now five seconds instead of ten."},
+			expectedChecks: []string{QualityCheckVerb, QualityCheckComponent},
+			expectedScore:  75,
+		},
+		{
+			name:           "words ending with ed which are no verbs in the past tense",
+			note:           &ReleaseNote{Text: "kubelet: Need to embed the speed of the node in the status."},
+			expectedChecks: []string{QualityCheckVerb},
+			expectedScore:  90,
+		},
+		{
+			name:           "speed is no verb in the past tense",
+			note:           &ReleaseNote{Text: "Speed of the kubelet garbage collection doubled."},
+			expectedChecks: []string{QualityCheckVerb},
+			expectedScore:  90,
+		},
+		{
+			name:           "embed is no verb in the past tense",
+			note:           &ReleaseNote{Text: "Embed the kubectl version into the client-go user agent."},
+			expectedChecks: []string{QualityCheckVerb},
+			expectedScore:  90,
+		},
+		{
+			name: "jargon and links",
+			note: &ReleaseNote{
+				Text: "kubectl: Added the --foo flag as requested in this PR, see https://github.com/kubernetes/kubernetes/pull/123 and the e2e tests.",
+			},
+			expectedChecks: []string{QualityCheckJargon, QualityCheckLinks},
+			expectedScore:  80,
+		},
+		{
+			name: "long note",
+			note: &ReleaseNote{
+				Text: "kubelet: Changed the behavior " + strings.Repeat("of the node ", 100) + ".",
+			},
+			expectedChecks: []string{QualityCheckLength},
+			expectedScore:  90,
+		},
+		{
+			name: "everything wrong",
+			note: &ReleaseNote{
+				Text: "This PR fixes the reported bug https://github.com/kubernetes/kubernetes/issues/1",
+			},
+			expectedChecks: []string{
+				QualityCheckVerb, QualityCheckPunctuation, QualityCheckComponent,
+				QualityCheckJargon, QualityCheckLinks,
+			},
+			expectedScore: 45,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			quality := ScoreReleaseNote(tc.note)
+			checks := []string{}
+			for _, suggestion := range quality.Suggestions {
+				checks = append(checks, suggestion.Check)
+			}
+			if tc.expectedChecks == nil {
+				tc.expectedChecks = []string{}
+			}
+			require.Equal(t, tc.expectedChecks, checks)
+			require.Equal(t, tc.expectedScore, quality.Score)
+		})
+	}
+}
+
+func TestScoreReleaseNotes(t *testing.T) {
+	report := ScoreReleaseNotes(releaseNotesFrom(
+		&ReleaseNote{PrNumber: 1, Text: "kubelet: Fixed a crash when the node has no pods."},
+		&ReleaseNote{PrNumber: 2, Text: "Fix kubelet crash"},
+		&ReleaseNote{PrNumber: 3, Text: "Fix kubelet panic"},
+	))
+	require.Len(t, report, 3)
+	require.Equal(t, 2, report[0].PrNumber)
+	require.Equal(t, 3, report[1].PrNumber)
+	require.Equal(t, 1, report[2].PrNumber)
+	require.InDelta(t, 73.3, report.AverageScore(), 0.1)
+
+	filtered := report.Filter(MaxQualityScore)
+	require.Len(t, filtered, 2)
+	require.Contains(t, filtered.Text(), `#2: score 60/100
+    length: the note has only 3 words, describe the change and its impact for users
+    tense: use the past tense, for example "Fixed" instead of "Fix"
+`)
+	require.Contains(t, filtered.Text(), "2 notes, average score 60.0")
+
+	res, err := filtered.JSON()
+	require.NoError(t, err)
+	decoded := QualityReport{}
+	require.NoError(t, json.Unmarshal([]byte(res), &decoded))
+	require.Equal(t, filtered, decoded)
+
+	require.InDelta(t, MaxQualityScore, QualityReport{}.AverageScore(), 0)
+}
@@ -27,11 +27,11 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/release-sdk/git"
-	"sigs.k8s.io/release-utils/log"
 	"sigs.k8s.io/release-utils/util"
 	"sigs.k8s.io/release-utils/version"
 
 	"k8s.io/release/pkg/consts"
+	"k8s.io/release/pkg/pipeline"
 	"k8s.io/release/pkg/release"
 )
 
@@ -144,6 +144,10 @@ type Options struct {
 
 	// Wait can be used to wait for the OBS build results.
 	Wait bool
+
+	// CustomSteps are additional steps which get inserted into the stage or
+	// release pipeline.
+	CustomSteps []*pipeline.CustomStep
 }
 
 // DefaultOptions returns a new `Options` instance.
@@ -367,12 +371,13 @@ func (s *StageOptions) Validate(state *State, submit bool) error {
 
 // Stage is the structure to be used for staging packages.
 type Stage struct {
-	client stageClient
+	client  stageClient
+	options *StageOptions
 }
 
 // NewStage creates a new `Stage` instance.
 func NewStage(options *StageOptions) *Stage {
-	return &Stage{NewDefaultStage(options)}
+	return &Stage{NewDefaultStage(options), options}
 }
 
 // SetClient can be used to set the internal stage client.
@@ -394,64 +399,91 @@ func (s *Stage) Submit(stream bool) error {
 func (s *Stage) Run() error {
 	s.client.InitState()
 
-	logger := log.NewStepLogger(11)
-	v := version.GetVersionInfo()
-	logger.Infof("Using krel version: %s", v.GitVersion)
-
-	logger.WithStep().Info("Validating options")
-	if err := s.client.ValidateOptions(); err != nil {
-		return fmt.Errorf("validating options: %w", err)
-	}
-
-	logger.WithStep().Info("Initializing OBS root and config")
-	if err := s.client.InitOBSRoot(); err != nil {
-		return fmt.Errorf("initializing obs root: %w", err)
-	}
-
-	logger.WithStep().Info("Checking prerequisites")
-	if err := s.client.CheckPrerequisites(); err != nil {
-		return fmt.Errorf("check prerequisites: %w", err)
-	}
-
-	logger.WithStep().Info("Checking release branch state")
-	if err := s.client.CheckReleaseBranchState(); err != nil {
-		return fmt.Errorf("checking release branch state: %w", err)
-	}
-
-	logger.WithStep().Info("Generating release version")
-	if err := s.client.GenerateReleaseVersion(); err != nil {
-		return fmt.Errorf("generating release version: %w", err)
+	p, err := s.Pipeline()
+	if err != nil {
+		return fmt.Errorf("create stage pipeline: %w", err)
 	}
 
-	logger.WithStep().Info("Generating package version")
-	s.client.GeneratePackageVersion()
-
-	logger.WithStep().Info("Generating OBS project name")
-	if err := s.client.GenerateOBSProject(); err != nil {
-		return fmt.Errorf("generating obs project name: %w", err)
-	}
+	v := version.GetVersionInfo()
+	logrus.Infof("Using krel version: %s", v.GitVersion)
 
-	logger.WithStep().Info("Checking out OBS project")
-	if err := s.client.CheckoutOBSProject(); err != nil {
-		return fmt.Errorf("checking out obs project: %w", err)
+	if err := p.Run(); err != nil {
+		return fmt.Errorf("run stage pipeline: %w", err)
 	}
 
-	logger.WithStep().Info("Generating spec files and artifact archives")
-	if err := s.client.GeneratePackageArtifacts(); err != nil {
-		return fmt.Errorf("generating package artifacts: %w", err)
-	}
+	return nil
+}
 
-	logger.WithStep().Info("Pushing packages to OBS")
-	if err := s.client.Push(); err != nil {
-		return fmt.Errorf("pushing packages to obs: %w", err)
-	}
+// Pipeline returns all steps of a stage run including the custom ones.
+func (s *Stage) Pipeline() (*pipeline.Pipeline, error) {
+	p := pipeline.New(
+		&pipeline.Step{
+			Name:        "ValidateOptions",
+			Description: "Validating options",
+			Run:         s.client.ValidateOptions,
+			Idempotent:  true,
+		},
+		&pipeline.Step{
+			Name:        "InitOBSRoot",
+			Description: "Initializing OBS root and config",
+			Run:         s.client.InitOBSRoot,
+		},
+		&pipeline.Step{
+			Name:        "CheckPrerequisites",
+			Description: "Checking prerequisites",
+			Run:         s.client.CheckPrerequisites,
+			Idempotent:  true,
+		},
+		&pipeline.Step{
+			Name:        "CheckReleaseBranchState",
+			Description: "Checking release branch state",
+			Run:         s.client.CheckReleaseBranchState,
+		},
+		&pipeline.Step{
+			Name:        "GenerateReleaseVersion",
+			Description: "Generating release version",
+			Run:         s.client.GenerateReleaseVersion,
+		},
+		&pipeline.Step{
+			Name:        "GeneratePackageVersion",
+			Description: "Generating package version",
+			Run: func() error {
+				s.client.GeneratePackageVersion()
+				return nil
+			},
+		},
+		&pipeline.Step{
+			Name:        "GenerateOBSProject",
+			Description: "Generating OBS project name",
+			Run:         s.client.GenerateOBSProject,
+		},
+		&pipeline.Step{
+			Name:        "CheckoutOBSProject",
+			Description: "Checking out OBS project",
+			Run:         s.client.CheckoutOBSProject,
+		},
+		&pipeline.Step{
+			Name:        "GeneratePackageArtifacts",
+			Description: "Generating spec files and artifact archives",
+			Run:         s.client.GeneratePackageArtifacts,
+		},
+		&pipeline.Step{
+			Name:        "Push",
+			Description: "Pushing packages to OBS",
+			Run:         s.client.Push,
+		},
+		&pipeline.Step{
+			Name:        "Wait",
+			Description: "Waiting for OBS build results if required",
+			Run:         s.client.Wait,
+		},
+	)
 
-	logger.WithStep().Info("Waiting for OBS build results if required")
-	if err := s.client.Wait(); err != nil {
-		return fmt.Errorf("wait for OBS build results: %w", err)
+	if err := p.Insert(s.options.CustomSteps...); err != nil {
+		return nil, fmt.Errorf("insert custom steps: %w", err)
 	}
 
-	return nil
+	return p, nil
 }
 
 // ReleaseState holds the release process state.
@@ -508,12 +540,13 @@ func (r *ReleaseOptions) Validate(state *State, submit bool) error {
 
 // Release is the structure to be used for releasing staged OBS builds.
 type Release struct {
-	client releaseClient
+	client  releaseClient
+	options *ReleaseOptions
 }
 
 // NewRelease creates a new `Release` instance.
 func NewRelease(options *ReleaseOptions) *Release {
-	return &Release{NewDefaultRelease(options)}
+	return &Release{NewDefaultRelease(options), options}
 }
 
 // SetClient can be used to set the internal stage client.
@@ -534,49 +567,71 @@ func (r *Release) Submit(stream bool) error {
 func (r *Release) Run() error {
 	r.client.InitState()
 
-	logger := log.NewStepLogger(8)
-	v := version.GetVersionInfo()
-	logger.Infof("Using krel version: %s", v.GitVersion)
-
-	logger.WithStep().Info("Validating options")
-	if err := r.client.ValidateOptions(); err != nil {
-		return fmt.Errorf("validating options: %w", err)
-	}
-
-	logger.WithStep().Info("Initializing OBS root and config")
-	if err := r.client.InitOBSRoot(); err != nil {
-		return fmt.Errorf("initializing obs root: %w", err)
-	}
-
-	logger.WithStep().Info("Checking prerequisites")
-	if err := r.client.CheckPrerequisites(); err != nil {
-		return fmt.Errorf("check prerequisites: %w", err)
+	p, err := r.Pipeline()
+	if err != nil {
+		return fmt.Errorf("create release pipeline: %w", err)
 	}
 
-	logger.WithStep().Info("Checking release branch state")
-	if err := r.client.CheckReleaseBranchState(); err != nil {
-		return fmt.Errorf("checking release branch state: %w", err)
-	}
+	v := version.GetVersionInfo()
+	logrus.Infof("Using krel version: %s", v.GitVersion)
 
-	logger.WithStep().Info("Generating release version")
-	if err := r.client.GenerateReleaseVersion(); err != nil {
-		return fmt.Errorf("generating release version: %w", err)
+	if err := p.Run(); err != nil {
+		return fmt.Errorf("run release pipeline: %w", err)
 	}
 
-	logger.WithStep().Info("Generating OBS project name")
-	if err := r.client.GenerateOBSProject(); err != nil {
-		return fmt.Errorf("generating obs project name: %w", err)
-	}
+	return nil
+}
 
-	logger.WithStep().Info("Checking out OBS project")
-	if err := r.client.CheckoutOBSProject(); err != nil {
-		return fmt.Errorf("checking out obs project: %w", err)
-	}
+// Pipeline returns all steps of a release run including the custom ones.
+func (r *Release) Pipeline() (*pipeline.Pipeline, error) {
+	p := pipeline.New(
+		&pipeline.Step{
+			Name:        "ValidateOptions",
+			Description: "Validating options",
+			Run:         r.client.ValidateOptions,
+			Idempotent:  true,
+		},
+		&pipeline.Step{
+			Name:        "InitOBSRoot",
+			Description: "Initializing OBS root and config",
+			Run:         r.client.InitOBSRoot,
+		},
+		&pipeline.Step{
+			Name:        "CheckPrerequisites",
+			Description: "Checking prerequisites",
+			Run:         r.client.CheckPrerequisites,
+			Idempotent:  true,
+		},
+		&pipeline.Step{
+			Name:        "CheckReleaseBranchState",
+			Description: "Checking release branch state",
+			Run:         r.client.CheckReleaseBranchState,
+		},
+		&pipeline.Step{
+			Name:        "GenerateReleaseVersion",
+			Description: "Generating release version",
+			Run:         r.client.GenerateReleaseVersion,
+		},
+		&pipeline.Step{
+			Name:        "GenerateOBSProject",
+			Description: "Generating OBS project name",
+			Run:         r.client.GenerateOBSProject,
+		},
+		&pipeline.Step{
+			Name:        "CheckoutOBSProject",
+			Description: "Checking out OBS project",
+			Run:         r.client.CheckoutOBSProject,
+		},
+		&pipeline.Step{
+			Name:        "ReleasePackages",
+			Description: "Releasing packages to OBS",
+			Run:         r.client.ReleasePackages,
+		},
+	)
 
-	logger.WithStep().Info("Releasing packages to OBS")
-	if err := r.client.ReleasePackages(); err != nil {
-		return fmt.Errorf("releasing packages: %w", err)
+	if err := p.Insert(r.options.CustomSteps...); err != nil {
+		return nil, fmt.Errorf("insert custom steps: %w", err)
 	}
 
-	return nil
+	return p, nil
 }
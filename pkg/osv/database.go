@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osv
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Database is an offline snapshot of OSV entries, for example the `all.zip`
+// of an ecosystem as published on https://osv.dev.
+type Database struct {
+	// entries are indexed by their ecosystem and package name
+	entries map[Package][]*Entry
+}
+
+// LoadDatabase reads all OSV entries from a directory of JSON files or from
+// a zip archive of them.
+func LoadDatabase(path string) (*Database, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("stat OSV database: %w", err)
+	}
+
+	db := &Database{entries: map[Package][]*Entry{}}
+	if info.IsDir() {
+		err = db.loadDir(path)
+	} else {
+		err = db.loadZip(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Loaded OSV entries for %d packages from %s", len(db.entries), path)
+	return db, nil
+}
+
+func (db *Database) loadDir(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("open OSV entry: %w", err)
+		}
+		defer file.Close()
+		return db.add(path, file)
+	})
+}
+
+func (db *Database) loadZip(path string) error {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("open OSV database archive: %w", err)
+	}
+	defer archive.Close()
+
+	for _, f := range archive.File {
+		if f.FileInfo().IsDir() || !strings.HasSuffix(f.Name, ".json") {
+			continue
+		}
+
+		file, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("open OSV entry %s: %w", f.Name, err)
+		}
+		err = db.add(f.Name, file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (db *Database) add(name string, r io.Reader) error {
+	entry := &Entry{}
+	if err := json.NewDecoder(r).Decode(entry); err != nil {
+		return fmt.Errorf("decode OSV entry %s: %w", name, err)
+	}
+
+	for _, affected := range entry.Affected {
+		pkg := Package{Ecosystem: affected.Package.Ecosystem, Name: affected.Package.Name}
+		db.entries[pkg] = append(db.entries[pkg], entry)
+	}
+	return nil
+}
+
+// Vulnerabilities returns the entries affecting the version of the package,
+// sorted by their ID.
+func (db *Database) Vulnerabilities(ecosystem, name, version string) []*Entry {
+	res := []*Entry{}
+	seen := map[string]bool{}
+	for _, entry := range db.entries[Package{Ecosystem: ecosystem, Name: name}] {
+		if !seen[entry.ID] && entry.Affects(ecosystem, name, version) {
+			seen[entry.ID] = true
+			res = append(res, entry)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res
+}
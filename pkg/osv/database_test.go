@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osv_test
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/osv"
+)
+
+func writeTestEntries(t *testing.T, write func(name string, content []byte)) {
+	t.Helper()
+
+	second := testEntry()
+	second.ID = "GO-2023-0002"
+	second.Affected[0].Ranges[0].Events = []osv.Event{{Introduced: "0.18.0"}}
+
+	for _, entry := range []*osv.Entry{testEntry(), second} {
+		content, err := json.Marshal(entry)
+		require.NoError(t, err)
+		write(entry.ID+".json", content)
+	}
+	write("README.md", []byte("not an entry"))
+}
+
+func requireTestVulnerabilities(t *testing.T, db *osv.Database) {
+	t.Helper()
+
+	ids := func(entries []*osv.Entry) []string {
+		res := []string{}
+		for _, e := range entries {
+			res = append(res, e.ID)
+		}
+		return res
+	}
+
+	require.Equal(t, []string{"GO-2024-0001"}, ids(db.Vulnerabilities(osv.EcosystemGo, testModule, "v0.1.0")))
+	require.Equal(t, []string{"GO-2023-0002", "GO-2024-0001"}, ids(db.Vulnerabilities(osv.EcosystemGo, testModule, "v0.20.0")))
+	require.Empty(t, db.Vulnerabilities(osv.EcosystemGo, testModule, "v0.17.0"))
+	require.Empty(t, db.Vulnerabilities(osv.EcosystemGo, "golang.org/x/text", "v0.1.0"))
+}
+
+func TestLoadDatabaseFromDirectory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "Go"), 0o755))
+	writeTestEntries(t, func(name string, content []byte) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "Go", name), content, 0o600))
+	})
+
+	db, err := osv.LoadDatabase(dir)
+	require.NoError(t, err)
+	requireTestVulnerabilities(t, db)
+}
+
+func TestLoadDatabaseFromZip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "all.zip")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+
+	archive := zip.NewWriter(file)
+	writeTestEntries(t, func(name string, content []byte) {
+		w, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write(content)
+		require.NoError(t, err)
+	})
+	require.NoError(t, archive.Close())
+	require.NoError(t, file.Close())
+
+	db, err := osv.LoadDatabase(path)
+	require.NoError(t, err)
+	requireTestVulnerabilities(t, db)
+}
+
+func TestLoadDatabaseFailure(t *testing.T) {
+	_, err := osv.LoadDatabase(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0o600))
+	_, err = osv.LoadDatabase(dir)
+	require.Error(t, err)
+}
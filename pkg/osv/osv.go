@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osv
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+const (
+	// SchemaVersion is the version of the OSV schema written by this package.
+	SchemaVersion = "1.6.0"
+
+	// EcosystemGo is the ecosystem of Go modules.
+	EcosystemGo = "Go"
+
+	// RangeSemver denotes versions following semantic versioning.
+	RangeSemver = "SEMVER"
+
+	// RangeEcosystem denotes versions in the format of the ecosystem.
+	RangeEcosystem = "ECOSYSTEM"
+
+	// RangeGit denotes full git commit hashes.
+	RangeGit = "GIT"
+
+	// SeverityCVSSv3 denotes a CVSS v3 vector string.
+	SeverityCVSSv3 = "CVSS_V3"
+
+	// ReferenceAdvisory denotes a published security advisory.
+	ReferenceAdvisory = "ADVISORY"
+
+	// ReferenceFix denotes the fix of the vulnerability.
+	ReferenceFix = "FIX"
+
+	// ReferenceReport denotes the report of the vulnerability.
+	ReferenceReport = "REPORT"
+
+	// ReferenceWeb denotes any other web page.
+	ReferenceWeb = "WEB"
+)
+
+// Entry is a vulnerability in the Open Source Vulnerability format, see
+// https://ossf.github.io/osv-schema
+type Entry struct {
+	SchemaVersion    string         `json:"schema_version,omitempty"`
+	ID               string         `json:"id"`
+	Modified         time.Time      `json:"modified"`
+	Published        *time.Time     `json:"published,omitempty"`
+	Withdrawn        *time.Time     `json:"withdrawn,omitempty"`
+	Aliases          []string       `json:"aliases,omitempty"`
+	Summary          string         `json:"summary,omitempty"`
+	Details          string         `json:"details,omitempty"`
+	Severity         []Severity     `json:"severity,omitempty"`
+	Affected         []Affected     `json:"affected,omitempty"`
+	References       []Reference    `json:"references,omitempty"`
+	DatabaseSpecific map[string]any `json:"database_specific,omitempty"`
+}
+
+// Severity is the severity of a vulnerability in a scoring system.
+type Severity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// Affected describes the affected versions of a package.
+type Affected struct {
+	Package           Package        `json:"package"`
+	Ranges            []Range        `json:"ranges,omitempty"`
+	Versions          []string       `json:"versions,omitempty"`
+	EcosystemSpecific map[string]any `json:"ecosystem_specific,omitempty"`
+	DatabaseSpecific  map[string]any `json:"database_specific,omitempty"`
+}
+
+// Package identifies the affected package.
+type Package struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	Purl      string `json:"purl,omitempty"`
+}
+
+// Range is a list of events marking the introduction and fix of a
+// vulnerability.
+type Range struct {
+	Type   string  `json:"type"`
+	Repo   string  `json:"repo,omitempty"`
+	Events []Event `json:"events"`
+}
+
+// Event is a single version event of a range. Exactly one field is set.
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// Reference is a link to further information.
+type Reference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// Affects returns true if the version of the package is affected by the
+// vulnerability. Only semantic versions are supported, ranges of type GIT
+// are ignored.
+func (e *Entry) Affects(ecosystem, name, version string) bool {
+	if e.Withdrawn != nil {
+		return false
+	}
+
+	for i := range e.Affected {
+		affected := &e.Affected[i]
+		if affected.Package.Ecosystem != ecosystem || affected.Package.Name != name {
+			continue
+		}
+
+		for _, v := range affected.Versions {
+			if compareVersions(v, version) == 0 {
+				return true
+			}
+		}
+
+		for _, r := range affected.Ranges {
+			if r.Type != RangeSemver && r.Type != RangeEcosystem {
+				continue
+			}
+			if r.contains(version) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// contains evaluates the events of the range in version order.
+func (r *Range) contains(version string) bool {
+	events := append([]Event{}, r.Events...)
+	sort.SliceStable(events, func(i, j int) bool {
+		return compareVersions(events[i].version(), events[j].version()) < 0
+	})
+
+	affected := false
+	for _, e := range events {
+		switch {
+		case e.Introduced != "":
+			if compareVersions(version, e.Introduced) >= 0 {
+				affected = true
+			}
+		case e.Fixed != "":
+			if compareVersions(version, e.Fixed) >= 0 {
+				affected = false
+			}
+		case e.LastAffected != "":
+			if compareVersions(version, e.LastAffected) > 0 {
+				affected = false
+			}
+		case e.Limit != "":
+			if compareVersions(version, e.Limit) >= 0 {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
+
+func (e *Event) version() string {
+	for _, v := range []string{e.Introduced, e.Fixed, e.LastAffected, e.Limit} {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// compareVersions compares two semantic versions with or without the `v`
+// prefix. The version "0" is the lowest possible version.
+func compareVersions(a, b string) int {
+	return semver.Compare(canonical(a), canonical(b))
+}
+
+func canonical(version string) string {
+	if version == "0" {
+		return "v0.0.0-0"
+	}
+	if !strings.HasPrefix(version, "v") {
+		return "v" + version
+	}
+	return version
+}
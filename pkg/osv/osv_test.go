@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osv_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/osv"
+)
+
+const testModule = "golang.org/x/net"
+
+func testEntry() *osv.Entry {
+	return &osv.Entry{
+		ID: "GO-2024-0001",
+		Affected: []osv.Affected{{
+			Package: osv.Package{Ecosystem: osv.EcosystemGo, Name: testModule},
+			Ranges: []osv.Range{{
+				Type: osv.RangeSemver,
+				Events: []osv.Event{
+					{Fixed: "0.17.0"},
+					{Introduced: "0"},
+					{Introduced: "0.20.0"},
+					{LastAffected: "0.21.0"},
+				},
+			}},
+			Versions: []string{"v0.25.0"},
+		}},
+	}
+}
+
+func TestAffects(t *testing.T) {
+	for _, tc := range []struct {
+		ecosystem, name, version string
+		expected                 bool
+	}{
+		{osv.EcosystemGo, testModule, "v0.1.0", true},
+		{osv.EcosystemGo, testModule, "v0.16.9", true},
+		{osv.EcosystemGo, testModule, "v0.0.0-20210226172049-e18ecbb05110", true},
+		{osv.EcosystemGo, testModule, "v0.17.0", false},
+		{osv.EcosystemGo, testModule, "v0.19.0", false},
+		{osv.EcosystemGo, testModule, "v0.20.0", true},
+		{osv.EcosystemGo, testModule, "v0.21.0", true},
+		{osv.EcosystemGo, testModule, "v0.21.1", false},
+		{osv.EcosystemGo, testModule, "v0.25.0", true},
+		{osv.EcosystemGo, "golang.org/x/text", "v0.1.0", false},
+		{"npm", testModule, "v0.1.0", false},
+	} {
+		require.Equal(t, tc.expected, testEntry().Affects(tc.ecosystem, tc.name, tc.version), tc.version)
+	}
+}
+
+func TestAffectsIgnoresWithdrawnAndGitRanges(t *testing.T) {
+	entry := testEntry()
+	entry.Withdrawn = &time.Time{}
+	require.False(t, entry.Affects(osv.EcosystemGo, testModule, "v0.1.0"))
+
+	entry = testEntry()
+	entry.Affected[0].Ranges[0].Type = osv.RangeGit
+	require.False(t, entry.Affects(osv.EcosystemGo, testModule, "v0.1.0"))
+}
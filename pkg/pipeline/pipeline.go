@@ -0,0 +1,270 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/log"
+)
+
+// Step is a single unit of work within a `Pipeline`.
+type Step struct {
+	// Name is the unique identifier of the step.
+	Name string
+
+	// Description is a human readable summary of the step, which gets logged
+	// when the step starts.
+	Description string
+
+	// Run executes the step.
+	Run func() error
+
+	// Skip is an optional condition evaluated right before the step would
+	// run. The step will be skipped if it returns true.
+	Skip func() bool
+
+	// Idempotent indicates that the step can be safely executed multiple
+	// times, for example because it only validates the environment.
+	// Idempotent steps always run and are never recorded as completed.
+	Idempotent bool
+
+	// ContinueOnError indicates that an error of the step should only be
+	// logged instead of aborting the whole pipeline.
+	ContinueOnError bool
+}
+
+// CustomStep is a step which gets inserted into an existing pipeline.
+type CustomStep struct {
+	// After is the name of the step after which the custom step will be
+	// inserted. The custom step gets appended if After is empty.
+	After string
+
+	// Step is the step to be inserted.
+	Step *Step
+}
+
+// Outcome is the result type of a step run.
+type Outcome string
+
+const (
+	// OutcomeSucceeded indicates that the step ran successfully.
+	OutcomeSucceeded Outcome = "succeeded"
+
+	// OutcomeFailed indicates that the step returned an error.
+	OutcomeFailed Outcome = "failed"
+
+	// OutcomeSkipped indicates that the step has been skipped because of its
+	// skip condition.
+	OutcomeSkipped Outcome = "skipped"
+
+	// OutcomeAlreadyCompleted indicates that the step has been skipped
+	// because a previous run already completed it.
+	OutcomeAlreadyCompleted Outcome = "already-completed"
+)
+
+// StepResult contains the details about a single step run.
+type StepResult struct {
+	// Name of the step.
+	Name string `json:"name"`
+
+	// Outcome of the step.
+	Outcome Outcome `json:"outcome"`
+
+	// StartTime is the time when the step started.
+	StartTime time.Time `json:"startTime"`
+
+	// DurationSeconds is the time the step took to run.
+	DurationSeconds float64 `json:"durationSeconds"`
+
+	// ErrorChain contains the messages of the error returned by the step
+	// and all of its wrapped errors, starting with the outermost one.
+	ErrorChain []string `json:"errorChain,omitempty"`
+}
+
+// ErrorChain returns the messages of the provided error and all errors
+// wrapped by it, starting with the outermost one.
+func ErrorChain(err error) (chain []string) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		chain = append(chain, err.Error())
+	}
+	return chain
+}
+
+// Checkpointer can be used to record completed steps and to skip them on
+// subsequent runs.
+type Checkpointer interface {
+	// StepCompleted returns true if the step with the provided name has
+	// been already completed.
+	StepCompleted(step string) bool
+
+	// CompleteStep marks the step of the provided result as completed.
+	CompleteStep(result *StepResult) error
+}
+
+// Pipeline is an ordered set of steps.
+type Pipeline struct {
+	steps        []*Step
+	only         []string
+	skip         []string
+	checkpointer Checkpointer
+	results      []*StepResult
+}
+
+// New creates a new `Pipeline` for the provided steps.
+func New(steps ...*Step) *Pipeline {
+	return &Pipeline{steps: steps}
+}
+
+// Steps returns all steps of the pipeline.
+func (p *Pipeline) Steps() []*Step {
+	return p.steps
+}
+
+// Names returns the names of all steps of the pipeline in order.
+func (p *Pipeline) Names() []string {
+	names := make([]string, 0, len(p.steps))
+	for _, step := range p.steps {
+		names = append(names, step.Name)
+	}
+	return names
+}
+
+// Insert adds the provided custom steps to the pipeline.
+func (p *Pipeline) Insert(customSteps ...*CustomStep) error {
+	for _, c := range customSteps {
+		if c.Step == nil || c.Step.Name == "" || c.Step.Run == nil {
+			return errors.New("custom step requires a name and run function")
+		}
+		if p.index(c.Step.Name) >= 0 {
+			return fmt.Errorf("step %s already exists", c.Step.Name)
+		}
+
+		if c.After == "" {
+			p.steps = append(p.steps, c.Step)
+			continue
+		}
+
+		i := p.index(c.After)
+		if i < 0 {
+			return fmt.Errorf(
+				"unable to insert step %s after unknown step %s",
+				c.Step.Name, c.After,
+			)
+		}
+		p.steps = slices.Insert(p.steps, i+1, c.Step)
+	}
+	return nil
+}
+
+// SetFilter restricts the steps which should run. If only is not empty, just
+// the listed steps and all idempotent steps will run. The steps listed in
+// skip will never run.
+func (p *Pipeline) SetFilter(only, skip []string) error {
+	for _, name := range append(slices.Clone(only), skip...) {
+		if p.index(name) < 0 {
+			return fmt.Errorf("unknown step %s, available steps: %v", name, p.Names())
+		}
+	}
+	p.only = only
+	p.skip = skip
+	return nil
+}
+
+// SetCheckpointer sets the checkpointer used for recording the completed
+// steps.
+func (p *Pipeline) SetCheckpointer(checkpointer Checkpointer) {
+	p.checkpointer = checkpointer
+}
+
+// Run executes all selected steps of the pipeline in order.
+func (p *Pipeline) Run() error {
+	selected := []*Step{}
+	for _, step := range p.steps {
+		if p.selected(step) {
+			selected = append(selected, step)
+		}
+	}
+
+	p.results = []*StepResult{}
+	logger := log.NewStepLogger(uint(len(selected)))
+	for _, step := range selected {
+		logger.WithStep().Info(step.Description)
+
+		result := &StepResult{Name: step.Name, StartTime: time.Now()}
+		p.results = append(p.results, result)
+
+		if step.Skip != nil && step.Skip() {
+			logrus.Infof("Skipping step %s", step.Name)
+			result.Outcome = OutcomeSkipped
+			continue
+		}
+
+		if !step.Idempotent && p.checkpointer != nil &&
+			p.checkpointer.StepCompleted(step.Name) {
+			logrus.Infof(
+				"Skipping step %s, already completed by a previous run",
+				step.Name,
+			)
+			result.Outcome = OutcomeAlreadyCompleted
+			continue
+		}
+
+		err := step.Run()
+		result.DurationSeconds = time.Since(result.StartTime).Seconds()
+		if err != nil {
+			result.Outcome = OutcomeFailed
+			result.ErrorChain = ErrorChain(err)
+			if step.ContinueOnError {
+				logrus.Warnf("Step %s failed: %v", step.Name, err)
+				continue
+			}
+			return fmt.Errorf("run step %s: %w", step.Name, err)
+		}
+		result.Outcome = OutcomeSucceeded
+
+		if !step.Idempotent && p.checkpointer != nil {
+			if err := p.checkpointer.CompleteStep(result); err != nil {
+				return fmt.Errorf("complete step %s: %w", step.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Results returns the results of all steps processed by the last run.
+func (p *Pipeline) Results() []*StepResult {
+	return p.results
+}
+
+func (p *Pipeline) index(name string) int {
+	return slices.IndexFunc(p.steps, func(s *Step) bool {
+		return s.Name == name
+	})
+}
+
+func (p *Pipeline) selected(step *Step) bool {
+	if slices.Contains(p.skip, step.Name) {
+		return false
+	}
+	return len(p.only) == 0 || step.Idempotent || slices.Contains(p.only, step.Name)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipeline_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/pipeline"
+)
+
+type fakeCheckpointer struct {
+	completed []string
+}
+
+func (f *fakeCheckpointer) StepCompleted(step string) bool {
+	for _, s := range f.completed {
+		if s == step {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeCheckpointer) CompleteStep(result *pipeline.StepResult) error {
+	f.completed = append(f.completed, result.Name)
+	return nil
+}
+
+func newTestPipeline(calls *[]string) *pipeline.Pipeline {
+	step := func(name string, idempotent bool) *pipeline.Step {
+		return &pipeline.Step{
+			Name:        name,
+			Description: "Running " + name,
+			Run: func() error {
+				*calls = append(*calls, name)
+				return nil
+			},
+			Idempotent: idempotent,
+		}
+	}
+	return pipeline.New(
+		step("validate", true),
+		step("first", false),
+		step("second", false),
+		step("third", false),
+	)
+}
+
+func TestRun(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		prepare  func(*pipeline.Pipeline, *fakeCheckpointer) error
+		expected []string
+		hasError bool
+	}{
+		{
+			name:     "all steps",
+			prepare:  func(*pipeline.Pipeline, *fakeCheckpointer) error { return nil },
+			expected: []string{"validate", "first", "second", "third"},
+		},
+		{
+			name: "only filter keeps idempotent steps",
+			prepare: func(p *pipeline.Pipeline, _ *fakeCheckpointer) error {
+				return p.SetFilter([]string{"second"}, nil)
+			},
+			expected: []string{"validate", "second"},
+		},
+		{
+			name: "skip filter",
+			prepare: func(p *pipeline.Pipeline, _ *fakeCheckpointer) error {
+				return p.SetFilter(nil, []string{"validate", "first"})
+			},
+			expected: []string{"second", "third"},
+		},
+		{
+			name: "unknown step in filter",
+			prepare: func(p *pipeline.Pipeline, _ *fakeCheckpointer) error {
+				return p.SetFilter([]string{"wrong"}, nil)
+			},
+			hasError: true,
+		},
+		{
+			name: "completed steps get skipped",
+			prepare: func(p *pipeline.Pipeline, c *fakeCheckpointer) error {
+				c.completed = []string{"validate", "first"}
+				p.SetCheckpointer(c)
+				return nil
+			},
+			expected: []string{"validate", "second", "third"},
+		},
+		{
+			name: "skip condition",
+			prepare: func(p *pipeline.Pipeline, _ *fakeCheckpointer) error {
+				p.Steps()[3].Skip = func() bool { return true }
+				return nil
+			},
+			expected: []string{"validate", "first", "second"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := []string{}
+			sut := newTestPipeline(&calls)
+			err := tc.prepare(sut, &fakeCheckpointer{})
+			if tc.hasError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, sut.Run())
+			require.Equal(t, tc.expected, calls)
+		})
+	}
+}
+
+func TestRunFailure(t *testing.T) {
+	calls := []string{}
+	sut := newTestPipeline(&calls)
+	checkpointer := &fakeCheckpointer{}
+	sut.SetCheckpointer(checkpointer)
+	sut.Steps()[2].Run = func() error { return errors.New("fail") }
+
+	require.Error(t, sut.Run())
+	require.Equal(t, []string{"validate", "first"}, calls)
+	require.Equal(t, []string{"first"}, checkpointer.completed)
+
+	results := sut.Results()
+	require.Len(t, results, 3)
+	require.Equal(t, pipeline.OutcomeSucceeded, results[1].Outcome)
+	require.Equal(t, "second", results[2].Name)
+	require.Equal(t, pipeline.OutcomeFailed, results[2].Outcome)
+	require.Equal(t, []string{"fail"}, results[2].ErrorChain)
+
+	// Continue on error
+	calls = []string{}
+	sut.Steps()[2].ContinueOnError = true
+	require.NoError(t, sut.Run())
+	require.Equal(t, []string{"validate", "third"}, calls)
+	require.Equal(t, pipeline.OutcomeAlreadyCompleted, sut.Results()[1].Outcome)
+}
+
+func TestErrorChain(t *testing.T) {
+	require.Empty(t, pipeline.ErrorChain(nil))
+	require.Equal(t,
+		[]string{"outer: inner", "inner"},
+		pipeline.ErrorChain(fmt.Errorf("outer: %w", errors.New("inner"))),
+	)
+}
+
+func TestInsert(t *testing.T) {
+	calls := []string{}
+	sut := newTestPipeline(&calls)
+	custom := func(name string) *pipeline.Step {
+		return &pipeline.Step{
+			Name: name,
+			Run: func() error {
+				calls = append(calls, name)
+				return nil
+			},
+		}
+	}
+
+	require.NoError(t, sut.Insert(
+		&pipeline.CustomStep{After: "first", Step: custom("scan")},
+		&pipeline.CustomStep{Step: custom("notify")},
+	))
+	require.Equal(t, []string{
+		"validate", "first", "scan", "second", "third", "notify",
+	}, sut.Names())
+
+	require.NoError(t, sut.Run())
+	require.Equal(t, sut.Names(), calls)
+
+	require.Error(t, sut.Insert(&pipeline.CustomStep{Step: custom("scan")}))
+	require.Error(t, sut.Insert(&pipeline.CustomStep{After: "wrong", Step: custom("new")}))
+	require.Error(t, sut.Insert(&pipeline.CustomStep{Step: &pipeline.Step{Name: "empty"}}))
+}
@@ -27,12 +27,19 @@ import (
 )
 
 type BranchChecker struct {
-	impl branchCheckerImpl
+	impl   branchCheckerImpl
+	policy *VersionPolicy
 }
 
 // NewBranchChecker creates a new release branch checker instance.
 func NewBranchChecker() *BranchChecker {
-	return &BranchChecker{&defaultBranchCheckerImpl{}}
+	return &BranchChecker{&defaultBranchCheckerImpl{}, DefaultVersionPolicy()}
+}
+
+// SetVersionPolicy can be used to set the version policy used to validate
+// release branches.
+func (r *BranchChecker) SetVersionPolicy(policy *VersionPolicy) {
+	r.policy = policy
 }
 
 // SetImpl can be used to set the internal BranchChecker implementation.
@@ -72,7 +79,18 @@ func (r *BranchChecker) NeedsCreation(
 		)
 	}
 
-	if output != "" {
+	return branchNeedsCreation(
+		r.policy, branch, releaseType, output != "", buildVersion,
+	)
+}
+
+// branchNeedsCreation returns if the provided release branch has to be
+// created depending on its existence and checks if it's correct.
+func branchNeedsCreation(
+	policy *VersionPolicy,
+	branch, releaseType string, branchExists bool, buildVersion semver.Version,
+) (createReleaseBranch bool, err error) {
+	if branchExists {
 		logrus.Infof("Branch %s does already exist on remote location", branch)
 	} else {
 		logrus.Infof("Branch %s does not yet exist on remote location", branch)
@@ -88,14 +106,16 @@ func (r *BranchChecker) NeedsCreation(
 	}
 
 	// Verify the required release branch
-	requiredReleaseBranch := fmt.Sprintf(
-		"release-%d.%d", buildVersion.Major, buildVersion.Minor,
-	)
-	if branch != requiredReleaseBranch {
+	major, minor, err := policy.BranchVersion(branch)
+	if err != nil {
+		return false, fmt.Errorf("parse release branch version: %w", err)
+	}
+	if major != buildVersion.Major || minor != buildVersion.Minor {
 		return false, fmt.Errorf(
-			"branch and build version does not match, got: %v, required: %v",
+			"branch and build version does not match, got: %v, required: %d.%d",
 			branch,
-			requiredReleaseBranch,
+			buildVersion.Major,
+			buildVersion.Minor,
 		)
 	}
 
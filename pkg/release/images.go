@@ -18,19 +18,26 @@ package release
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
 
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/google"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/sirupsen/logrus"
 
-	"k8s.io/apimachinery/pkg/util/wait"
-
 	"sigs.k8s.io/release-sdk/sign"
-	"sigs.k8s.io/release-utils/command"
 
 	"k8s.io/release/pkg/consts"
 )
@@ -58,36 +65,83 @@ func (i *Images) SetImpl(impl imageImpl) {
 //
 //counterfeiter:generate . imageImpl
 type imageImpl interface {
-	Execute(cmd string, args ...string) error
-	ExecuteOutput(cmd string, args ...string) (string, error)
 	RepoTagFromTarball(path string) (string, error)
+	ImageFromTarball(path, tag string) (v1.Image, error)
+	WriteImage(reference string, img v1.Image) error
+	WriteIndex(reference string, index v1.ImageIndex) error
+	IndexManifest(reference string) (*v1.IndexManifest, error)
 	SignImage(*sign.Signer, string) error
 	VerifyImage(*sign.Signer, string) error
+	Digest(reference string) (string, error)
 }
 
 type defaultImageImpl struct{}
 
-func (*defaultImageImpl) Execute(cmd string, args ...string) error {
-	return command.New(cmd, args...).RunSilentSuccess()
+// keychain is used to authenticate against the target registries.
+var keychain = authn.NewMultiKeychain(authn.DefaultKeychain, google.Keychain)
+
+func (*defaultImageImpl) RepoTagFromTarball(path string) (string, error) {
+	manifest, err := tarball.LoadManifest(func() (io.ReadCloser, error) {
+		return os.Open(path)
+	})
+	if err != nil {
+		return "", fmt.Errorf("load tarball manifest: %w", err)
+	}
+	if len(manifest) == 0 || len(manifest[0].RepoTags) == 0 {
+		return "", fmt.Errorf("no repo tags found in %s", path)
+	}
+	return manifest[0].RepoTags[0], nil
 }
 
-func (*defaultImageImpl) ExecuteOutput(cmd string, args ...string) (string, error) {
-	res, err := command.New(cmd, args...).RunSilentSuccessOutput()
+func (*defaultImageImpl) ImageFromTarball(path, tag string) (v1.Image, error) {
+	t, err := name.NewTag(tag)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("parse tag %s: %w", tag, err)
 	}
-	return res.OutputTrimNL(), nil
+	return tarball.ImageFromPath(path, &t)
 }
 
-func (*defaultImageImpl) RepoTagFromTarball(path string) (string, error) {
-	tagOutput, err := command.
-		New("tar", "xf", path, "manifest.json", "-O").
-		Pipe("jq", "-r", ".[0].RepoTags[0]").
-		RunSilentSuccessOutput()
+// pushRetryBackoff is used to retry failed registry requests when pushing
+// images and manifest lists, which can fail temporarily.
+// ref: https://github.com/kubernetes/release/issues/2810
+var pushRetryBackoff = remote.Backoff{
+	Duration: time.Second,
+	Factor:   1.5,
+	Steps:    5,
+}
+
+func (*defaultImageImpl) WriteImage(reference string, img v1.Image) error {
+	ref, err := name.ParseReference(reference)
+	if err != nil {
+		return fmt.Errorf("parse reference %s: %w", reference, err)
+	}
+	return remote.Write(ref, img,
+		remote.WithAuthFromKeychain(keychain),
+		remote.WithRetryBackoff(pushRetryBackoff),
+	)
+}
+
+func (*defaultImageImpl) WriteIndex(reference string, index v1.ImageIndex) error {
+	ref, err := name.ParseReference(reference)
 	if err != nil {
-		return "", err
+		return fmt.Errorf("parse reference %s: %w", reference, err)
 	}
-	return tagOutput.OutputTrimNL(), nil
+	return remote.WriteIndex(ref, index,
+		remote.WithAuthFromKeychain(keychain),
+		remote.WithRetryBackoff(pushRetryBackoff),
+	)
+}
+
+func (*defaultImageImpl) IndexManifest(reference string) (*v1.IndexManifest, error) {
+	ref, err := name.ParseReference(reference)
+	if err != nil {
+		return nil, fmt.Errorf("parse reference %s: %w", reference, err)
+	}
+	index, err := remote.Index(ref, remote.WithAuthFromKeychain(keychain))
+	if err != nil {
+		return nil, err
+	}
+	return index.IndexManifest()
 }
 
 func (*defaultImageImpl) SignImage(signer *sign.Signer, reference string) error {
@@ -105,10 +159,17 @@ func (*defaultImageImpl) VerifyImage(_ *sign.Signer, _ string) error {
 	return nil
 }
 
+func (*defaultImageImpl) Digest(reference string) (string, error) {
+	return crane.Digest(reference)
+}
+
 var tagRegex = regexp.MustCompile(`^.+/(.+):.+$`)
 
-// PublishImages releases container images to the provided target registry.
-func (i *Images) Publish(registry, version, buildPath string) error {
+// Publish releases container images to the provided target registry. It
+// pushes all per architecture images as well as their manifest lists and
+// returns the resulting digests, indexed by their reference. The digests are
+// also recorded in the `ImagesLockFile` inside the images path of the build.
+func (i *Images) Publish(registry, version, buildPath string) (map[string]string, error) {
 	version = i.normalizeVersion(version)
 
 	releaseImagesPath := filepath.Join(buildPath, ImagesPath)
@@ -117,108 +178,99 @@ func (i *Images) Publish(registry, version, buildPath string) error {
 		releaseImagesPath, registry,
 	)
 
+	digests := map[string]string{}
+	archImages := map[string]v1.Image{}
 	manifestImages, err := i.GetManifestImages(
 		registry, version, buildPath,
 		func(path, origTag, newTagWithArch string) error {
-			if err := i.Execute(
-				"docker", "load", "-qi", path,
-			); err != nil {
+			img, err := i.ImageFromTarball(path, origTag)
+			if err != nil {
 				return fmt.Errorf("load container image: %w", err)
 			}
 
-			if err := i.Execute(
-				"docker", "tag", origTag, newTagWithArch,
-			); err != nil {
-				return fmt.Errorf("tag container image: %w", err)
-			}
-
 			logrus.Infof("Pushing %s", newTagWithArch)
-
-			if err := i.Execute(
-				"gcloud", "docker", "--", "push", newTagWithArch,
-			); err != nil {
+			if err := i.WriteImage(newTagWithArch, img); err != nil {
 				return fmt.Errorf("push container image: %w", err)
 			}
 
-			if err := i.SignImage(i.signer, newTagWithArch); err != nil {
-				return fmt.Errorf("sign container image: %w", err)
+			digest, err := img.Digest()
+			if err != nil {
+				return fmt.Errorf("get container image digest: %w", err)
 			}
+			digests[newTagWithArch] = digest.String()
+			archImages[newTagWithArch] = img
 
-			if err := i.Execute(
-				"docker", "rmi", origTag, newTagWithArch,
-			); err != nil {
-				return fmt.Errorf("remove local container image: %w", err)
+			if err := i.SignImage(i.signer, newTagWithArch); err != nil {
+				return fmt.Errorf("sign container image: %w", err)
 			}
 
 			return nil
 		},
 	)
 	if err != nil {
-		return fmt.Errorf("get manifest images: %w", err)
-	}
-
-	if err := os.Setenv("DOCKER_CLI_EXPERIMENTAL", "enabled"); err != nil {
-		return fmt.Errorf("enable docker experimental CLI: %w", err)
+		return nil, fmt.Errorf("get manifest images: %w", err)
 	}
 
 	for image, arches := range manifestImages {
 		imageVersion := fmt.Sprintf("%s:%s", image, version)
-		logrus.Infof("Creating manifest image %s", imageVersion)
+		logrus.Infof("Pushing manifest list %s", imageVersion)
 
-		manifests := []string{}
-		for _, arch := range arches {
-			manifests = append(manifests,
-				fmt.Sprintf("%s-%s:%s", image, arch, version),
-			)
+		index := manifestList(image, version, arches, archImages)
+		if err := i.WriteIndex(imageVersion, index); err != nil {
+			return nil, fmt.Errorf("push manifest list: %w", err)
+		}
+
+		digest, err := index.Digest()
+		if err != nil {
+			return nil, fmt.Errorf("get manifest list digest: %w", err)
 		}
-		if err := i.Execute("docker", append(
-			[]string{"manifest", "create", "--amend", imageVersion},
-			manifests...,
-		)...); err != nil {
-			return fmt.Errorf("create manifest: %w", err)
+		digests[imageVersion] = digest.String()
+
+		if err := i.SignImage(i.signer, imageVersion); err != nil {
+			return nil, fmt.Errorf("sign manifest list: %w", err)
 		}
+	}
 
+	lock := &ImageLock{Images: []ImageLockEntry{}}
+	for image, arches := range manifestImages {
+		lock.Images = append(lock.Images, newImageLockEntry(
+			image, version, "", digests[fmt.Sprintf("%s:%s", image, version)],
+		))
 		for _, arch := range arches {
-			logrus.Infof(
-				"Annotating %s-%s:%s with --arch %s",
-				image, arch, version, arch,
-			)
-			if err := i.Execute(
-				"docker", "manifest", "annotate", "--arch", arch,
-				imageVersion, fmt.Sprintf("%s-%s:%s", image, arch, version),
-			); err != nil {
-				return fmt.Errorf("annotate manifest with arch: %w", err)
-			}
+			archImage := fmt.Sprintf("%s-%s", image, arch)
+			lock.Images = append(lock.Images, newImageLockEntry(
+				archImage, version, arch, digests[fmt.Sprintf("%s:%s", archImage, version)],
+			))
 		}
+	}
 
-		logrus.Infof("Pushing manifest image %s", imageVersion)
-		if err := wait.ExponentialBackoff(wait.Backoff{
-			Duration: time.Second,
-			Factor:   1.5,
-			Steps:    5,
-		}, func() (bool, error) {
-			if err := i.Execute("docker", "manifest", "push", imageVersion, "--purge"); err == nil {
-				return true, nil
-			} else if strings.Contains(err.Error(), "request canceled while waiting for connection") {
-				// The error is unfortunately not exported:
-				// https://github.com/golang/go/blob/dc04f3b/src/net/http/client.go#L720
-				// https://github.com/golang/go/blob/dc04f3b/src/net/http/transport.go#L2518
-				// ref: https://github.com/kubernetes/release/issues/2810
-				logrus.Info("Retrying manifest push")
-				return false, nil
-			}
+	lockPath := filepath.Join(releaseImagesPath, ImagesLockFile)
+	logrus.Infof("Writing image lockfile %s", lockPath)
+	if err := lock.Write(lockPath); err != nil {
+		return nil, fmt.Errorf("write image lockfile: %w", err)
+	}
 
-			return false, err
-		}); err != nil {
-			return fmt.Errorf("push manifest: %w", err)
-		}
+	return digests, nil
+}
 
-		if err := i.SignImage(i.signer, imageVersion); err != nil {
-			return fmt.Errorf("sign manifest list: %w", err)
-		}
+// manifestList creates the manifest list of an image from its per
+// architecture images.
+func manifestList(
+	image, version string, arches []string, archImages map[string]v1.Image,
+) v1.ImageIndex {
+	adds := []mutate.IndexAddendum{}
+	for _, arch := range arches {
+		adds = append(adds, mutate.IndexAddendum{
+			Add: archImages[fmt.Sprintf("%s-%s:%s", image, arch, version)],
+			Descriptor: v1.Descriptor{
+				Platform: &v1.Platform{OS: "linux", Architecture: arch},
+			},
+		})
 	}
 
-	return nil
+	return mutate.AppendManifests(
+		mutate.IndexMediaType(empty.Index, types.DockerManifestList), adds...,
+	)
 }
 
 // Validates that image manifests have been pushed to a specified remote
@@ -245,51 +297,67 @@ func (i *Images) Validate(registry, version, buildPath string) error {
 	for image, arches := range manifestImages {
 		imageVersion := fmt.Sprintf("%s:%s", image, version)
 
-		manifestBytes, err := crane.Manifest(imageVersion)
-		if err != nil {
-			return fmt.Errorf("get remote manifest from %s: %w", imageVersion, err)
-		}
-
 		logrus.Info("Verifying that image manifest list is signed")
 		if err := i.VerifyImage(i.signer, imageVersion); err != nil {
 			return fmt.Errorf("verify signed manifest list: %w", err)
 		}
 
-		manifest := string(manifestBytes)
-		manifestFile, err := os.CreateTemp("", "manifest-")
-		if err != nil {
-			return fmt.Errorf("create temp file for manifest: %w", err)
-		}
-		if _, err := manifestFile.WriteString(manifest); err != nil {
-			return fmt.Errorf("write manifest to %s: %w", manifestFile.Name(), err)
+		if err := i.validateManifestList(imageVersion, arches); err != nil {
+			return err
 		}
+	}
 
-		for _, arch := range arches {
-			logrus.Infof(
-				"Checking image digest for %s on %s architecture", image, arch,
-			)
+	if err := i.validateLock(version, buildPath, manifestImages); err != nil {
+		return fmt.Errorf("validate image lockfile: %w", err)
+	}
 
-			digest, err := i.ExecuteOutput(
-				"jq", "--arg", "a", arch, "-r",
-				".manifests[] | select(.platform.architecture == $a) | .digest",
-				manifestFile.Name(),
-			)
-			if err != nil {
-				return fmt.Errorf("get digest from manifest file %s for arch %s: %w", manifestFile.Name(), arch, err)
-			}
+	return nil
+}
 
-			if digest == "" {
-				return fmt.Errorf(
-					"could not find the image digest for %s on %s",
-					imageVersion, arch,
-				)
-			}
+// validateLock verifies that the remote digests of all images match the
+// ones recorded in the `ImagesLockFile` of the build, if it exists.
+func (i *Images) validateLock(
+	version, buildPath string, manifestImages map[string][]string,
+) error {
+	lockPath := filepath.Join(buildPath, ImagesPath, ImagesLockFile)
+	if _, err := os.Stat(lockPath); os.IsNotExist(err) {
+		logrus.Infof("No image lockfile found in %s, skipping digest verification", lockPath)
+		return nil
+	}
+
+	lock, err := ReadImageLock(lockPath)
+	if err != nil {
+		return err
+	}
+	lockDigests := lock.Digests()
 
-			logrus.Infof("Digest for %s on %s: %s", imageVersion, arch, digest)
+	// Every staged image has to be part of the lockfile
+	for image, arches := range manifestImages {
+		references := []string{fmt.Sprintf("%s:%s", image, version)}
+		for _, arch := range arches {
+			references = append(references,
+				fmt.Sprintf("%s-%s:%s", image, arch, version),
+			)
 		}
+		for _, reference := range references {
+			if _, ok := lockDigests[reference]; !ok {
+				return fmt.Errorf("image %s is missing in the lockfile", reference)
+			}
+		}
+	}
 
-		if err := os.RemoveAll(manifestFile.Name()); err != nil {
-			return fmt.Errorf("remove manifest file: %w", err)
+	logrus.Infof("Verifying %d image digests from lockfile", len(lock.Images))
+	for j := range lock.Images {
+		entry := &lock.Images[j]
+		digest, err := i.Digest(entry.Reference())
+		if err != nil {
+			return fmt.Errorf("get digest of %s: %w", entry.Reference(), err)
+		}
+		if digest != entry.Digest {
+			return fmt.Errorf(
+				"digest of %s does not match the lockfile, got: %s, expected: %s",
+				entry.Reference(), digest, entry.Digest,
+			)
 		}
 	}
 
@@ -317,51 +385,78 @@ func (i *Images) Exists(registry, version string, fast bool) (bool, error) {
 
 	for _, image := range manifestImages {
 		imageVersion := fmt.Sprintf("%s/%s:%s", registry, image, version)
-
-		manifestBytes, err := crane.Manifest(imageVersion)
-		if err != nil {
-			return false, fmt.Errorf("get remote manifest from %s: %w", imageVersion, err)
+		if err := i.validateManifestList(imageVersion, arches); err != nil {
+			return false, err
 		}
+	}
 
-		manifest := string(manifestBytes)
-		manifestFile, err := os.CreateTemp("", "manifest-")
-		if err != nil {
-			return false, fmt.Errorf("create temp file for manifest: %w", err)
-		}
-		if _, err := manifestFile.WriteString(manifest); err != nil {
-			return false, fmt.Errorf("write manifest to %s: %w", manifestFile.Name(), err)
+	return true, nil
+}
+
+// validateManifestList checks that the remote manifest list of imageVersion
+// contains an image for every provided architecture.
+func (i *Images) validateManifestList(imageVersion string, arches []string) error {
+	manifest, err := i.IndexManifest(imageVersion)
+	if err != nil {
+		return fmt.Errorf("get remote manifest from %s: %w", imageVersion, err)
+	}
+
+	archDigests := map[string]string{}
+	for j := range manifest.Manifests {
+		desc := &manifest.Manifests[j]
+		if desc.Platform != nil {
+			archDigests[desc.Platform.Architecture] = desc.Digest.String()
 		}
+	}
 
-		for _, arch := range arches {
-			logrus.Infof(
-				"Checking image digest for %s on %s architecture", image, arch,
-			)
+	for _, arch := range arches {
+		logrus.Infof(
+			"Checking image digest for %s on %s architecture", imageVersion, arch,
+		)
 
-			digest, err := i.ExecuteOutput(
-				"jq", "--arg", "a", arch, "-r",
-				".manifests[] | select(.platform.architecture == $a) | .digest",
-				manifestFile.Name(),
+		digest, ok := archDigests[arch]
+		if !ok {
+			return fmt.Errorf(
+				"could not find the image digest for %s on %s",
+				imageVersion, arch,
 			)
-			if err != nil {
-				return false, fmt.Errorf("get digest from manifest file %s for arch %s: %w", manifestFile.Name(), arch, err)
-			}
+		}
 
-			if digest == "" {
-				return false, fmt.Errorf(
-					"could not find the image digest for %s on %s",
-					imageVersion, arch,
-				)
-			}
+		logrus.Infof("Digest for %s on %s: %s", imageVersion, arch, digest)
+	}
 
-			logrus.Infof("Digest for %s on %s: %s", imageVersion, arch, digest)
+	return nil
+}
+
+// Digests returns the remote digests of the manifest lists and all per
+// architecture images for the provided build, indexed by their reference.
+func (i *Images) Digests(registry, version, buildPath string) (map[string]string, error) {
+	version = i.normalizeVersion(version)
+
+	manifestImages, err := i.GetManifestImages(registry, version, buildPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get manifest images: %w", err)
+	}
+
+	digests := map[string]string{}
+	for image, arches := range manifestImages {
+		references := []string{fmt.Sprintf("%s:%s", image, version)}
+		for _, arch := range arches {
+			references = append(references,
+				fmt.Sprintf("%s-%s:%s", image, arch, version),
+			)
 		}
 
-		if err := os.RemoveAll(manifestFile.Name()); err != nil {
-			return false, fmt.Errorf("remove manifest file: %w", err)
+		for _, reference := range references {
+			digest, err := i.Digest(reference)
+			if err != nil {
+				return nil, fmt.Errorf("get digest of %s: %w", reference, err)
+			}
+			digests[reference] = digest
 		}
 	}
 
-	return true, nil
+	return digests, nil
 }
 
 // GetManifestImages can be used to retrieve the map of built images and
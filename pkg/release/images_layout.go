@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/match"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/sirupsen/logrus"
+)
+
+// layoutRefNameAnnotation is the OCI annotation used to store the image
+// reference of the manifests inside of an image layout.
+const layoutRefNameAnnotation = "org.opencontainers.image.ref.name"
+
+// PublishToLayout writes the container images of the provided build into the
+// OCI image layout at layoutPath instead of pushing them to the registry.
+// The registry is only used to build the image references, which are stored
+// as annotations in the layout. Existing images with the same references
+// get replaced.
+func (i *Images) PublishToLayout(layoutPath, registry, version, buildPath string) error {
+	version = i.normalizeVersion(version)
+	logrus.Infof("Writing container images to OCI layout %s", layoutPath)
+
+	p, err := openLayout(layoutPath)
+	if err != nil {
+		return fmt.Errorf("open OCI layout: %w", err)
+	}
+
+	archImages := map[string]v1.Image{}
+	manifestImages, err := i.GetManifestImages(
+		registry, version, buildPath,
+		func(path, origTag, newTagWithArch string) error {
+			tag, err := name.NewTag(origTag)
+			if err != nil {
+				return fmt.Errorf("parse tag %s: %w", origTag, err)
+			}
+
+			img, err := tarball.ImageFromPath(path, &tag)
+			if err != nil {
+				return fmt.Errorf("load container image: %w", err)
+			}
+
+			logrus.Infof("Writing %s", newTagWithArch)
+			if err := p.ReplaceImage(
+				img, match.Name(newTagWithArch), layoutRefName(newTagWithArch),
+			); err != nil {
+				return fmt.Errorf("write container image: %w", err)
+			}
+
+			archImages[newTagWithArch] = img
+			return nil
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("get manifest images: %w", err)
+	}
+
+	for image, arches := range manifestImages {
+		imageVersion := fmt.Sprintf("%s:%s", image, version)
+		logrus.Infof("Writing manifest list %s", imageVersion)
+
+		index := manifestList(image, version, arches, archImages)
+		if err := p.ReplaceIndex(
+			index, match.Name(imageVersion), layoutRefName(imageVersion),
+		); err != nil {
+			return fmt.Errorf("write manifest list: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// LayoutDigests returns the digests of the manifest lists and all per
+// architecture images for the provided build from the OCI image layout,
+// indexed by their reference.
+func (i *Images) LayoutDigests(layoutPath, registry, version, buildPath string) (map[string]string, error) {
+	version = i.normalizeVersion(version)
+
+	manifestImages, err := i.GetManifestImages(registry, version, buildPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get manifest images: %w", err)
+	}
+
+	index, err := layout.ImageIndexFromPath(layoutPath)
+	if err != nil {
+		return nil, fmt.Errorf("open OCI layout: %w", err)
+	}
+	indexManifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, fmt.Errorf("get OCI layout index: %w", err)
+	}
+
+	layoutDigests := map[string]string{}
+	for j := range indexManifest.Manifests {
+		desc := &indexManifest.Manifests[j]
+		if ref, ok := desc.Annotations[layoutRefNameAnnotation]; ok {
+			layoutDigests[ref] = desc.Digest.String()
+		}
+	}
+
+	digests := map[string]string{}
+	for image, arches := range manifestImages {
+		references := []string{fmt.Sprintf("%s:%s", image, version)}
+		for _, arch := range arches {
+			references = append(references,
+				fmt.Sprintf("%s-%s:%s", image, arch, version),
+			)
+		}
+
+		for _, reference := range references {
+			digest, ok := layoutDigests[reference]
+			if !ok {
+				return nil, fmt.Errorf("image %s not found in OCI layout", reference)
+			}
+			digests[reference] = digest
+		}
+	}
+
+	return digests, nil
+}
+
+// openLayout opens the OCI image layout at the provided path or creates a new
+// one if it does not exist.
+func openLayout(path string) (layout.Path, error) {
+	if p, err := layout.FromPath(path); err == nil {
+		return p, nil
+	}
+	return layout.Write(path, empty.Index)
+}
+
+func layoutRefName(reference string) layout.Option {
+	return layout.WithAnnotations(map[string]string{
+		layoutRefNameAnnotation: reference,
+	})
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ImagesLockFile is the name of the lockfile written by `Images.Publish`
+// into the `ImagesPath` of a build.
+const ImagesLockFile = "images-lock.json"
+
+// ImageLock records the digests of all published container images.
+type ImageLock struct {
+	Images []ImageLockEntry `json:"images"`
+}
+
+// ImageLockEntry is a single published image or manifest list.
+type ImageLockEntry struct {
+	// Image is the image name without the tag, for example
+	// `registry.k8s.io/kube-apiserver-amd64`.
+	Image string `json:"image"`
+
+	// Tag is the tag of the image.
+	Tag string `json:"tag"`
+
+	// Arch is the architecture of the image, empty for manifest lists.
+	Arch string `json:"arch,omitempty"`
+
+	// Digest is the digest of the image or manifest list.
+	Digest string `json:"digest"`
+
+	// Signature is the reference of the image signature.
+	Signature string `json:"signature"`
+}
+
+// Reference returns the tagged reference of the entry.
+func (e *ImageLockEntry) Reference() string {
+	return fmt.Sprintf("%s:%s", e.Image, e.Tag)
+}
+
+// newImageLockEntry creates a new lockfile entry for the provided image.
+func newImageLockEntry(image, tag, arch, digest string) ImageLockEntry {
+	return ImageLockEntry{
+		Image:     image,
+		Tag:       tag,
+		Arch:      arch,
+		Digest:    digest,
+		Signature: signatureReference(image, digest),
+	}
+}
+
+// signatureReference returns the reference of the signature of the image
+// with the provided digest, for example
+// `registry.k8s.io/kube-apiserver:sha256-<hex>.sig`.
+func signatureReference(image, digest string) string {
+	return fmt.Sprintf("%s:%s.sig", image, strings.Replace(digest, ":", "-", 1))
+}
+
+// Write writes the lockfile to the provided path.
+func (l *ImageLock) Write(path string) error {
+	sort.Slice(l.Images, func(i, j int) bool {
+		return l.Images[i].Reference() < l.Images[j].Reference()
+	})
+
+	content, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal image lock: %w", err)
+	}
+	if err := os.WriteFile(path, content, os.FileMode(0o644)); err != nil {
+		return fmt.Errorf("write image lock: %w", err)
+	}
+	return nil
+}
+
+// ReadImageLock reads a lockfile from the provided path.
+func ReadImageLock(path string) (*ImageLock, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read image lock: %w", err)
+	}
+
+	lock := &ImageLock{}
+	if err := json.Unmarshal(content, lock); err != nil {
+		return nil, fmt.Errorf("unmarshal image lock: %w", err)
+	}
+	return lock, nil
+}
+
+// Digests returns the lockfile digests indexed by their reference.
+func (l *ImageLock) Digests() map[string]string {
+	digests := map[string]string{}
+	for i := range l.Images {
+		digests[l.Images[i].Reference()] = l.Images[i].Digest
+	}
+	return digests
+}
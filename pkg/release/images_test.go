@@ -24,6 +24,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -85,12 +89,12 @@ func TestPublish(t *testing.T) {
 			shouldError: false,
 		},
 		{
-			name: "failure on docker load",
+			name: "failure on image load",
 			prepare: func(mock *releasefakes.FakeImageImpl) (string, func()) {
 				tempDir := newImagesPath(t)
 				prepareImages(t, tempDir, mock)
 
-				mock.ExecuteReturnsOnCall(0, errors.New(""))
+				mock.ImageFromTarballReturnsOnCall(0, nil, errors.New(""))
 
 				return tempDir, func() {
 					require.Nil(t, os.RemoveAll(tempDir))
@@ -99,12 +103,12 @@ func TestPublish(t *testing.T) {
 			shouldError: true,
 		},
 		{
-			name: "failure on docker tag",
+			name: "failure on image push",
 			prepare: func(mock *releasefakes.FakeImageImpl) (string, func()) {
 				tempDir := newImagesPath(t)
 				prepareImages(t, tempDir, mock)
 
-				mock.ExecuteReturnsOnCall(1, errors.New(""))
+				mock.WriteImageReturnsOnCall(2, errors.New(""))
 
 				return tempDir, func() {
 					require.Nil(t, os.RemoveAll(tempDir))
@@ -113,76 +117,12 @@ func TestPublish(t *testing.T) {
 			shouldError: true,
 		},
 		{
-			name: "failure on docker push",
+			name: "failure on manifest list push",
 			prepare: func(mock *releasefakes.FakeImageImpl) (string, func()) {
 				tempDir := newImagesPath(t)
 				prepareImages(t, tempDir, mock)
 
-				mock.ExecuteReturnsOnCall(2, errors.New(""))
-
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
-			},
-			shouldError: true,
-		},
-		{
-			name: "failure on docker rmi",
-			prepare: func(mock *releasefakes.FakeImageImpl) (string, func()) {
-				tempDir := newImagesPath(t)
-				prepareImages(t, tempDir, mock)
-
-				mock.ExecuteReturnsOnCall(3, errors.New(""))
-
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
-			},
-			shouldError: true,
-		},
-		{
-			name: "failure on docker manifest create",
-			prepare: func(mock *releasefakes.FakeImageImpl) (string, func()) {
-				tempDir := newImagesPath(t)
-				prepareImages(t, tempDir, mock)
-
-				mock.ExecuteReturnsOnCall(36, errors.New(""))
-
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
-			},
-			shouldError: true,
-		},
-		{
-			name: "failure on docker manifest annotate",
-			prepare: func(mock *releasefakes.FakeImageImpl) (string, func()) {
-				tempDir := newImagesPath(t)
-				prepareImages(t, tempDir, mock)
-
-				mock.ExecuteReturnsOnCall(37, errors.New(""))
-
-				return tempDir, func() {
-					require.Nil(t, os.RemoveAll(tempDir))
-				}
-			},
-			shouldError: true,
-		},
-		{
-			name: "failure on docker manifest push",
-			prepare: func(mock *releasefakes.FakeImageImpl) (string, func()) {
-				tempDir := newImagesPath(t)
-				prepareImages(t, tempDir, mock)
-
-				i := 0
-				mock.ExecuteCalls(func(cmd string, args ...string) error {
-					// There is an ExponentialBackoff which we have to overcome
-					if i == 40 {
-						return errors.New("")
-					}
-					i++
-					return nil
-				})
+				mock.WriteIndexReturns(errors.New(""))
 
 				return tempDir, func() {
 					require.Nil(t, os.RemoveAll(tempDir))
@@ -271,12 +211,16 @@ func TestPublish(t *testing.T) {
 			buildPath, cleanup := prepare(clientMock)
 			defer cleanup()
 
-			err := sut.Publish(release.GCRIOPathProd, "v1.18.9", buildPath)
+			digests, err := sut.Publish(release.GCRIOPathProd, "v1.18.9", buildPath)
 
 			if shouldError {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
+				if clientMock.WriteIndexCallCount() > 0 {
+					assert.Contains(t, digests, release.GCRIOPathProd+"/kube-apiserver:v1.18.9")
+					assert.Contains(t, digests, release.GCRIOPathProd+"/kube-apiserver-arm64:v1.18.9")
+				}
 			}
 		})
 	}
@@ -292,7 +236,7 @@ func TestValidate(t *testing.T) {
 				tempDir := newImagesPath(t)
 				prepareImages(t, tempDir, mock)
 
-				mock.ExecuteOutputReturns("digest", nil)
+				mock.IndexManifestReturns(indexManifest("amd64", "arm", "arm64"), nil)
 
 				return tempDir, func() {
 					require.Nil(t, os.RemoveAll(tempDir))
@@ -300,12 +244,12 @@ func TestValidate(t *testing.T) {
 			},
 			shouldError: false,
 		},
-		{ // failure on crane call
+		{ // failure missing architecture
 			prepare: func(mock *releasefakes.FakeImageImpl) (string, func()) {
 				tempDir := newImagesPath(t)
 				prepareImages(t, tempDir, mock)
 
-				mock.ExecuteOutputReturnsOnCall(1, "", errors.New(""))
+				mock.IndexManifestReturns(indexManifest("amd64", "arm64"), nil)
 
 				return tempDir, func() {
 					require.Nil(t, os.RemoveAll(tempDir))
@@ -318,6 +262,8 @@ func TestValidate(t *testing.T) {
 				tempDir := newImagesPath(t)
 				prepareImages(t, tempDir, mock)
 
+				mock.IndexManifestReturns(&v1.IndexManifest{}, nil)
+
 				return tempDir, func() {
 					require.Nil(t, os.RemoveAll(tempDir))
 				}
@@ -329,7 +275,7 @@ func TestValidate(t *testing.T) {
 				tempDir := newImagesPath(t)
 				prepareImages(t, tempDir, mock)
 
-				mock.ExecuteOutputReturns("", errors.New(""))
+				mock.IndexManifestReturns(nil, errors.New(""))
 
 				return tempDir, func() {
 					require.Nil(t, os.RemoveAll(tempDir))
@@ -365,7 +311,7 @@ func TestValidate(t *testing.T) {
 				tempDir := newImagesPath(t)
 				prepareImages(t, tempDir, mock)
 
-				mock.ExecuteOutputReturns("digest", nil)
+				mock.IndexManifestReturns(indexManifest("amd64", "arm", "arm64"), nil)
 				mock.VerifyImageReturnsOnCall(10, errors.New(""))
 
 				return tempDir, func() {
@@ -390,6 +336,52 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestDigests(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		prepare     func(*releasefakes.FakeImageImpl, string)
+		shouldError bool
+	}{
+		{
+			name: "success",
+			prepare: func(mock *releasefakes.FakeImageImpl, tempDir string) {
+				prepareImages(t, tempDir, mock)
+				mock.DigestReturns("sha256:123", nil)
+			},
+			shouldError: false,
+		},
+		{
+			name: "failure on digest",
+			prepare: func(mock *releasefakes.FakeImageImpl, tempDir string) {
+				prepareImages(t, tempDir, mock)
+				mock.DigestReturns("", errors.New(""))
+			},
+			shouldError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sut := release.NewImages()
+			clientMock := &releasefakes.FakeImageImpl{}
+			sut.SetImpl(clientMock)
+			buildPath := t.TempDir()
+			require.Nil(t, os.MkdirAll(
+				filepath.Join(buildPath, release.ImagesPath), os.FileMode(0o755),
+			))
+			tc.prepare(clientMock, buildPath)
+
+			digests, err := sut.Digests(release.GCRIOPathStaging, "v1.18.9+abc", buildPath)
+			if tc.shouldError {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Len(t, digests, 12)
+			require.Equal(t, "sha256:123", digests[release.GCRIOPathStaging+"/kube-apiserver:v1.18.9_abc"])
+			require.Equal(t, "sha256:123", digests[release.GCRIOPathStaging+"/kube-apiserver-arm64:v1.18.9_abc"])
+		})
+	}
+}
+
 func newImagesPath(t *testing.T) string {
 	tempDir, err := os.MkdirTemp("", "publish-test-")
 	require.Nil(t, err)
@@ -402,7 +394,22 @@ func newImagesPath(t *testing.T) string {
 	return tempDir
 }
 
+func indexManifest(arches ...string) *v1.IndexManifest {
+	manifest := &v1.IndexManifest{}
+	for _, arch := range arches {
+		manifest.Manifests = append(manifest.Manifests, v1.Descriptor{
+			Digest:   v1.Hash{Algorithm: "sha256", Hex: arch},
+			Platform: &v1.Platform{OS: "linux", Architecture: arch},
+		})
+	}
+	return manifest
+}
+
 func prepareImages(t *testing.T, tempDir string, mock *releasefakes.FakeImageImpl) {
+	img, err := random.Image(64, 1)
+	require.Nil(t, err)
+	mock.ImageFromTarballReturns(img, nil)
+
 	c := 0
 	for _, arch := range []string{"amd64", "arm", "arm64"} {
 		archPath := filepath.Join(tempDir, release.ImagesPath, arch)
@@ -427,3 +434,159 @@ func prepareImages(t *testing.T, tempDir string, mock *releasefakes.FakeImageImp
 		}
 	}
 }
+
+func TestPublishToLayout(t *testing.T) {
+	const version = "v1.18.9+abc"
+
+	sut := release.NewImages()
+	clientMock := &releasefakes.FakeImageImpl{}
+	sut.SetImpl(clientMock)
+
+	buildPath := t.TempDir()
+	c := 0
+	for _, arch := range []string{"amd64", "arm64"} {
+		archPath := filepath.Join(buildPath, release.ImagesPath, arch)
+		require.Nil(t, os.MkdirAll(archPath, os.FileMode(0o755)))
+
+		for _, image := range []string{"kube-apiserver", "kube-proxy"} {
+			tag, err := name.NewTag("registry.k8s.io/" + image + ":v1.18.9")
+			require.Nil(t, err)
+			img, err := random.Image(64, 1)
+			require.Nil(t, err)
+			require.Nil(t, tarball.WriteToFile(
+				filepath.Join(archPath, image+".tar"), tag, img,
+			))
+			clientMock.RepoTagFromTarballReturnsOnCall(c, tag.String(), nil)
+			clientMock.RepoTagFromTarballReturnsOnCall(c+4, tag.String(), nil)
+			c++
+		}
+	}
+
+	layoutPath := filepath.Join(t.TempDir(), release.ImageLayoutDir)
+	require.Nil(t, sut.PublishToLayout(
+		layoutPath, release.GCRIOPathMock, version, buildPath,
+	))
+
+	digests, err := sut.LayoutDigests(
+		layoutPath, release.GCRIOPathMock, version, buildPath,
+	)
+	require.Nil(t, err)
+	require.Len(t, digests, 6)
+	require.Contains(t, digests, release.GCRIOPathMock+"/kube-apiserver:v1.18.9_abc")
+	require.Contains(t, digests, release.GCRIOPathMock+"/kube-proxy-arm64:v1.18.9_abc")
+
+	// No registry interaction should happen at all
+	require.Zero(t, clientMock.WriteImageCallCount())
+	require.Zero(t, clientMock.WriteIndexCallCount())
+	require.Zero(t, clientMock.SignImageCallCount())
+}
+
+func TestPublishImageLock(t *testing.T) {
+	sut := release.NewImages()
+	clientMock := &releasefakes.FakeImageImpl{}
+	sut.SetImpl(clientMock)
+
+	buildPath := newImagesPath(t)
+	defer os.RemoveAll(buildPath)
+	prepareImages(t, buildPath, clientMock)
+
+	digests, err := sut.Publish(release.GCRIOPathProd, "v1.18.9", buildPath)
+	require.Nil(t, err)
+
+	lock, err := release.ReadImageLock(
+		filepath.Join(buildPath, release.ImagesPath, release.ImagesLockFile),
+	)
+	require.Nil(t, err)
+	require.Equal(t, digests, lock.Digests())
+
+	for _, entry := range lock.Images {
+		require.Equal(t, "v1.18.9", entry.Tag)
+		require.Equal(t,
+			entry.Image+":"+strings.Replace(entry.Digest, ":", "-", 1)+".sig",
+			entry.Signature,
+		)
+		if entry.Image == release.GCRIOPathProd+"/kube-proxy-arm" {
+			require.Equal(t, "arm", entry.Arch)
+		}
+		if entry.Image == release.GCRIOPathProd+"/kube-proxy" {
+			require.Empty(t, entry.Arch)
+		}
+	}
+}
+
+func TestValidateImageLock(t *testing.T) {
+	writeLock := func(t *testing.T, buildPath string, digest string, skip string) {
+		lock := &release.ImageLock{}
+		for _, image := range []string{"conformance-amd64", "conformance", "kube-apiserver", "kube-proxy"} {
+			for _, suffix := range []string{"", "-amd64", "-arm", "-arm64"} {
+				name := release.GCRIOPathStaging + "/" + image + suffix
+				if name == skip {
+					continue
+				}
+				lock.Images = append(lock.Images, release.ImageLockEntry{
+					Image: name, Tag: "v1.18.9", Digest: digest,
+				})
+			}
+		}
+		require.Nil(t, lock.Write(
+			filepath.Join(buildPath, release.ImagesPath, release.ImagesLockFile),
+		))
+	}
+
+	for _, tc := range []struct {
+		name        string
+		prepare     func(*releasefakes.FakeImageImpl, string)
+		shouldError bool
+	}{
+		{
+			name: "success",
+			prepare: func(mock *releasefakes.FakeImageImpl, buildPath string) {
+				writeLock(t, buildPath, "sha256:123", "")
+				mock.DigestReturns("sha256:123", nil)
+			},
+		},
+		{
+			name: "failure on digest mismatch",
+			prepare: func(mock *releasefakes.FakeImageImpl, buildPath string) {
+				writeLock(t, buildPath, "sha256:123", "")
+				mock.DigestReturns("sha256:456", nil)
+			},
+			shouldError: true,
+		},
+		{
+			name: "failure on missing lockfile entry",
+			prepare: func(mock *releasefakes.FakeImageImpl, buildPath string) {
+				writeLock(t, buildPath, "sha256:123", release.GCRIOPathStaging+"/kube-proxy-arm")
+				mock.DigestReturns("sha256:123", nil)
+			},
+			shouldError: true,
+		},
+		{
+			name: "failure on digest",
+			prepare: func(mock *releasefakes.FakeImageImpl, buildPath string) {
+				writeLock(t, buildPath, "sha256:123", "")
+				mock.DigestReturns("", errors.New(""))
+			},
+			shouldError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sut := release.NewImages()
+			clientMock := &releasefakes.FakeImageImpl{}
+			sut.SetImpl(clientMock)
+
+			buildPath := newImagesPath(t)
+			defer os.RemoveAll(buildPath)
+			prepareImages(t, buildPath, clientMock)
+			clientMock.IndexManifestReturns(indexManifest("amd64", "arm", "arm64"), nil)
+			tc.prepare(clientMock, buildPath)
+
+			err := sut.Validate(release.GCRIOPathStaging, "v1.18.9", buildPath)
+			if tc.shouldError {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+		})
+	}
+}
@@ -0,0 +1,337 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"crypto/md5" //nolint:gosec // used to compare with GCS object checksums
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// InventoryFile is the name of the artifact inventory written for every
+// staged version.
+const InventoryFile = "inventory.json"
+
+// Inventory is the list of all staged artifacts of a version.
+type Inventory struct {
+	// Dirs are the directories covered by the inventory, relative to the
+	// build directory.
+	Dirs []string `json:"dirs"`
+
+	// Files are all files inside of `Dirs`.
+	Files []InventoryEntry `json:"files"`
+}
+
+// InventoryEntry is a single file of the inventory.
+type InventoryEntry struct {
+	// Path is the slash separated path relative to the build directory.
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA512 string `json:"sha512,omitempty"`
+
+	// SHA256 and MD5 allow verifying the file against the checksums of
+	// the uploaded objects without downloading them.
+	SHA256 string `json:"sha256,omitempty"`
+	MD5    string `json:"md5,omitempty"`
+}
+
+// InventoryChange is a file which differs from its inventory entry.
+type InventoryChange struct {
+	Expected InventoryEntry
+	Actual   InventoryEntry
+}
+
+// InventoryDiff contains all differences between an inventory and a
+// directory tree.
+type InventoryDiff struct {
+	Missing  []string
+	Extra    []string
+	Modified []InventoryChange
+}
+
+// StagedInventoryDirs returns the directories of a build covered by the
+// inventory of the provided version.
+func StagedInventoryDirs(version string) []string {
+	return []string{
+		filepath.ToSlash(filepath.Join(GCSStagePath, version)),
+		ImagesPath,
+	}
+}
+
+// NewInventory creates a new inventory of all files in `dirs`, which are
+// relative to `rootPath`. Not existing directories are skipped.
+func NewInventory(rootPath string, dirs ...string) (*Inventory, error) {
+	inventory := &Inventory{Dirs: dirs, Files: []InventoryEntry{}}
+
+	files, err := inventoryFiles(rootPath, dirs)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		entry, err := newInventoryEntry(rootPath, file)
+		if err != nil {
+			return nil, err
+		}
+		inventory.Files = append(inventory.Files, entry)
+	}
+
+	return inventory, nil
+}
+
+// ReadInventory reads an inventory from the provided path.
+func ReadInventory(path string) (*Inventory, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read inventory: %w", err)
+	}
+
+	inventory := &Inventory{}
+	if err := json.Unmarshal(content, inventory); err != nil {
+		return nil, fmt.Errorf("unmarshal inventory: %w", err)
+	}
+	return inventory, nil
+}
+
+// Write writes the inventory to the provided path.
+func (i *Inventory) Write(path string) error {
+	content, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal inventory: %w", err)
+	}
+	if err := os.WriteFile(path, content, os.FileMode(0o644)); err != nil {
+		return fmt.Errorf("write inventory: %w", err)
+	}
+	return nil
+}
+
+// Diff compares the inventory with the files inside of `rootPath`.
+func (i *Inventory) Diff(rootPath string) (*InventoryDiff, error) {
+	files, err := inventoryFiles(rootPath, i.Dirs)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := map[string]bool{}
+	for _, entry := range i.Files {
+		expected[entry.Path] = true
+	}
+
+	// Only the expected files need to be hashed
+	actual := map[string]InventoryEntry{}
+	for _, file := range files {
+		if !expected[file] {
+			actual[file] = InventoryEntry{Path: file}
+			continue
+		}
+		entry, err := newInventoryEntry(rootPath, file)
+		if err != nil {
+			return nil, err
+		}
+		actual[file] = entry
+	}
+	return i.DiffEntries(actual), nil
+}
+
+// DiffEntries compares the inventory with the provided entries by path, for
+// example the objects of a remote location. The entries have to contain all
+// files inside of the inventory directories.
+func (i *Inventory) DiffEntries(actual map[string]InventoryEntry) *InventoryDiff {
+	diff := &InventoryDiff{
+		Missing:  []string{},
+		Extra:    []string{},
+		Modified: []InventoryChange{},
+	}
+
+	expected := map[string]bool{}
+	for _, entry := range i.Files {
+		expected[entry.Path] = true
+		actualEntry, ok := actual[entry.Path]
+		if !ok {
+			diff.Missing = append(diff.Missing, entry.Path)
+			continue
+		}
+		if !entry.Matches(actualEntry) {
+			diff.Modified = append(diff.Modified, InventoryChange{
+				Expected: entry, Actual: actualEntry,
+			})
+		}
+	}
+
+	for path := range actual {
+		if !expected[path] {
+			diff.Extra = append(diff.Extra, path)
+		}
+	}
+
+	sort.Strings(diff.Missing)
+	sort.Strings(diff.Extra)
+	sort.Slice(diff.Modified, func(a, b int) bool {
+		return diff.Modified[a].Expected.Path < diff.Modified[b].Expected.Path
+	})
+	return diff
+}
+
+// VerifyEntries compares the inventory with the provided entries and
+// returns an error containing the diff if they do not match.
+func (i *Inventory) VerifyEntries(actual map[string]InventoryEntry) error {
+	logrus.Infof("Verifying %d inventory files against %d entries", len(i.Files), len(actual))
+	if diff := i.DiffEntries(actual); !diff.Empty() {
+		return fmt.Errorf("artifacts do not match the inventory:\n%s", diff.String())
+	}
+	return nil
+}
+
+// Matches returns true if both entries have the same size and all checksums
+// known by both of them are equal. Entries without any common checksum do
+// not match.
+func (e InventoryEntry) Matches(other InventoryEntry) bool {
+	if e.Size != other.Size {
+		return false
+	}
+	_, _, _, ok := e.checksumDiff(other)
+	return ok
+}
+
+// checksumDiff returns the name and values of the first checksum which is
+// known by both entries and differs. The returned bool is true if there are
+// common checksums and all of them are equal.
+func (e InventoryEntry) checksumDiff(other InventoryEntry) (name, expected, actual string, ok bool) {
+	common := false
+	for _, checksum := range []struct{ name, expected, actual string }{
+		{"sha512", e.SHA512, other.SHA512},
+		{"sha256", e.SHA256, other.SHA256},
+		{"md5", e.MD5, other.MD5},
+	} {
+		if checksum.expected == "" || checksum.actual == "" {
+			continue
+		}
+		if checksum.expected != checksum.actual {
+			return checksum.name, checksum.expected, checksum.actual, false
+		}
+		common = true
+	}
+	return "", "", "", common
+}
+
+// Verify compares the inventory with the files inside of `rootPath` and
+// returns an error containing the diff if they do not match.
+func (i *Inventory) Verify(rootPath string) error {
+	logrus.Infof("Verifying %d inventory files in %s", len(i.Files), rootPath)
+	diff, err := i.Diff(rootPath)
+	if err != nil {
+		return fmt.Errorf("diff inventory: %w", err)
+	}
+	if !diff.Empty() {
+		return fmt.Errorf("artifacts do not match the inventory:\n%s", diff.String())
+	}
+	return nil
+}
+
+// Empty returns true if there are no differences.
+func (d *InventoryDiff) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0 && len(d.Modified) == 0
+}
+
+// String returns a human readable representation of the diff.
+func (d *InventoryDiff) String() string {
+	sb := &strings.Builder{}
+	for _, path := range d.Missing {
+		fmt.Fprintf(sb, "- missing:  %s\n", path)
+	}
+	for _, path := range d.Extra {
+		fmt.Fprintf(sb, "- extra:    %s\n", path)
+	}
+	for _, change := range d.Modified {
+		fmt.Fprintf(sb, "- modified: %s", change.Expected.Path)
+		if change.Expected.Size != change.Actual.Size {
+			fmt.Fprintf(sb,
+				" (size: expected %d, got %d)",
+				change.Expected.Size, change.Actual.Size,
+			)
+		} else if name, expected, actual, ok := change.Expected.checksumDiff(change.Actual); !ok && name != "" {
+			fmt.Fprintf(sb, " (%s: expected %s, got %s)", name, expected, actual)
+		} else {
+			sb.WriteString(" (no common checksum)")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// inventoryFiles returns the sorted slash separated paths of all files in
+// `dirs`, relative to `rootPath`.
+func inventoryFiles(rootPath string, dirs []string) ([]string, error) {
+	files := []string{}
+	for _, dir := range dirs {
+		dirPath := filepath.Join(rootPath, filepath.FromSlash(dir))
+		if _, err := os.Stat(dirPath); os.IsNotExist(err) {
+			logrus.Infof("Skipping not existing inventory directory %s", dirPath)
+			continue
+		}
+
+		if err := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			rel, err := filepath.Rel(rootPath, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(rel))
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("traversing inventory directory %s: %w", dirPath, err)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func newInventoryEntry(rootPath, file string) (InventoryEntry, error) {
+	path := filepath.Join(rootPath, filepath.FromSlash(file))
+	f, err := os.Open(path)
+	if err != nil {
+		return InventoryEntry{}, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	sha512sum, sha256sum, md5sum := sha512.New(), sha256.New(), md5.New() //nolint:gosec // see import
+	size, err := io.Copy(io.MultiWriter(sha512sum, sha256sum, md5sum), f)
+	if err != nil {
+		return InventoryEntry{}, fmt.Errorf("hash %s: %w", path, err)
+	}
+	return InventoryEntry{
+		Path:   file,
+		Size:   size,
+		SHA512: hex.EncodeToString(sha512sum.Sum(nil)),
+		SHA256: hex.EncodeToString(sha256sum.Sum(nil)),
+		MD5:    hex.EncodeToString(md5sum.Sum(nil)),
+	}, nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/release"
+)
+
+const inventoryTestVersion = "v1.30.0"
+
+// writeInventoryTree creates a build directory with staged artifacts.
+func writeInventoryTree(t *testing.T) string {
+	dir := t.TempDir()
+	for file, content := range map[string]string{
+		"gcs-stage/v1.30.0/kubernetes.tar.gz":              "tarball",
+		"gcs-stage/v1.30.0/bin/linux/amd64/kubectl":        "kubectl",
+		"release-images/amd64/kube-apiserver.tar":          "apiserver",
+		"release-images/images-lock.json":                  "{}",
+		"gcs-stage/v1.30.0/bin/linux/amd64/kubectl.sha512": "sha",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestInventoryWriteRead(t *testing.T) {
+	t.Parallel()
+
+	dir := writeInventoryTree(t)
+	sut, err := release.NewInventory(
+		dir, release.StagedInventoryDirs(inventoryTestVersion)...,
+	)
+	require.NoError(t, err)
+	require.Len(t, sut.Files, 5)
+	require.Equal(t, "gcs-stage/v1.30.0/bin/linux/amd64/kubectl", sut.Files[0].Path)
+	require.EqualValues(t, 7, sut.Files[0].Size)
+	require.Len(t, sut.Files[0].SHA512, 128)
+
+	path := filepath.Join(dir, release.InventoryFile)
+	require.NoError(t, sut.Write(path))
+	res, err := release.ReadInventory(path)
+	require.NoError(t, err)
+	require.Equal(t, sut, res)
+
+	require.NoError(t, res.Verify(dir))
+}
+
+func TestInventoryDiff(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		modify   func(dir string)
+		expected release.InventoryDiff
+	}{
+		{
+			name:   "unchanged",
+			modify: func(string) {},
+			expected: release.InventoryDiff{
+				Missing: []string{}, Extra: []string{}, Modified: []release.InventoryChange{},
+			},
+		},
+		{
+			name: "missing and extra",
+			modify: func(dir string) {
+				require.NoError(t, os.Remove(
+					filepath.Join(dir, "release-images", "amd64", "kube-apiserver.tar"),
+				))
+				require.NoError(t, os.WriteFile(
+					filepath.Join(dir, "release-images", "extra"), []byte{}, 0o644,
+				))
+			},
+			expected: release.InventoryDiff{
+				Missing:  []string{"release-images/amd64/kube-apiserver.tar"},
+				Extra:    []string{"release-images/extra"},
+				Modified: []release.InventoryChange{},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := writeInventoryTree(t)
+			sut, err := release.NewInventory(
+				dir, release.StagedInventoryDirs(inventoryTestVersion)...,
+			)
+			require.NoError(t, err)
+
+			tc.modify(dir)
+			res, err := sut.Diff(dir)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, *res)
+		})
+	}
+}
+
+func TestInventoryVerifyModified(t *testing.T) {
+	t.Parallel()
+
+	dir := writeInventoryTree(t)
+	sut, err := release.NewInventory(
+		dir, release.StagedInventoryDirs(inventoryTestVersion)...,
+	)
+	require.NoError(t, err)
+
+	tarball := filepath.Join(dir, "gcs-stage", inventoryTestVersion, "kubernetes.tar.gz")
+	require.NoError(t, os.WriteFile(tarball, []byte("changed"), 0o644))
+	lock := filepath.Join(dir, "release-images", "images-lock.json")
+	require.NoError(t, os.WriteFile(lock, []byte("{ }"), 0o644))
+
+	err = sut.Verify(dir)
+	require.Error(t, err)
+	require.Contains(t, err.Error(),
+		"- modified: gcs-stage/v1.30.0/kubernetes.tar.gz (sha512: expected",
+	)
+	require.Contains(t, err.Error(),
+		"- modified: release-images/images-lock.json (size: expected 2, got 3)",
+	)
+}
+
+func TestInventoryDiffEntries(t *testing.T) {
+	t.Parallel()
+
+	dir := writeInventoryTree(t)
+	sut, err := release.NewInventory(
+		dir, release.StagedInventoryDirs(inventoryTestVersion)...,
+	)
+	require.NoError(t, err)
+
+	// Remote objects only provide either a SHA256 or a MD5
+	remote := map[string]release.InventoryEntry{}
+	for i, entry := range sut.Files {
+		remoteEntry := release.InventoryEntry{Path: entry.Path, Size: entry.Size, SHA256: entry.SHA256}
+		if i%2 == 0 {
+			remoteEntry = release.InventoryEntry{Path: entry.Path, Size: entry.Size, MD5: entry.MD5}
+		}
+		remote[entry.Path] = remoteEntry
+	}
+	require.NoError(t, sut.VerifyEntries(remote))
+
+	tarball := remote["gcs-stage/v1.30.0/kubernetes.tar.gz"]
+	tarball.SHA256, tarball.MD5 = "changed", ""
+	remote[tarball.Path] = tarball
+	remote["release-images/z"] = release.InventoryEntry{Path: "release-images/z"}
+	remote["release-images/a"] = release.InventoryEntry{Path: "release-images/a"}
+	delete(remote, "release-images/images-lock.json")
+
+	diff := sut.DiffEntries(remote)
+	require.Equal(t, []string{"release-images/images-lock.json"}, diff.Missing)
+	require.Equal(t, []string{"release-images/a", "release-images/z"}, diff.Extra)
+	require.Len(t, diff.Modified, 1)
+	require.Contains(t, diff.String(),
+		"- modified: gcs-stage/v1.30.0/kubernetes.tar.gz (sha256: expected",
+	)
+
+	// Entries without any common checksum do not match
+	require.False(t, sut.Files[0].Matches(release.InventoryEntry{Size: sut.Files[0].Size}))
+}
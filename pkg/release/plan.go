@@ -0,0 +1,290 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-sdk/object"
+	"sigs.k8s.io/release-utils/util"
+)
+
+// describeRegex matches the output of `git describe --tags` for revisions
+// which are not directly tagged, like `v1.30.0-alpha.1-12-g0123456789abcd`.
+var describeRegex = regexp.MustCompile(`^(v.+)-(\d+)-g([0-9a-f]+)$`)
+
+// PlanOptions are the options for creating a release `Plan`.
+type PlanOptions struct {
+	// The release type which should be planned. Can be either `alpha`,
+	// `beta`, `rc` or `official`.
+	ReleaseType string
+
+	// The release branch for which the release should be planned.
+	ReleaseBranch string
+
+	// The build version from which the release would be cut. Will be
+	// discovered from the tags of the repository if empty.
+	BuildVersion string
+
+	// RepoPath is the path to the local Kubernetes repository.
+	RepoPath string
+
+	// Bucket is the Google Cloud Storage bucket containing the version
+	// markers.
+	Bucket string
+
+	// VersionPolicy is the policy used to generate the release versions.
+	// Defaults to the Kubernetes version policy if nil.
+	VersionPolicy *VersionPolicy
+}
+
+// Plan contains everything a stage and release run would create for a set of
+// options, without modifying anything.
+type Plan struct {
+	ReleaseType   string `json:"releaseType"`
+	ReleaseBranch string `json:"releaseBranch"`
+	BuildVersion  string `json:"buildVersion"`
+
+	// CreateReleaseBranch is true if the release branch does not exist yet.
+	CreateReleaseBranch bool `json:"createReleaseBranch"`
+
+	// PrimeVersion is the main version of the release.
+	PrimeVersion string `json:"primeVersion"`
+
+	// Versions are all versions which would be released, where each version
+	// results in a git tag.
+	Versions []string `json:"versions"`
+
+	// CreatedBranches are the git branches which would be created.
+	CreatedBranches []string `json:"createdBranches"`
+
+	// PushedBranches are the git branches which would be pushed.
+	PushedBranches []string `json:"pushedBranches"`
+
+	// VersionMarkers are the version marker files which `PublishVersion`
+	// would update, if the published version is newer than their current
+	// content.
+	VersionMarkers []PlanVersionMarker `json:"versionMarkers"`
+}
+
+// PlanVersionMarker is a single version marker update of a `Plan`.
+type PlanVersionMarker struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+// String returns a human readable representation of the plan.
+func (p *Plan) String() string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "Release type:          %s\n", p.ReleaseType)
+	fmt.Fprintf(sb, "Release branch:        %s\n", p.ReleaseBranch)
+	fmt.Fprintf(sb, "Build version:         %s\n", p.BuildVersion)
+	fmt.Fprintf(sb, "Create release branch: %v\n", p.CreateReleaseBranch)
+	fmt.Fprintf(sb, "Prime version:         %s\n", p.PrimeVersion)
+
+	writeList := func(title string, items []string) {
+		fmt.Fprintf(sb, "\n%s:\n", title)
+		if len(items) == 0 {
+			sb.WriteString("  (none)\n")
+		}
+		for _, item := range items {
+			fmt.Fprintf(sb, "  - %s\n", item)
+		}
+	}
+	writeList("Tags", p.Versions)
+	writeList("Created branches", p.CreatedBranches)
+	writeList("Pushed branches", p.PushedBranches)
+
+	markers := []string{}
+	for _, m := range p.VersionMarkers {
+		markers = append(markers, fmt.Sprintf("%s -> %s", m.Path, m.Version))
+	}
+	writeList("Version markers (if newer)", markers)
+
+	return sb.String()
+}
+
+// Planner can be used to create release plans.
+type Planner struct {
+	impl plannerImpl
+}
+
+// NewPlanner creates a new release planner instance.
+func NewPlanner() *Planner {
+	return &Planner{&defaultPlannerImpl{}}
+}
+
+// SetImpl can be used to set the internal Planner implementation.
+func (p *Planner) SetImpl(impl plannerImpl) {
+	p.impl = impl
+}
+
+//counterfeiter:generate . plannerImpl
+type plannerImpl interface {
+	OpenRepo(repoPath string) (*git.Repo, error)
+	RevParse(repo *git.Repo, rev string) (string, error)
+	Describe(repo *git.Repo, rev string) (string, error)
+}
+
+type defaultPlannerImpl struct{}
+
+func (*defaultPlannerImpl) OpenRepo(repoPath string) (*git.Repo, error) {
+	return git.OpenRepo(repoPath)
+}
+
+func (*defaultPlannerImpl) RevParse(repo *git.Repo, rev string) (string, error) {
+	return repo.RevParse(rev)
+}
+
+func (*defaultPlannerImpl) Describe(repo *git.Repo, rev string) (string, error) {
+	return repo.Describe(
+		git.NewDescribeOptions().
+			WithRevision(rev).
+			WithTags().
+			WithAbbrev(14),
+	)
+}
+
+// Plan creates a new release plan for the provided options by using the
+// branches and tags of the local repository.
+func (p *Planner) Plan(opts *PlanOptions) (*Plan, error) {
+	policy := opts.VersionPolicy
+	if policy == nil {
+		policy = DefaultVersionPolicy()
+	}
+
+	repo, err := p.impl.OpenRepo(opts.RepoPath)
+	if err != nil {
+		return nil, fmt.Errorf("open repository: %w", err)
+	}
+
+	// The release branch may only exist as remote tracking branch
+	branchRev := ""
+	for _, rev := range []string{opts.ReleaseBranch, git.Remotify(opts.ReleaseBranch)} {
+		if _, err := p.impl.RevParse(repo, rev); err == nil {
+			branchRev = rev
+			break
+		}
+	}
+	branchExists := branchRev != ""
+
+	buildVersion := opts.BuildVersion
+	if buildVersion == "" {
+		// New release branches get cut from the default branch
+		rev := branchRev
+		if rev == "" {
+			rev = git.Remotify(git.DefaultBranch)
+		}
+
+		buildVersion, err = p.buildVersionFromRepo(repo, rev)
+		if err != nil {
+			return nil, fmt.Errorf("discover build version: %w", err)
+		}
+	}
+	logrus.Infof("Using build version %s", buildVersion)
+
+	semverBuildVersion, err := util.TagStringToSemver(buildVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid build version %s: %w", buildVersion, err)
+	}
+
+	createReleaseBranch, err := branchNeedsCreation(
+		policy, opts.ReleaseBranch, opts.ReleaseType, branchExists, semverBuildVersion,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("check if release branch needs creation: %w", err)
+	}
+
+	versions, err := policy.GenerateReleaseVersion(
+		opts.ReleaseType, buildVersion, opts.ReleaseBranch, createReleaseBranch,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("generate release versions: %w", err)
+	}
+
+	plan := &Plan{
+		ReleaseType:         opts.ReleaseType,
+		ReleaseBranch:       opts.ReleaseBranch,
+		BuildVersion:        buildVersion,
+		CreateReleaseBranch: createReleaseBranch,
+		PrimeVersion:        versions.Prime(),
+		Versions:            versions.Ordered(),
+		CreatedBranches:     []string{},
+		PushedBranches:      []string{},
+		VersionMarkers:      []PlanVersionMarker{},
+	}
+
+	if createReleaseBranch {
+		plan.CreatedBranches = append(plan.CreatedBranches, opts.ReleaseBranch)
+	}
+	if opts.ReleaseBranch != git.DefaultBranch {
+		plan.PushedBranches = append(plan.PushedBranches, opts.ReleaseBranch)
+	}
+	plan.PushedBranches = append(plan.PushedBranches, git.DefaultBranch)
+
+	const gcsRoot = "release"
+	for _, version := range plan.Versions {
+		markers, err := VersionMarkers(gcsRoot, version, nil, false)
+		if err != nil {
+			return nil, fmt.Errorf("get version markers: %w", err)
+		}
+		for _, marker := range markers {
+			path, err := object.NewGCS().NormalizePath(
+				opts.Bucket, gcsRoot, marker+".txt",
+			)
+			if err != nil {
+				return nil, fmt.Errorf("normalize version marker path: %w", err)
+			}
+			plan.VersionMarkers = append(plan.VersionMarkers, PlanVersionMarker{
+				Path: path, Version: version,
+			})
+		}
+	}
+
+	return plan, nil
+}
+
+// buildVersionFromRepo discovers the build version for the provided revision
+// the same way the Kubernetes build does, for example
+// `v1.30.0-alpha.1-12-g0123456789abcd` becomes
+// `v1.30.0-alpha.1.12+0123456789abcd` and `v1.30.0-12-g0123456789abcd`
+// becomes `v1.30.0-12+0123456789abcd`.
+func (p *Planner) buildVersionFromRepo(repo *git.Repo, rev string) (string, error) {
+	describe, err := p.impl.Describe(repo, rev)
+	if err != nil {
+		return "", fmt.Errorf("describe %s: %w", rev, err)
+	}
+
+	matches := describeRegex.FindStringSubmatch(describe)
+	if matches == nil {
+		// The revision is exactly a tag
+		return describe, nil
+	}
+
+	separator := "-"
+	if strings.Contains(matches[1], "-") {
+		separator = "."
+	}
+	return fmt.Sprintf(
+		"%s%s%s+%s", matches[1], separator, matches[2], matches[3],
+	), nil
+}
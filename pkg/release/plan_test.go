@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/release"
+	"k8s.io/release/pkg/release/releasefakes"
+)
+
+func TestPlan(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		opts     *release.PlanOptions
+		prepare  func(*releasefakes.FakePlannerImpl)
+		assert   func(*release.Plan)
+		hasError bool
+	}{
+		{
+			name: "alpha on master",
+			opts: &release.PlanOptions{
+				ReleaseType:   release.ReleaseTypeAlpha,
+				ReleaseBranch: "master",
+			},
+			prepare: func(mock *releasefakes.FakePlannerImpl) {
+				mock.DescribeReturns("v1.31.0-alpha.1-12-g0123456789abcd", nil)
+			},
+			assert: func(plan *release.Plan) {
+				require.Equal(t, "v1.31.0-alpha.1.12+0123456789abcd", plan.BuildVersion)
+				require.False(t, plan.CreateReleaseBranch)
+				require.Equal(t, "v1.31.0-alpha.2", plan.PrimeVersion)
+				require.Equal(t, []string{"v1.31.0-alpha.2"}, plan.Versions)
+				require.Empty(t, plan.CreatedBranches)
+				require.Equal(t, []string{"master"}, plan.PushedBranches)
+				require.Equal(t, []release.PlanVersionMarker{
+					{Path: "gs://bucket/release/latest.txt", Version: "v1.31.0-alpha.2"},
+					{Path: "gs://bucket/release/latest-1.txt", Version: "v1.31.0-alpha.2"},
+					{Path: "gs://bucket/release/latest-1.31.txt", Version: "v1.31.0-alpha.2"},
+				}, plan.VersionMarkers)
+			},
+		},
+		{
+			name: "rc on new release branch",
+			opts: &release.PlanOptions{
+				ReleaseType:   release.ReleaseTypeRC,
+				ReleaseBranch: "release-1.31",
+			},
+			prepare: func(mock *releasefakes.FakePlannerImpl) {
+				mock.RevParseReturns("", errors.New("not found"))
+				mock.DescribeReturns("v1.31.0-beta.0-5-g0123456789abcd", nil)
+			},
+			assert: func(plan *release.Plan) {
+				require.True(t, plan.CreateReleaseBranch)
+				require.Equal(t, "v1.31.0-rc.0", plan.PrimeVersion)
+				require.ElementsMatch(t, []string{"v1.31.0-rc.0", "v1.32.0-alpha.0"}, plan.Versions)
+				require.Equal(t, []string{"release-1.31"}, plan.CreatedBranches)
+				require.Equal(t, []string{"release-1.31", "master"}, plan.PushedBranches)
+				require.Len(t, plan.VersionMarkers, 6)
+			},
+		},
+		{
+			name: "official on existing release branch",
+			opts: &release.PlanOptions{
+				ReleaseType:   release.ReleaseTypeOfficial,
+				ReleaseBranch: "release-1.30",
+				BuildVersion:  "v1.30.1-rc.0.5+0123456789abcd",
+			},
+			assert: func(plan *release.Plan) {
+				require.False(t, plan.CreateReleaseBranch)
+				require.Equal(t, "v1.30.1", plan.PrimeVersion)
+				require.Equal(t, []string{"release-1.30", "master"}, plan.PushedBranches)
+				require.Equal(t, "gs://bucket/release/stable.txt", plan.VersionMarkers[0].Path)
+			},
+		},
+		{
+			name: "official on exact tag",
+			opts: &release.PlanOptions{
+				ReleaseType:   release.ReleaseTypeOfficial,
+				ReleaseBranch: "release-1.30",
+			},
+			prepare: func(mock *releasefakes.FakePlannerImpl) {
+				mock.DescribeReturns("v1.30.0", nil)
+			},
+			assert: func(plan *release.Plan) {
+				require.Equal(t, "v1.30.0", plan.BuildVersion)
+				require.Equal(t, "v1.30.1", plan.PrimeVersion)
+			},
+		},
+		{
+			name: "official on new release branch",
+			opts: &release.PlanOptions{
+				ReleaseType:   release.ReleaseTypeOfficial,
+				ReleaseBranch: "release-1.31",
+			},
+			prepare: func(mock *releasefakes.FakePlannerImpl) {
+				mock.RevParseReturns("", errors.New("not found"))
+				mock.DescribeReturns("v1.31.0-beta.0-5-g0123456789abcd", nil)
+			},
+			hasError: true,
+		},
+		{
+			name: "build version does not match branch",
+			opts: &release.PlanOptions{
+				ReleaseType:   release.ReleaseTypeRC,
+				ReleaseBranch: "release-1.30",
+				BuildVersion:  "v1.31.0-rc.0.5+0123456789abcd",
+			},
+			hasError: true,
+		},
+		{
+			name: "describe fails",
+			opts: &release.PlanOptions{
+				ReleaseType:   release.ReleaseTypeAlpha,
+				ReleaseBranch: "master",
+			},
+			prepare: func(mock *releasefakes.FakePlannerImpl) {
+				mock.DescribeReturns("", errors.New(""))
+			},
+			hasError: true,
+		},
+		{
+			name: "open repo fails",
+			opts: &release.PlanOptions{},
+			prepare: func(mock *releasefakes.FakePlannerImpl) {
+				mock.OpenRepoReturns(nil, errors.New(""))
+			},
+			hasError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mock := &releasefakes.FakePlannerImpl{}
+			if tc.prepare != nil {
+				tc.prepare(mock)
+			}
+			sut := release.NewPlanner()
+			sut.SetImpl(mock)
+
+			tc.opts.Bucket = "bucket"
+			plan, err := sut.Plan(tc.opts)
+			if tc.hasError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			tc.assert(plan)
+			require.NotEmpty(t, plan.String())
+		})
+	}
+}
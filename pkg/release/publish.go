@@ -164,20 +164,11 @@ func (p *Publisher) PublishVersion(
 	privateBucket, fast bool,
 ) error {
 	logrus.Info("Publishing version")
-	releaseType := "latest"
-
-	if buildType == "release" {
-		// For release/ targets, type should be 'stable'
-		if !(strings.Contains(version, ReleaseTypeAlpha) ||
-			strings.Contains(version, ReleaseTypeBeta) ||
-			strings.Contains(version, ReleaseTypeRC)) {
-			releaseType = "stable"
-		}
-	}
-
-	sv, err := util.TagStringToSemver(version)
+	versionMarkers, err := VersionMarkers(
+		buildType, version, extraVersionMarkers, fast,
+	)
 	if err != nil {
-		return fmt.Errorf("invalid version %s", version)
+		return err
 	}
 
 	markerPath, markerPathErr := p.client.GetMarkerPath(
@@ -205,25 +196,6 @@ func (p *Publisher) PublishVersion(
 		return fmt.Errorf("release files don't exist at %s: %w", releasePath, err)
 	}
 
-	var versionMarkers []string
-	if fast {
-		versionMarkers = append(
-			versionMarkers,
-			releaseType+"-fast",
-		)
-	} else {
-		versionMarkers = append(
-			versionMarkers,
-			releaseType,
-			fmt.Sprintf("%s-%d", releaseType, sv.Major),
-			fmt.Sprintf("%s-%d.%d", releaseType, sv.Major, sv.Minor),
-		)
-	}
-
-	if len(extraVersionMarkers) > 0 {
-		versionMarkers = append(versionMarkers, extraVersionMarkers...)
-	}
-
 	logrus.Infof("Publish version markers: %v", versionMarkers)
 	logrus.Infof("Publish official pointer text files to %s", markerPath)
 
@@ -256,6 +228,50 @@ func (p *Publisher) PublishVersion(
 	return nil
 }
 
+// VersionMarkers returns the names of the version marker files (without the
+// `.txt` suffix) which get updated by `PublishVersion` for the provided
+// parameters.
+func VersionMarkers(
+	buildType, version string, extraVersionMarkers []string, fast bool,
+) ([]string, error) {
+	releaseType := "latest"
+
+	if buildType == "release" {
+		// For release/ targets, type should be 'stable'
+		if !(strings.Contains(version, ReleaseTypeAlpha) ||
+			strings.Contains(version, ReleaseTypeBeta) ||
+			strings.Contains(version, ReleaseTypeRC)) {
+			releaseType = "stable"
+		}
+	}
+
+	sv, err := util.TagStringToSemver(version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version %s", version)
+	}
+
+	var versionMarkers []string
+	if fast {
+		versionMarkers = append(
+			versionMarkers,
+			releaseType+"-fast",
+		)
+	} else {
+		versionMarkers = append(
+			versionMarkers,
+			releaseType,
+			fmt.Sprintf("%s-%d", releaseType, sv.Major),
+			fmt.Sprintf("%s-%d.%d", releaseType, sv.Major, sv.Minor),
+		)
+	}
+
+	if len(extraVersionMarkers) > 0 {
+		versionMarkers = append(versionMarkers, extraVersionMarkers...)
+	}
+
+	return versionMarkers, nil
+}
+
 // VerifyLatestUpdate checks if the new version is greater than the version
 // currently published on GCS. It returns `true` for `needsUpdate` if the remote
 // version does not exist or needs to be updated.
@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/release-sdk/object"
+
+	"k8s.io/release/pkg/localstore"
+)
+
+// NewLocalPublisher creates a new Publisher instance which uses the provided
+// local object store instead of Google Cloud Storage.
+func NewLocalPublisher(store *localstore.Store) *Publisher {
+	store.SetOptions(store.WithNoClobber(false))
+	return &Publisher{
+		client: &localPublisher{
+			defaultPublisher: defaultPublisher{store},
+			store:            store,
+		},
+	}
+}
+
+// localPublisher is a publisherClient which emulates the used `gsutil`
+// commands and public URLs on top of a local object store.
+type localPublisher struct {
+	defaultPublisher
+	store *localstore.Store
+}
+
+func (l *localPublisher) GSUtil(args ...string) error {
+	_, err := l.GSUtilOutput(args...)
+	return err
+}
+
+func (l *localPublisher) GSUtilOutput(args ...string) (string, error) {
+	args = stripGSUtilFlags(args)
+	if len(args) < 2 {
+		return "", fmt.Errorf("unsupported gsutil arguments for local store: %v", args)
+	}
+
+	switch args[0] {
+	case "ls", "stat":
+		exists, err := l.store.PathExists(args[len(args)-1])
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return "", fmt.Errorf("%s matched no objects", args[len(args)-1])
+		}
+		return "", nil
+
+	case "cat":
+		return l.cat(args[len(args)-1])
+
+	case "cp":
+		if len(args) < 3 {
+			return "", fmt.Errorf("missing copy destination: %v", args)
+		}
+		return "", l.store.CopyToRemote(args[len(args)-2], args[len(args)-1])
+	}
+
+	return "", fmt.Errorf("unsupported gsutil command for local store: %s", args[0])
+}
+
+func (l *localPublisher) GSUtilStatus(args ...string) (bool, error) {
+	args = stripGSUtilFlags(args)
+	if len(args) < 2 || args[0] != "stat" {
+		return false, fmt.Errorf("unsupported gsutil arguments for local store: %v", args)
+	}
+	return l.store.PathExists(args[len(args)-1])
+}
+
+// GetURLResponse resolves the public bucket URL into the local object store.
+func (l *localPublisher) GetURLResponse(url string) (string, error) {
+	var gcsPath string
+	switch {
+	case strings.HasPrefix(url, ProductionBucketURL+"/"):
+		gcsPath = object.GcsPrefix + ProductionBucket +
+			strings.TrimPrefix(url, ProductionBucketURL)
+	case strings.HasPrefix(url, URLPrefixForBucket("")):
+		gcsPath = object.GcsPrefix + strings.TrimPrefix(url, URLPrefixForBucket(""))
+	default:
+		return "", fmt.Errorf("unable to map URL %s to local store", url)
+	}
+	return l.cat(gcsPath)
+}
+
+func (l *localPublisher) cat(gcsPath string) (string, error) {
+	localPath, err := l.store.LocalPath(gcsPath)
+	if err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(localPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("%s matched no objects", gcsPath)
+		}
+		return "", fmt.Errorf("read %s: %w", localPath, err)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// stripGSUtilFlags removes the global and header flags from the provided
+// gsutil arguments.
+func stripGSUtilFlags(args []string) []string {
+	res := []string{}
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-m", "-q":
+			continue
+		case "-h":
+			i++
+			continue
+		}
+		res = append(res, args[i])
+	}
+	return res
+}
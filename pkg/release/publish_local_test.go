@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/release/pkg/localstore"
+	"k8s.io/release/pkg/release"
+)
+
+func TestLocalPublishVersion(t *testing.T) {
+	const (
+		bucket  = "bucket"
+		version = "v1.20.0-alpha.1.66+d19aec8bf1c8ca"
+	)
+
+	for _, private := range []bool{false, true} {
+		root := t.TempDir()
+		require.NoError(t, os.MkdirAll(
+			filepath.Join(root, bucket, "ci", version), 0o755,
+		))
+
+		// An older marker which needs to be updated
+		require.NoError(t, os.WriteFile(
+			filepath.Join(root, bucket, "ci", "latest.txt"),
+			[]byte("v1.19.0"), 0o644,
+		))
+
+		sut := release.NewLocalPublisher(localstore.New(root))
+		require.NoError(t, sut.PublishVersion(
+			"ci", version, t.TempDir(), bucket, "ci", nil, private, false,
+		))
+
+		for _, marker := range []string{"latest.txt", "latest-1.txt", "latest-1.20.txt"} {
+			content, err := os.ReadFile(filepath.Join(root, bucket, "ci", marker))
+			require.NoError(t, err)
+			require.Equal(t, version, string(content))
+		}
+	}
+}
+
+func TestLocalPublishVersionMissingRelease(t *testing.T) {
+	sut := release.NewLocalPublisher(localstore.New(t.TempDir()))
+	require.Error(t, sut.PublishVersion(
+		"ci", "v1.20.0", t.TempDir(), "bucket", "ci", nil, false, false,
+	))
+}
+
+func TestLocalPublishReleaseNotesIndex(t *testing.T) {
+	root := t.TempDir()
+	sut := release.NewLocalPublisher(localstore.New(root))
+
+	for _, version := range []string{"v1.20.0", "v1.20.1"} {
+		require.NoError(t, sut.PublishReleaseNotesIndex(
+			"gs://bucket/release",
+			"gs://bucket/release/"+version+"/release-notes.json",
+			version,
+		))
+	}
+
+	content, err := os.ReadFile(
+		filepath.Join(root, "bucket", "release", "release-notes-index.json"),
+	)
+	require.NoError(t, err)
+
+	index := map[string]string{}
+	require.NoError(t, json.Unmarshal(content, &index))
+	require.Equal(t, map[string]string{
+		"v1.20.0": "gs://bucket/release/v1.20.0/release-notes.json",
+		"v1.20.1": "gs://bucket/release/v1.20.1/release-notes.json",
+	}, index)
+}
@@ -17,11 +17,9 @@ limitations under the License.
 package release
 
 import (
-	"errors"
 	"fmt"
 	"strings"
 
-	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/release-sdk/git"
@@ -46,6 +44,10 @@ type GitObjectPusherOptions struct {
 
 	// Path to the repository
 	RepoPath string
+
+	// VersionPolicy defines the valid release branch names. Defaults to the
+	// Kubernetes version policy if not set.
+	VersionPolicy *VersionPolicy
 }
 
 // NewGitPusher returns a new git object pusher.
@@ -192,14 +194,12 @@ func (gp *GitObjectPusher) checkTagName(tagName string) error {
 
 // checkBranchName verifies that the branch name is valid.
 func (gp *GitObjectPusher) checkBranchName(branchName string) error {
-	if !strings.HasPrefix(branchName, "release-") {
-		return errors.New("branch name has to start with release-")
+	policy := gp.opts.VersionPolicy
+	if policy == nil {
+		policy = DefaultVersionPolicy()
 	}
-	versionTag := strings.TrimPrefix(branchName, "release-")
-	// Add .0 and check is we get a valid semver
-	_, err := semver.Parse(versionTag + ".0")
-	if err != nil {
-		return fmt.Errorf("parsing semantic version in branchname: %w", err)
+	if _, _, err := policy.BranchVersion(branchName); err != nil {
+		return fmt.Errorf("branch name has to match %s: %w", policy.ReleaseBranchPattern, err)
 	}
 	return nil
 }
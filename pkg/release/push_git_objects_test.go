@@ -92,6 +92,12 @@ func TestCheckBranchName(t *testing.T) {
 			require.NotNil(t, ghp.checkBranchName(testCase.branchName))
 		}
 	}
+
+	policy := DefaultVersionPolicy()
+	policy.ReleaseBranchPattern = `^stable/(?P<major>\d+)\.(?P<minor>\d+)$`
+	ghp.opts.VersionPolicy = policy
+	require.Nil(t, ghp.checkBranchName("stable/1.20"))
+	require.NotNil(t, ghp.checkBranchName("release-1.20"))
 }
 
 func TestCheckTagName(t *testing.T) {
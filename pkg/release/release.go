@@ -72,6 +72,10 @@ const (
 	// Path where the release container images are stored.
 	ImagesPath = "release-images"
 
+	// ImageLayoutDir is the directory inside of a local object store where
+	// container images are written to as OCI image layout.
+	ImageLayoutDir = "oci-layout"
+
 	// GCSStagePath is the directory where release artifacts are staged before
 	// push to GCS.
 	GCSStagePath = "gcs-stage"
@@ -255,12 +259,41 @@ func URLPrefixForBucket(bucket string) string {
 // CopyBinaries takes the provided `rootPath` and copies the binaries sorted by
 // their platform into the `targetPath`.
 func CopyBinaries(rootPath, targetPath string) error {
+	dirs, err := BinaryDirs(rootPath)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range dirs {
+		dst := filepath.Join(targetPath, dir.Dst)
+		logrus.Infof("Copying binaries from %s to %s", dir.Src, dst)
+		if err := util.CopyDirContentsLocal(dir.Src, dst); err != nil {
+			return fmt.Errorf("copy binaries from %s to %s: %w", dir.Src, dst, err)
+		}
+	}
+	return nil
+}
+
+// BinaryDir is a directory of plain binaries for a single platform.
+type BinaryDir struct {
+	// Src is the directory containing the built binaries.
+	Src string
+
+	// Dst is the destination relative to the staging directory, for example
+	// `bin/linux/amd64`.
+	Dst string
+}
+
+// BinaryDirs returns the binary directories below `rootPath` sorted by
+// their platform.
+func BinaryDirs(rootPath string) ([]BinaryDir, error) {
 	platformsPath := filepath.Join(rootPath, "client")
 	platformsAndArches, err := os.ReadDir(platformsPath)
 	if err != nil {
-		return fmt.Errorf("retrieve platforms from %s: %w", platformsPath, err)
+		return nil, fmt.Errorf("retrieve platforms from %s: %w", platformsPath, err)
 	}
 
+	dirs := []BinaryDir{}
 	for _, platformArch := range platformsAndArches {
 		if !platformArch.IsDir() {
 			logrus.Warnf(
@@ -272,16 +305,14 @@ func CopyBinaries(rootPath, targetPath string) error {
 
 		split := strings.Split(platformArch.Name(), "-")
 		if len(split) != 2 {
-			return fmt.Errorf(
+			return nil, fmt.Errorf(
 				"expected `platform-arch` format for %s", platformArch.Name(),
 			)
 		}
 
 		platform := split[0]
 		arch := split[1]
-		logrus.Infof(
-			"Copying binaries for %s platform on %s arch", platform, arch,
-		)
+		dst := filepath.Join("bin", platform, arch)
 
 		src := filepath.Join(
 			rootPath, "client", platformArch.Name(), "kubernetes", "client", "bin",
@@ -291,28 +322,21 @@ func CopyBinaries(rootPath, targetPath string) error {
 		// package"
 		serverSrc := filepath.Join(rootPath, "server", platformArch.Name())
 		if util.Exists(serverSrc) {
-			logrus.Infof("Server source found in %s, copying them", serverSrc)
+			logrus.Infof("Server source found in %s", serverSrc)
 			src = filepath.Join(serverSrc, "kubernetes", "server", "bin")
 		}
+		dirs = append(dirs, BinaryDir{Src: src, Dst: dst})
 
-		dst := filepath.Join(targetPath, "bin", platform, arch)
-		logrus.Infof("Copying server binaries from %s to %s", src, dst)
-		if err := util.CopyDirContentsLocal(src, dst); err != nil {
-			return fmt.Errorf("copy server binaries from %s to %s: %w", src, dst, err)
-		}
-
-		// Copy node binaries if they exist and this isn't a 'server' platform
+		// Add node binaries if they exist and this isn't a 'server' platform
 		nodeSrc := filepath.Join(rootPath, "node", platformArch.Name())
 		if !util.Exists(serverSrc) && util.Exists(nodeSrc) {
-			src = filepath.Join(nodeSrc, "kubernetes", "node", "bin")
-
-			logrus.Infof("Copying node binaries from %s to %s", src, dst)
-			if err := util.CopyDirContentsLocal(src, dst); err != nil {
-				return fmt.Errorf("copy node binaries from %s to %s: %w", src, dst, err)
-			}
+			dirs = append(dirs, BinaryDir{
+				Src: filepath.Join(nodeSrc, "kubernetes", "node", "bin"),
+				Dst: dst,
+			})
 		}
 	}
-	return nil
+	return dirs, nil
 }
 
 // WriteChecksums writes the SHA256SUMS/SHA512SUMS files (contains all
@@ -428,13 +452,19 @@ func WriteChecksums(rootPath string) error {
 	return nil
 }
 
-// CreatePubBotBranchIssue creates an issue on GitHub to notify.
-func CreatePubBotBranchIssue(branchName string) error {
+// CreatePubBotBranchIssue creates an issue on GitHub to notify. The milestone
+// is derived from the branch name using the provided version policy.
+func CreatePubBotBranchIssue(branchName string, policy *VersionPolicy) error {
 	// Check the GH token is set
 	if os.Getenv(github.TokenEnvKey) == "" {
 		return errors.New("cannot file publishing bot issue as GitHub token is not set")
 	}
 
+	major, minor, err := policy.BranchVersion(branchName)
+	if err != nil {
+		return fmt.Errorf("parsing release branch version: %w", err)
+	}
+
 	gh := github.New()
 
 	// Create the body for the issue
@@ -443,7 +473,7 @@ func CreatePubBotBranchIssue(branchName string) error {
 	issueBody += "/sig release\n"
 	issueBody += "/area release-eng\n"
 	issueBody += "/assign @kubernetes/release-managers\n"
-	issueBody += "/milestone v" + strings.TrimPrefix(branchName, "release-") + "\n"
+	issueBody += fmt.Sprintf("/milestone v%d.%d\n", major, minor)
 
 	// Create the issue on GitHub
 	issue, err := gh.CreateIssue(
@@ -22,12 +22,21 @@ import (
 	"compress/gzip"
 	"fmt"
 	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/stretchr/testify/require"
 
 	"sigs.k8s.io/release-sdk/git"
@@ -504,3 +513,35 @@ func TestWriteChecksums(t *testing.T) {
 		cleanup()
 	}
 }
+
+func TestWriteImageRetry(t *testing.T) {
+	backoff := pushRetryBackoff
+	pushRetryBackoff = remote.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 5}
+	t.Cleanup(func() { pushRetryBackoff = backoff })
+
+	// The registry fails the first manifest pushes temporarily
+	var failures atomic.Int32
+	handler := registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && strings.Contains(r.URL.Path, "/manifests/") &&
+			failures.Add(1) <= 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+
+	reference := strings.TrimPrefix(server.URL, "http://") + "/kube-apiserver-amd64:v1.30.0"
+	require.NoError(t, (&defaultImageImpl{}).WriteImage(reference, img))
+	require.EqualValues(t, 4, failures.Load())
+
+	// Requests fail once the retries are exhausted
+	failures.Store(-10)
+	require.Error(t, (&defaultImageImpl{}).WriteImage(
+		strings.Replace(reference, "v1.30.0", "v1.30.1", 1), img,
+	))
+}
@@ -18,13 +18,8 @@ package release
 
 import (
 	"fmt"
-	"strconv"
+	"sort"
 	"strings"
-
-	"github.com/sirupsen/logrus"
-
-	"sigs.k8s.io/release-sdk/regex"
-	"sigs.k8s.io/release-utils/util"
 )
 
 const (
@@ -41,16 +36,20 @@ type Versions struct {
 	rc       string
 	beta     string
 	alpha    string
+
+	// custom contains the versions of pre-release labels which are not
+	// part of the default Kubernetes version policy.
+	custom map[string]string
 }
 
 // NewReleaseVersions can be used to create a new `*Versions` instance.
 func NewReleaseVersions(prime, official, rc, beta, alpha string) *Versions {
 	return &Versions{
-		prime,
-		official,
-		rc,
-		beta,
-		alpha,
+		prime:    prime,
+		official: official,
+		rc:       rc,
+		beta:     beta,
+		alpha:    alpha,
 	}
 }
 
@@ -79,6 +78,60 @@ func (r *Versions) Alpha() string {
 	return r.alpha
 }
 
+// Get returns the version for the provided release type or pre-release
+// label.
+func (r *Versions) Get(label string) string {
+	switch label {
+	case ReleaseTypeOfficial:
+		return r.official
+	case ReleaseTypeRC:
+		return r.rc
+	case ReleaseTypeBeta:
+		return r.beta
+	case ReleaseTypeAlpha:
+		return r.alpha
+	default:
+		return r.custom[label]
+	}
+}
+
+// Set sets the version for the provided release type or pre-release label.
+func (r *Versions) Set(label, version string) {
+	switch label {
+	case ReleaseTypeOfficial:
+		r.official = version
+	case ReleaseTypeRC:
+		r.rc = version
+	case ReleaseTypeBeta:
+		r.beta = version
+	case ReleaseTypeAlpha:
+		r.alpha = version
+	default:
+		if r.custom == nil {
+			r.custom = map[string]string{}
+		}
+		r.custom[label] = version
+	}
+}
+
+// Custom returns the versions of all pre-release labels which are not part
+// of the default Kubernetes version policy.
+func (r *Versions) Custom() map[string]string {
+	return r.custom
+}
+
+// customLabels returns the sorted custom pre-release labels.
+func (r *Versions) customLabels() []string {
+	labels := make([]string, 0, len(r.custom))
+	for label, version := range r.custom {
+		if version != "" {
+			labels = append(labels, label)
+		}
+	}
+	sort.Strings(labels)
+	return labels
+}
+
 // String returns a string representation for the release versions.
 func (r *Versions) String() string {
 	sb := &strings.Builder{}
@@ -95,6 +148,9 @@ func (r *Versions) String() string {
 	if r.alpha != "" {
 		fmt.Fprintf(sb, ", %s: %s", ReleaseTypeAlpha, r.alpha)
 	}
+	for _, label := range r.customLabels() {
+		fmt.Fprintf(sb, ", %s: %s", label, r.custom[label])
+	}
 	return sb.String()
 }
 
@@ -112,132 +168,18 @@ func (r *Versions) Ordered() (versions []string) {
 	if r.Alpha() != "" {
 		versions = append(versions, r.Alpha())
 	}
+	for _, label := range r.customLabels() {
+		versions = append(versions, r.custom[label])
+	}
 	return versions
 }
 
-// GenerateReleaseVersion returns the next build versions for the provided parameters.
+// GenerateReleaseVersion returns the next build versions for the provided
+// parameters by using the default Kubernetes version policy.
 func GenerateReleaseVersion(
 	releaseType, version, branch string, branchFromMaster bool,
 ) (*Versions, error) {
-	logrus.Infof(
-		"Setting release version for %s (branch: %q, branch from master: %v)",
-		version, branch, branchFromMaster,
+	return DefaultVersionPolicy().GenerateReleaseVersion(
+		releaseType, version, branch, branchFromMaster,
 	)
-
-	// if branch == git.DefaultBranch, version is an alpha or beta
-	// if branch == release, version is a rc
-	// if branch == release+1, version is an alpha
-	v, err := util.TagStringToSemver(version)
-	if err != nil {
-		return nil, fmt.Errorf("invalid formatted version %s", version)
-	}
-
-	var label string
-	if len(v.Pre) > 0 {
-		// alpha, beta, rc
-		label = v.Pre[0].String()
-	}
-
-	var labelID uint64 = 1
-	labelIDAvailable := false
-	if len(v.Pre) > 1 && v.Pre[1].IsNum {
-		labelIDAvailable = true
-		labelID = v.Pre[1].VersionNum + 1
-	}
-
-	// releaseVersions.prime is the default release version for this
-	// session/type Other labels such as alpha, beta, and rc are set as needed
-	// Index ordering is important here as it's how they are processed
-	releaseVersions := &Versions{}
-	if branchFromMaster { //nolint:gocritic // a switch case would not make it better
-		branchMatch := regex.BranchRegex.FindStringSubmatch(branch)
-		if len(branchMatch) < 3 {
-			return nil, fmt.Errorf("invalid formatted branch %s", branch)
-		}
-		branchMajor, err := strconv.Atoi(branchMatch[1])
-		if err != nil {
-			return nil, fmt.Errorf("parsing branch major version %q to int: %w", branchMatch[1], err)
-		}
-		branchMinor, err := strconv.Atoi(branchMatch[2])
-		if err != nil {
-			return nil, fmt.Errorf("parsing branch minor version %q to int: %w", branchMatch[2], err)
-		}
-		releaseBranch := struct{ major, minor int }{
-			major: branchMajor, minor: branchMinor,
-		}
-
-		// This is a new branch, set new alpha and RC versions
-		releaseVersions.alpha = fmt.Sprintf(
-			"v%d.%d.0-alpha.0", releaseBranch.major, releaseBranch.minor+1,
-		)
-
-		releaseVersions.rc = fmt.Sprintf(
-			"v%d.%d.0-rc.0", releaseBranch.major, releaseBranch.minor,
-		)
-
-		releaseVersions.prime = releaseVersions.rc
-	} else if strings.HasPrefix(branch, "release-") {
-		// Build directly from releaseVersions
-		releaseVersions.prime = fmt.Sprintf("v%d.%d", v.Major, v.Minor)
-
-		// If the incoming version is anything bigger than vX.Y.Z, then it's a
-		// Jenkin's build version and it stands as is, otherwise increment the
-		// patch
-		patch := v.Patch
-		if !labelIDAvailable {
-			patch++
-		}
-		releaseVersions.prime += fmt.Sprintf(".%d", patch)
-
-		if releaseType == ReleaseTypeOfficial {
-			releaseVersions.official = releaseVersions.prime
-		} else if releaseType == ReleaseTypeRC {
-			releaseVersions.rc = fmt.Sprintf(
-				"%s-rc.%d", releaseVersions.prime, labelID,
-			)
-			releaseVersions.prime = releaseVersions.rc
-		}
-	} else if releaseType == ReleaseTypeBeta {
-		releaseVersions.beta = fmt.Sprintf(
-			"v%d.%d.%d", v.Major, v.Minor, v.Patch,
-		)
-
-		// Enable building beta releases on the main branch.
-		// If the last build version was an alpha (x.y.z-alpha.N), set the
-		// build
-		// label to 'beta' and release version to x.y.z-beta.0.
-		//
-		// Otherwise, we'll assume this is the next x.y beta, so just
-		// increment the
-		// beta version e.g., x.y.z-beta.1 --> x.y.z-beta.2
-		if label == ReleaseTypeAlpha {
-			releaseVersions.beta += fmt.Sprintf("-%s.0", ReleaseTypeBeta)
-		} else {
-			releaseVersions.beta += fmt.Sprintf("-%s.%d", label, labelID)
-		}
-
-		releaseVersions.prime = releaseVersions.beta
-	} else {
-		// In this code branch, we're implicitly supposed to be at an alpha
-		// release. Here, we verify that we're not attempting to cut an
-		// alpha release after a beta in the x.y release series.
-		//
-		// Concretely:
-		// We should not be able to cut x.y.z-alpha.N after x.y.z-beta.M
-		if label != ReleaseTypeAlpha {
-			return nil, fmt.Errorf(
-				"cannot cut an alpha tag after a non-alpha release %s. %s",
-				version,
-				"please specify an allowed release type ('beta')",
-			)
-		}
-
-		releaseVersions.alpha = fmt.Sprintf(
-			"v%d.%d.%d-%s.%d", v.Major, v.Minor, v.Patch, label, labelID,
-		)
-		releaseVersions.prime = releaseVersions.alpha
-	}
-
-	logrus.Infof("Found release versions: %+v", releaseVersions.String())
-	return releaseVersions, nil
 }
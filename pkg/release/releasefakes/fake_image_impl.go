@@ -20,34 +20,49 @@ package releasefakes
 import (
 	"sync"
 
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"sigs.k8s.io/release-sdk/sign"
 )
 
 type FakeImageImpl struct {
-	ExecuteStub        func(string, ...string) error
-	executeMutex       sync.RWMutex
-	executeArgsForCall []struct {
+	DigestStub        func(string) (string, error)
+	digestMutex       sync.RWMutex
+	digestArgsForCall []struct {
 		arg1 string
-		arg2 []string
 	}
-	executeReturns struct {
-		result1 error
+	digestReturns struct {
+		result1 string
+		result2 error
 	}
-	executeReturnsOnCall map[int]struct {
-		result1 error
+	digestReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
 	}
-	ExecuteOutputStub        func(string, ...string) (string, error)
-	executeOutputMutex       sync.RWMutex
-	executeOutputArgsForCall []struct {
+	ImageFromTarballStub        func(string, string) (v1.Image, error)
+	imageFromTarballMutex       sync.RWMutex
+	imageFromTarballArgsForCall []struct {
 		arg1 string
-		arg2 []string
+		arg2 string
 	}
-	executeOutputReturns struct {
-		result1 string
+	imageFromTarballReturns struct {
+		result1 v1.Image
 		result2 error
 	}
-	executeOutputReturnsOnCall map[int]struct {
-		result1 string
+	imageFromTarballReturnsOnCall map[int]struct {
+		result1 v1.Image
+		result2 error
+	}
+	IndexManifestStub        func(string) (*v1.IndexManifest, error)
+	indexManifestMutex       sync.RWMutex
+	indexManifestArgsForCall []struct {
+		arg1 string
+	}
+	indexManifestReturns struct {
+		result1 *v1.IndexManifest
+		result2 error
+	}
+	indexManifestReturnsOnCall map[int]struct {
+		result1 *v1.IndexManifest
 		result2 error
 	}
 	RepoTagFromTarballStub        func(string) (string, error)
@@ -87,85 +102,111 @@ type FakeImageImpl struct {
 	verifyImageReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WriteImageStub        func(string, v1.Image) error
+	writeImageMutex       sync.RWMutex
+	writeImageArgsForCall []struct {
+		arg1 string
+		arg2 v1.Image
+	}
+	writeImageReturns struct {
+		result1 error
+	}
+	writeImageReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WriteIndexStub        func(string, v1.ImageIndex) error
+	writeIndexMutex       sync.RWMutex
+	writeIndexArgsForCall []struct {
+		arg1 string
+		arg2 v1.ImageIndex
+	}
+	writeIndexReturns struct {
+		result1 error
+	}
+	writeIndexReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeImageImpl) Execute(arg1 string, arg2 ...string) error {
-	fake.executeMutex.Lock()
-	ret, specificReturn := fake.executeReturnsOnCall[len(fake.executeArgsForCall)]
-	fake.executeArgsForCall = append(fake.executeArgsForCall, struct {
+func (fake *FakeImageImpl) Digest(arg1 string) (string, error) {
+	fake.digestMutex.Lock()
+	ret, specificReturn := fake.digestReturnsOnCall[len(fake.digestArgsForCall)]
+	fake.digestArgsForCall = append(fake.digestArgsForCall, struct {
 		arg1 string
-		arg2 []string
-	}{arg1, arg2})
-	stub := fake.ExecuteStub
-	fakeReturns := fake.executeReturns
-	fake.recordInvocation("Execute", []interface{}{arg1, arg2})
-	fake.executeMutex.Unlock()
+	}{arg1})
+	stub := fake.DigestStub
+	fakeReturns := fake.digestReturns
+	fake.recordInvocation("Digest", []interface{}{arg1})
+	fake.digestMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2...)
+		return stub(arg1)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeImageImpl) ExecuteCallCount() int {
-	fake.executeMutex.RLock()
-	defer fake.executeMutex.RUnlock()
-	return len(fake.executeArgsForCall)
+func (fake *FakeImageImpl) DigestCallCount() int {
+	fake.digestMutex.RLock()
+	defer fake.digestMutex.RUnlock()
+	return len(fake.digestArgsForCall)
 }
 
-func (fake *FakeImageImpl) ExecuteCalls(stub func(string, ...string) error) {
-	fake.executeMutex.Lock()
-	defer fake.executeMutex.Unlock()
-	fake.ExecuteStub = stub
+func (fake *FakeImageImpl) DigestCalls(stub func(string) (string, error)) {
+	fake.digestMutex.Lock()
+	defer fake.digestMutex.Unlock()
+	fake.DigestStub = stub
 }
 
-func (fake *FakeImageImpl) ExecuteArgsForCall(i int) (string, []string) {
-	fake.executeMutex.RLock()
-	defer fake.executeMutex.RUnlock()
-	argsForCall := fake.executeArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+func (fake *FakeImageImpl) DigestArgsForCall(i int) string {
+	fake.digestMutex.RLock()
+	defer fake.digestMutex.RUnlock()
+	argsForCall := fake.digestArgsForCall[i]
+	return argsForCall.arg1
 }
 
-func (fake *FakeImageImpl) ExecuteReturns(result1 error) {
-	fake.executeMutex.Lock()
-	defer fake.executeMutex.Unlock()
-	fake.ExecuteStub = nil
-	fake.executeReturns = struct {
-		result1 error
-	}{result1}
+func (fake *FakeImageImpl) DigestReturns(result1 string, result2 error) {
+	fake.digestMutex.Lock()
+	defer fake.digestMutex.Unlock()
+	fake.DigestStub = nil
+	fake.digestReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeImageImpl) ExecuteReturnsOnCall(i int, result1 error) {
-	fake.executeMutex.Lock()
-	defer fake.executeMutex.Unlock()
-	fake.ExecuteStub = nil
-	if fake.executeReturnsOnCall == nil {
-		fake.executeReturnsOnCall = make(map[int]struct {
-			result1 error
+func (fake *FakeImageImpl) DigestReturnsOnCall(i int, result1 string, result2 error) {
+	fake.digestMutex.Lock()
+	defer fake.digestMutex.Unlock()
+	fake.DigestStub = nil
+	if fake.digestReturnsOnCall == nil {
+		fake.digestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
 		})
 	}
-	fake.executeReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+	fake.digestReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeImageImpl) ExecuteOutput(arg1 string, arg2 ...string) (string, error) {
-	fake.executeOutputMutex.Lock()
-	ret, specificReturn := fake.executeOutputReturnsOnCall[len(fake.executeOutputArgsForCall)]
-	fake.executeOutputArgsForCall = append(fake.executeOutputArgsForCall, struct {
+func (fake *FakeImageImpl) ImageFromTarball(arg1 string, arg2 string) (v1.Image, error) {
+	fake.imageFromTarballMutex.Lock()
+	ret, specificReturn := fake.imageFromTarballReturnsOnCall[len(fake.imageFromTarballArgsForCall)]
+	fake.imageFromTarballArgsForCall = append(fake.imageFromTarballArgsForCall, struct {
 		arg1 string
-		arg2 []string
+		arg2 string
 	}{arg1, arg2})
-	stub := fake.ExecuteOutputStub
-	fakeReturns := fake.executeOutputReturns
-	fake.recordInvocation("ExecuteOutput", []interface{}{arg1, arg2})
-	fake.executeOutputMutex.Unlock()
+	stub := fake.ImageFromTarballStub
+	fakeReturns := fake.imageFromTarballReturns
+	fake.recordInvocation("ImageFromTarball", []interface{}{arg1, arg2})
+	fake.imageFromTarballMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2...)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -173,47 +214,111 @@ func (fake *FakeImageImpl) ExecuteOutput(arg1 string, arg2 ...string) (string, e
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeImageImpl) ExecuteOutputCallCount() int {
-	fake.executeOutputMutex.RLock()
-	defer fake.executeOutputMutex.RUnlock()
-	return len(fake.executeOutputArgsForCall)
+func (fake *FakeImageImpl) ImageFromTarballCallCount() int {
+	fake.imageFromTarballMutex.RLock()
+	defer fake.imageFromTarballMutex.RUnlock()
+	return len(fake.imageFromTarballArgsForCall)
 }
 
-func (fake *FakeImageImpl) ExecuteOutputCalls(stub func(string, ...string) (string, error)) {
-	fake.executeOutputMutex.Lock()
-	defer fake.executeOutputMutex.Unlock()
-	fake.ExecuteOutputStub = stub
+func (fake *FakeImageImpl) ImageFromTarballCalls(stub func(string, string) (v1.Image, error)) {
+	fake.imageFromTarballMutex.Lock()
+	defer fake.imageFromTarballMutex.Unlock()
+	fake.ImageFromTarballStub = stub
 }
 
-func (fake *FakeImageImpl) ExecuteOutputArgsForCall(i int) (string, []string) {
-	fake.executeOutputMutex.RLock()
-	defer fake.executeOutputMutex.RUnlock()
-	argsForCall := fake.executeOutputArgsForCall[i]
+func (fake *FakeImageImpl) ImageFromTarballArgsForCall(i int) (string, string) {
+	fake.imageFromTarballMutex.RLock()
+	defer fake.imageFromTarballMutex.RUnlock()
+	argsForCall := fake.imageFromTarballArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeImageImpl) ExecuteOutputReturns(result1 string, result2 error) {
-	fake.executeOutputMutex.Lock()
-	defer fake.executeOutputMutex.Unlock()
-	fake.ExecuteOutputStub = nil
-	fake.executeOutputReturns = struct {
-		result1 string
+func (fake *FakeImageImpl) ImageFromTarballReturns(result1 v1.Image, result2 error) {
+	fake.imageFromTarballMutex.Lock()
+	defer fake.imageFromTarballMutex.Unlock()
+	fake.ImageFromTarballStub = nil
+	fake.imageFromTarballReturns = struct {
+		result1 v1.Image
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeImageImpl) ExecuteOutputReturnsOnCall(i int, result1 string, result2 error) {
-	fake.executeOutputMutex.Lock()
-	defer fake.executeOutputMutex.Unlock()
-	fake.ExecuteOutputStub = nil
-	if fake.executeOutputReturnsOnCall == nil {
-		fake.executeOutputReturnsOnCall = make(map[int]struct {
-			result1 string
+func (fake *FakeImageImpl) ImageFromTarballReturnsOnCall(i int, result1 v1.Image, result2 error) {
+	fake.imageFromTarballMutex.Lock()
+	defer fake.imageFromTarballMutex.Unlock()
+	fake.ImageFromTarballStub = nil
+	if fake.imageFromTarballReturnsOnCall == nil {
+		fake.imageFromTarballReturnsOnCall = make(map[int]struct {
+			result1 v1.Image
 			result2 error
 		})
 	}
-	fake.executeOutputReturnsOnCall[i] = struct {
-		result1 string
+	fake.imageFromTarballReturnsOnCall[i] = struct {
+		result1 v1.Image
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImageImpl) IndexManifest(arg1 string) (*v1.IndexManifest, error) {
+	fake.indexManifestMutex.Lock()
+	ret, specificReturn := fake.indexManifestReturnsOnCall[len(fake.indexManifestArgsForCall)]
+	fake.indexManifestArgsForCall = append(fake.indexManifestArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.IndexManifestStub
+	fakeReturns := fake.indexManifestReturns
+	fake.recordInvocation("IndexManifest", []interface{}{arg1})
+	fake.indexManifestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeImageImpl) IndexManifestCallCount() int {
+	fake.indexManifestMutex.RLock()
+	defer fake.indexManifestMutex.RUnlock()
+	return len(fake.indexManifestArgsForCall)
+}
+
+func (fake *FakeImageImpl) IndexManifestCalls(stub func(string) (*v1.IndexManifest, error)) {
+	fake.indexManifestMutex.Lock()
+	defer fake.indexManifestMutex.Unlock()
+	fake.IndexManifestStub = stub
+}
+
+func (fake *FakeImageImpl) IndexManifestArgsForCall(i int) string {
+	fake.indexManifestMutex.RLock()
+	defer fake.indexManifestMutex.RUnlock()
+	argsForCall := fake.indexManifestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeImageImpl) IndexManifestReturns(result1 *v1.IndexManifest, result2 error) {
+	fake.indexManifestMutex.Lock()
+	defer fake.indexManifestMutex.Unlock()
+	fake.IndexManifestStub = nil
+	fake.indexManifestReturns = struct {
+		result1 *v1.IndexManifest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeImageImpl) IndexManifestReturnsOnCall(i int, result1 *v1.IndexManifest, result2 error) {
+	fake.indexManifestMutex.Lock()
+	defer fake.indexManifestMutex.Unlock()
+	fake.IndexManifestStub = nil
+	if fake.indexManifestReturnsOnCall == nil {
+		fake.indexManifestReturnsOnCall = make(map[int]struct {
+			result1 *v1.IndexManifest
+			result2 error
+		})
+	}
+	fake.indexManifestReturnsOnCall[i] = struct {
+		result1 *v1.IndexManifest
 		result2 error
 	}{result1, result2}
 }
@@ -406,19 +511,149 @@ func (fake *FakeImageImpl) VerifyImageReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeImageImpl) WriteImage(arg1 string, arg2 v1.Image) error {
+	fake.writeImageMutex.Lock()
+	ret, specificReturn := fake.writeImageReturnsOnCall[len(fake.writeImageArgsForCall)]
+	fake.writeImageArgsForCall = append(fake.writeImageArgsForCall, struct {
+		arg1 string
+		arg2 v1.Image
+	}{arg1, arg2})
+	stub := fake.WriteImageStub
+	fakeReturns := fake.writeImageReturns
+	fake.recordInvocation("WriteImage", []interface{}{arg1, arg2})
+	fake.writeImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImageImpl) WriteImageCallCount() int {
+	fake.writeImageMutex.RLock()
+	defer fake.writeImageMutex.RUnlock()
+	return len(fake.writeImageArgsForCall)
+}
+
+func (fake *FakeImageImpl) WriteImageCalls(stub func(string, v1.Image) error) {
+	fake.writeImageMutex.Lock()
+	defer fake.writeImageMutex.Unlock()
+	fake.WriteImageStub = stub
+}
+
+func (fake *FakeImageImpl) WriteImageArgsForCall(i int) (string, v1.Image) {
+	fake.writeImageMutex.RLock()
+	defer fake.writeImageMutex.RUnlock()
+	argsForCall := fake.writeImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImageImpl) WriteImageReturns(result1 error) {
+	fake.writeImageMutex.Lock()
+	defer fake.writeImageMutex.Unlock()
+	fake.WriteImageStub = nil
+	fake.writeImageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImageImpl) WriteImageReturnsOnCall(i int, result1 error) {
+	fake.writeImageMutex.Lock()
+	defer fake.writeImageMutex.Unlock()
+	fake.WriteImageStub = nil
+	if fake.writeImageReturnsOnCall == nil {
+		fake.writeImageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeImageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImageImpl) WriteIndex(arg1 string, arg2 v1.ImageIndex) error {
+	fake.writeIndexMutex.Lock()
+	ret, specificReturn := fake.writeIndexReturnsOnCall[len(fake.writeIndexArgsForCall)]
+	fake.writeIndexArgsForCall = append(fake.writeIndexArgsForCall, struct {
+		arg1 string
+		arg2 v1.ImageIndex
+	}{arg1, arg2})
+	stub := fake.WriteIndexStub
+	fakeReturns := fake.writeIndexReturns
+	fake.recordInvocation("WriteIndex", []interface{}{arg1, arg2})
+	fake.writeIndexMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeImageImpl) WriteIndexCallCount() int {
+	fake.writeIndexMutex.RLock()
+	defer fake.writeIndexMutex.RUnlock()
+	return len(fake.writeIndexArgsForCall)
+}
+
+func (fake *FakeImageImpl) WriteIndexCalls(stub func(string, v1.ImageIndex) error) {
+	fake.writeIndexMutex.Lock()
+	defer fake.writeIndexMutex.Unlock()
+	fake.WriteIndexStub = stub
+}
+
+func (fake *FakeImageImpl) WriteIndexArgsForCall(i int) (string, v1.ImageIndex) {
+	fake.writeIndexMutex.RLock()
+	defer fake.writeIndexMutex.RUnlock()
+	argsForCall := fake.writeIndexArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeImageImpl) WriteIndexReturns(result1 error) {
+	fake.writeIndexMutex.Lock()
+	defer fake.writeIndexMutex.Unlock()
+	fake.WriteIndexStub = nil
+	fake.writeIndexReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeImageImpl) WriteIndexReturnsOnCall(i int, result1 error) {
+	fake.writeIndexMutex.Lock()
+	defer fake.writeIndexMutex.Unlock()
+	fake.WriteIndexStub = nil
+	if fake.writeIndexReturnsOnCall == nil {
+		fake.writeIndexReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeIndexReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeImageImpl) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.executeMutex.RLock()
-	defer fake.executeMutex.RUnlock()
-	fake.executeOutputMutex.RLock()
-	defer fake.executeOutputMutex.RUnlock()
+	fake.digestMutex.RLock()
+	defer fake.digestMutex.RUnlock()
+	fake.imageFromTarballMutex.RLock()
+	defer fake.imageFromTarballMutex.RUnlock()
+	fake.indexManifestMutex.RLock()
+	defer fake.indexManifestMutex.RUnlock()
 	fake.repoTagFromTarballMutex.RLock()
 	defer fake.repoTagFromTarballMutex.RUnlock()
 	fake.signImageMutex.RLock()
 	defer fake.signImageMutex.RUnlock()
 	fake.verifyImageMutex.RLock()
 	defer fake.verifyImageMutex.RUnlock()
+	fake.writeImageMutex.RLock()
+	defer fake.writeImageMutex.RUnlock()
+	fake.writeIndexMutex.RLock()
+	defer fake.writeIndexMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,292 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by counterfeiter. DO NOT EDIT.
+package releasefakes
+
+import (
+	"sync"
+
+	"sigs.k8s.io/release-sdk/git"
+)
+
+type FakePlannerImpl struct {
+	DescribeStub        func(*git.Repo, string) (string, error)
+	describeMutex       sync.RWMutex
+	describeArgsForCall []struct {
+		arg1 *git.Repo
+		arg2 string
+	}
+	describeReturns struct {
+		result1 string
+		result2 error
+	}
+	describeReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	OpenRepoStub        func(string) (*git.Repo, error)
+	openRepoMutex       sync.RWMutex
+	openRepoArgsForCall []struct {
+		arg1 string
+	}
+	openRepoReturns struct {
+		result1 *git.Repo
+		result2 error
+	}
+	openRepoReturnsOnCall map[int]struct {
+		result1 *git.Repo
+		result2 error
+	}
+	RevParseStub        func(*git.Repo, string) (string, error)
+	revParseMutex       sync.RWMutex
+	revParseArgsForCall []struct {
+		arg1 *git.Repo
+		arg2 string
+	}
+	revParseReturns struct {
+		result1 string
+		result2 error
+	}
+	revParseReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakePlannerImpl) Describe(arg1 *git.Repo, arg2 string) (string, error) {
+	fake.describeMutex.Lock()
+	ret, specificReturn := fake.describeReturnsOnCall[len(fake.describeArgsForCall)]
+	fake.describeArgsForCall = append(fake.describeArgsForCall, struct {
+		arg1 *git.Repo
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DescribeStub
+	fakeReturns := fake.describeReturns
+	fake.recordInvocation("Describe", []interface{}{arg1, arg2})
+	fake.describeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePlannerImpl) DescribeCallCount() int {
+	fake.describeMutex.RLock()
+	defer fake.describeMutex.RUnlock()
+	return len(fake.describeArgsForCall)
+}
+
+func (fake *FakePlannerImpl) DescribeCalls(stub func(*git.Repo, string) (string, error)) {
+	fake.describeMutex.Lock()
+	defer fake.describeMutex.Unlock()
+	fake.DescribeStub = stub
+}
+
+func (fake *FakePlannerImpl) DescribeArgsForCall(i int) (*git.Repo, string) {
+	fake.describeMutex.RLock()
+	defer fake.describeMutex.RUnlock()
+	argsForCall := fake.describeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePlannerImpl) DescribeReturns(result1 string, result2 error) {
+	fake.describeMutex.Lock()
+	defer fake.describeMutex.Unlock()
+	fake.DescribeStub = nil
+	fake.describeReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePlannerImpl) DescribeReturnsOnCall(i int, result1 string, result2 error) {
+	fake.describeMutex.Lock()
+	defer fake.describeMutex.Unlock()
+	fake.DescribeStub = nil
+	if fake.describeReturnsOnCall == nil {
+		fake.describeReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.describeReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePlannerImpl) OpenRepo(arg1 string) (*git.Repo, error) {
+	fake.openRepoMutex.Lock()
+	ret, specificReturn := fake.openRepoReturnsOnCall[len(fake.openRepoArgsForCall)]
+	fake.openRepoArgsForCall = append(fake.openRepoArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.OpenRepoStub
+	fakeReturns := fake.openRepoReturns
+	fake.recordInvocation("OpenRepo", []interface{}{arg1})
+	fake.openRepoMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePlannerImpl) OpenRepoCallCount() int {
+	fake.openRepoMutex.RLock()
+	defer fake.openRepoMutex.RUnlock()
+	return len(fake.openRepoArgsForCall)
+}
+
+func (fake *FakePlannerImpl) OpenRepoCalls(stub func(string) (*git.Repo, error)) {
+	fake.openRepoMutex.Lock()
+	defer fake.openRepoMutex.Unlock()
+	fake.OpenRepoStub = stub
+}
+
+func (fake *FakePlannerImpl) OpenRepoArgsForCall(i int) string {
+	fake.openRepoMutex.RLock()
+	defer fake.openRepoMutex.RUnlock()
+	argsForCall := fake.openRepoArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePlannerImpl) OpenRepoReturns(result1 *git.Repo, result2 error) {
+	fake.openRepoMutex.Lock()
+	defer fake.openRepoMutex.Unlock()
+	fake.OpenRepoStub = nil
+	fake.openRepoReturns = struct {
+		result1 *git.Repo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePlannerImpl) OpenRepoReturnsOnCall(i int, result1 *git.Repo, result2 error) {
+	fake.openRepoMutex.Lock()
+	defer fake.openRepoMutex.Unlock()
+	fake.OpenRepoStub = nil
+	if fake.openRepoReturnsOnCall == nil {
+		fake.openRepoReturnsOnCall = make(map[int]struct {
+			result1 *git.Repo
+			result2 error
+		})
+	}
+	fake.openRepoReturnsOnCall[i] = struct {
+		result1 *git.Repo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePlannerImpl) RevParse(arg1 *git.Repo, arg2 string) (string, error) {
+	fake.revParseMutex.Lock()
+	ret, specificReturn := fake.revParseReturnsOnCall[len(fake.revParseArgsForCall)]
+	fake.revParseArgsForCall = append(fake.revParseArgsForCall, struct {
+		arg1 *git.Repo
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RevParseStub
+	fakeReturns := fake.revParseReturns
+	fake.recordInvocation("RevParse", []interface{}{arg1, arg2})
+	fake.revParseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePlannerImpl) RevParseCallCount() int {
+	fake.revParseMutex.RLock()
+	defer fake.revParseMutex.RUnlock()
+	return len(fake.revParseArgsForCall)
+}
+
+func (fake *FakePlannerImpl) RevParseCalls(stub func(*git.Repo, string) (string, error)) {
+	fake.revParseMutex.Lock()
+	defer fake.revParseMutex.Unlock()
+	fake.RevParseStub = stub
+}
+
+func (fake *FakePlannerImpl) RevParseArgsForCall(i int) (*git.Repo, string) {
+	fake.revParseMutex.RLock()
+	defer fake.revParseMutex.RUnlock()
+	argsForCall := fake.revParseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePlannerImpl) RevParseReturns(result1 string, result2 error) {
+	fake.revParseMutex.Lock()
+	defer fake.revParseMutex.Unlock()
+	fake.RevParseStub = nil
+	fake.revParseReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePlannerImpl) RevParseReturnsOnCall(i int, result1 string, result2 error) {
+	fake.revParseMutex.Lock()
+	defer fake.revParseMutex.Unlock()
+	fake.RevParseStub = nil
+	if fake.revParseReturnsOnCall == nil {
+		fake.revParseReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.revParseReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePlannerImpl) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.describeMutex.RLock()
+	defer fake.describeMutex.RUnlock()
+	fake.openRepoMutex.RLock()
+	defer fake.openRepoMutex.RUnlock()
+	fake.revParseMutex.RLock()
+	defer fake.revParseMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakePlannerImpl) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
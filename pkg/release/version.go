@@ -19,7 +19,6 @@ package release
 import (
 	"bytes"
 	"fmt"
-	"strings"
 
 	"github.com/sirupsen/logrus"
 
@@ -30,6 +29,7 @@ import (
 // Version is a wrapper around version related functionality.
 type Version struct {
 	client VersionClient
+	policy *VersionPolicy
 }
 
 // VersionClient is a client for getting Kubernetes versions
@@ -75,7 +75,7 @@ const (
 
 // NewVersion creates a new Version.
 func NewVersion() *Version {
-	return &Version{&versionClient{}}
+	return &Version{&versionClient{}, DefaultVersionPolicy()}
 }
 
 // SetClient can be used to manually set the internal Version client.
@@ -83,6 +83,12 @@ func (v *Version) SetClient(client VersionClient) {
 	v.client = client
 }
 
+// SetVersionPolicy can be used to set the policy defining the release branch
+// names.
+func (v *Version) SetVersionPolicy(policy *VersionPolicy) {
+	v.policy = policy
+}
+
 // URL retrieves the full URL of the Kubernetes release version.
 func (t VersionType) URL(version string) string {
 	url := baseURL + string(t)
@@ -111,10 +117,11 @@ func (v *Version) GetKubeVersionForBranch(versionType VersionType, branch string
 
 	version := ""
 	if branch != git.DefaultBranch {
-		if !git.IsReleaseBranch(branch) {
-			return "", fmt.Errorf("%s is not a valid release branch", branch)
+		major, minor, err := v.policy.BranchVersion(branch)
+		if err != nil {
+			return "", fmt.Errorf("%s is not a valid release branch: %w", branch, err)
 		}
-		version = strings.TrimPrefix(branch, "release-")
+		version = fmt.Sprintf("%d.%d", major, minor)
 	}
 	url := versionType.URL(version)
 
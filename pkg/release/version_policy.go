@@ -0,0 +1,298 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-sdk/git"
+	"sigs.k8s.io/release-utils/util"
+	"sigs.k8s.io/yaml"
+)
+
+// VersionPolicy defines which pre-release labels exist and how release
+// branches are named. It is used to generate the next release versions
+// from a build version.
+type VersionPolicy struct {
+	// PreReleaseLabels are the pre-release labels which can be cut from the
+	// default branch, ordered from the least to the most stable one. A label
+	// can only follow a build of itself or of a less stable label.
+	PreReleaseLabels []string `json:"preReleaseLabels"`
+
+	// ReleaseBranchLabels are the pre-release labels which can be cut from
+	// release branches.
+	ReleaseBranchLabels []string `json:"releaseBranchLabels"`
+
+	// NewBranchLabel is the pre-release label of the version tagged when a
+	// new release branch gets created.
+	NewBranchLabel string `json:"newBranchLabel"`
+
+	// NextMinorLabel is the pre-release label of the next minor version
+	// tagged on the default branch when a new release branch gets created.
+	NextMinorLabel string `json:"nextMinorLabel"`
+
+	// InitialLabelID is the first number used for a newly started
+	// pre-release label, like `0` for `v1.30.0-beta.0`.
+	InitialLabelID uint64 `json:"initialLabelID"`
+
+	// ReleaseBranchPattern is the regular expression release branches have
+	// to match. It has to contain the named groups `major` and `minor`.
+	ReleaseBranchPattern string `json:"releaseBranchPattern"`
+}
+
+// DefaultVersionPolicy returns the version policy used by Kubernetes.
+func DefaultVersionPolicy() *VersionPolicy {
+	return &VersionPolicy{
+		PreReleaseLabels:     []string{ReleaseTypeAlpha, ReleaseTypeBeta},
+		ReleaseBranchLabels:  []string{ReleaseTypeRC},
+		NewBranchLabel:       ReleaseTypeRC,
+		NextMinorLabel:       ReleaseTypeAlpha,
+		InitialLabelID:       0,
+		ReleaseBranchPattern: `^release-(?P<major>\d+)\.(?P<minor>\d+)(\.\d+)*$`,
+	}
+}
+
+// LoadVersionPolicy reads a YAML or JSON version policy from the provided
+// path. Unset fields are taken from the default version policy.
+func LoadVersionPolicy(path string) (*VersionPolicy, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read version policy: %w", err)
+	}
+	return ParseVersionPolicy(content)
+}
+
+// ParseVersionPolicy parses a YAML or JSON version policy. Unset fields are
+// taken from the default version policy.
+func ParseVersionPolicy(content []byte) (*VersionPolicy, error) {
+	policy := &VersionPolicy{}
+	if err := yaml.UnmarshalStrict(content, policy); err != nil {
+		return nil, fmt.Errorf("unmarshal version policy: %w", err)
+	}
+
+	defaults := DefaultVersionPolicy()
+	if len(policy.PreReleaseLabels) == 0 {
+		policy.PreReleaseLabels = defaults.PreReleaseLabels
+	}
+	if len(policy.ReleaseBranchLabels) == 0 {
+		policy.ReleaseBranchLabels = defaults.ReleaseBranchLabels
+	}
+	if policy.NewBranchLabel == "" {
+		policy.NewBranchLabel = defaults.NewBranchLabel
+	}
+	if policy.NextMinorLabel == "" {
+		policy.NextMinorLabel = defaults.NextMinorLabel
+	}
+	if policy.ReleaseBranchPattern == "" {
+		policy.ReleaseBranchPattern = defaults.ReleaseBranchPattern
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("validate version policy: %w", err)
+	}
+	return policy, nil
+}
+
+// Validate checks if the version policy is consistent.
+func (p *VersionPolicy) Validate() error {
+	re, err := regexp.Compile(p.ReleaseBranchPattern)
+	if err != nil {
+		return fmt.Errorf("compile release branch pattern: %w", err)
+	}
+	if re.SubexpIndex("major") < 0 || re.SubexpIndex("minor") < 0 {
+		return errors.New("release branch pattern requires the named groups 'major' and 'minor'")
+	}
+
+	seen := map[string]bool{}
+	for _, label := range append(
+		slices.Clone(p.PreReleaseLabels), p.ReleaseBranchLabels...,
+	) {
+		if label == "" || label == ReleaseTypeOfficial {
+			return fmt.Errorf("invalid pre-release label: %q", label)
+		}
+		if seen[label] {
+			return fmt.Errorf("duplicate pre-release label: %s", label)
+		}
+		seen[label] = true
+	}
+
+	if p.NewBranchLabel == "" || p.NextMinorLabel == "" {
+		return errors.New("new branch and next minor labels are required")
+	}
+	return nil
+}
+
+// ReleaseTypes returns all release types supported by the policy.
+func (p *VersionPolicy) ReleaseTypes() []string {
+	types := slices.Clone(p.PreReleaseLabels)
+	types = append(types, p.ReleaseBranchLabels...)
+	return append(types, ReleaseTypeOfficial)
+}
+
+// ValidReleaseType returns true if the release type is supported by the
+// policy.
+func (p *VersionPolicy) ValidReleaseType(releaseType string) bool {
+	return slices.Contains(p.ReleaseTypes(), releaseType)
+}
+
+// ValidBranch returns true if the branch is either the default branch or a
+// release branch.
+func (p *VersionPolicy) ValidBranch(branch string) bool {
+	return branch == git.DefaultBranch || p.IsReleaseBranch(branch)
+}
+
+// IsReleaseBranch returns true if the branch matches the release branch
+// pattern of the policy.
+func (p *VersionPolicy) IsReleaseBranch(branch string) bool {
+	_, _, err := p.BranchVersion(branch)
+	return err == nil
+}
+
+// BranchVersion parses the major and minor version from a release branch.
+func (p *VersionPolicy) BranchVersion(branch string) (major, minor uint64, err error) {
+	re, err := regexp.Compile(p.ReleaseBranchPattern)
+	if err != nil {
+		return 0, 0, fmt.Errorf("compile release branch pattern: %w", err)
+	}
+
+	match := re.FindStringSubmatch(branch)
+	majorIdx, minorIdx := re.SubexpIndex("major"), re.SubexpIndex("minor")
+	if match == nil || majorIdx < 0 || minorIdx < 0 {
+		return 0, 0, fmt.Errorf("invalid formatted branch %s", branch)
+	}
+
+	major, err = strconv.ParseUint(match[majorIdx], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parsing branch major version %q to int: %w", match[majorIdx], err)
+	}
+	minor, err = strconv.ParseUint(match[minorIdx], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parsing branch minor version %q to int: %w", match[minorIdx], err)
+	}
+	return major, minor, nil
+}
+
+// GenerateReleaseVersion returns the next build versions for the provided
+// parameters according to the policy.
+func (p *VersionPolicy) GenerateReleaseVersion(
+	releaseType, version, branch string, branchFromMaster bool,
+) (*Versions, error) {
+	logrus.Infof(
+		"Setting release version for %s (branch: %q, branch from master: %v)",
+		version, branch, branchFromMaster,
+	)
+
+	releaseVersions := &Versions{}
+	if branchFromMaster {
+		major, minor, err := p.BranchVersion(branch)
+		if err != nil {
+			return nil, err
+		}
+
+		// This is a new branch, set the next minor and new branch versions
+		releaseVersions.Set(p.NextMinorLabel, fmt.Sprintf(
+			"v%d.%d.0-%s.%d", major, minor+1, p.NextMinorLabel, p.InitialLabelID,
+		))
+		releaseVersions.prime = fmt.Sprintf(
+			"v%d.%d.0-%s.%d", major, minor, p.NewBranchLabel, p.InitialLabelID,
+		)
+		releaseVersions.Set(p.NewBranchLabel, releaseVersions.prime)
+
+		logrus.Infof("Found release versions: %+v", releaseVersions.String())
+		return releaseVersions, nil
+	}
+
+	v, err := util.TagStringToSemver(version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid formatted version %s", version)
+	}
+
+	var label string
+	if len(v.Pre) > 0 {
+		label = v.Pre[0].String()
+	}
+
+	var labelID uint64 = 1
+	labelIDAvailable := false
+	if len(v.Pre) > 1 && v.Pre[1].IsNum {
+		labelIDAvailable = true
+		labelID = v.Pre[1].VersionNum + 1
+	}
+
+	if p.IsReleaseBranch(branch) {
+		// If the incoming version is anything bigger than vX.Y.Z, then it's a
+		// Jenkin's build version and it stands as is, otherwise increment the
+		// patch
+		patch := v.Patch
+		if !labelIDAvailable {
+			patch++
+		}
+		releaseVersions.prime = fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, patch)
+
+		if releaseType == ReleaseTypeOfficial {
+			releaseVersions.official = releaseVersions.prime
+		} else if slices.Contains(p.ReleaseBranchLabels, releaseType) {
+			releaseVersions.prime = fmt.Sprintf(
+				"%s-%s.%d", releaseVersions.prime, releaseType, labelID,
+			)
+			releaseVersions.Set(releaseType, releaseVersions.prime)
+		}
+
+		logrus.Infof("Found release versions: %+v", releaseVersions.String())
+		return releaseVersions, nil
+	}
+
+	// On the default branch, a pre-release label can only follow a build of
+	// itself or a less stable label, for example we should not be able to
+	// cut x.y.z-alpha.N after x.y.z-beta.M
+	typeIdx := slices.Index(p.PreReleaseLabels, releaseType)
+	if typeIdx < 0 {
+		return nil, fmt.Errorf(
+			"cannot cut a %s release from branch %s", releaseType, branch,
+		)
+	}
+	labelIdx := slices.Index(p.PreReleaseLabels, label)
+
+	var nextID uint64
+	switch {
+	case labelIdx == typeIdx:
+		nextID = labelID
+	case labelIdx >= 0 && labelIdx < typeIdx:
+		nextID = p.InitialLabelID
+	default:
+		return nil, fmt.Errorf(
+			"cannot cut a %q tag after a release that is not %q: %s. %s",
+			releaseType, releaseType, version,
+			"please specify an allowed release type",
+		)
+	}
+
+	releaseVersions.prime = fmt.Sprintf(
+		"v%d.%d.%d-%s.%d", v.Major, v.Minor, v.Patch, releaseType, nextID,
+	)
+	releaseVersions.Set(releaseType, releaseVersions.prime)
+
+	logrus.Infof("Found release versions: %+v", releaseVersions.String())
+	return releaseVersions, nil
+}
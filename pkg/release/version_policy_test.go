@@ -0,0 +1,207 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/release-sdk/git"
+
+	"k8s.io/release/pkg/release"
+)
+
+func customVersionPolicy() *release.VersionPolicy {
+	return &release.VersionPolicy{
+		PreReleaseLabels:     []string{"dev", release.ReleaseTypeAlpha, release.ReleaseTypeBeta},
+		ReleaseBranchLabels:  []string{release.ReleaseTypeRC, "hotfix"},
+		NewBranchLabel:       release.ReleaseTypeRC,
+		NextMinorLabel:       "dev",
+		InitialLabelID:       1,
+		ReleaseBranchPattern: `^stable-(?P<major>\d+)\.(?P<minor>\d+)$`,
+	}
+}
+
+func TestVersionPolicyGenerateReleaseVersion(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name             string
+		releaseType      string
+		version          string
+		branch           string
+		branchFromMaster bool
+		expectedPrime    string
+		expectedOrdered  []string
+		shouldError      bool
+		expectedError    string
+	}{
+		{
+			name:            "next dev",
+			releaseType:     "dev",
+			version:         "v1.2.0-dev.3.5+0123456789abcd",
+			branch:          git.DefaultBranch,
+			expectedPrime:   "v1.2.0-dev.4",
+			expectedOrdered: []string{"v1.2.0-dev.4"},
+		},
+		{
+			name:            "alpha after dev",
+			releaseType:     release.ReleaseTypeAlpha,
+			version:         "v1.2.0-dev.3.5+0123456789abcd",
+			branch:          git.DefaultBranch,
+			expectedPrime:   "v1.2.0-alpha.1",
+			expectedOrdered: []string{"v1.2.0-alpha.1"},
+		},
+		{
+			name:          "dev after alpha",
+			releaseType:   "dev",
+			version:       "v1.2.0-alpha.1.5+0123456789abcd",
+			branch:        git.DefaultBranch,
+			shouldError:   true,
+			expectedError: `cannot cut a "dev" tag after a release that is not "dev"`,
+		},
+		{
+			name:        "release branch label on default branch",
+			releaseType: "hotfix",
+			version:     "v1.2.0-dev.3.5+0123456789abcd",
+			branch:      git.DefaultBranch,
+			shouldError: true,
+		},
+		{
+			name:            "hotfix on release branch",
+			releaseType:     "hotfix",
+			version:         "v1.2.3-hotfix.0.5+0123456789abcd",
+			branch:          "stable-1.2",
+			expectedPrime:   "v1.2.3-hotfix.1",
+			expectedOrdered: []string{"v1.2.3-hotfix.1"},
+		},
+		{
+			name:            "official on release branch",
+			releaseType:     release.ReleaseTypeOfficial,
+			version:         "v1.2.3-rc.1.5+0123456789abcd",
+			branch:          "stable-1.2",
+			expectedPrime:   "v1.2.3",
+			expectedOrdered: []string{"v1.2.3"},
+		},
+		{
+			name:             "new release branch",
+			releaseType:      release.ReleaseTypeRC,
+			version:          "v1.2.0-beta.1.5+0123456789abcd",
+			branch:           "stable-1.2",
+			branchFromMaster: true,
+			expectedPrime:    "v1.2.0-rc.1",
+			expectedOrdered:  []string{"v1.2.0-rc.1", "v1.3.0-dev.1"},
+		},
+		{
+			name:             "new branch not matching pattern",
+			releaseType:      release.ReleaseTypeRC,
+			version:          "v1.2.0-beta.1.5+0123456789abcd",
+			branch:           "release-1.2",
+			branchFromMaster: true,
+			shouldError:      true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := customVersionPolicy().GenerateReleaseVersion(
+				tc.releaseType, tc.version, tc.branch, tc.branchFromMaster,
+			)
+			if tc.shouldError {
+				require.Error(t, err)
+				if tc.expectedError != "" {
+					require.ErrorContains(t, err, tc.expectedError)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedPrime, res.Prime())
+			require.Equal(t, tc.expectedOrdered, res.Ordered())
+		})
+	}
+}
+
+func TestVersionPolicyReleaseTypes(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{
+		release.ReleaseTypeAlpha,
+		release.ReleaseTypeBeta,
+		release.ReleaseTypeRC,
+		release.ReleaseTypeOfficial,
+	}, release.DefaultVersionPolicy().ReleaseTypes())
+
+	sut := customVersionPolicy()
+	require.True(t, sut.ValidReleaseType("hotfix"))
+	require.False(t, sut.ValidReleaseType("invalid"))
+	require.True(t, sut.ValidBranch(git.DefaultBranch))
+	require.True(t, sut.ValidBranch("stable-1.2"))
+	require.False(t, sut.ValidBranch("release-1.2"))
+	require.True(t, release.DefaultVersionPolicy().IsReleaseBranch("release-1.2"))
+}
+
+func TestLoadVersionPolicy(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, tc := range []struct {
+		content     string
+		assert      func(*release.VersionPolicy)
+		shouldError bool
+	}{
+		{ // defaults
+			content: "preReleaseLabels: [dev, alpha]\n",
+			assert: func(p *release.VersionPolicy) {
+				require.Equal(t, []string{"dev", release.ReleaseTypeAlpha}, p.PreReleaseLabels)
+				require.Equal(t, []string{release.ReleaseTypeRC}, p.ReleaseBranchLabels)
+				require.True(t, p.IsReleaseBranch("release-1.2"))
+			},
+		},
+		{ // missing named groups
+			content:     "releaseBranchPattern: '^stable-(\\d+)$'\n",
+			shouldError: true,
+		},
+		{ // duplicate labels
+			content:     "preReleaseLabels: [rc]\n",
+			shouldError: true,
+		},
+		{ // unknown field
+			content:     "unknown: true\n",
+			shouldError: true,
+		},
+	} {
+		file, err := os.CreateTemp(dir, "policy-*.yaml")
+		require.NoError(t, err)
+		_, err = file.WriteString(tc.content)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		res, err := release.LoadVersionPolicy(file.Name())
+		if tc.shouldError {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		tc.assert(res)
+	}
+
+	_, err := release.LoadVersionPolicy(filepath.Join(dir, "missing.yaml"))
+	require.Error(t, err)
+}
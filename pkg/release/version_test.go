@@ -115,6 +115,21 @@ func TestGetKubeVersionForBranchSuccess(t *testing.T) {
 	}
 }
 
+func TestGetKubeVersionForBranchVersionPolicy(t *testing.T) {
+	sut, client := newVersionSUT()
+	policy := release.DefaultVersionPolicy()
+	policy.ReleaseBranchPattern = `^stable/(?P<major>\d+)\.(?P<minor>\d+)$`
+	sut.SetVersionPolicy(policy)
+	client.GetURLResponseReturns("v1.30.1-rc.0.5+5f400ccfa32aff", nil)
+
+	_, err := sut.GetKubeVersionForBranch(release.VersionTypeCILatest, "stable/1.30")
+	require.Nil(t, err)
+	require.Equal(t, "https://dl.k8s.io/ci/latest-1.30.txt", client.GetURLResponseArgsForCall(0))
+
+	_, err = sut.GetKubeVersionForBranch(release.VersionTypeCILatest, "release-1.30")
+	require.NotNil(t, err)
+}
+
 func TestGetKubeVersionForBranchFailure(t *testing.T) {
 	testcases := []struct {
 		behavior    func(*releasefakes.FakeVersionClient)